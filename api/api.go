@@ -4,7 +4,10 @@ package api
 
 import (
 	"context"
+	"fmt"
 	"net/http"
+	"net/url"
+	"sync"
 
 	"github.com/diamondburned/arikawa/v3/api/rate"
 	"github.com/diamondburned/arikawa/v3/discord"
@@ -28,22 +31,46 @@ type Client struct {
 	*httputil.Client
 	*Session
 	AcquireOptions rate.AcquireOptions
+
+	// hooks is where InjectRequest and OnResponse are in the hooks of
+	// httputil.Client, so that copies with a different Session can rebind
+	// them.
+	hooks *clientHooks
+}
+
+type clientHooks struct {
+	request  int
+	response int
 }
 
 func NewClient(token string) *Client {
 	return NewCustomClient(token, httputil.NewClient())
 }
 
+// NewProxyClient creates a new client that sends all requests to the API proxy
+// at the given base URL. Local rate limiting is disabled, since the proxy is
+// expected to handle it.
+func NewProxyClient(token, baseURL string) *Client {
+	return NewClient(token).WithBaseURL(baseURL).WithoutRateLimit()
+}
+
 func NewCustomClient(token string, httpClient *httputil.Client) *Client {
 	c := &Client{
 		Session: &Session{
-			Limiter:   rate.NewLimiter(Path),
-			Token:     token,
-			UserAgent: UserAgent,
+			Limiter:    rate.NewLimiter(Path),
+			Token:      token,
+			UserAgent:  UserAgent,
+			apiHosts:   newHostSet(BaseEndpoint),
+			dmChannels: newDMChannelCache(DMChannelCacheSize),
 		},
 		Client: httpClient.Copy(),
 	}
 
+	c.hooks = &clientHooks{
+		request:  len(c.Client.OnRequest),
+		response: len(c.Client.OnResponse),
+	}
+
 	c.Client.OnRequest = append(c.Client.OnRequest, c.InjectRequest)
 	c.Client.OnResponse = append(c.Client.OnResponse, c.OnResponse)
 
@@ -63,9 +90,117 @@ func (c *Client) WithLocale(language discord.Language) *Client {
 		Client:         client,
 		Session:        c.Session,
 		AcquireOptions: c.AcquireOptions,
+		hooks:          c.hooks,
+	}
+}
+
+// WithUserAgent creates a copy of Client that sends the given User-Agent header
+// instead of the Session's. Discord expects bots to use the format
+// "DiscordBot ($url, $version)".
+func (c *Client) WithUserAgent(userAgent string) *Client {
+	client := c.Client.Copy()
+	client.OnRequest = append(client.OnRequest, func(r httpdriver.Request) error {
+		if c.Session.isAPIRequest(r) {
+			r.AddHeader(http.Header{"User-Agent": {userAgent}})
+		}
+		return nil
+	})
+
+	return &Client{
+		Client:         client,
+		Session:        c.Session,
+		AcquireOptions: c.AcquireOptions,
+		hooks:          c.hooks,
+	}
+}
+
+// WithTokenFunc creates a copy of Client that calls fn for the token of every
+// request instead of using the Session's. It is useful for tokens that expire,
+// such as OAuth2 access tokens; see package oauth2. The returned token is used
+// as-is for the Authorization header, so it must include its type, e.g.
+// "Bearer".
+func (c *Client) WithTokenFunc(fn func(ctx context.Context) (string, error)) *Client {
+	client := c.Client.Copy()
+	client.OnRequest = append(client.OnRequest, func(r httpdriver.Request) error {
+		if !c.Session.isAPIRequest(r) {
+			return nil
+		}
+
+		token, err := fn(r.GetContext())
+		if err != nil {
+			return fmt.Errorf("failed to get token: %w", err)
+		}
+
+		r.AddHeader(http.Header{"Authorization": {token}})
+		return nil
+	})
+
+	return &Client{
+		Client:         client,
+		Session:        c.Session,
+		AcquireOptions: c.AcquireOptions,
+		hooks:          c.hooks,
+	}
+}
+
+// WithBaseURL creates a copy of Client that sends all requests to the given
+// base URL instead of BaseEndpoint. It is useful for routing requests through
+// an API proxy, such as twilight-http-proxy or Nirn. The base URL must not
+// contain the API path, e.g. "http://localhost:8080".
+//
+// The host of the base URL is trusted to receive the token for the whole
+// Session, including the Client that WithBaseURL is called on.
+func (c *Client) WithBaseURL(baseURL string) *Client {
+	if c.Session.apiHosts != nil {
+		c.Session.apiHosts.add(baseURL)
+	}
+
+	client := c.Client.Copy()
+	client.Client = httpdriver.WithBaseURL(client.Client, BaseEndpoint, baseURL)
+
+	return &Client{
+		Client:         client,
+		Session:        c.Session,
+		AcquireOptions: c.AcquireOptions,
+		hooks:          c.hooks,
 	}
 }
 
+// WithoutRateLimit creates a copy of Client that does not do any local rate
+// limiting. It should only be used when the requests are sent to a proxy that
+// handles rate limiting by itself; otherwise, the bot risks being banned from
+// the API.
+func (c *Client) WithoutRateLimit() *Client {
+	session := *c.Session
+	session.Limiter = nil
+
+	return c.withSession(&session)
+}
+
+// withSession creates a copy of Client with the given Session. The request and
+// response hooks of the copy are rebound to it, since the original ones use the
+// Session of c.
+func (c *Client) withSession(session *Session) *Client {
+	client := c.Client.Copy()
+
+	copied := &Client{
+		Client:         client,
+		Session:        session,
+		AcquireOptions: c.AcquireOptions,
+		hooks:          c.hooks,
+	}
+
+	if c.hooks != nil {
+		client.OnRequest = append([]httputil.RequestOption(nil), client.OnRequest...)
+		client.OnRequest[c.hooks.request] = copied.InjectRequest
+
+		client.OnResponse = append([]httputil.ResponseFunc(nil), client.OnResponse...)
+		client.OnResponse[c.hooks.response] = copied.OnResponse
+	}
+
+	return copied
+}
+
 // WithContext returns a shallow copy of Client with the given context. It's
 // used for method timeouts and such. This method is thread-safe.
 func (c *Client) WithContext(ctx context.Context) *Client {
@@ -73,29 +208,87 @@ func (c *Client) WithContext(ctx context.Context) *Client {
 		Client:         c.Client.WithContext(ctx),
 		Session:        c.Session,
 		AcquireOptions: c.AcquireOptions,
+		hooks:          c.hooks,
 	}
 }
 
+// InjectRequest adds the authorization headers to the request and acquires the
+// rate limiter. Requests to hosts other than the API, such as attachment URLs
+// on the CDN, are sent as-is, so the token never leaks to them.
 func (c *Client) InjectRequest(r httpdriver.Request) error {
+	if !c.Session.isAPIRequest(r) {
+		return nil
+	}
+
 	r.AddHeader(http.Header{
 		"Authorization": {c.Session.Token},
 		"User-Agent":    {c.Session.UserAgent},
 	})
 
+	if c.Session.Limiter == nil {
+		return nil
+	}
+
 	ctx := c.AcquireOptions.Context(r.GetContext())
 	return c.Session.Limiter.Acquire(ctx, r.GetPath())
 }
 
 func (c *Client) OnResponse(r httpdriver.Request, resp httpdriver.Response) error {
+	if c.Session.Limiter == nil || !c.Session.isAPIRequest(r) {
+		return nil
+	}
+
 	return c.Session.Limiter.Release(r.GetPath(), httpdriver.OptHeader(resp))
 }
 
 // Session keeps a single session. This is typically wrapped around Client.
 type Session struct {
+	// Limiter is the local rate limiter. If it is nil, then no local rate
+	// limiting is done; see WithoutRateLimit.
 	Limiter *rate.Limiter
 
 	Token     string
 	UserAgent string
+
+	apiHosts   *hostSet
+	dmChannels *dmChannelCache
+}
+
+// isAPIRequest returns true if the request is sent to a host that may receive
+// the token. If either the host of the request or the list of API hosts is
+// unknown, then true is returned.
+func (s *Session) isAPIRequest(r httpdriver.Request) bool {
+	if s.apiHosts == nil {
+		return true
+	}
+
+	host := httpdriver.RequestHost(r)
+	return host == "" || s.apiHosts.has(host)
+}
+
+// hostSet is a concurrently safe set of URL hosts.
+type hostSet struct {
+	hosts sync.Map // map[string]struct{}
+}
+
+func newHostSet(urls ...string) *hostSet {
+	set := &hostSet{}
+	for _, u := range urls {
+		set.add(u)
+	}
+	return set
+}
+
+func (set *hostSet) add(rawURL string) {
+	u, err := url.Parse(rawURL)
+	if err == nil && u.Host != "" {
+		set.hosts.Store(u.Host, struct{}{})
+	}
+}
+
+func (set *hostSet) has(host string) bool {
+	_, ok := set.hosts.Load(host)
+	return ok
 }
 
 // AuditLogReason is the type embedded in data structs when the action
@@ -3,7 +3,13 @@ package api
 import (
 	"context"
 	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
 	"testing"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/utils/httputil/httpdriver"
 )
 
 func TestContext(t *testing.T) {
@@ -18,3 +24,84 @@ func TestContext(t *testing.T) {
 		t.Fatal("Unexpected error:", err)
 	}
 }
+
+func TestInjectRequestHosts(t *testing.T) {
+	client := NewClient("Bot token").WithoutRateLimit()
+	proxied := client.WithBaseURL("http://localhost:8080")
+
+	tests := []struct {
+		url  string
+		auth bool
+	}{
+		{EndpointGateway, true},
+		{"http://localhost:8080/api/v9/gateway", true},
+		{"https://cdn.discordapp.com/attachments/1/2/a.png", false},
+		{"https://example.com/api/v9/gateway", false},
+	}
+
+	for _, test := range tests {
+		for _, c := range []*Client{client, proxied} {
+			r := httpdriver.NewMockRequest("GET", test.url, nil, nil)
+			if err := c.InjectRequest(r); err != nil {
+				t.Fatal("failed to inject request:", err)
+			}
+
+			if auth := r.Header.Get("Authorization") != ""; auth != test.auth {
+				t.Errorf("%s: expected authorization %v, got %v", test.url, test.auth, auth)
+			}
+		}
+	}
+}
+
+func TestWithUserAgent(t *testing.T) {
+	const userAgent = "DiscordBot (https://example.com, 1.0)"
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if ua := r.Header.Get("User-Agent"); ua != userAgent {
+			t.Errorf("unexpected User-Agent %q", ua)
+		}
+		w.Write([]byte(`{"id":"1"}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	client := NewClient("").WithBaseURL(srv.URL).WithUserAgent(userAgent)
+
+	if _, err := client.Me(); err != nil {
+		t.Fatal("failed to get me:", err)
+	}
+}
+
+func TestWithoutRateLimit(t *testing.T) {
+	var hits int32
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&hits, 1) == 1 {
+			w.Header().Set("Retry-After", "10")
+			w.Header().Set("X-RateLimit-Global", "true")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte(`{"id":"1"}`))
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	client := NewClient("Bot token").
+		WithBaseURL(srv.URL).
+		WithoutRateLimit().
+		WithContext(ctx)
+
+	start := time.Now()
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.Me(); err != nil {
+			t.Fatalf("request %d: unexpected error: %v", i, err)
+		}
+	}
+
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatal("requests waited for the local rate limiter:", elapsed)
+	}
+}
@@ -2,6 +2,9 @@ package api
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
+	"unicode/utf8"
 
 	"github.com/diamondburned/arikawa/v3/discord"
 	"github.com/diamondburned/arikawa/v3/utils/httputil"
@@ -10,12 +13,14 @@ import (
 var EndpointApplications = Endpoint + "applications/"
 
 // CurrentApplication returns the current bot account's Discord application. It
-// can be used to get the application ID.
+// can be used to get the application ID. The returned application contains the
+// owning Team, if any, which can be used to check for owners using
+// Application.IsOwner.
 func (c *Client) CurrentApplication() (*discord.Application, error) {
 	var app *discord.Application
 	return app, c.RequestJSON(
 		&app, "GET",
-		Endpoint+"/oauth2/applications/@me",
+		Endpoint+"oauth2/applications/@me",
 	)
 }
 
@@ -32,6 +37,13 @@ type CreateCommandData struct {
 	NoDMPermission           bool                   `json:"-"`
 	NoDefaultPermission      bool                   `json:"-"`
 	Type                     discord.CommandType    `json:"type,omitempty"`
+
+	// IntegrationTypes are the installation contexts where the command is
+	// available, only for globally-scoped commands.
+	IntegrationTypes []discord.ApplicationIntegrationType `json:"integration_types,omitempty"`
+	// Contexts are the interaction contexts where the command can be used,
+	// only for globally-scoped commands.
+	Contexts []discord.InteractionContextType `json:"contexts,omitempty"`
 }
 
 func (c CreateCommandData) MarshalJSON() ([]byte, error) {
@@ -76,6 +88,149 @@ func (c *CreateCommandData) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// maxCommandTextLength is the maximum combined length of the names,
+// descriptions and choice values of a chat input command in any language.
+const maxCommandTextLength = 4000
+
+// ValidateCommand checks the given command against the constraints that Discord
+// enforces when it is created, so that mistakes are caught before Discord
+// rejects the command with a cryptic error. It checks that:
+//
+//   - chat input commands have a valid name and a 1 to 100 characters long
+//     description, and that their options are valid according to
+//     discord.ValidateCommandOptions, which also limits how deep options can
+//     be nested;
+//   - user and message commands have a 1 to 32 characters long name, and
+//     neither a description nor options;
+//   - localizations use valid language codes;
+//   - the combined length of the command's names, descriptions and choice
+//     values is at most 4000 characters in every language.
+//
+// Commands of other types, such as entry point commands, are not checked.
+// ValidateCommand is never called by Client, since Discord may accept commands
+// and languages that it doesn't know about yet.
+func ValidateCommand(cmd CreateCommandData) error {
+	switch cmd.Type {
+	case 0, discord.ChatInputCommand:
+		return validateChatInputCommand(cmd)
+	case discord.UserCommand, discord.MessageCommand:
+		return validateContextMenuCommand(cmd)
+	default:
+		return nil
+	}
+}
+
+func validateChatInputCommand(cmd CreateCommandData) error {
+	if err := discord.ValidateCommandName(cmd.Name); err != nil {
+		return fmt.Errorf("invalid name: %w", err)
+	}
+
+	switch n := utf8.RuneCountInString(cmd.Description); {
+	case n == 0:
+		return errors.New("description is empty")
+	case n > 100:
+		return &discord.OverboundError{Count: n, Max: 100, Thing: "description"}
+	}
+
+	err := discord.ValidateCommandLocalizations(cmd.NameLocalizations, cmd.DescriptionLocalizations)
+	if err != nil {
+		return err
+	}
+
+	if err := discord.ValidateCommandOptions(cmd.Options); err != nil {
+		return fmt.Errorf("invalid options: %w", err)
+	}
+
+	if err := discord.ValidateCommandOptionLocalizations(cmd.Options); err != nil {
+		return fmt.Errorf("invalid options: %w", err)
+	}
+
+	// Languages that the command isn't localized into fall back to the
+	// default strings, so every language has to be checked.
+	langs := append([]discord.Language{""}, discord.Languages...)
+
+	for _, lang := range langs {
+		n := commandTextLength(cmd, lang)
+		if n <= maxCommandTextLength {
+			continue
+		}
+
+		thing := "command text"
+		if lang != "" {
+			thing = string(lang) + " command text"
+		}
+
+		return &discord.OverboundError{Count: n, Max: maxCommandTextLength, Thing: thing}
+	}
+
+	return nil
+}
+
+func validateContextMenuCommand(cmd CreateCommandData) error {
+	if err := validateContextMenuName(cmd.Name); err != nil {
+		return fmt.Errorf("invalid name: %w", err)
+	}
+
+	for lang, name := range cmd.NameLocalizations {
+		if !lang.IsValid() {
+			return fmt.Errorf("invalid language %q in name localizations", lang)
+		}
+		if err := validateContextMenuName(name); err != nil {
+			return fmt.Errorf("invalid %s name localization: %w", lang, err)
+		}
+	}
+
+	if cmd.Description != "" || len(cmd.DescriptionLocalizations) > 0 {
+		return errors.New("user and message commands cannot have a description")
+	}
+
+	if len(cmd.Options) > 0 {
+		return errors.New("user and message commands cannot have options")
+	}
+
+	return nil
+}
+
+// validateContextMenuName checks the name of a user or message command, which
+// may contain any characters, including spaces and uppercase letters.
+func validateContextMenuName(name string) error {
+	switch n := utf8.RuneCountInString(name); {
+	case n == 0:
+		return errors.New("name is empty")
+	case n > 32:
+		return &discord.OverboundError{Count: n, Max: 32, Thing: "name"}
+	}
+	return nil
+}
+
+// commandTextLength returns the combined length of the command's text in the
+// given language. See discord.CommandOptions.TextLength.
+func commandTextLength(cmd CreateCommandData, lang discord.Language) int {
+	name := cmd.Name
+	if localized, ok := cmd.NameLocalizations[lang]; ok && lang != "" {
+		name = localized
+	}
+
+	desc := cmd.Description
+	if localized, ok := cmd.DescriptionLocalizations[lang]; ok && lang != "" {
+		desc = localized
+	}
+
+	n := utf8.RuneCountInString(name) + utf8.RuneCountInString(desc)
+	return n + cmd.Options.TextLength(lang)
+}
+
+// validateCommands checks the options of the given commands before they're
+// sent. See discord.ValidateCommandOptions.
+func validateCommands(cmds ...CreateCommandData) error {
+	for _, cmd := range cmds {
+		if err := discord.ValidateCommandOptions(cmd.Options); err != nil {
+			return fmt.Errorf("invalid options for command %q: %w", cmd.Name, err)
+		}
+	}
+	return nil
+}
+
 func (c *Client) Commands(appID discord.AppID) ([]discord.Command, error) {
 	var cmds []discord.Command
 	return cmds, c.RequestJSON(
@@ -97,6 +252,10 @@ func (c *Client) Command(
 func (c *Client) CreateCommand(
 	appID discord.AppID, data CreateCommandData) (*discord.Command, error) {
 
+	if err := validateCommands(data); err != nil {
+		return nil, err
+	}
+
 	var cmd *discord.Command
 	return cmd, c.RequestJSON(
 		&cmd, "POST",
@@ -109,6 +268,10 @@ func (c *Client) EditCommand(
 	appID discord.AppID,
 	commandID discord.CommandID, data CreateCommandData) (*discord.Command, error) {
 
+	if err := validateCommands(data); err != nil {
+		return nil, err
+	}
+
 	var cmd *discord.Command
 	return cmd, c.RequestJSON(
 		&cmd, "PATCH",
@@ -133,6 +296,10 @@ func (c *Client) DeleteCommand(appID discord.AppID, commandID discord.CommandID)
 func (c *Client) BulkOverwriteCommands(
 	appID discord.AppID, commands []CreateCommandData) ([]discord.Command, error) {
 
+	if err := validateCommands(commands...); err != nil {
+		return nil, err
+	}
+
 	var cmds []discord.Command
 	return cmds, c.RequestJSON(
 		&cmds, "PUT",
@@ -167,6 +334,10 @@ func (c *Client) CreateGuildCommand(
 	appID discord.AppID,
 	guildID discord.GuildID, data CreateCommandData) (*discord.Command, error) {
 
+	if err := validateCommands(data); err != nil {
+		return nil, err
+	}
+
 	var cmd *discord.Command
 	return cmd, c.RequestJSON(
 		&cmd, "POST",
@@ -179,6 +350,10 @@ func (c *Client) EditGuildCommand(
 	appID discord.AppID, guildID discord.GuildID,
 	commandID discord.CommandID, data CreateCommandData) (*discord.Command, error) {
 
+	if err := validateCommands(data); err != nil {
+		return nil, err
+	}
+
 	var cmd *discord.Command
 	return cmd, c.RequestJSON(
 		&cmd, "PATCH",
@@ -206,6 +381,10 @@ func (c *Client) BulkOverwriteGuildCommands(
 	appID discord.AppID,
 	guildID discord.GuildID, commands []CreateCommandData) ([]discord.Command, error) {
 
+	if err := validateCommands(commands...); err != nil {
+		return nil, err
+	}
+
 	var cmds []discord.Command
 	return cmds, c.RequestJSON(
 		&cmds, "PUT",
@@ -244,8 +423,13 @@ type editCommandPermissionsData struct {
 }
 
 // EditCommandPermissions edits command permissions for a specific command for
-// the application in a guild. Up to 10 permission overwrites can be added for
-// a command.
+// the application in a guild. Up to 100 permission overwrites can be added for
+// a command. To edit the permissions of all commands that have no overwrites
+// of their own, give the application ID as the command ID.
+//
+// This endpoint requires a Bearer token with the
+// applications.commands.permissions.update scope, which the client must be
+// created with. Bot tokens are not accepted.
 //
 // Existing permissions for the command will be overwritten in that guild.
 // Deleting or renaming a command will permanently delete all permissions for
@@ -277,6 +461,9 @@ type BatchEditCommandPermissionsData struct {
 // Existing permissions for the command will be overwritten in that guild.
 // Deleting or renaming a command will permanently delete all permissions for
 // that command.
+//
+// Deprecated: Discord has removed this endpoint with the permissions v2
+// update. Use EditCommandPermissions for each command instead.
 func (c *Client) BatchEditCommandPermissions(
 	appID discord.AppID, guildID discord.GuildID,
 	data []BatchEditCommandPermissionsData) ([]discord.GuildCommandPermissions, error) {
@@ -0,0 +1,158 @@
+package api
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+)
+
+func TestValidateCommand(t *testing.T) {
+	longChoices := make([]discord.StringChoice, 25)
+	for i := range longChoices {
+		longChoices[i] = discord.StringChoice{
+			Name:  strings.Repeat("n", 100),
+			Value: string(rune('a'+i)) + strings.Repeat("v", 99),
+		}
+	}
+
+	tests := []struct {
+		name string
+		cmd  CreateCommandData
+		err  string
+	}{
+		{
+			name: "valid chat input",
+			cmd: CreateCommandData{
+				Name:              "ping",
+				NameLocalizations: discord.StringLocales{discord.German: "pingen"},
+				Description:       "Ping the bot.",
+				Options: discord.CommandOptions{
+					&discord.BooleanOption{OptionName: "loud", Description: "Be loud."},
+				},
+			},
+		},
+		{
+			name: "valid user command",
+			cmd: CreateCommandData{
+				Type:              discord.UserCommand,
+				Name:              "High Five",
+				NameLocalizations: discord.StringLocales{discord.French: "Tape-là"},
+			},
+		},
+		{
+			name: "uppercase chat input name",
+			cmd:  CreateCommandData{Name: "Ping", Description: "Ping the bot."},
+			err:  `invalid name: name "Ping" must be lowercase`,
+		},
+		{
+			name: "missing description",
+			cmd:  CreateCommandData{Name: "ping"},
+			err:  "description is empty",
+		},
+		{
+			name: "invalid language",
+			cmd: CreateCommandData{
+				Name:              "ping",
+				Description:       "Ping the bot.",
+				NameLocalizations: discord.StringLocales{"en": "ping"},
+			},
+			err: `invalid language "en" in name localizations`,
+		},
+		{
+			name: "invalid option localization",
+			cmd: CreateCommandData{
+				Name:        "ping",
+				Description: "Ping the bot.",
+				Options: discord.CommandOptions{
+					&discord.BooleanOption{
+						OptionName:              "loud",
+						OptionNameLocalizations: discord.StringLocales{discord.German: "Laut"},
+						Description:             "Be loud.",
+					},
+				},
+			},
+			err: `invalid options: option "loud": invalid de name localization: name "Laut" must be lowercase`,
+		},
+		{
+			name: "message command with description",
+			cmd: CreateCommandData{
+				Type:        discord.MessageCommand,
+				Name:        "Bookmark",
+				Description: "Bookmark a message.",
+			},
+			err: "user and message commands cannot have a description",
+		},
+		{
+			name: "unknown type",
+			cmd:  CreateCommandData{Type: 4, Name: "launch"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := ValidateCommand(test.cmd)
+			if test.err == "" {
+				if err != nil {
+					t.Fatal("unexpected error:", err)
+				}
+				return
+			}
+
+			if err == nil {
+				t.Fatalf("expected error %q", test.err)
+			}
+			if err.Error() != test.err {
+				t.Fatalf("expected error %q, got %q", test.err, err)
+			}
+		})
+	}
+
+	t.Run("text length", func(t *testing.T) {
+		cmd := CreateCommandData{
+			Name:        "choose",
+			Description: "Choose something.",
+			Options: discord.CommandOptions{
+				&discord.StringOption{
+					OptionName:  "first",
+					Description: "The first choice.",
+					Choices:     longChoices,
+				},
+				&discord.StringOption{
+					OptionName:  "second",
+					Description: "The second choice.",
+					Choices:     longChoices,
+				},
+			},
+		}
+
+		var overbound *discord.OverboundError
+		if err := ValidateCommand(cmd); !errors.As(err, &overbound) {
+			t.Fatal("expected OverboundError, got", err)
+		}
+		if overbound.Max != 4000 {
+			t.Fatal("unexpected maximum:", overbound.Max)
+		}
+	})
+}
+
+func TestCreateCommandDataMarshalContexts(t *testing.T) {
+	data := CreateCommandData{
+		Name:             "ping",
+		Description:      "Ping the bot.",
+		IntegrationTypes: []discord.ApplicationIntegrationType{discord.GuildInstall, discord.UserInstall},
+		Contexts:         []discord.InteractionContextType{discord.InteractionContextBotDM},
+	}
+
+	j := mustMarshal(t, data)
+
+	for _, expect := range []string{
+		`"integration_types":[0,1]`,
+		`"contexts":[1]`,
+	} {
+		if !strings.Contains(j, expect) {
+			t.Errorf("expected %s in %s", expect, j)
+		}
+	}
+}
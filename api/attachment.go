@@ -0,0 +1,59 @@
+package api
+
+import (
+	"fmt"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/utils/httputil"
+)
+
+// EndpointAttachments is the endpoint for refreshing attachment URLs.
+var EndpointAttachments = Endpoint + "attachments/"
+
+var _ discord.CDNURLRefresher = (*Client)(nil)
+
+// RefreshedCDNURL is a CDN URL and its refreshed counterpart.
+type RefreshedCDNURL struct {
+	Original  discord.URL `json:"original"`
+	Refreshed discord.URL `json:"refreshed"`
+}
+
+// RefreshCDNURL refreshes the signatures of the given Discord CDN URLs, which
+// expire after some time. The returned URLs are in the same order as the given
+// ones. It implements discord.CDNURLRefresher.
+func (c *Client) RefreshCDNURL(urls ...discord.URL) ([]discord.URL, error) {
+	var param struct {
+		AttachmentURLs []discord.URL `json:"attachment_urls"`
+	}
+
+	param.AttachmentURLs = urls
+
+	var resp struct {
+		RefreshedURLs []RefreshedCDNURL `json:"refreshed_urls"`
+	}
+
+	err := c.RequestJSON(
+		&resp, "POST",
+		EndpointAttachments+"refresh-urls",
+		httputil.WithJSONBody(param),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	refreshed := make(map[discord.URL]discord.URL, len(resp.RefreshedURLs))
+	for _, u := range resp.RefreshedURLs {
+		refreshed[u.Original] = u.Refreshed
+	}
+
+	out := make([]discord.URL, len(urls))
+	for i, u := range urls {
+		r, ok := refreshed[u]
+		if !ok {
+			return nil, fmt.Errorf("URL %q was not refreshed", u)
+		}
+		out[i] = r
+	}
+
+	return out, nil
+}
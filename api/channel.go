@@ -361,6 +361,29 @@ func (c *Client) RemoveRecipient(channelID discord.ChannelID, userID discord.Use
 	)
 }
 
+// FollowNewsChannel follows the announcement channel with the given ID, so
+// that its messages are crossposted into the target channel through a webhook.
+//
+// Requires the MANAGE_WEBHOOKS permission in the target channel.
+//
+// Fires a Webhooks Update Gateway event for the target channel.
+func (c *Client) FollowNewsChannel(
+	channelID, targetChannelID discord.ChannelID,
+	reason AuditLogReason) (*discord.FollowedChannel, error) {
+
+	var param struct {
+		WebhookChannelID discord.ChannelID `json:"webhook_channel_id"`
+	}
+
+	param.WebhookChannelID = targetChannelID
+
+	var followed *discord.FollowedChannel
+	return followed, c.RequestJSON(
+		&followed, "POST", EndpointChannels+channelID.String()+"/followers",
+		httputil.WithJSONBody(param), httputil.WithHeaders(reason.Header()),
+	)
+}
+
 // Ack is the read state of a channel. This is undocumented.
 type Ack struct {
 	Token *string `json:"token"`
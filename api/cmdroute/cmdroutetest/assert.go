@@ -0,0 +1,70 @@
+package cmdroutetest
+
+import (
+	"testing"
+
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/discord"
+)
+
+// AssertContent asserts that the given response is a message response with the
+// given content.
+func AssertContent(t testing.TB, resp *api.InteractionResponse, content string) {
+	t.Helper()
+
+	if resp == nil {
+		t.Fatalf("expected response with content %q, got nil", content)
+	}
+	if resp.Type != api.MessageInteractionWithSource {
+		t.Fatalf("expected message response, got type %d", resp.Type)
+	}
+	AssertDataContent(t, resp.Data, content)
+}
+
+// AssertDataContent asserts that the given response data has the given
+// content.
+func AssertDataContent(t testing.TB, data *api.InteractionResponseData, content string) {
+	t.Helper()
+
+	if data == nil || data.Content == nil {
+		t.Fatalf("expected content %q, got none", content)
+	}
+	if data.Content.Val != content {
+		t.Fatalf("expected content %q, got %q", content, data.Content.Val)
+	}
+}
+
+// AssertDeferred asserts that the given response defers the message.
+func AssertDeferred(t testing.TB, resp *api.InteractionResponse) {
+	t.Helper()
+
+	if resp == nil {
+		t.Fatal("expected deferred response, got nil")
+	}
+	if resp.Type != api.DeferredMessageInteractionWithSource {
+		t.Fatalf("expected deferred response, got type %d", resp.Type)
+	}
+}
+
+// AssertEphemeral asserts that the given response is only visible to the
+// invoking user.
+func AssertEphemeral(t testing.TB, resp *api.InteractionResponse) {
+	t.Helper()
+
+	if resp == nil || resp.Data == nil {
+		t.Fatal("expected ephemeral response, got no data")
+	}
+	if resp.Data.Flags&discord.EphemeralMessage == 0 {
+		t.Fatalf("expected ephemeral response, got flags %d", resp.Data.Flags)
+	}
+}
+
+// AssertNoResponse asserts that the handler did not respond, which the router
+// does when no handler matches.
+func AssertNoResponse(t testing.TB, resp *api.InteractionResponse) {
+	t.Helper()
+
+	if resp != nil {
+		t.Fatalf("expected no response, got type %d", resp.Type)
+	}
+}
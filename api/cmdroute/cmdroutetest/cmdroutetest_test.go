@@ -0,0 +1,102 @@
+package cmdroutetest
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/api/cmdroute"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/utils/json/option"
+)
+
+func TestCommand(t *testing.T) {
+	var opts struct {
+		Text  string         `discord:"text"`
+		Count int            `discord:"count"`
+		User  discord.UserID `discord:"user"`
+	}
+
+	r := cmdroute.NewRouter()
+	r.Sub("group", func(r *cmdroute.Router) {
+		r.Sub("sub", func(r *cmdroute.Router) {
+			r.AddFunc("cmd", func(ctx context.Context, data cmdroute.CommandData) *api.InteractionResponseData {
+				if err := data.Options.Unmarshal(&opts); err != nil {
+					t.Fatal("failed to unmarshal options:", err)
+				}
+
+				user := data.Data.Resolved.Users[opts.User]
+				return &api.InteractionResponseData{
+					Content: option.NewNullableString(fmt.Sprint(opts.Text, opts.Count, user.Username)),
+				}
+			})
+		})
+	})
+
+	cmd := NewCommand("group").
+		Sub("sub", "cmd").
+		Options(map[string]interface{}{
+			"text":  "hi",
+			"count": 2,
+			"user":  discord.UserID(1),
+		}).
+		Resolved(discord.ResolvedData{
+			Users: map[discord.UserID]discord.User{1: {ID: 1, Username: "user"}},
+		})
+
+	resp := NewResponder()
+	AssertContent(t, resp.Handle(r, cmd.Event()), "hi2user")
+
+	if n := len(resp.Responses()); n != 1 {
+		t.Fatalf("expected 1 recorded response, got %d", n)
+	}
+
+	AssertNoResponse(t, resp.Handle(r, NewCommand("unknown").Event()))
+}
+
+func TestResponderDeferred(t *testing.T) {
+	resp := NewResponder()
+
+	r := cmdroute.NewRouter()
+	r.Use(cmdroute.Deferrable(resp, cmdroute.DeferOpts{
+		Timeout: 10 * time.Millisecond,
+		Flags:   discord.EphemeralMessage,
+	}))
+	r.AddFunc("slow", func(ctx context.Context, data cmdroute.CommandData) *api.InteractionResponseData {
+		<-cmdroute.DeferTicketFromContext(ctx).Context().Done()
+		return &api.InteractionResponseData{
+			Content: option.NewNullableString("done"),
+		}
+	})
+
+	got := resp.Handle(r, NewCommand("slow").Event())
+	AssertDeferred(t, got)
+	AssertEphemeral(t, got)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	followUps, err := resp.WaitFollowUps(ctx, 1)
+	if err != nil {
+		t.Fatal("failed to wait for follow-ups:", err)
+	}
+	AssertDataContent(t, &followUps[0], "done")
+}
+
+func TestCommandData(t *testing.T) {
+	data := NewCommand("ping").
+		Options(map[string]interface{}{"b": true, "a": 1.5}).
+		Data()
+
+	if data.Name != "ping" || len(data.Options) != 2 {
+		t.Fatalf("unexpected data %#v", data.CommandInteractionOption)
+	}
+	if data.Options[0].Name != "a" || data.Options[0].Type != discord.NumberOptionType {
+		t.Fatalf("unexpected first option %#v", data.Options[0])
+	}
+	if data.Options[1].Type != discord.BooleanOptionType {
+		t.Fatalf("unexpected second option %#v", data.Options[1])
+	}
+}
@@ -0,0 +1,194 @@
+// Package cmdroutetest provides utilities for testing cmdroute handlers
+// without a live State or API client.
+package cmdroutetest
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/diamondburned/arikawa/v3/api/cmdroute"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/utils/json"
+)
+
+// Command builds a fake slash command interaction. A zero-value Command is not
+// valid; use NewCommand.
+type Command struct {
+	event   discord.InteractionEvent
+	data    discord.CommandInteraction
+	path    []string
+	options discord.CommandInteractionOptions
+}
+
+// NewCommand creates a new Command builder for the command with the given
+// name. The event has fake but non-zero IDs and token.
+func NewCommand(name string) *Command {
+	return &Command{
+		event: discord.InteractionEvent{
+			ID:        100,
+			AppID:     200,
+			ChannelID: 300,
+			Token:     "cmdroutetest token",
+			User:      &discord.User{ID: 400, Username: "cmdroutetest"},
+		},
+		data: discord.CommandInteraction{
+			ID:   500,
+			Name: name,
+		},
+	}
+}
+
+// Sub sets the subcommand path of the command. For a subcommand, give one
+// name; for a subcommand within a group, give the group name then the
+// subcommand name.
+func (c *Command) Sub(names ...string) *Command {
+	if len(names) > 2 {
+		panic("cmdroutetest: subcommands can only be nested twice")
+	}
+	c.path = names
+	return c
+}
+
+// Options sets the options of the (sub)command from the given map. The option
+// type is inferred from the type of each value:
+//
+//   - string is a StringOptionType
+//   - int and int64 are IntegerOptionType
+//   - float64 is a NumberOptionType
+//   - bool is a BooleanOptionType
+//   - discord.UserID is a UserOptionType
+//   - discord.ChannelID is a ChannelOptionType
+//   - discord.RoleID is a RoleOptionType
+//   - discord.Snowflake is a MentionableOptionType
+//   - discord.AttachmentID is an AttachmentOptionType
+//
+// Options are sorted by name. Any other value type causes a panic.
+func (c *Command) Options(opts map[string]interface{}) *Command {
+	names := make([]string, 0, len(opts))
+	for name := range opts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	c.options = make(discord.CommandInteractionOptions, len(names))
+	for i, name := range names {
+		c.options[i] = newOption(name, opts[name])
+	}
+
+	return c
+}
+
+func newOption(name string, v interface{}) discord.CommandInteractionOption {
+	var typ discord.CommandOptionType
+
+	switch v.(type) {
+	case string:
+		typ = discord.StringOptionType
+	case int, int64:
+		typ = discord.IntegerOptionType
+	case float64:
+		typ = discord.NumberOptionType
+	case bool:
+		typ = discord.BooleanOptionType
+	case discord.UserID:
+		typ = discord.UserOptionType
+	case discord.ChannelID:
+		typ = discord.ChannelOptionType
+	case discord.RoleID:
+		typ = discord.RoleOptionType
+	case discord.Snowflake:
+		typ = discord.MentionableOptionType
+	case discord.AttachmentID:
+		typ = discord.AttachmentOptionType
+	default:
+		panic(fmt.Sprintf("cmdroutetest: unsupported option %q of type %T", name, v))
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		panic(fmt.Sprintf("cmdroutetest: failed to marshal option %q: %v", name, err))
+	}
+
+	return discord.CommandInteractionOption{
+		Type:  typ,
+		Name:  name,
+		Value: json.Raw(b),
+	}
+}
+
+// Resolved sets the resolved data of the command, which is where Discord puts
+// the objects referenced by user, channel, role, mentionable and attachment
+// options.
+func (c *Command) Resolved(resolved discord.ResolvedData) *Command {
+	c.data.Resolved = resolved
+	return c
+}
+
+// Guild makes the command appear to be invoked in the given guild by the given
+// member.
+func (c *Command) Guild(guildID discord.GuildID, member discord.Member) *Command {
+	c.event.GuildID = guildID
+	c.event.Member = &member
+	c.event.User = nil
+	return c
+}
+
+// User makes the command appear to be invoked in a DM by the given user.
+func (c *Command) User(user discord.User) *Command {
+	c.event.GuildID = 0
+	c.event.Member = nil
+	c.event.User = &user
+	return c
+}
+
+// Event returns a new interaction event for the command. It can be given to a
+// cmdroute.Router's HandleInteraction.
+func (c *Command) Event() *discord.InteractionEvent {
+	data := c.data
+	data.Options = c.options
+
+	switch len(c.path) {
+	case 1:
+		data.Options = discord.CommandInteractionOptions{{
+			Type:    discord.SubcommandOptionType,
+			Name:    c.path[0],
+			Options: c.options,
+		}}
+	case 2:
+		data.Options = discord.CommandInteractionOptions{{
+			Type: discord.SubcommandGroupOptionType,
+			Name: c.path[0],
+			Options: discord.CommandInteractionOptions{{
+				Type:    discord.SubcommandOptionType,
+				Name:    c.path[1],
+				Options: c.options,
+			}},
+		}}
+	}
+
+	ev := c.event
+	ev.Data = &data
+	return &ev
+}
+
+// Data returns the data that a cmdroute.Router would give to the
+// CommandHandler for the command. It can be given directly to a
+// CommandHandler's HandleCommand.
+func (c *Command) Data() cmdroute.CommandData {
+	ev := c.Event()
+
+	name := c.data.Name
+	if len(c.path) > 0 {
+		name = c.path[len(c.path)-1]
+	}
+
+	return cmdroute.CommandData{
+		CommandInteractionOption: discord.CommandInteractionOption{
+			Type:    discord.SubcommandOptionType,
+			Name:    name,
+			Options: c.options,
+		},
+		Event: ev,
+		Data:  ev.Data.(*discord.CommandInteraction),
+	}
+}
@@ -0,0 +1,130 @@
+package cmdroutetest
+
+import (
+	"context"
+	"sync"
+
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/api/cmdroute"
+	"github.com/diamondburned/arikawa/v3/api/webhook"
+	"github.com/diamondburned/arikawa/v3/discord"
+)
+
+// Responder is an in-memory stand-in for an *api.Client that records every
+// interaction response and follow-up that it is given. It can be given to
+// cmdroute.Deferrable as its FollowUpSender. A zero-value Responder is valid.
+type Responder struct {
+	mu        sync.Mutex
+	cond      *sync.Cond
+	responses []api.InteractionResponse
+	followUps []api.InteractionResponseData
+}
+
+var _ cmdroute.FollowUpSender = (*Responder)(nil)
+
+// NewResponder creates a new Responder.
+func NewResponder() *Responder {
+	return &Responder{}
+}
+
+func (r *Responder) init() {
+	if r.cond == nil {
+		r.cond = sync.NewCond(&r.mu)
+	}
+}
+
+// Handle calls h with the given event and records the returned response, if
+// any. The response is returned.
+func (r *Responder) Handle(h webhook.InteractionHandler, ev *discord.InteractionEvent) *api.InteractionResponse {
+	resp := h.HandleInteraction(ev)
+	if resp != nil {
+		r.RespondInteraction(ev.ID, ev.Token, *resp)
+	}
+	return resp
+}
+
+// RespondInteraction records the given response. It never fails.
+func (r *Responder) RespondInteraction(
+	id discord.InteractionID, token string, resp api.InteractionResponse) error {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.init()
+	r.responses = append(r.responses, resp)
+	r.cond.Broadcast()
+
+	return nil
+}
+
+// FollowUpInteraction records the given follow-up. It never fails, and the
+// returned message only has its content set.
+func (r *Responder) FollowUpInteraction(
+	appID discord.AppID, token string, data api.InteractionResponseData) (*discord.Message, error) {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.init()
+	r.followUps = append(r.followUps, data)
+	r.cond.Broadcast()
+
+	msg := &discord.Message{}
+	if data.Content != nil {
+		msg.Content = data.Content.Val
+	}
+
+	return msg, nil
+}
+
+// Responses returns a copy of all recorded responses.
+func (r *Responder) Responses() []api.InteractionResponse {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]api.InteractionResponse(nil), r.responses...)
+}
+
+// FollowUps returns a copy of all recorded follow-ups.
+func (r *Responder) FollowUps() []api.InteractionResponseData {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]api.InteractionResponseData(nil), r.followUps...)
+}
+
+// WaitFollowUps waits until at least n follow-ups are recorded, then returns
+// all of them. It is useful for deferred handlers, which send their follow-ups
+// in the background. An error is returned if ctx expires first.
+func (r *Responder) WaitFollowUps(ctx context.Context, n int) ([]api.InteractionResponseData, error) {
+	r.mu.Lock()
+	r.init()
+	r.mu.Unlock()
+
+	// Wake the waiter up once the context expires, since sync.Cond cannot
+	// select on it.
+	stop := make(chan struct{})
+	defer close(stop)
+
+	go func() {
+		select {
+		case <-ctx.Done():
+			r.mu.Lock()
+			r.cond.Broadcast()
+			r.mu.Unlock()
+		case <-stop:
+		}
+	}()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for len(r.followUps) < n {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		r.cond.Wait()
+	}
+
+	return append([]api.InteractionResponseData(nil), r.followUps...), nil
+}
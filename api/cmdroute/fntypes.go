@@ -41,6 +41,34 @@ type CommandData struct {
 	Data  *discord.CommandInteraction
 }
 
+// Sender returns the user who invoked the command. See
+// discord.InteractionEvent.Sender.
+func (d CommandData) Sender() *discord.User {
+	return d.Event.Sender()
+}
+
+// Member returns the member who invoked the command, or nil if the command was
+// not invoked in a guild.
+func (d CommandData) Member() *discord.Member {
+	return d.Event.Member
+}
+
+// GuildID returns the ID of the guild that the command was invoked in, or 0 if
+// it was not invoked in a guild.
+func (d CommandData) GuildID() discord.GuildID {
+	return d.Event.GuildID
+}
+
+// ChannelID returns the ID of the channel that the command was invoked in.
+func (d CommandData) ChannelID() discord.ChannelID {
+	return d.Event.ChannelID
+}
+
+// Locale returns the selected language of the user who invoked the command.
+func (d CommandData) Locale() discord.Language {
+	return d.Event.Locale
+}
+
 // CommandHandler is a slash command handler.
 type CommandHandler interface {
 	// HandleCommand is expected to return a response synchronously, either to
@@ -14,6 +14,7 @@ const (
 	_ ctxKey = iota
 	ctxCtx
 	deferTicketCtx
+	ticketCtx
 )
 
 // UseContext returns a middleware that override the handler context to the
@@ -52,6 +53,9 @@ type DeferOpts struct {
 // Deferrable marks a router as deferrable, meaning if the handler does not
 // return a response within the deadline, the response will be automatically
 // deferred.
+//
+// If client is also a TicketClient, handlers are given a Ticket through the
+// context to manage the response later. See TicketFromContext.
 func Deferrable(client FollowUpSender, opts DeferOpts) Middleware {
 	if opts.Timeout == 0 {
 		opts.Timeout = 1*time.Second + 500*time.Millisecond
@@ -62,12 +66,20 @@ func Deferrable(client FollowUpSender, opts DeferOpts) Middleware {
 			timeout, cancel := context.WithTimeout(ctx, opts.Timeout)
 			defer cancel()
 
+			var ticket *Ticket
+			if client, ok := client.(TicketClient); ok {
+				ticket = NewTicket(client, ev)
+			}
+
 			respCh := make(chan *api.InteractionResponse, 1)
 			go func() {
 				ctx := context.WithValue(ctx, deferTicketCtx, DeferTicket{
 					ctx:     timeout,
 					deferFn: cancel,
 				})
+				if ticket != nil {
+					ctx = context.WithValue(ctx, ticketCtx, ticket)
+				}
 
 				resp := next.HandleInteraction(ctx, ev)
 				if resp != nil && opts.Flags > 0 {
@@ -92,7 +104,13 @@ func Deferrable(client FollowUpSender, opts DeferOpts) Middleware {
 					if resp == nil || resp.Data == nil {
 						return
 					}
-					m, err := client.FollowUpInteraction(ev.AppID, ev.Token, *resp.Data)
+					var m *discord.Message
+					var err error
+					if ticket != nil {
+						m, err = ticket.Followup(*resp.Data)
+					} else {
+						m, err = client.FollowUpInteraction(ev.AppID, ev.Token, *resp.Data)
+					}
 					if err != nil && opts.Error != nil {
 						opts.Error(err)
 					}
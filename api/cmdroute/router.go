@@ -12,8 +12,8 @@ import (
 type Router struct {
 	nodes  map[string]routeNode
 	mws    []Middleware
-	parent *Router  // parent router, if any
-	groups []Router // next routers to check, if any
+	parent *Router   // parent router, if any
+	groups []*Router // next routers to check, if any
 }
 
 type routeNode interface {
@@ -115,11 +115,15 @@ func (r *Router) Group(f func(r *Router)) {
 //
 //	r := cmdroute.NewRouter()
 //	r.With(cmdroute.Deferrable(client, cmdroute.DeferOpts{})).Add("foo", handleFoo)
+//
+// The middlewares given are only applied to the handlers added to the returned
+// router, which makes With the way to assign middlewares to a single command.
+// Groups may be nested, in which case the middlewares of the outer groups are
+// applied first.
 func (r *Router) With(mws ...Middleware) *Router {
-	r.groups = append(r.groups, Router{})
-	sub := &r.groups[len(r.groups)-1]
-	sub.parent = r
+	sub := &Router{parent: r}
 	sub.mws = append(sub.mws, mws...)
+	r.groups = append(r.groups, sub)
 	return sub
 }
 
@@ -194,7 +198,7 @@ type handlerData struct {
 }
 
 // findCommandHandler finds the command handler for the given command name.
-// It checks the current router and its groups.
+// It checks the current router and its groups, recursively.
 func (r *Router) findCommandHandler(ev *discord.InteractionEvent, data discord.CommandInteractionOption) (handlerData, bool) {
 	found, ok := r.findCommandHandlerOnce(ev, data)
 	if ok {
@@ -202,7 +206,7 @@ func (r *Router) findCommandHandler(ev *discord.InteractionEvent, data discord.C
 	}
 
 	for _, sub := range r.groups {
-		found, ok = sub.findCommandHandlerOnce(ev, data)
+		found, ok = sub.findCommandHandler(ev, data)
 		if ok {
 			return found, true
 		}
@@ -259,17 +263,31 @@ func (r *Router) callCommandHandler(ev *discord.InteractionEvent, found handlerD
 	)
 }
 
-// AddAutocompleter registers an autocompleter for the given command name.
+// AddAutocompleter registers an autocompleter for the given command name. The
+// command may belong to the router itself or to any of its groups.
 func (r *Router) AddAutocompleter(name string, ac Autocompleter) {
-	r.init()
-
-	node, ok := r.nodes[name].(routeNodeCommand)
-	if !ok {
+	owner := r.findCommandOwner(name)
+	if owner == nil {
 		panic("cmdroute: cannot add autocompleter to unknown command " + name)
 	}
 
+	node := owner.nodes[name].(routeNodeCommand)
 	node.autocomplete = ac
-	r.nodes[name] = node
+	owner.nodes[name] = node
+}
+
+// findCommandOwner finds the router within r and its groups that owns the
+// command with the given name. Nil is returned if none is found.
+func (r *Router) findCommandOwner(name string) *Router {
+	if _, ok := r.nodes[name].(routeNodeCommand); ok {
+		return r
+	}
+	for _, sub := range r.groups {
+		if owner := sub.findCommandOwner(name); owner != nil {
+			return owner
+		}
+	}
+	return nil
 }
 
 // AddAutocompleterFunc is a convenience function that calls AddAutocompleter
@@ -317,7 +335,7 @@ type autocompleterData struct {
 }
 
 // findAutocompleter finds the autocomplete handler for the given option name.
-// It checks the current router and its groups.
+// It checks the current router and its groups, recursively.
 func (r *Router) findAutocompleter(ev *discord.InteractionEvent, data discord.AutocompleteOption) (autocompleterData, bool) {
 	found, ok := r.findAutocompleterOnce(ev, data)
 	if ok {
@@ -325,7 +343,7 @@ func (r *Router) findAutocompleter(ev *discord.InteractionEvent, data discord.Au
 	}
 
 	for _, sub := range r.groups {
-		found, ok = sub.findAutocompleterOnce(ev, data)
+		found, ok = sub.findAutocompleter(ev, data)
 		if ok {
 			return found, true
 		}
@@ -406,6 +424,13 @@ func (r *Router) handleComponent(ev *discord.InteractionEvent, component discord
 	if ok {
 		return r.callComponentHandler(ev, node.component)
 	}
+
+	for _, sub := range r.groups {
+		if resp := sub.handleComponent(ev, component); resp != nil {
+			return resp
+		}
+	}
+
 	return nil
 }
 
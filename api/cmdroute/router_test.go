@@ -75,6 +75,36 @@ func TestRouter(t *testing.T) {
 		}
 	})
 
+	t.Run("accessors", func(t *testing.T) {
+		ev := newInteractionEvent(&discord.CommandInteraction{ID: 4, Name: "test"})
+		ev.GuildID = 400
+		ev.Locale = discord.German
+		ev.Member = &discord.Member{User: discord.User{ID: 500}}
+
+		var called bool
+
+		r := NewRouter()
+		r.AddFunc("test", func(_ context.Context, data CommandData) *api.InteractionResponseData {
+			called = true
+
+			if data.Sender().ID != 500 || data.Member() != ev.Member {
+				t.Errorf("unexpected sender %+v", data.Sender())
+			}
+			if data.GuildID() != 400 || data.ChannelID() != 300 {
+				t.Errorf("unexpected guild %d or channel %d", data.GuildID(), data.ChannelID())
+			}
+			if data.Locale() != discord.German {
+				t.Errorf("unexpected locale %q", data.Locale())
+			}
+			return nil
+		})
+		r.HandleInteraction(ev)
+
+		if !called {
+			t.Fatal("handler was not called")
+		}
+	})
+
 	t.Run("autocomplete", func(t *testing.T) {
 		choices := []string{
 			"foo",
@@ -227,6 +257,49 @@ func TestRouter(t *testing.T) {
 		})
 	})
 
+	t.Run("groups", func(t *testing.T) {
+		var stack middlewareStacker
+
+		r := NewRouter()
+		r.Use(stack.pusher("root"))
+
+		foo := r.With(stack.pusher("foo"))
+		bar := r.With(stack.pusher("bar"))
+		foo.Add("foo", assertHandler(t, mockOptions))
+		bar.Add("bar", assertHandler(t, mockOptions))
+
+		r.Group(func(r *Router) {
+			r.Use(stack.pusher("admin"))
+			r.Group(func(r *Router) {
+				r.Use(stack.pusher("admin.inner"))
+				r.Add("baz", assertHandler(t, mockOptions))
+				r.AddComponentFunc("qux", func(ctx context.Context, data ComponentData) *api.InteractionResponse {
+					return &api.InteractionResponse{Type: api.UpdateMessage}
+				})
+			})
+		})
+
+		for _, name := range []string{"foo", "bar", "baz"} {
+			r.HandleInteraction(newInteractionEvent(&discord.CommandInteraction{
+				ID:      4,
+				Name:    name,
+				Options: mockOptions,
+			}))
+		}
+
+		resp := r.HandleInteraction(newInteractionEvent(&discord.ButtonInteraction{
+			CustomID: "qux",
+		}))
+		assertInteractionResp(t, resp, &api.InteractionResponse{Type: api.UpdateMessage})
+
+		stack.expect(t, []string{
+			"root", "foo",
+			"root", "bar",
+			"root", "admin", "admin.inner",
+			"root", "admin", "admin.inner",
+		})
+	})
+
 	t.Run("deferred", func(t *testing.T) {
 		var wg sync.WaitGroup
 
@@ -0,0 +1,204 @@
+package cmdroute
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/utils/json/option"
+)
+
+// ThrottleScope is the scope that a cooldown applies to. Cooldowns are always
+// tracked separately for each command.
+type ThrottleScope uint8
+
+const (
+	// ThrottlePerUser applies the cooldown to each user separately.
+	ThrottlePerUser ThrottleScope = iota
+	// ThrottlePerGuild applies the cooldown to each guild separately. Commands
+	// used in DMs are tracked per channel instead.
+	ThrottlePerGuild
+	// ThrottlePerCommand applies the cooldown to the command as a whole,
+	// regardless of who uses it and where.
+	ThrottlePerCommand
+)
+
+// ThrottleStore stores the state of cooldowns. Implementations must be
+// thread-safe.
+type ThrottleStore interface {
+	// Take takes one use out of the bucket with the given key, which allows
+	// limit uses per period. If the bucket has no uses left, then the duration
+	// until it does is returned, otherwise 0 is returned.
+	Take(key string, limit int, period time.Duration) time.Duration
+}
+
+// MemoryThrottleStore is a ThrottleStore that keeps cooldowns in memory. It is
+// the default store used by Throttle. A zero-value MemoryThrottleStore is
+// ready to use.
+type MemoryThrottleStore struct {
+	mut     sync.Mutex
+	buckets map[string]throttleBucket
+	swept   time.Time
+}
+
+type throttleBucket struct {
+	reset time.Time
+	uses  int
+}
+
+var _ ThrottleStore = (*MemoryThrottleStore)(nil)
+
+// NewMemoryThrottleStore creates a new MemoryThrottleStore.
+func NewMemoryThrottleStore() *MemoryThrottleStore {
+	return &MemoryThrottleStore{}
+}
+
+// Take implements ThrottleStore.
+func (s *MemoryThrottleStore) Take(key string, limit int, period time.Duration) time.Duration {
+	now := time.Now()
+
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	if s.buckets == nil {
+		s.buckets = make(map[string]throttleBucket)
+	}
+
+	// Occasionally drop expired buckets so that the map doesn't grow forever.
+	if now.Sub(s.swept) > period {
+		for k, bucket := range s.buckets {
+			if !now.Before(bucket.reset) {
+				delete(s.buckets, k)
+			}
+		}
+		s.swept = now
+	}
+
+	bucket, ok := s.buckets[key]
+	if !ok || !now.Before(bucket.reset) {
+		bucket = throttleBucket{reset: now.Add(period)}
+	}
+
+	if bucket.uses >= limit {
+		return bucket.reset.Sub(now)
+	}
+
+	bucket.uses++
+	s.buckets[key] = bucket
+	return 0
+}
+
+// ThrottleOpts is the options for Throttle().
+type ThrottleOpts struct {
+	// Period is the cooldown period. It is required.
+	Period time.Duration
+	// Limit is the number of times a command can be used within each period.
+	//
+	// Defaults to 1.
+	Limit int
+	// Scope is the scope that the cooldown applies to.
+	//
+	// Defaults to ThrottlePerUser.
+	Scope ThrottleScope
+	// Store is the store to keep cooldowns in. A store may be shared between
+	// multiple Throttle middlewares.
+	//
+	// Defaults to a new MemoryThrottleStore.
+	Store ThrottleStore
+	// Response is called to create the response sent when a command is on
+	// cooldown. If nil, an ephemeral message telling the user to try again in
+	// a number of seconds is sent.
+	Response func(ev *discord.InteractionEvent, retryAfter time.Duration) *api.InteractionResponse
+}
+
+// Throttle returns a middleware that limits how often commands can be used.
+// Only command interactions are throttled; other interactions are passed
+// through.
+func Throttle(opts ThrottleOpts) Middleware {
+	if opts.Period <= 0 {
+		panic("cmdroute: Throttle requires a positive Period")
+	}
+	if opts.Limit <= 0 {
+		opts.Limit = 1
+	}
+	if opts.Store == nil {
+		opts.Store = NewMemoryThrottleStore()
+	}
+	if opts.Response == nil {
+		opts.Response = throttleResponse
+	}
+
+	// Use a unique prefix so that throttles sharing a store don't overlap.
+	prefix := strconv.FormatUint(atomic.AddUint64(&throttleID, 1), 10)
+
+	return func(next InteractionHandler) InteractionHandler {
+		return InteractionHandlerFunc(func(ctx context.Context, ev *discord.InteractionEvent) *api.InteractionResponse {
+			data, ok := ev.Data.(*discord.CommandInteraction)
+			if !ok {
+				return next.HandleInteraction(ctx, ev)
+			}
+
+			key := prefix + ":" + throttleKey(opts.Scope, ev, data)
+			if wait := opts.Store.Take(key, opts.Limit, opts.Period); wait > 0 {
+				return opts.Response(ev, wait)
+			}
+
+			return next.HandleInteraction(ctx, ev)
+		})
+	}
+}
+
+var throttleID uint64
+
+func throttleKey(scope ThrottleScope, ev *discord.InteractionEvent, data *discord.CommandInteraction) string {
+	var key strings.Builder
+	key.WriteString(data.Name)
+
+	// Include subcommand groups and subcommands into the name.
+	opts := data.Options
+	for len(opts) == 1 {
+		opt := opts[0]
+		if opt.Type != discord.SubcommandGroupOptionType && opt.Type != discord.SubcommandOptionType {
+			break
+		}
+		key.WriteByte(' ')
+		key.WriteString(opt.Name)
+		opts = opt.Options
+	}
+
+	switch scope {
+	case ThrottlePerUser:
+		key.WriteString(":u")
+		key.WriteString(ev.SenderID().String())
+	case ThrottlePerGuild:
+		if ev.GuildID.IsValid() {
+			key.WriteString(":g")
+			key.WriteString(ev.GuildID.String())
+		} else {
+			key.WriteString(":c")
+			key.WriteString(ev.ChannelID.String())
+		}
+	}
+
+	return key.String()
+}
+
+func throttleResponse(ev *discord.InteractionEvent, retryAfter time.Duration) *api.InteractionResponse {
+	secs := int(math.Ceil(retryAfter.Seconds()))
+	return &api.InteractionResponse{
+		Type: api.MessageInteractionWithSource,
+		Data: &api.InteractionResponseData{
+			Content: option.NewNullableString(fmt.Sprintf(
+				"This command is on cooldown. Please try again in %ds.", secs,
+			)),
+			Flags: discord.EphemeralMessage,
+		},
+	}
+}
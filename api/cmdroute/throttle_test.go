@@ -0,0 +1,66 @@
+package cmdroute
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/discord"
+)
+
+func TestThrottle(t *testing.T) {
+	var calls int
+
+	r := NewRouter()
+	r.Use(Throttle(ThrottleOpts{
+		Period: time.Minute,
+		Limit:  2,
+	}))
+	r.AddFunc("test", func(ctx context.Context, data CommandData) *api.InteractionResponseData {
+		calls++
+		return nil
+	})
+
+	send := func(userID discord.UserID) *api.InteractionResponse {
+		ev := newInteractionEvent(&discord.CommandInteraction{ID: 4, Name: "test"})
+		ev.User = &discord.User{ID: userID}
+		return r.HandleInteraction(ev)
+	}
+
+	for i := 0; i < 2; i++ {
+		if resp := send(1); resp != nil {
+			t.Fatalf("use %d unexpectedly throttled: %+v", i, resp)
+		}
+	}
+
+	resp := send(1)
+	if resp == nil || resp.Data == nil || resp.Data.Flags != discord.EphemeralMessage {
+		t.Fatalf("expected ephemeral cooldown response, got %+v", resp)
+	}
+
+	if resp := send(2); resp != nil {
+		t.Fatal("other user unexpectedly throttled")
+	}
+
+	if calls != 3 {
+		t.Fatalf("expected 3 calls, got %d", calls)
+	}
+}
+
+func TestMemoryThrottleStore(t *testing.T) {
+	s := NewMemoryThrottleStore()
+
+	if wait := s.Take("a", 1, 50*time.Millisecond); wait != 0 {
+		t.Fatal("first take unexpectedly throttled")
+	}
+	if wait := s.Take("a", 1, 50*time.Millisecond); wait <= 0 {
+		t.Fatal("second take not throttled")
+	}
+
+	time.Sleep(60 * time.Millisecond)
+
+	if wait := s.Take("a", 1, 50*time.Millisecond); wait != 0 {
+		t.Fatal("take after period unexpectedly throttled")
+	}
+}
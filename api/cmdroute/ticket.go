@@ -0,0 +1,114 @@
+package cmdroute
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/utils/httputil"
+)
+
+// InteractionTokenLifetime is the duration for which an interaction token can
+// be used to edit the response or send follow-up messages, counted from when
+// the interaction was created.
+const InteractionTokenLifetime = 15 * time.Minute
+
+// ErrInteractionExpired is returned by Ticket's methods if the interaction
+// token has expired.
+var ErrInteractionExpired = errors.New("interaction token has expired")
+
+// TicketClient is the client that a Ticket uses to manage the interaction
+// response. Usually, anything that extends *api.Client can be used as a
+// TicketClient.
+type TicketClient interface {
+	FollowUpSender
+	EditInteractionResponse(appID discord.AppID, token string, data api.EditInteractionResponseData) (*discord.Message, error)
+	DeleteInteractionResponse(appID discord.AppID, token string) error
+}
+
+// Ticket manages the response of a deferred interaction. It keeps track of
+// the lifetime of the interaction token, so that late calls fail with
+// ErrInteractionExpired instead of an HTTP error.
+//
+// Deferrable gives handlers a Ticket through the context if its client is a
+// TicketClient. Use TicketFromContext to get it.
+type Ticket struct {
+	client TicketClient
+	appID  discord.AppID
+	token  string
+	expiry time.Time
+}
+
+// NewTicket creates a new Ticket for the given interaction event.
+func NewTicket(client TicketClient, ev *discord.InteractionEvent) *Ticket {
+	created := time.Now()
+	if ev.ID.IsValid() {
+		created = ev.ID.Time()
+	}
+
+	return &Ticket{
+		client: client,
+		appID:  ev.AppID,
+		token:  ev.Token,
+		expiry: created.Add(InteractionTokenLifetime),
+	}
+}
+
+// TicketFromContext returns the Ticket from the context. If no ticket is
+// found, it returns nil.
+func TicketFromContext(ctx context.Context) *Ticket {
+	ticket, _ := ctx.Value(ticketCtx).(*Ticket)
+	return ticket
+}
+
+// Expiry returns the time at which the interaction token expires.
+func (t *Ticket) Expiry() time.Time {
+	return t.expiry
+}
+
+// Expired returns true if the interaction token has expired.
+func (t *Ticket) Expired() bool {
+	return !time.Now().Before(t.expiry)
+}
+
+// Edit edits the initial response of the interaction.
+func (t *Ticket) Edit(data api.EditInteractionResponseData) (*discord.Message, error) {
+	if t.Expired() {
+		return nil, ErrInteractionExpired
+	}
+
+	m, err := t.client.EditInteractionResponse(t.appID, t.token, data)
+	return m, t.wrapErr(err)
+}
+
+// Followup sends a follow-up message for the interaction.
+func (t *Ticket) Followup(data api.InteractionResponseData) (*discord.Message, error) {
+	if t.Expired() {
+		return nil, ErrInteractionExpired
+	}
+
+	m, err := t.client.FollowUpInteraction(t.appID, t.token, data)
+	return m, t.wrapErr(err)
+}
+
+// Delete deletes the initial response of the interaction.
+func (t *Ticket) Delete() error {
+	if t.Expired() {
+		return ErrInteractionExpired
+	}
+
+	return t.wrapErr(t.client.DeleteInteractionResponse(t.appID, t.token))
+}
+
+// wrapErr turns the error returned by Discord for an invalid token into
+// ErrInteractionExpired, since the local clock may be off.
+func (t *Ticket) wrapErr(err error) error {
+	var httpErr *httputil.HTTPError
+	if errors.As(err, &httpErr) && httpErr.Status == http.StatusUnauthorized {
+		return ErrInteractionExpired
+	}
+	return err
+}
@@ -0,0 +1,113 @@
+package cmdroute
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/utils/httputil"
+	"github.com/diamondburned/arikawa/v3/utils/json/option"
+)
+
+type mockedTicketClient struct {
+	calls int
+	err   error
+}
+
+func (m *mockedTicketClient) FollowUpInteraction(discord.AppID, string, api.InteractionResponseData) (*discord.Message, error) {
+	m.calls++
+	return &discord.Message{}, m.err
+}
+
+func (m *mockedTicketClient) EditInteractionResponse(discord.AppID, string, api.EditInteractionResponseData) (*discord.Message, error) {
+	m.calls++
+	return &discord.Message{}, m.err
+}
+
+func (m *mockedTicketClient) DeleteInteractionResponse(discord.AppID, string) error {
+	m.calls++
+	return m.err
+}
+
+func TestTicket(t *testing.T) {
+	data := api.InteractionResponseData{
+		Content: option.NewNullableString("pong"),
+	}
+
+	t.Run("fresh", func(t *testing.T) {
+		client := &mockedTicketClient{}
+
+		ev := newInteractionEvent(nil)
+		ev.ID = discord.InteractionID(discord.NewSnowflake(time.Now()))
+
+		ticket := NewTicket(client, ev)
+		if ticket.Expired() {
+			t.Fatal("fresh ticket is expired")
+		}
+
+		if _, err := ticket.Followup(data); err != nil {
+			t.Fatal("unexpected follow-up error:", err)
+		}
+		if _, err := ticket.Edit(api.EditInteractionResponseData{}); err != nil {
+			t.Fatal("unexpected edit error:", err)
+		}
+		if err := ticket.Delete(); err != nil {
+			t.Fatal("unexpected delete error:", err)
+		}
+
+		if client.calls != 3 {
+			t.Fatalf("expected 3 calls, got %d", client.calls)
+		}
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		client := &mockedTicketClient{}
+
+		// The mocked interaction ID is from 2015.
+		ticket := NewTicket(client, newInteractionEvent(nil))
+
+		if _, err := ticket.Followup(data); !errors.Is(err, ErrInteractionExpired) {
+			t.Fatal("unexpected follow-up error:", err)
+		}
+		if client.calls != 0 {
+			t.Fatalf("expected no calls, got %d", client.calls)
+		}
+	})
+
+	t.Run("unauthorized", func(t *testing.T) {
+		client := &mockedTicketClient{
+			err: &httputil.HTTPError{Status: http.StatusUnauthorized},
+		}
+
+		ev := newInteractionEvent(nil)
+		ev.ID = discord.InteractionID(discord.NewSnowflake(time.Now()))
+
+		if err := NewTicket(client, ev).Delete(); !errors.Is(err, ErrInteractionExpired) {
+			t.Fatal("unexpected delete error:", err)
+		}
+	})
+}
+
+func TestDeferrableTicket(t *testing.T) {
+	client := &mockedTicketClient{}
+
+	r := NewRouter()
+	r.Use(Deferrable(client, DeferOpts{}))
+	r.AddFunc("ping", func(ctx context.Context, data CommandData) *api.InteractionResponseData {
+		if TicketFromContext(ctx) == nil {
+			t.Error("expected a ticket in the context")
+		}
+		return &api.InteractionResponseData{
+			Content: option.NewNullableString("pong"),
+		}
+	})
+
+	r.HandleInteraction(newInteractionEvent(&discord.CommandInteraction{
+		ID:   4,
+		Name: "ping",
+	}))
+}
@@ -0,0 +1,124 @@
+package api
+
+import (
+	"strconv"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/utils/httputil"
+	"github.com/diamondburned/arikawa/v3/utils/json/option"
+)
+
+var EndpointDiscovery = Endpoint + "discovery/"
+
+// MaxDiscoveryKeywords is the maximum number of discovery search keywords that
+// a guild can have.
+const MaxDiscoveryKeywords = 10
+
+// MaxDiscoverySubcategories is the maximum number of discovery subcategories
+// that a guild can have.
+const MaxDiscoverySubcategories = 5
+
+// DiscoveryCategories returns all discovery categories.
+func (c *Client) DiscoveryCategories() ([]discord.DiscoveryCategory, error) {
+	var cs []discord.DiscoveryCategory
+	return cs, c.RequestJSON(&cs, "GET", EndpointDiscovery+"categories")
+}
+
+// ValidDiscoveryTerm returns whether the given term can be used as a discovery
+// search keyword.
+func (c *Client) ValidDiscoveryTerm(term string) (bool, error) {
+	var param struct {
+		Term string `schema:"term"`
+	}
+
+	param.Term = term
+
+	var resp struct {
+		Valid bool `json:"valid"`
+	}
+
+	return resp.Valid, c.RequestJSON(
+		&resp, "GET", EndpointDiscovery+"valid-term",
+		httputil.WithSchema(c, param),
+	)
+}
+
+// DiscoveryMetadata returns the discovery metadata of the guild.
+//
+// Requires the MANAGE_GUILD permission.
+func (c *Client) DiscoveryMetadata(guildID discord.GuildID) (*discord.DiscoveryMetadata, error) {
+	var m *discord.DiscoveryMetadata
+	return m, c.RequestJSON(
+		&m, "GET",
+		EndpointGuilds+guildID.String()+"/discovery-metadata",
+	)
+}
+
+// https://discord.com/developers/docs/resources/discovery#modify-guild-discovery-metadata-json-params
+type ModifyDiscoveryMetadataData struct {
+	// PrimaryCategoryID is the ID of the primary discovery category.
+	PrimaryCategoryID discord.DiscoveryCategoryID `json:"primary_category_id,omitempty"`
+	// Keywords are up to 10 discovery search keywords. A pointer to an empty
+	// slice removes all keywords.
+	Keywords *[]string `json:"keywords,omitempty"`
+	// EmojiDiscoverabilityEnabled is whether guild info is shown when custom
+	// emojis are clicked.
+	EmojiDiscoverabilityEnabled option.Bool `json:"emoji_discoverability_enabled,omitempty"`
+
+	AuditLogReason `json:"-"`
+}
+
+// ModifyDiscoveryMetadata modifies the discovery metadata of the guild and
+// returns the updated metadata.
+//
+// Requires the MANAGE_GUILD permission.
+func (c *Client) ModifyDiscoveryMetadata(
+	guildID discord.GuildID,
+	data ModifyDiscoveryMetadataData) (*discord.DiscoveryMetadata, error) {
+
+	if data.Keywords != nil && len(*data.Keywords) > MaxDiscoveryKeywords {
+		return nil, &discord.OverboundError{
+			Count: len(*data.Keywords),
+			Max:   MaxDiscoveryKeywords,
+			Thing: "discovery keywords",
+		}
+	}
+
+	var m *discord.DiscoveryMetadata
+	return m, c.RequestJSON(
+		&m, "PATCH",
+		EndpointGuilds+guildID.String()+"/discovery-metadata",
+		httputil.WithJSONBody(data), httputil.WithHeaders(data.Header()),
+	)
+}
+
+// AddDiscoverySubcategory adds a discovery subcategory to the guild. A guild
+// can have at most 5 subcategories.
+//
+// Requires the MANAGE_GUILD permission.
+func (c *Client) AddDiscoverySubcategory(
+	guildID discord.GuildID, categoryID discord.DiscoveryCategoryID,
+	reason AuditLogReason) error {
+
+	return c.FastRequest(
+		"POST",
+		EndpointGuilds+guildID.String()+"/discovery-categories/"+
+			strconv.Itoa(int(categoryID)),
+		httputil.WithHeaders(reason.Header()),
+	)
+}
+
+// RemoveDiscoverySubcategory removes a discovery subcategory from the guild.
+//
+// Requires the MANAGE_GUILD permission.
+func (c *Client) RemoveDiscoverySubcategory(
+	guildID discord.GuildID, categoryID discord.DiscoveryCategoryID,
+	reason AuditLogReason) error {
+
+	return c.FastRequest(
+		"DELETE",
+		EndpointGuilds+guildID.String()+"/discovery-categories/"+
+			strconv.Itoa(int(categoryID)),
+		httputil.WithHeaders(reason.Header()),
+	)
+}
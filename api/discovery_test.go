@@ -0,0 +1,48 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+)
+
+func TestDiscoveryMetadata(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v9/guilds/1/discovery-metadata" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(`{
+			"guild_id": "1",
+			"primary_category_id": 3,
+			"keywords": ["go"],
+			"emoji_discoverability_enabled": true,
+			"partner_actioned_timestamp": null,
+			"partner_application_timestamp": null,
+			"category_ids": [4, 5]
+		}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	client := NewClient("").WithBaseURL(srv.URL)
+
+	m, err := client.DiscoveryMetadata(1)
+	if err != nil {
+		t.Fatal("failed to get discovery metadata:", err)
+	}
+
+	if m.PrimaryCategoryID != 3 || len(m.CategoryIDs) != 2 || m.PartnerActionedTimestamp.IsValid() {
+		t.Fatalf("unexpected discovery metadata %+v", m)
+	}
+
+	keywords := make([]string, MaxDiscoveryKeywords+1)
+
+	_, err = client.ModifyDiscoveryMetadata(1, ModifyDiscoveryMetadataData{Keywords: &keywords})
+	var overbound *discord.OverboundError
+	if !errors.As(err, &overbound) {
+		t.Fatal("expected OverboundError, got", err)
+	}
+}
@@ -0,0 +1,111 @@
+package api
+
+import (
+	"container/list"
+	"errors"
+	"net/http"
+	"sync"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/utils/httputil"
+)
+
+// DMChannelCacheSize is the default number of DM channel IDs that each Client
+// remembers for SendDirectMessage.
+var DMChannelCacheSize = 1000
+
+// dmChannelCache is a LRU cache mapping user IDs to their DM channel IDs.
+type dmChannelCache struct {
+	mutex   sync.Mutex
+	size    int
+	list    *list.List // of dmChannelEntry
+	entries map[discord.UserID]*list.Element
+}
+
+type dmChannelEntry struct {
+	userID    discord.UserID
+	channelID discord.ChannelID
+}
+
+func newDMChannelCache(size int) *dmChannelCache {
+	return &dmChannelCache{
+		size:    size,
+		list:    list.New(),
+		entries: make(map[discord.UserID]*list.Element),
+	}
+}
+
+func (c *dmChannelCache) get(userID discord.UserID) (discord.ChannelID, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	elem, ok := c.entries[userID]
+	if !ok {
+		return 0, false
+	}
+
+	c.list.MoveToFront(elem)
+	return elem.Value.(dmChannelEntry).channelID, true
+}
+
+func (c *dmChannelCache) set(userID discord.UserID, channelID discord.ChannelID) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if elem, ok := c.entries[userID]; ok {
+		elem.Value = dmChannelEntry{userID, channelID}
+		c.list.MoveToFront(elem)
+		return
+	}
+
+	c.entries[userID] = c.list.PushFront(dmChannelEntry{userID, channelID})
+
+	for c.list.Len() > c.size {
+		oldest := c.list.Back()
+		c.list.Remove(oldest)
+		delete(c.entries, oldest.Value.(dmChannelEntry).userID)
+	}
+}
+
+func (c *dmChannelCache) delete(userID discord.UserID) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if elem, ok := c.entries[userID]; ok {
+		c.list.Remove(elem)
+		delete(c.entries, userID)
+	}
+}
+
+// SendDirectMessage sends a message to the user with the given ID in their DM
+// channel, creating the channel if needed. The DM channel IDs of recently
+// messaged users are remembered, so most calls only do one request.
+func (c *Client) SendDirectMessage(
+	userID discord.UserID, data SendMessageData) (*discord.Message, error) {
+
+	if c.Session.dmChannels != nil {
+		if channelID, ok := c.Session.dmChannels.get(userID); ok {
+			m, err := c.SendMessageComplex(channelID, data)
+
+			var httpErr *httputil.HTTPError
+			if !errors.As(err, &httpErr) || httpErr.Status != http.StatusNotFound {
+				return m, err
+			}
+
+			// The channel is gone, so forget about it and retry with a fresh
+			// one.
+			c.Session.dmChannels.delete(userID)
+		}
+	}
+
+	ch, err := c.CreatePrivateChannel(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.Session.dmChannels != nil {
+		c.Session.dmChannels.set(userID, ch.ID)
+	}
+
+	return c.SendMessageComplex(ch.ID, data)
+}
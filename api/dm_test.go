@@ -0,0 +1,35 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+)
+
+func TestDMChannelCache(t *testing.T) {
+	c := newDMChannelCache(2)
+	c.set(1, 10)
+	c.set(2, 20)
+
+	// Touch 1 so that 2 is the least recently used.
+	if id, ok := c.get(1); !ok || id != 10 {
+		t.Fatalf("expected channel 10, got %d (%v)", id, ok)
+	}
+
+	c.set(3, 30)
+
+	if _, ok := c.get(2); ok {
+		t.Fatal("expected user 2 to be evicted")
+	}
+
+	for userID, channelID := range map[discord.UserID]discord.ChannelID{1: 10, 3: 30} {
+		if id, ok := c.get(userID); !ok || id != channelID {
+			t.Fatalf("expected channel %d for user %d, got %d (%v)", channelID, userID, id, ok)
+		}
+	}
+
+	c.delete(1)
+	if _, ok := c.get(1); ok {
+		t.Fatal("expected user 1 to be deleted")
+	}
+}
@@ -30,6 +30,9 @@ type CreateEmojiData struct {
 	AuditLogReason `json:"-"`
 }
 
+// maxEmojiSize is the maximum file size of an emoji, which is 256KB.
+const maxEmojiSize = 256 * 1000
+
 // CreateEmoji creates a new emoji in the guild. This endpoint requires
 // MANAGE_EMOJIS. ContentType must be "image/jpeg", "image/png", or
 // "image/gif". However, ContentType can also be automatically detected (though
@@ -39,8 +42,7 @@ type CreateEmojiData struct {
 func (c *Client) CreateEmoji(
 	guildID discord.GuildID, data CreateEmojiData) (*discord.Emoji, error) {
 
-	// Max 256KB
-	if err := data.Image.Validate(256 * 1000); err != nil {
+	if err := data.Image.Validate(maxEmojiSize); err != nil {
 		return nil, err
 	}
 
@@ -52,6 +54,33 @@ func (c *Client) CreateEmoji(
 	)
 }
 
+// CreateEmojiFromURL downloads the image at the given URL and creates a new
+// emoji in the guild with it. The image must be a PNG, JPEG or GIF image of at
+// most 256KB. If roles is nil, everyone can use the emoji.
+//
+// The image is downloaded with the client's context. See FetchImage.
+func (c *Client) CreateEmojiFromURL(
+	guildID discord.GuildID, name, url string,
+	roles []discord.RoleID, reason AuditLogReason) (*discord.Emoji, error) {
+
+	img, err := FetchImage(c.Context(), url, maxEmojiSize)
+	if err != nil {
+		return nil, err
+	}
+
+	data := CreateEmojiData{
+		Name:           name,
+		Image:          *img,
+		AuditLogReason: reason,
+	}
+
+	if roles != nil {
+		data.Roles = &roles
+	}
+
+	return c.CreateEmoji(guildID, data)
+}
+
 // https://discord.com/developers/docs/resources/emoji#modify-guild-emoji-json-params
 type ModifyEmojiData struct {
 	// Name is the name of the emoji.
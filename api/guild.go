@@ -1,6 +1,7 @@
 package api
 
 import (
+	"fmt"
 	"io"
 	"net/url"
 
@@ -265,7 +266,7 @@ type ModifyGuildData struct {
 
 	// AFKChannelID is the id for the afk channel.
 	//
-	// This field is nullable.
+	// This field is nullable. Set it to discord.NullChannelID to clear it.
 	AFKChannelID discord.ChannelID `json:"afk_channel_id,string,omitempty"`
 	// AFKTimeout is the afk timeout in seconds.
 	AFKTimeout discord.OptionalSeconds `json:"afk_timeout,omitempty"`
@@ -285,17 +286,17 @@ type ModifyGuildData struct {
 	// SystemChannelID is the id of the channel where guild notices such as
 	// welcome messages and boost events are posted.
 	//
-	// This field is nullable.
+	// This field is nullable. Set it to discord.NullChannelID to clear it.
 	SystemChannelID discord.ChannelID `json:"system_channel_id,omitempty"`
 	// RulesChannelID is the id of the channel where "PUBLIC" guilds display
 	// rules and/or guidelines.
 	//
-	// This field is nullable.
+	// This field is nullable. Set it to discord.NullChannelID to clear it.
 	RulesChannelID discord.ChannelID `json:"rules_channel_id,omitempty"`
 	// PublicUpdatesChannelID is the id of the channel where admins and
 	// moderators of "PUBLIC" guilds receive notices from Discord.
 	//
-	// This field is nullable.
+	// This field is nullable. Set it to discord.NullChannelID to clear it.
 	PublicUpdatesChannelID discord.ChannelID `json:"public_updates_channel_id,omitempty"`
 
 	// PreferredLocale is the preferred locale of a "PUBLIC" guild used in
@@ -304,6 +305,11 @@ type ModifyGuildData struct {
 	// This defaults to "en-US".
 	PreferredLocale option.NullableString `json:"preferred_locale,omitempty"`
 
+	// Features are the enabled guild features. Only mutable features, as
+	// reported by GuildFeature.IsMutable, may be added or removed. See
+	// SetGuildFeature.
+	Features *[]discord.GuildFeature `json:"features,omitempty"`
+
 	AuditLogReason `json:"-"`
 }
 
@@ -322,6 +328,44 @@ func (c *Client) ModifyGuild(id discord.GuildID, data ModifyGuildData) (*discord
 
 }
 
+// SetGuildFeature enables or disables the given mutable feature of the guild
+// while keeping its other features intact. An error is returned if the feature
+// is not mutable.
+//
+// Requires the MANAGE_GUILD permission. Enabling or disabling Community also
+// requires the ADMINISTRATOR permission.
+//
+// Fires a Guild Update Gateway event.
+func (c *Client) SetGuildFeature(
+	guildID discord.GuildID,
+	feature discord.GuildFeature, enabled bool) (*discord.Guild, error) {
+
+	if !feature.IsMutable() {
+		return nil, fmt.Errorf("guild feature %s is not mutable", feature)
+	}
+
+	g, err := c.Guild(guildID)
+	if err != nil {
+		return nil, err
+	}
+
+	if g.HasFeature(feature) == enabled {
+		return g, nil
+	}
+
+	features := make([]discord.GuildFeature, 0, len(g.Features)+1)
+	for _, f := range g.Features {
+		if f != feature {
+			features = append(features, f)
+		}
+	}
+	if enabled {
+		features = append(features, feature)
+	}
+
+	return c.ModifyGuild(guildID, ModifyGuildData{Features: &features})
+}
+
 // DeleteGuild deletes a guild permanently. The User must be owner.
 //
 // Fires a Guild Delete Gateway event.
@@ -499,6 +543,57 @@ func (c *Client) GuildVanityInvite(guildID discord.GuildID) (*discord.Invite, er
 	return inv, c.RequestJSON(&inv, "GET", EndpointGuilds+guildID.String()+"/vanity-url")
 }
 
+// SetGuildVanityURL sets the vanity invite code of the guild. The guild must
+// have the VANITY_URL feature. An empty code removes the vanity URL. Only Code
+// and Uses are filled in the returned invite.
+//
+// Requires MANAGE_GUILD.
+func (c *Client) SetGuildVanityURL(
+	guildID discord.GuildID, code string, reason AuditLogReason) (*discord.Invite, error) {
+
+	var param struct {
+		Code option.NullableString `json:"code"`
+	}
+
+	if code == "" {
+		param.Code = option.NullString
+	} else {
+		param.Code = option.NewNullableString(code)
+	}
+
+	var inv *discord.Invite
+	return inv, c.RequestJSON(
+		&inv, "PATCH",
+		EndpointGuilds+guildID.String()+"/vanity-url",
+		httputil.WithJSONBody(param), httputil.WithHeaders(reason.Header()),
+	)
+}
+
+// ModifyGuildMFALevel modifies the required MFA level of the guild and
+// returns the new level. It fires a Guild Update Gateway event.
+//
+// Requires guild ownership.
+func (c *Client) ModifyGuildMFALevel(
+	guildID discord.GuildID, level discord.MFALevel, reason AuditLogReason) (discord.MFALevel, error) {
+
+	var param struct {
+		Level discord.MFALevel `json:"level"`
+	}
+
+	param.Level = level
+
+	var resp struct {
+		Level discord.MFALevel `json:"level"`
+	}
+
+	err := c.RequestJSON(
+		&resp, "POST",
+		EndpointGuilds+guildID.String()+"/mfa",
+		httputil.WithJSONBody(param), httputil.WithHeaders(reason.Header()),
+	)
+	return resp.Level, err
+}
+
 // https://discord.com/developers/docs/resources/guild#get-guild-widget-image-widget-style-options
 type GuildWidgetImageStyle string
 
@@ -2,11 +2,22 @@ package api
 
 import (
 	"bytes"
+	"context"
 	"encoding/base64"
+	"encoding/binary"
 	"errors"
 	"fmt"
+	"image"
+	"image/gif"
+	"io"
+	"io/fs"
 	"net/http"
 
+	// Register the decoders for the formats that Discord accepts. The GIF
+	// decoder is registered by the import above.
+	_ "image/jpeg"
+	_ "image/png"
+
 	"github.com/diamondburned/arikawa/v3/utils/json"
 )
 
@@ -60,6 +71,180 @@ func DecodeImage(data []byte) (*Image, error) {
 	return &img, nil
 }
 
+// FetchImage downloads the image at the given URL, reading at most maxSize
+// bytes. If the image is larger, an ImageTooLargeError is returned. The image
+// is checked to be a valid PNG, JPEG or GIF image, so that Discord doesn't
+// reject it with a less helpful error.
+//
+// The image is downloaded using http.DefaultClient; the Discord token is never
+// sent.
+func FetchImage(ctx context.Context, url string, maxSize int) (*Image, error) {
+	return ImageFromURL(ctx, http.DefaultClient, url, maxSize)
+}
+
+// ImageFromURL is like FetchImage, except the image is downloaded using the
+// given HTTP client. If client is nil, then http.DefaultClient is used.
+func ImageFromURL(ctx context.Context, client *http.Client, url string, maxSize int) (*Image, error) {
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download image: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("failed to download image: unexpected status %s", resp.Status)
+	}
+
+	if maxSize > 0 && resp.ContentLength > int64(maxSize) {
+		return nil, ImageTooLargeError{int(resp.ContentLength), maxSize}
+	}
+
+	return ImageFromReader(resp.Body, maxSize)
+}
+
+// ImageFromFile reads the image from the given file, such as one opened using
+// os.Open or fs.FS. The file is not closed. Like FetchImage, the image must be
+// at most maxSize bytes and a valid PNG, JPEG or GIF image. The size is checked
+// before the file is read.
+func ImageFromFile(f fs.File, maxSize int) (*Image, error) {
+	stat, err := f.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat image: %w", err)
+	}
+
+	if maxSize > 0 && stat.Size() > int64(maxSize) {
+		return nil, ImageTooLargeError{int(stat.Size()), maxSize}
+	}
+
+	return ImageFromReader(f, maxSize)
+}
+
+// ImageFromReader reads the image from the given reader, reading at most
+// maxSize bytes. If maxSize is 0, then the size is not limited. Like
+// FetchImage, the content type is detected from the image itself, which must
+// be a valid PNG, JPEG or GIF image.
+//
+// If r is an io.Seeker, then its size is checked before anything is read.
+func ImageFromReader(r io.Reader, maxSize int) (*Image, error) {
+	if seeker, ok := r.(io.Seeker); ok && maxSize > 0 {
+		if size, err := remainingSize(seeker); err == nil && size > int64(maxSize) {
+			return nil, ImageTooLargeError{int(size), maxSize}
+		}
+	}
+
+	if maxSize > 0 {
+		// Read one more byte to know if the image is too large.
+		r = io.LimitReader(r, int64(maxSize)+1)
+	}
+
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read image: %w", err)
+	}
+
+	if maxSize > 0 && len(content) > maxSize {
+		return nil, ImageTooLargeError{len(content), maxSize}
+	}
+
+	// Decode the header to ensure that the content is an actual image, since
+	// the Content-Type header or the file extension is not reliable.
+	cfg, format, err := image.DecodeConfig(bytes.NewReader(content))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidImageData, err)
+	}
+
+	if cfg.Width <= 0 || cfg.Height <= 0 {
+		return nil, fmt.Errorf("%w: image has no dimensions", ErrInvalidImageData)
+	}
+
+	img := &Image{
+		ContentType: "image/" + format,
+		Content:     content,
+	}
+
+	if err := img.Validate(maxSize); err != nil {
+		return nil, err
+	}
+
+	return img, nil
+}
+
+// remainingSize returns the number of bytes between the current offset of the
+// seeker and its end. The offset is restored afterwards.
+func remainingSize(s io.Seeker) (int64, error) {
+	cur, err := s.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return 0, err
+	}
+
+	end, err := s.Seek(0, io.SeekEnd)
+	if err != nil {
+		return 0, err
+	}
+
+	if _, err := s.Seek(cur, io.SeekStart); err != nil {
+		return 0, err
+	}
+
+	return end - cur, nil
+}
+
+// IsAnimated returns true if the image is an animated GIF or an animated PNG
+// (APNG). Discord only keeps the animation of some images, such as avatars of
+// Nitro users, banners and emojis, so this is useful for picking the right
+// endpoint or rejecting the image early.
+func (i Image) IsAnimated() bool {
+	switch i.ContentType {
+	case "image/gif":
+		g, err := gif.DecodeAll(bytes.NewReader(i.Content))
+		return err == nil && len(g.Image) > 1
+	case "image/png":
+		return isAPNG(i.Content)
+	default:
+		return false
+	}
+}
+
+// isAPNG returns true if the PNG data has an animation control chunk before the
+// first image data chunk.
+func isAPNG(b []byte) bool {
+	const sigLen = 8
+	if len(b) < sigLen {
+		return false
+	}
+
+	b = b[sigLen:]
+
+	// Each chunk is a 4-byte length, a 4-byte type, the data and a 4-byte CRC.
+	for len(b) >= 8 {
+		length := binary.BigEndian.Uint32(b[:4])
+
+		switch string(b[4:8]) {
+		case "acTL":
+			return true
+		case "IDAT":
+			return false
+		}
+
+		if uint64(length)+12 > uint64(len(b)) {
+			return false
+		}
+
+		b = b[length+12:]
+	}
+
+	return false
+}
+
 func (i Image) Validate(maxSize int) error {
 	if maxSize > 0 && len(i.Content) > maxSize {
 		return ImageTooLargeError{len(i.Content), maxSize}
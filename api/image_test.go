@@ -0,0 +1,164 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFetchImage(t *testing.T) {
+	var pngData bytes.Buffer
+	if err := png.Encode(&pngData, image.NewRGBA(image.Rect(0, 0, 16, 16))); err != nil {
+		t.Fatal("failed to encode PNG:", err)
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/emoji.png":
+			// Lie about the content type, since it must be detected instead.
+			w.Header().Set("Content-Type", "application/octet-stream")
+			w.Write(pngData.Bytes())
+		case "/text":
+			w.Write([]byte("not an image"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	ctx := context.Background()
+
+	t.Run("valid", func(t *testing.T) {
+		img, err := FetchImage(ctx, srv.URL+"/emoji.png", maxEmojiSize)
+		if err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+		if img.ContentType != "image/png" {
+			t.Fatalf("expected image/png, got %q", img.ContentType)
+		}
+		if !bytes.Equal(img.Content, pngData.Bytes()) {
+			t.Fatal("image content mismatch")
+		}
+	})
+
+	t.Run("too large", func(t *testing.T) {
+		_, err := FetchImage(ctx, srv.URL+"/emoji.png", 10)
+
+		var tooLarge ImageTooLargeError
+		if !errors.As(err, &tooLarge) {
+			t.Fatal("expected ImageTooLargeError, got", err)
+		}
+	})
+
+	t.Run("not an image", func(t *testing.T) {
+		_, err := FetchImage(ctx, srv.URL+"/text", maxEmojiSize)
+		if !errors.Is(err, ErrInvalidImageData) {
+			t.Fatal("expected ErrInvalidImageData, got", err)
+		}
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		if _, err := FetchImage(ctx, srv.URL+"/missing", maxEmojiSize); err == nil {
+			t.Fatal("expected error")
+		}
+	})
+}
+
+func TestImageFromReader(t *testing.T) {
+	var pngData bytes.Buffer
+	if err := png.Encode(&pngData, image.NewRGBA(image.Rect(0, 0, 16, 16))); err != nil {
+		t.Fatal("failed to encode PNG:", err)
+	}
+
+	t.Run("file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "icon.png")
+		if err := os.WriteFile(path, pngData.Bytes(), 0600); err != nil {
+			t.Fatal("failed to write file:", err)
+		}
+
+		f, err := os.Open(path)
+		if err != nil {
+			t.Fatal("failed to open file:", err)
+		}
+		defer f.Close()
+
+		img, err := ImageFromFile(f, maxEmojiSize)
+		if err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+		if img.ContentType != "image/png" || img.IsAnimated() {
+			t.Fatalf("unexpected image %q, animated: %v", img.ContentType, img.IsAnimated())
+		}
+	})
+
+	t.Run("seeker too large", func(t *testing.T) {
+		r := bytes.NewReader(pngData.Bytes())
+
+		_, err := ImageFromReader(r, 10)
+
+		var tooLarge ImageTooLargeError
+		if !errors.As(err, &tooLarge) || tooLarge.Size != pngData.Len() {
+			t.Fatal("expected ImageTooLargeError with the full size, got", err)
+		}
+		if r.Len() != pngData.Len() {
+			t.Fatal("reader was read before checking its size")
+		}
+	})
+
+	t.Run("animated gif", func(t *testing.T) {
+		palette := color.Palette{color.Black, color.White}
+
+		var gifData bytes.Buffer
+		err := gif.EncodeAll(&gifData, &gif.GIF{
+			Image: []*image.Paletted{
+				image.NewPaletted(image.Rect(0, 0, 4, 4), palette),
+				image.NewPaletted(image.Rect(0, 0, 4, 4), palette),
+			},
+			Delay: []int{10, 10},
+		})
+		if err != nil {
+			t.Fatal("failed to encode GIF:", err)
+		}
+
+		img, err := ImageFromReader(&gifData, 0)
+		if err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+		if img.ContentType != "image/gif" || !img.IsAnimated() {
+			t.Fatalf("expected animated GIF, got %q", img.ContentType)
+		}
+	})
+}
+
+func TestIsAPNG(t *testing.T) {
+	chunk := func(typ string, data ...byte) []byte {
+		b := make([]byte, 8, 12+len(data))
+		binary.BigEndian.PutUint32(b, uint32(len(data)))
+		copy(b[4:], typ)
+		b = append(b, data...)
+		return append(b, 0, 0, 0, 0) // CRC
+	}
+
+	sig := []byte("\x89PNG\r\n\x1a\n")
+	ihdr := chunk("IHDR", make([]byte, 13)...)
+
+	apng := bytes.Join([][]byte{sig, ihdr, chunk("acTL", make([]byte, 8)...), chunk("IDAT")}, nil)
+	if !isAPNG(apng) {
+		t.Error("expected APNG")
+	}
+
+	still := bytes.Join([][]byte{sig, ihdr, chunk("IDAT"), chunk("acTL", make([]byte, 8)...)}, nil)
+	if isAPNG(still) {
+		t.Error("unexpected APNG")
+	}
+}
@@ -77,6 +77,9 @@ type InteractionResponseData struct {
 	// Files represents a list of files to upload. This will not be
 	// JSON-encoded and will only be available through WriteMultipart.
 	Files []sendpart.File `json:"-"`
+	// Attachments contains the metadata of the files in Files, such as their
+	// descriptions. It is optional and may describe only some of the files.
+	Attachments []SendAttachment `json:"attachments,omitempty"`
 
 	// Choices are the results to display on autocomplete interaction events.
 	//
@@ -129,54 +132,121 @@ type AutocompleteNumberChoices []discord.NumberChoice
 func (c AutocompleteNumberChoices) choices() {}
 
 // RespondInteraction responds to an incoming interaction. It is also known as
-// an "interaction callback".
+// an "interaction callback". Any files in resp.Data are uploaded alongside the
+// response.
 func (c *Client) RespondInteraction(
 	id discord.InteractionID, token string, resp InteractionResponse) error {
 
-	if resp.Data != nil {
-		switch resp.Type {
-		case MessageInteractionWithSource:
-			// A new message is being created, make sure none of the fields
-			// are null or empty.
-			if (resp.Data.Content == nil || resp.Data.Content.Val == "") &&
-				(resp.Data.Embeds == nil || len(*resp.Data.Embeds) == 0) &&
-				len(resp.Data.Files) == 0 {
-				return ErrEmptyMessage
-			}
-		case UpdateMessage:
-			// A component is being updated. We therefore don't know what
-			// fields are filled. The only thing we can check is if content,
-			// embeds and files are null.
-			if (resp.Data.Content != nil && !resp.Data.Content.Init) &&
-				(resp.Data.Embeds != nil && *resp.Data.Embeds == nil) && len(resp.Data.Files) == 0 {
-				return ErrEmptyMessage
-			}
+	if err := resp.validate(); err != nil {
+		return err
+	}
+
+	URL := EndpointInteractions + id.String() + "/" + token + "/callback"
+	return sendpart.POST(c.Client, resp, nil, URL)
+}
+
+// InteractionCallbackResponse is the response of
+// RespondInteractionWithResponse.
+//
+// https://discord.com/developers/docs/interactions/receiving-and-responding#interaction-callback-interaction-callback-response-object
+type InteractionCallbackResponse struct {
+	// Interaction is the interaction that was responded to.
+	Interaction InteractionCallback `json:"interaction"`
+	// Resource is what the response created, if anything.
+	Resource *InteractionCallbackResource `json:"resource,omitempty"`
+}
+
+// InteractionCallback describes the interaction that was responded to.
+type InteractionCallback struct {
+	// ID is the ID of the interaction.
+	ID discord.InteractionID `json:"id"`
+	// Type is the type of the interaction.
+	Type discord.InteractionDataType `json:"type"`
+	// ResponseMessageID is the ID of the message that was created by the
+	// response, if any.
+	ResponseMessageID discord.MessageID `json:"response_message_id,omitempty"`
+	// ResponseMessageLoading is true if the response created a message that
+	// is still loading, which is the case for deferred responses.
+	ResponseMessageLoading bool `json:"response_message_loading,omitempty"`
+	// ResponseMessageEphemeral is true if the message created by the response
+	// is ephemeral.
+	ResponseMessageEphemeral bool `json:"response_message_ephemeral,omitempty"`
+}
+
+// InteractionCallbackResource is the resource created by an interaction
+// response.
+type InteractionCallbackResource struct {
+	// Type is the type of the interaction response.
+	Type InteractionResponseType `json:"type"`
+	// Message is the message that was created or updated by the response. It
+	// is only present for MessageInteractionWithSource and UpdateMessage
+	// responses.
+	Message *discord.Message `json:"message,omitempty"`
+}
+
+// RespondInteractionWithResponse is like RespondInteraction, except Discord
+// returns what the response created, such as the message that was sent. This
+// saves a call to InteractionResponse.
+func (c *Client) RespondInteractionWithResponse(
+	id discord.InteractionID, token string,
+	resp InteractionResponse) (*InteractionCallbackResponse, error) {
+
+	if err := resp.validate(); err != nil {
+		return nil, err
+	}
+
+	var callback *InteractionCallbackResponse
+
+	URL := EndpointInteractions + id.String() + "/" + token + "/callback?with_response=true"
+	return callback, sendpart.POST(c.Client, resp, &callback, URL)
+}
+
+func (resp InteractionResponse) validate() error {
+	if resp.Data == nil {
+		return nil
+	}
+
+	switch resp.Type {
+	case MessageInteractionWithSource:
+		// A new message is being created, make sure none of the fields
+		// are null or empty.
+		if (resp.Data.Content == nil || resp.Data.Content.Val == "") &&
+			(resp.Data.Embeds == nil || len(*resp.Data.Embeds) == 0) &&
+			len(resp.Data.Files) == 0 {
+			return ErrEmptyMessage
 		}
+	case UpdateMessage:
+		// A component is being updated. We therefore don't know what
+		// fields are filled. The only thing we can check is if content,
+		// embeds and files are null.
+		if (resp.Data.Content != nil && !resp.Data.Content.Init) &&
+			(resp.Data.Embeds != nil && *resp.Data.Embeds == nil) && len(resp.Data.Files) == 0 {
+			return ErrEmptyMessage
+		}
+	}
 
-		if resp.Data.AllowedMentions != nil {
-			if err := resp.Data.AllowedMentions.Verify(); err != nil {
-				return fmt.Errorf("allowedMentions error: %w", err)
-			}
+	if resp.Data.AllowedMentions != nil {
+		if err := resp.Data.AllowedMentions.Verify(); err != nil {
+			return fmt.Errorf("allowedMentions error: %w", err)
 		}
+	}
 
-		if resp.Data.Embeds != nil {
-			sum := 0
-			for i, embed := range *resp.Data.Embeds {
-				if err := embed.Validate(); err != nil {
-					return fmt.Errorf("embed error at %d: %w", i, err)
-				}
-				sum += embed.Length()
-				if sum > 6000 {
-					return &discord.OverboundError{Count: sum, Max: 6000, Thing: "sum of all text in embeds"}
-				}
-
-				(*resp.Data.Embeds)[i] = embed // embed.Validate changes fields
+	if resp.Data.Embeds != nil {
+		sum := 0
+		for i, embed := range *resp.Data.Embeds {
+			if err := embed.Validate(); err != nil {
+				return fmt.Errorf("embed error at %d: %w", i, err)
+			}
+			sum += embed.Length()
+			if sum > 6000 {
+				return &discord.OverboundError{Count: sum, Max: 6000, Thing: "sum of all text in embeds"}
 			}
+
+			(*resp.Data.Embeds)[i] = embed // embed.Validate changes fields
 		}
 	}
 
-	URL := EndpointInteractions + id.String() + "/" + token + "/callback"
-	return sendpart.POST(c.Client, resp, nil, URL)
+	return nil
 }
 
 // InteractionResponse returns the initial interaction response.
@@ -198,7 +268,11 @@ type EditInteractionResponseData struct {
 	Components *discord.ContainerComponents `json:"components,omitempty"`
 	// AllowedMentions are the allowed mentions for the message.
 	AllowedMentions *AllowedMentions `json:"allowed_mentions,omitempty"`
-	// Attachments are the attached files to keep.
+	// Attachments are the existing attachments to keep. If nil, all existing
+	// attachments are kept. Otherwise, any existing attachment that is not in
+	// the list is removed. See api.KeepAttachments.
+	//
+	// New files given in Files are always added to the message.
 	Attachments *[]discord.Attachment `json:"attachments,omitempty"`
 
 	// Files represents a list of files to upload. This will not be
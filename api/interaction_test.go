@@ -0,0 +1,68 @@
+package api
+
+import (
+	"bytes"
+	"io"
+	"mime/multipart"
+	"strings"
+	"testing"
+
+	"github.com/diamondburned/arikawa/v3/utils/json/option"
+	"github.com/diamondburned/arikawa/v3/utils/sendpart"
+)
+
+func TestInteractionResponseMultipart(t *testing.T) {
+	resp := InteractionResponse{
+		Type: MessageInteractionWithSource,
+		Data: &InteractionResponseData{
+			Content: option.NewNullableString("hi"),
+			Files: []sendpart.File{
+				{Name: "a.txt", Reader: strings.NewReader("file content")},
+			},
+			Attachments: []SendAttachment{
+				{Index: 0, Description: "a file"},
+			},
+		},
+	}
+
+	if !resp.NeedsMultipart() {
+		t.Fatal("expected response with files to need multipart")
+	}
+	if err := resp.validate(); err != nil {
+		t.Fatal("unexpected validation error:", err)
+	}
+
+	var buf bytes.Buffer
+	w := multipart.NewWriter(&buf)
+	if err := resp.WriteMultipart(w); err != nil {
+		t.Fatal("failed to write multipart:", err)
+	}
+	w.Close()
+
+	r := multipart.NewReader(&buf, w.Boundary())
+	parts := map[string]string{}
+
+	for {
+		part, err := r.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal("failed to read part:", err)
+		}
+
+		b, err := io.ReadAll(part)
+		if err != nil {
+			t.Fatal("failed to read part body:", err)
+		}
+		parts[part.FormName()] = string(b)
+	}
+
+	const payload = `{"type":4,"data":{"content":"hi","attachments":[{"id":0,"description":"a file"}]}}`
+	if got := strings.TrimSpace(parts["payload_json"]); got != payload {
+		t.Errorf("unexpected payload_json %s", got)
+	}
+	if got := parts["file0"]; got != "file content" {
+		t.Errorf("unexpected file content %q", got)
+	}
+}
@@ -1,6 +1,9 @@
 package api
 
 import (
+	"errors"
+	"fmt"
+
 	"github.com/diamondburned/arikawa/v3/discord"
 	"github.com/diamondburned/arikawa/v3/utils/httputil"
 	"github.com/diamondburned/arikawa/v3/utils/json/option"
@@ -77,6 +80,19 @@ type CreateInviteData struct {
 	// Default:	false
 	Unique bool `json:"unique,omitempty"`
 
+	// TargetType is the type of target for this voice channel invite. It must
+	// be either discord.InviteUserStream or discord.InviteEmbeddedApplication.
+	TargetType discord.InviteUserType `json:"target_type,omitempty"`
+	// TargetUserID is the ID of the user whose stream to display for this
+	// invite. It is required if TargetType is discord.InviteUserStream, and
+	// the user must be streaming in the channel.
+	TargetUserID discord.UserID `json:"target_user_id,omitempty"`
+	// TargetApplicationID is the ID of the embedded application to open for
+	// this invite. It is required if TargetType is
+	// discord.InviteEmbeddedApplication, and the application must have the
+	// EMBEDDED flag.
+	TargetApplicationID discord.AppID `json:"target_application_id,omitempty"`
+
 	AuditLogReason `json:"-"`
 }
 
@@ -87,6 +103,20 @@ type CreateInviteData struct {
 func (c *Client) CreateInvite(
 	channelID discord.ChannelID, data CreateInviteData) (*discord.Invite, error) {
 
+	switch data.TargetType {
+	case discord.InviteNormalUser:
+	case discord.InviteUserStream:
+		if !data.TargetUserID.IsValid() {
+			return nil, errors.New("stream invites require TargetUserID")
+		}
+	case discord.InviteEmbeddedApplication:
+		if !data.TargetApplicationID.IsValid() {
+			return nil, errors.New("embedded application invites require TargetApplicationID")
+		}
+	default:
+		return nil, fmt.Errorf("unknown invite target type %d", data.TargetType)
+	}
+
 	var inv *discord.Invite
 	return inv, c.RequestJSON(
 		&inv, "POST",
@@ -1,6 +1,9 @@
 package api
 
 import (
+	"errors"
+	"time"
+
 	"github.com/diamondburned/arikawa/v3/discord"
 	"github.com/diamondburned/arikawa/v3/internal/intmath"
 	"github.com/diamondburned/arikawa/v3/utils/httputil"
@@ -9,6 +12,22 @@ import (
 
 const MaxMemberFetchLimit = 1000
 
+// MaxBanFetchLimit is the maximum number of bans that can be fetched in a
+// single request.
+const MaxBanFetchLimit = 1000
+
+// MaxTimeoutDuration is the maximum duration that a member can be timed out
+// for.
+const MaxTimeoutDuration = 28 * 24 * time.Hour
+
+// ErrInvalidTimeout is returned by TimeoutMember if the given time is not in
+// the future or is more than MaxTimeoutDuration away.
+var ErrInvalidTimeout = errors.New("timeout must be in the future and at most 28 days away")
+
+// MaxMemberSearchLimit is the maximum number of members that SearchMembers can
+// return.
+const MaxMemberSearchLimit = 1000
+
 // Member returns a guild member object for the specified user.
 func (c *Client) Member(guildID discord.GuildID, userID discord.UserID) (*discord.Member, error) {
 	var m *discord.Member
@@ -99,6 +118,39 @@ func (c *Client) membersAfter(
 	)
 }
 
+// SearchMembers returns the members of the guild whose username or nickname
+// starts with the given query. At most limit members are returned; limit is
+// clamped to between 1 and MaxMemberSearchLimit, and it defaults to 1 if it is
+// 0.
+//
+// Unlike Members, this endpoint does not require the privileged guild members
+// intent.
+func (c *Client) SearchMembers(
+	guildID discord.GuildID, query string, limit uint) ([]discord.Member, error) {
+
+	switch {
+	case limit == 0:
+		limit = 1
+	case limit > MaxMemberSearchLimit:
+		limit = MaxMemberSearchLimit
+	}
+
+	var param struct {
+		Query string `schema:"query"`
+		Limit uint   `schema:"limit"`
+	}
+
+	param.Query = query
+	param.Limit = limit
+
+	var mems []discord.Member
+	return mems, c.RequestJSON(
+		&mems, "GET",
+		EndpointGuilds+guildID.String()+"/members/search",
+		httputil.WithSchema(c, param),
+	)
+}
+
 // https://discord.com/developers/docs/resources/guild#add-guild-member-json-params
 type AddMemberData struct {
 	// Token is an oauth2 access token granted with the guilds.join to the
@@ -190,6 +242,44 @@ func (c *Client) ModifyMember(
 	)
 }
 
+// TimeoutMember times out the member until the given time, which must be in the
+// future and at most MaxTimeoutDuration away. A timed out member can't send
+// messages, react or join voice channels.
+//
+// Requires MODERATE_MEMBERS.
+//
+// Fires a Guild Member Update Gateway event.
+func (c *Client) TimeoutMember(
+	guildID discord.GuildID, userID discord.UserID,
+	until time.Time, reason AuditLogReason) error {
+
+	if d := time.Until(until); d <= 0 || d > MaxTimeoutDuration {
+		return ErrInvalidTimeout
+	}
+
+	timestamp := discord.NewTimestamp(until)
+
+	return c.ModifyMember(guildID, userID, ModifyMemberData{
+		CommunicationDisabledUntil: &timestamp,
+		AuditLogReason:             reason,
+	})
+}
+
+// RemoveTimeout removes the timeout of the member, if any.
+//
+// Requires MODERATE_MEMBERS.
+//
+// Fires a Guild Member Update Gateway event.
+func (c *Client) RemoveTimeout(
+	guildID discord.GuildID, userID discord.UserID, reason AuditLogReason) error {
+
+	// The zero Timestamp is marshaled as null, which clears the timeout.
+	return c.ModifyMember(guildID, userID, ModifyMemberData{
+		CommunicationDisabledUntil: &discord.Timestamp{},
+		AuditLogReason:             reason,
+	})
+}
+
 // https://discord.com/developers/docs/resources/guild#get-guild-prune-count-query-string-params
 type PruneCountData struct {
 	// Days is the number of days to count prune for (1 or more, default 7).
@@ -278,13 +368,134 @@ func (c *Client) Kick(
 }
 
 // Bans returns a list of ban objects for the users banned from this guild.
+// This method automatically paginates until it reaches the passed limit, or,
+// if the limit is set to 0, has fetched all bans of the guild.
+//
+// As the underlying endpoint has a maximum of 1000 bans per request, at
+// maximum a total of limit/1000 rounded up requests will be made, although
+// they may be less, if no more bans are available.
+//
+// When fetching the bans, those with the smallest user ID will be fetched
+// first.
+//
+// Requires the BAN_MEMBERS permission.
+func (c *Client) Bans(guildID discord.GuildID, limit uint) ([]discord.Ban, error) {
+	return c.BansAfter(guildID, 0, limit)
+}
+
+// BansBefore returns a list of ban objects for the users banned from this
+// guild. This method automatically paginates until it reaches the passed
+// limit, or, if the limit is set to 0, has fetched all bans with a user ID
+// smaller than before.
+//
+// As the underlying endpoint has a maximum of 1000 bans per request, at
+// maximum a total of limit/1000 rounded up requests will be made, although
+// they may be less, if no more bans are available.
 //
 // Requires the BAN_MEMBERS permission.
-func (c *Client) Bans(guildID discord.GuildID) ([]discord.Ban, error) {
+func (c *Client) BansBefore(
+	guildID discord.GuildID, before discord.UserID, limit uint) ([]discord.Ban, error) {
+
+	bans := make([]discord.Ban, 0, limit)
+
+	fetch := uint(MaxBanFetchLimit)
+
+	unlimited := limit == 0
+
+	for limit > 0 || unlimited {
+		if limit > 0 {
+			// Only fetch as much as we need. Since limit gradually decreases,
+			// we only need to fetch intmath.Min(fetch, limit).
+			fetch = uint(intmath.Min(MaxBanFetchLimit, int(limit)))
+			limit -= fetch
+		}
+
+		b, err := c.bansRange(guildID, before, 0, fetch)
+		if err != nil {
+			return bans, err
+		}
+		bans = append(b, bans...)
+
+		if len(b) < MaxBanFetchLimit {
+			break
+		}
+
+		before = b[0].User.ID
+	}
+
+	if len(bans) == 0 {
+		return nil, nil
+	}
+
+	return bans, nil
+}
+
+// BansAfter returns a list of ban objects for the users banned from this
+// guild. This method automatically paginates until it reaches the passed
+// limit, or, if the limit is set to 0, has fetched all bans with a user ID
+// higher than after.
+//
+// As the underlying endpoint has a maximum of 1000 bans per request, at
+// maximum a total of limit/1000 rounded up requests will be made, although
+// they may be less, if no more bans are available.
+//
+// Requires the BAN_MEMBERS permission.
+func (c *Client) BansAfter(
+	guildID discord.GuildID, after discord.UserID, limit uint) ([]discord.Ban, error) {
+
+	bans := make([]discord.Ban, 0, limit)
+
+	fetch := uint(MaxBanFetchLimit)
+
+	unlimited := limit == 0
+
+	for limit > 0 || unlimited {
+		if limit > 0 {
+			// Only fetch as much as we need. Since limit gradually decreases,
+			// we only need to fetch intmath.Min(fetch, limit).
+			fetch = uint(intmath.Min(MaxBanFetchLimit, int(limit)))
+			limit -= fetch
+		}
+
+		b, err := c.bansRange(guildID, 0, after, fetch)
+		if err != nil {
+			return bans, err
+		}
+		bans = append(bans, b...)
+
+		if len(b) < MaxBanFetchLimit {
+			break
+		}
+
+		after = b[len(b)-1].User.ID
+	}
+
+	if len(bans) == 0 {
+		return nil, nil
+	}
+
+	return bans, nil
+}
+
+func (c *Client) bansRange(
+	guildID discord.GuildID, before, after discord.UserID, limit uint) ([]discord.Ban, error) {
+
+	var param struct {
+		Before discord.UserID `schema:"before,omitempty"`
+		After  discord.UserID `schema:"after,omitempty"`
+
+		Limit uint `schema:"limit"`
+	}
+
+	param.Before = before
+	param.After = after
+	param.Limit = limit
+
 	var bans []discord.Ban
 	return bans, c.RequestJSON(
 		&bans, "GET",
 		EndpointGuilds+guildID.String()+"/bans",
+		httputil.WithSchema(c, param),
 	)
 }
 
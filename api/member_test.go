@@ -0,0 +1,21 @@
+package api
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestTimeoutMemberValidation(t *testing.T) {
+	client := NewClient("")
+
+	for _, until := range []time.Time{
+		time.Now().Add(-time.Minute),
+		time.Now().Add(MaxTimeoutDuration + time.Minute),
+	} {
+		err := client.TimeoutMember(1, 2, until, "")
+		if !errors.Is(err, ErrInvalidTimeout) {
+			t.Errorf("until %v: expected ErrInvalidTimeout, got %v", until, err)
+		}
+	}
+}
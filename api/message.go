@@ -3,6 +3,7 @@ package api
 import (
 	"fmt"
 	"mime/multipart"
+	"time"
 
 	"github.com/diamondburned/arikawa/v3/discord"
 	"github.com/diamondburned/arikawa/v3/internal/intmath"
@@ -288,7 +289,12 @@ type EditMessageData struct {
 	Components *discord.ContainerComponents `json:"components,omitempty"`
 	// AllowedMentions are the allowed mentions for a message.
 	AllowedMentions *AllowedMentions `json:"allowed_mentions,omitempty"`
-	// Attachments are the attached files to keep
+	// Attachments are the existing attachments to keep. If nil, all existing
+	// attachments are kept. Otherwise, any existing attachment that is not in
+	// the list is removed. Use KeepAttachments or KeepAttachmentsExcept to
+	// construct this field.
+	//
+	// New files given in Files are always added to the message.
 	Attachments *[]discord.Attachment `json:"attachments,omitempty"`
 	// Flags edits the flags of a message (only SUPPRESS_EMBEDS can currently
 	// be set/unset)
@@ -296,9 +302,46 @@ type EditMessageData struct {
 	// This field is nullable.
 	Flags *discord.MessageFlags `json:"flags,omitempty"`
 
+	// Files represents a list of files to upload. They are added to the
+	// message alongside the attachments being kept.
 	Files []sendpart.File `json:"-"`
 }
 
+// KeepAttachments returns a value for the Attachments field of the Edit data
+// types that keeps only the existing attachments with the given IDs. Calling
+// it without any IDs removes all existing attachments.
+func KeepAttachments(ids ...discord.AttachmentID) *[]discord.Attachment {
+	attachments := make([]discord.Attachment, len(ids))
+	for i, id := range ids {
+		attachments[i] = discord.Attachment{ID: id}
+	}
+	return &attachments
+}
+
+// KeepAttachmentsExcept returns a value for the Attachments field of the Edit
+// data types that keeps all the given existing attachments except for the ones
+// with the given IDs. It is useful for removing a few attachments from a
+// message, such as:
+//
+//	data := api.EditMessageData{
+//		Attachments: api.KeepAttachmentsExcept(msg.Attachments, toRemove),
+//	}
+func KeepAttachmentsExcept(
+	existing []discord.Attachment, remove ...discord.AttachmentID) *[]discord.Attachment {
+
+	attachments := make([]discord.Attachment, 0, len(existing))
+existing:
+	for _, attachment := range existing {
+		for _, id := range remove {
+			if attachment.ID == id {
+				continue existing
+			}
+		}
+		attachments = append(attachments, discord.Attachment{ID: attachment.ID})
+	}
+	return &attachments
+}
+
 // NeedsMultipart returns true if the SendMessageData has files.
 func (data EditMessageData) NeedsMultipart() bool {
 	return len(data.Files) > 0
@@ -418,38 +461,122 @@ func (c *Client) DeleteMessage(
 		httputil.WithHeaders(reason.Header()))
 }
 
+// MaxBulkDeleteAge is the maximum age of messages that can be deleted using
+// DeleteMessages. Discord rejects the whole request if any message is older.
+const MaxBulkDeleteAge = 14 * 24 * time.Hour
+
 // DeleteMessages deletes multiple messages in a single request. This endpoint
 // can only be used on guild channels and requires the MANAGE_MESSAGES
 // permission. This endpoint only works for bots.
 //
 // This endpoint will not delete messages older than 2 weeks, and will fail if
 // any message provided is older than that or if any duplicate message IDs are
-// provided.
+// provided. Use DeleteMessagesComplex to filter those out beforehand.
 //
 // Because the underlying endpoint only supports a maximum of 100 message IDs
 // per request, DeleteMessages will make a total of messageIDs/100 rounded up
 // requests.
 //
+// If a request fails, then a *DeleteMessagesError is returned, which describes
+// which messages were deleted and which were not. This is the case even if
+// there is only one request, or if the first request fails.
+//
 // Fires a Message Delete Bulk Gateway event.
 func (c *Client) DeleteMessages(
 	channelID discord.ChannelID, messageIDs []discord.MessageID, reason AuditLogReason) error {
 
-	switch {
-	case len(messageIDs) == 0:
-		return nil
-	case len(messageIDs) == 1:
-		return c.DeleteMessage(channelID, messageIDs[0], reason)
-	case len(messageIDs) <= maxMessageDeleteLimit: // Fast path
-		return c.deleteMessages(channelID, messageIDs, reason)
+	return c.deleteMessagesChunked(channelID, messageIDs, reason)
+}
+
+// DeleteMessagesOpts contains the options for DeleteMessagesComplex.
+type DeleteMessagesOpts struct {
+	// SkipOld, if true, filters out messages older than MaxBulkDeleteAge
+	// instead of letting Discord reject the request. The filtered messages are
+	// returned as skipped.
+	SkipOld bool
+
+	AuditLogReason
+}
+
+// DeleteMessagesError is returned by DeleteMessages and DeleteMessagesComplex
+// if one of their requests fails.
+type DeleteMessagesError struct {
+	// Deleted contains the IDs of the messages that were deleted before the
+	// failing request. It is empty if the first request failed.
+	Deleted []discord.MessageID
+	// Failed contains the IDs of the messages that were not deleted, which
+	// includes the IDs in the failing request and all requests after it.
+	Failed []discord.MessageID
+	// Err is the error of the failing request.
+	Err error
+}
+
+// Unwrap returns err.Err.
+func (err *DeleteMessagesError) Unwrap() error { return err.Err }
+
+// Error formats the DeleteMessagesError.
+func (err *DeleteMessagesError) Error() string {
+	return fmt.Sprintf(
+		"failed to delete %d messages (%d deleted): %v",
+		len(err.Failed), len(err.Deleted), err.Err)
+}
+
+// DeleteMessagesComplex is similar to DeleteMessages, except duplicate message
+// IDs are removed and messages older than MaxBulkDeleteAge are optionally
+// skipped. The IDs of skipped messages are returned.
+//
+// If a request fails, then a *DeleteMessagesError is returned, which describes
+// which messages were deleted and which were not. This is the case even if
+// there is only one request, or if the first request fails.
+//
+// Fires a Message Delete Bulk Gateway event.
+func (c *Client) DeleteMessagesComplex(
+	channelID discord.ChannelID,
+	messageIDs []discord.MessageID, opts DeleteMessagesOpts) (skipped []discord.MessageID, err error) {
+
+	seen := make(map[discord.MessageID]struct{}, len(messageIDs))
+	filtered := make([]discord.MessageID, 0, len(messageIDs))
+
+	for _, id := range messageIDs {
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+
+		if opts.SkipOld && time.Since(id.Time()) >= MaxBulkDeleteAge {
+			skipped = append(skipped, id)
+			continue
+		}
+
+		filtered = append(filtered, id)
 	}
 
+	return skipped, c.deleteMessagesChunked(channelID, filtered, opts.AuditLogReason)
+}
+
+func (c *Client) deleteMessagesChunked(
+	channelID discord.ChannelID, messageIDs []discord.MessageID, reason AuditLogReason) error {
+
 	// If the number of messages to be deleted exceeds the amount discord is willing
 	// to accept at one time then batches of messages will be deleted
 	for start := 0; start < len(messageIDs); start += maxMessageDeleteLimit {
 		end := intmath.Min(len(messageIDs), start+maxMessageDeleteLimit)
-		err := c.deleteMessages(channelID, messageIDs[start:end], reason)
+		chunk := messageIDs[start:end]
+
+		var err error
+		if len(chunk) == 1 {
+			// The bulk delete endpoint requires at least 2 messages.
+			err = c.DeleteMessage(channelID, chunk[0], reason)
+		} else {
+			err = c.deleteMessages(channelID, chunk, reason)
+		}
+
 		if err != nil {
-			return err
+			return &DeleteMessagesError{
+				Deleted: messageIDs[:start],
+				Failed:  messageIDs[start:],
+				Err:     err,
+			}
 		}
 	}
 
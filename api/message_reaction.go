@@ -74,7 +74,8 @@ func (c *Client) ReactionsBefore(
 			limit -= fetch
 		}
 
-		r, err := c.reactionsRange(channelID, messageID, before, 0, emoji, fetch)
+		r, err := c.reactionsRange(
+			channelID, messageID, before, 0, emoji, discord.NormalReaction, fetch)
 		if err != nil {
 			return users, err
 		}
@@ -120,7 +121,8 @@ func (c *Client) ReactionsAfter(
 			limit -= fetch
 		}
 
-		r, err := c.reactionsRange(channelID, messageID, 0, after, emoji, fetch)
+		r, err := c.reactionsRange(
+			channelID, messageID, 0, after, emoji, discord.NormalReaction, fetch)
 		if err != nil {
 			return users, err
 		}
@@ -140,11 +142,104 @@ func (c *Client) ReactionsAfter(
 	return users, nil
 }
 
+// ReactionsIterator iterates over the users that reacted with an emoji,
+// fetching them in pages as needed. It is created using ReactionsIter. The
+// usage is similar to bufio.Scanner:
+//
+//	it := client.ReactionsIter(channelID, messageID, 0, emoji, discord.NormalReaction)
+//	for it.Next() {
+//		user := it.User()
+//		...
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+type ReactionsIterator struct {
+	client    *Client
+	channelID discord.ChannelID
+	messageID discord.MessageID
+	emoji     discord.APIEmoji
+	typ       discord.ReactionType
+
+	after discord.UserID
+	page  []discord.User
+	user  discord.User
+	last  bool
+	err   error
+}
+
+// ReactionsIter returns an iterator over the users that reacted to the message
+// with the passed emoji and reaction type, starting with the user with the
+// smallest ID higher than after. Pages of MaxMessageReactionFetchLimit users
+// are fetched lazily, so the iterator can be stopped at any time.
+func (c *Client) ReactionsIter(
+	channelID discord.ChannelID, messageID discord.MessageID,
+	after discord.UserID, emoji discord.APIEmoji,
+	typ discord.ReactionType) *ReactionsIterator {
+
+	return &ReactionsIterator{
+		client:    c,
+		channelID: channelID,
+		messageID: messageID,
+		emoji:     emoji,
+		typ:       typ,
+		after:     after,
+	}
+}
+
+// Next advances the iterator to the next user, fetching the next page if
+// needed. It returns false once there are no more users or an error occurred,
+// in which case Err returns the error.
+func (it *ReactionsIterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+
+	if len(it.page) == 0 {
+		if it.last {
+			return false
+		}
+
+		page, err := it.client.reactionsRange(
+			it.channelID, it.messageID, 0, it.after, it.emoji, it.typ,
+			MaxMessageReactionFetchLimit,
+		)
+		if err != nil {
+			it.err = err
+			return false
+		}
+
+		it.page = page
+		it.last = len(page) < MaxMessageReactionFetchLimit
+
+		if len(page) == 0 {
+			return false
+		}
+
+		it.after = page[len(page)-1].ID
+	}
+
+	it.user = it.page[0]
+	it.page = it.page[1:]
+	return true
+}
+
+// User returns the current user. It is only valid after Next returns true.
+func (it *ReactionsIterator) User() discord.User {
+	return it.user
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (it *ReactionsIterator) Err() error {
+	return it.err
+}
+
 // reactionsRange get users before and after IDs. Before, after, and limit are
 // optional. A maximum limit of only 100 reactions could be returned.
 func (c *Client) reactionsRange(
 	channelID discord.ChannelID, messageID discord.MessageID,
-	before, after discord.UserID, emoji discord.APIEmoji, limit uint) ([]discord.User, error) {
+	before, after discord.UserID, emoji discord.APIEmoji,
+	typ discord.ReactionType, limit uint) ([]discord.User, error) {
 
 	switch {
 	case limit == 0:
@@ -157,11 +252,13 @@ func (c *Client) reactionsRange(
 		Before discord.UserID `schema:"before,omitempty"`
 		After  discord.UserID `schema:"after,omitempty"`
 
-		Limit uint `schema:"limit"`
+		Type  discord.ReactionType `schema:"type,omitempty"`
+		Limit uint                 `schema:"limit"`
 	}
 
 	param.Before = before
 	param.After = after
+	param.Type = typ
 	param.Limit = limit
 
 	var users []discord.User
@@ -0,0 +1,52 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+)
+
+func TestReactionsIter(t *testing.T) {
+	const total = 250
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if typ := r.URL.Query().Get("type"); typ != "1" {
+			t.Errorf("unexpected reaction type %q", typ)
+		}
+
+		after, _ := strconv.Atoi(r.URL.Query().Get("after"))
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+
+		users := []discord.User{}
+		for id := after + 1; id <= total && len(users) < limit; id++ {
+			users = append(users, discord.User{ID: discord.UserID(id)})
+		}
+
+		json.NewEncoder(w).Encode(users)
+	}))
+	t.Cleanup(srv.Close)
+
+	client := NewClient("").WithBaseURL(srv.URL)
+
+	it := client.ReactionsIter(1, 2, 10, "🎉", discord.BurstReaction)
+
+	var expect discord.UserID = 10
+	for it.Next() {
+		expect++
+		if id := it.User().ID; id != expect {
+			t.Fatalf("expected user %d, got %d", expect, id)
+		}
+	}
+
+	if err := it.Err(); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if expect != total {
+		t.Fatalf("expected to iterate until user %d, stopped at %d", total, expect)
+	}
+}
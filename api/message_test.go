@@ -0,0 +1,124 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/utils/httputil"
+)
+
+func TestDeleteMessagesComplex(t *testing.T) {
+	var mu sync.Mutex
+	var chunks [][]discord.MessageID
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Messages []discord.MessageID `json:"messages"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Error("failed to decode body:", err)
+		}
+
+		mu.Lock()
+		chunks = append(chunks, body.Messages)
+		n := len(chunks)
+		mu.Unlock()
+
+		if n > 1 {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"code":50034,"message":"too old"}`))
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	client := NewClient("Bot token").WithBaseURL(srv.URL)
+
+	recent := discord.NewSnowflake(time.Now().Add(-time.Hour))
+	old := discord.MessageID(discord.NewSnowflake(time.Now().Add(-MaxBulkDeleteAge - time.Hour)))
+
+	ids := make([]discord.MessageID, 150)
+	for i := range ids {
+		ids[i] = discord.MessageID(recent + discord.Snowflake(i))
+	}
+
+	// Add duplicates and an old message, which should not be sent.
+	input := append([]discord.MessageID{old}, ids...)
+	input = append(input, ids[:10]...)
+
+	skipped, err := client.DeleteMessagesComplex(1, input, DeleteMessagesOpts{SkipOld: true})
+
+	if !reflect.DeepEqual(skipped, []discord.MessageID{old}) {
+		t.Fatal("unexpected skipped messages:", skipped)
+	}
+
+	var deleteErr *DeleteMessagesError
+	if !errors.As(err, &deleteErr) {
+		t.Fatal("expected DeleteMessagesError, got", err)
+	}
+	if !reflect.DeepEqual(deleteErr.Deleted, ids[:100]) {
+		t.Fatalf("unexpected deleted messages (%d)", len(deleteErr.Deleted))
+	}
+	if !reflect.DeepEqual(deleteErr.Failed, ids[100:]) {
+		t.Fatalf("unexpected failed messages (%d)", len(deleteErr.Failed))
+	}
+
+	if len(chunks) != 2 || len(chunks[0]) != 100 || len(chunks[1]) != 50 {
+		t.Fatalf("unexpected requests: %d", len(chunks))
+	}
+}
+
+func TestDeleteMessagesFailed(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`{"code":50013,"message":"Missing Permissions"}`))
+	}))
+	defer srv.Close()
+
+	client := NewClient("Bot token").WithBaseURL(srv.URL)
+
+	recent := discord.NewSnowflake(time.Now().Add(-time.Hour))
+	ids := make([]discord.MessageID, 150)
+	for i := range ids {
+		ids[i] = discord.MessageID(recent + discord.Snowflake(i))
+	}
+
+	tests := []struct {
+		name string
+		ids  []discord.MessageID
+	}{
+		{"single message", ids[:1]},
+		{"single chunk", ids[:3]},
+		{"first of many chunks", ids},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := client.DeleteMessages(1, test.ids, "")
+
+			var deleteErr *DeleteMessagesError
+			if !errors.As(err, &deleteErr) {
+				t.Fatal("expected DeleteMessagesError, got", err)
+			}
+			if len(deleteErr.Deleted) != 0 {
+				t.Fatalf("unexpected deleted messages (%d)", len(deleteErr.Deleted))
+			}
+			if !reflect.DeepEqual(deleteErr.Failed, test.ids) {
+				t.Fatalf("unexpected failed messages (%d)", len(deleteErr.Failed))
+			}
+
+			var httpErr *httputil.HTTPError
+			if !errors.As(err, &httpErr) || httpErr.Status != http.StatusForbidden {
+				t.Fatal("expected the HTTP error to be wrapped, got", err)
+			}
+		})
+	}
+}
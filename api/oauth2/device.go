@@ -0,0 +1,127 @@
+package oauth2
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/discord"
+)
+
+// DeviceGrantType is the grant type of the device authorization grant.
+const DeviceGrantType = "urn:ietf:params:oauth:grant-type:device_code"
+
+// DefaultDeviceInterval is the polling interval used by DeviceToken if the
+// device authorization doesn't specify one.
+var DefaultDeviceInterval = 5 * time.Second
+
+var (
+	// ErrAccessDenied is returned by DeviceToken if the user denied the
+	// authorization request.
+	ErrAccessDenied = errors.New("user denied the authorization request")
+	// ErrDeviceCodeExpired is returned by DeviceToken if the device code
+	// expired before the user authorized it.
+	ErrDeviceCodeExpired = errors.New("device code expired")
+)
+
+// DeviceAuthorization is the response of a device authorization request. The
+// user must visit VerificationURI and enter UserCode to authorize the device.
+//
+// https://www.rfc-editor.org/rfc/rfc8628#section-3.2
+type DeviceAuthorization struct {
+	// DeviceCode is the code that DeviceToken exchanges for a token.
+	DeviceCode string `json:"device_code"`
+	// UserCode is the code that the user enters at VerificationURI.
+	UserCode string `json:"user_code"`
+	// VerificationURI is the URL that the user must visit.
+	VerificationURI string `json:"verification_uri"`
+	// VerificationURIComplete is VerificationURI with UserCode already
+	// filled in, if any. It is suited for QR codes.
+	VerificationURIComplete string `json:"verification_uri_complete,omitempty"`
+	// ExpiresIn is how long DeviceCode and UserCode are valid for.
+	ExpiresIn discord.Seconds `json:"expires_in"`
+	// Interval is the minimum time to wait between polls for the token.
+	Interval discord.Seconds `json:"interval,omitempty"`
+}
+
+// AuthorizeDevice starts the device authorization grant. The returned
+// DeviceAuthorization should be shown to the user, then passed to DeviceToken
+// or DeviceClient to wait for the user to authorize it.
+func (c *Config) AuthorizeDevice(ctx context.Context) (*DeviceAuthorization, error) {
+	var auth *DeviceAuthorization
+	return auth, c.postForm(ctx, &auth, EndpointDeviceAuthorization, url.Values{
+		"scope": {strings.Join(c.Scopes, " ")},
+	})
+}
+
+// DeviceToken polls for the token of the given device authorization until the
+// user authorizes it. ErrAccessDenied is returned if the user denies it, and
+// ErrDeviceCodeExpired is returned if the user doesn't act in time.
+func (c *Config) DeviceToken(ctx context.Context, auth *DeviceAuthorization) (*Token, error) {
+	interval := auth.Interval.Duration()
+	if interval <= 0 {
+		interval = DefaultDeviceInterval
+	}
+
+	if auth.ExpiresIn > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, auth.ExpiresIn.Duration())
+		defer cancel()
+	}
+
+	timer := time.NewTimer(interval)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return nil, ErrDeviceCodeExpired
+			}
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+
+		token, err := c.requestToken(ctx, url.Values{
+			"grant_type":  {DeviceGrantType},
+			"device_code": {auth.DeviceCode},
+		})
+		if err == nil {
+			return token, nil
+		}
+
+		var oauthErr *Error
+		if !errors.As(err, &oauthErr) {
+			return nil, err
+		}
+
+		switch oauthErr.Code {
+		case "authorization_pending":
+		case "slow_down":
+			interval += 5 * time.Second
+		case "access_denied":
+			return nil, ErrAccessDenied
+		case "expired_token":
+			return nil, ErrDeviceCodeExpired
+		default:
+			return nil, err
+		}
+
+		timer.Reset(interval)
+	}
+}
+
+// DeviceClient waits for the user to authorize the given device authorization
+// using DeviceToken, then returns an API client authorized with the token. The
+// client refreshes the token automatically.
+func (c *Config) DeviceClient(ctx context.Context, auth *DeviceAuthorization) (*api.Client, error) {
+	token, err := c.DeviceToken(ctx, auth)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.Client(token), nil
+}
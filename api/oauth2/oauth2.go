@@ -0,0 +1,255 @@
+// Package oauth2 implements the OAuth2 grants that don't need a browser
+// redirect, namely the client credentials grant and the device authorization
+// grant. The tokens that they return can be turned into an api.Client that
+// refreshes its token automatically.
+package oauth2
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/utils/httputil"
+	"github.com/diamondburned/arikawa/v3/utils/httputil/httpdriver"
+	"github.com/diamondburned/arikawa/v3/utils/json"
+)
+
+var (
+	EndpointToken               = api.Endpoint + "oauth2/token"
+	EndpointDeviceAuthorization = api.Endpoint + "oauth2/device/authorize"
+)
+
+// ExpiryDelta is how long before its expiry a token is already considered
+// expired, so that it isn't used right as it expires.
+var ExpiryDelta = 10 * time.Second
+
+// ErrNoRefreshToken is returned when a token has expired but can't be
+// refreshed, since it has no refresh token.
+var ErrNoRefreshToken = errors.New("token has no refresh token")
+
+// Config describes an OAuth2 application.
+type Config struct {
+	// ClientID is the ID of the application.
+	ClientID discord.AppID
+	// ClientSecret is the secret of the application. It may be empty for the
+	// device authorization grant.
+	ClientSecret string
+	// Scopes are the scopes to request, such as "identify" or
+	// "applications.commands.update".
+	Scopes []string
+
+	// HTTPClient, if not nil, is used for token requests instead of a new
+	// httputil.Client.
+	HTTPClient *httputil.Client
+}
+
+// Token is an OAuth2 access token.
+//
+// https://discord.com/developers/docs/topics/oauth2#client-credentials-grant-client-credentials-access-token-response
+type Token struct {
+	// AccessToken is the token that authorizes requests.
+	AccessToken string `json:"access_token"`
+	// TokenType is the type of the token. It is always "Bearer".
+	TokenType string `json:"token_type"`
+	// RefreshToken is the token used to get a new access token once this one
+	// expires. It is empty for tokens from the client credentials grant.
+	RefreshToken string `json:"refresh_token,omitempty"`
+	// Scope is the space-separated list of scopes that the token has.
+	Scope string `json:"scope"`
+	// ExpiresIn is how long the token is valid for after it is issued.
+	ExpiresIn discord.Seconds `json:"expires_in"`
+	// Expiry is when the token expires. It is calculated from ExpiresIn when
+	// the token is received. If it is zero, then the token never expires.
+	Expiry time.Time `json:"-"`
+}
+
+// Authorization returns the value of the Authorization header for the token,
+// e.g. "Bearer abc".
+func (t *Token) Authorization() string {
+	tokenType := t.TokenType
+	if tokenType == "" {
+		tokenType = "Bearer"
+	}
+	return tokenType + " " + t.AccessToken
+}
+
+// Expired returns true if the token has expired or is about to expire. See
+// ExpiryDelta.
+func (t *Token) Expired() bool {
+	return !t.Expiry.IsZero() && time.Now().Add(ExpiryDelta).After(t.Expiry)
+}
+
+// Error is an error response from the OAuth2 endpoints.
+//
+// https://www.rfc-editor.org/rfc/rfc6749#section-5.2
+type Error struct {
+	// Code is the error code, such as "invalid_grant".
+	Code string `json:"error"`
+	// Description is the human-readable description of the error, if any.
+	Description string `json:"error_description,omitempty"`
+}
+
+// Error implements error.
+func (err *Error) Error() string {
+	if err.Description != "" {
+		return "oauth2 error " + err.Code + ": " + err.Description
+	}
+	return "oauth2 error " + err.Code
+}
+
+// ClientCredentialsToken requests a token for the application's owner using
+// the client credentials grant. This is mainly useful for testing, since it
+// gives a bearer token without going through the browser. If the application
+// is owned by a team, then only the "identify" and
+// "applications.commands.update" scopes are allowed.
+//
+// https://discord.com/developers/docs/topics/oauth2#client-credentials-grant
+func (c *Config) ClientCredentialsToken(ctx context.Context) (*Token, error) {
+	return c.requestToken(ctx, url.Values{
+		"grant_type": {"client_credentials"},
+		"scope":      {strings.Join(c.Scopes, " ")},
+	})
+}
+
+// RefreshToken exchanges the given refresh token for a new token. The old
+// token, including its refresh token, is invalidated.
+//
+// https://discord.com/developers/docs/topics/oauth2#authorization-code-grant-refresh-token-exchange-example
+func (c *Config) RefreshToken(ctx context.Context, refreshToken string) (*Token, error) {
+	return c.requestToken(ctx, url.Values{
+		"grant_type":    {"refresh_token"},
+		"refresh_token": {refreshToken},
+	})
+}
+
+// ClientCredentialsClient returns an API client authorized with a token from
+// the client credentials grant. Since the grant has no refresh token, a new
+// token is requested once it expires.
+func (c *Config) ClientCredentialsClient(ctx context.Context) (*api.Client, error) {
+	token, err := c.ClientCredentialsToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	src := &TokenSource{token: *token, refresh: c.ClientCredentialsToken}
+	return src.Client(), nil
+}
+
+// TokenSource returns a TokenSource for the given token that refreshes it
+// using its refresh token.
+func (c *Config) TokenSource(token *Token) *TokenSource {
+	src := &TokenSource{token: *token}
+	src.refresh = func(ctx context.Context) (*Token, error) {
+		// The mutex is held by Token.
+		if src.token.RefreshToken == "" {
+			return nil, ErrNoRefreshToken
+		}
+		return c.RefreshToken(ctx, src.token.RefreshToken)
+	}
+	return src
+}
+
+// Client returns an API client authorized with the given token that refreshes
+// it automatically. It is a shortcut for c.TokenSource(token).Client().
+func (c *Config) Client(token *Token) *api.Client {
+	return c.TokenSource(token).Client()
+}
+
+func (c *Config) requestToken(ctx context.Context, form url.Values) (*Token, error) {
+	var token *Token
+	if err := c.postForm(ctx, &token, EndpointToken, form); err != nil {
+		return nil, err
+	}
+
+	if token.ExpiresIn > 0 {
+		token.Expiry = time.Now().Add(token.ExpiresIn.Duration())
+	}
+
+	return token, nil
+}
+
+// postForm sends the given form to the given endpoint with the client's
+// credentials. OAuth2 error responses are returned as *Error.
+func (c *Config) postForm(ctx context.Context, to interface{}, endpoint string, form url.Values) error {
+	client := c.HTTPClient
+	if client == nil {
+		client = httputil.NewClient()
+	}
+
+	form.Set("client_id", c.ClientID.String())
+
+	header := http.Header{"Content-Type": {"application/x-www-form-urlencoded"}}
+	if c.ClientSecret != "" {
+		credentials := c.ClientID.String() + ":" + c.ClientSecret
+		header.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(credentials)))
+	}
+
+	body := form.Encode()
+
+	err := client.WithContext(ctx).RequestJSON(
+		to, "POST", endpoint,
+		httputil.WithHeaders(header),
+		// The body is created for every try, since retries would otherwise
+		// send an exhausted reader.
+		func(r httpdriver.Request) error {
+			r.WithBody(io.NopCloser(strings.NewReader(body)))
+			return nil
+		},
+	)
+
+	var httpErr *httputil.HTTPError
+	if errors.As(err, &httpErr) {
+		var oauthErr Error
+		if json.Unmarshal(httpErr.Body, &oauthErr) == nil && oauthErr.Code != "" {
+			return &oauthErr
+		}
+	}
+
+	return err
+}
+
+// TokenSource holds a token and refreshes it once it expires. It is safe for
+// concurrent use.
+type TokenSource struct {
+	mutex   sync.Mutex
+	token   Token
+	refresh func(context.Context) (*Token, error)
+}
+
+// Token returns the current token, refreshing it first if it has expired.
+func (s *TokenSource) Token(ctx context.Context) (Token, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if !s.token.Expired() {
+		return s.token, nil
+	}
+
+	token, err := s.refresh(ctx)
+	if err != nil {
+		return Token{}, fmt.Errorf("failed to refresh token: %w", err)
+	}
+
+	s.token = *token
+	return s.token, nil
+}
+
+// Client returns an API client that is authorized with the source's token.
+func (s *TokenSource) Client() *api.Client {
+	return api.NewClient("").WithTokenFunc(func(ctx context.Context) (string, error) {
+		token, err := s.Token(ctx)
+		if err != nil {
+			return "", err
+		}
+		return token.Authorization(), nil
+	})
+}
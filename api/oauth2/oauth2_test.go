@@ -0,0 +1,156 @@
+package oauth2
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/utils/httputil"
+	"github.com/diamondburned/arikawa/v3/utils/httputil/httpdriver"
+)
+
+func newTestConfig(t *testing.T, handler http.HandlerFunc) (*Config, string) {
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	client := httputil.NewClient()
+	client.Client = httpdriver.WithBaseURL(client.Client, api.BaseEndpoint, srv.URL)
+
+	return &Config{
+		ClientID:     1,
+		ClientSecret: "secret",
+		Scopes:       []string{"identify", "applications.commands.update"},
+		HTTPClient:   client,
+	}, srv.URL
+}
+
+func TestClientCredentials(t *testing.T) {
+	var tokens int
+
+	cfg, url := newTestConfig(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v9/oauth2/token":
+			if id, secret, _ := r.BasicAuth(); id != "1" || secret != "secret" {
+				t.Errorf("unexpected credentials %q:%q", id, secret)
+			}
+			if grant := r.FormValue("grant_type"); grant != "client_credentials" {
+				t.Errorf("unexpected grant type %q", grant)
+			}
+			if scope := r.FormValue("scope"); scope != "identify applications.commands.update" {
+				t.Errorf("unexpected scope %q", scope)
+			}
+
+			tokens++
+			// Expire the token right away to force a refresh.
+			w.Write([]byte(`{"access_token":"token","token_type":"Bearer","expires_in":1}`))
+
+		case "/api/v9/users/@me":
+			if auth := r.Header.Get("Authorization"); auth != "Bearer token" {
+				t.Errorf("unexpected authorization %q", auth)
+			}
+			w.Write([]byte(`{"id":"1"}`))
+
+		default:
+			t.Errorf("unexpected path %q", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	client, err := cfg.ClientCredentialsClient(context.Background())
+	if err != nil {
+		t.Fatal("failed to get client:", err)
+	}
+
+	if _, err := client.WithBaseURL(url).Me(); err != nil {
+		t.Fatal("failed to get me:", err)
+	}
+
+	if tokens != 2 {
+		t.Fatalf("expected 2 token requests, got %d", tokens)
+	}
+}
+
+func TestDeviceFlow(t *testing.T) {
+	defer func(interval time.Duration) { DefaultDeviceInterval = interval }(DefaultDeviceInterval)
+	DefaultDeviceInterval = time.Millisecond
+
+	var polls int
+
+	cfg, url := newTestConfig(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v9/oauth2/device/authorize":
+			w.Write([]byte(`{"device_code":"device","user_code":"ABCD","expires_in":60}`))
+
+		case "/api/v9/oauth2/token":
+			switch grant := r.FormValue("grant_type"); grant {
+			case DeviceGrantType:
+				if code := r.FormValue("device_code"); code != "device" {
+					t.Errorf("unexpected device code %q", code)
+				}
+				if polls++; polls == 1 {
+					w.WriteHeader(http.StatusBadRequest)
+					w.Write([]byte(`{"error":"authorization_pending"}`))
+					return
+				}
+				w.Write([]byte(`{"access_token":"old","refresh_token":"refresh","expires_in":1}`))
+
+			case "refresh_token":
+				if token := r.FormValue("refresh_token"); token != "refresh" {
+					t.Errorf("unexpected refresh token %q", token)
+				}
+				w.Write([]byte(`{"access_token":"new","refresh_token":"refresh2","expires_in":3600}`))
+
+			default:
+				t.Errorf("unexpected grant type %q", grant)
+			}
+
+		case "/api/v9/users/@me":
+			if auth := r.Header.Get("Authorization"); auth != "Bearer new" {
+				t.Errorf("unexpected authorization %q", auth)
+			}
+			w.Write([]byte(`{"id":"1"}`))
+		}
+	})
+
+	ctx := context.Background()
+
+	auth, err := cfg.AuthorizeDevice(ctx)
+	if err != nil {
+		t.Fatal("failed to authorize device:", err)
+	}
+
+	if auth.UserCode != "ABCD" {
+		t.Fatalf("unexpected user code %q", auth.UserCode)
+	}
+
+	client, err := cfg.DeviceClient(ctx, auth)
+	if err != nil {
+		t.Fatal("failed to get client:", err)
+	}
+
+	if polls != 2 {
+		t.Fatalf("expected 2 polls, got %d", polls)
+	}
+
+	if _, err := client.WithBaseURL(url).Me(); err != nil {
+		t.Fatal("failed to get me:", err)
+	}
+}
+
+func TestDeviceTokenDenied(t *testing.T) {
+	defer func(interval time.Duration) { DefaultDeviceInterval = interval }(DefaultDeviceInterval)
+	DefaultDeviceInterval = time.Millisecond
+
+	cfg, _ := newTestConfig(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error":"access_denied"}`))
+	})
+
+	_, err := cfg.DeviceToken(context.Background(), &DeviceAuthorization{DeviceCode: "device"})
+	if err != ErrAccessDenied {
+		t.Fatalf("expected ErrAccessDenied, got %v", err)
+	}
+}
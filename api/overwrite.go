@@ -0,0 +1,126 @@
+package api
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+)
+
+// OverwritesDiff is the set of changes needed to turn a channel's permission
+// overwrites into the desired ones. It is created using DiffOverwrites.
+type OverwritesDiff struct {
+	// Edit contains the overwrites that must be created or changed.
+	Edit []discord.Overwrite
+	// Delete contains the current overwrites that must be deleted.
+	Delete []discord.Overwrite
+}
+
+// DiffOverwrites diffs the current permission overwrites of a channel against
+// the desired full set of overwrites. Overwrites are matched by their ID, and
+// overwrites that are already as desired are left out of the diff.
+func DiffOverwrites(current, desired []discord.Overwrite) OverwritesDiff {
+	var diff OverwritesDiff
+
+	currentByID := make(map[discord.Snowflake]discord.Overwrite, len(current))
+	for _, overwrite := range current {
+		currentByID[overwrite.ID] = overwrite
+	}
+
+	desiredIDs := make(map[discord.Snowflake]struct{}, len(desired))
+	for _, overwrite := range desired {
+		desiredIDs[overwrite.ID] = struct{}{}
+
+		if old, ok := currentByID[overwrite.ID]; !ok || old != overwrite {
+			diff.Edit = append(diff.Edit, overwrite)
+		}
+	}
+
+	for _, overwrite := range current {
+		if _, ok := desiredIDs[overwrite.ID]; !ok {
+			diff.Delete = append(diff.Delete, overwrite)
+		}
+	}
+
+	return diff
+}
+
+// IsEmpty returns true if the diff has no changes.
+func (d OverwritesDiff) IsEmpty() bool {
+	return len(d.Edit) == 0 && len(d.Delete) == 0
+}
+
+// String formats the diff with one change per line. It is useful for dry runs.
+func (d OverwritesDiff) String() string {
+	var b strings.Builder
+
+	for _, overwrite := range d.Edit {
+		fmt.Fprintf(&b, "edit %s %d: allow %d, deny %d\n",
+			overwriteTypeName(overwrite.Type), overwrite.ID, overwrite.Allow, overwrite.Deny)
+	}
+
+	for _, overwrite := range d.Delete {
+		fmt.Fprintf(&b, "delete %s %d\n", overwriteTypeName(overwrite.Type), overwrite.ID)
+	}
+
+	return b.String()
+}
+
+func overwriteTypeName(t discord.OverwriteType) string {
+	switch t {
+	case discord.OverwriteRole:
+		return "role"
+	case discord.OverwriteMember:
+		return "member"
+	default:
+		return fmt.Sprintf("type %d", t)
+	}
+}
+
+// ApplyOverwritesDiff issues the EditChannelPermission and
+// DeleteChannelPermission calls needed to apply the given diff to the channel.
+// It stops at the first error.
+//
+// Requires the MANAGE_ROLES permission.
+func (c *Client) ApplyOverwritesDiff(
+	channelID discord.ChannelID, diff OverwritesDiff, reason AuditLogReason) error {
+
+	for _, overwrite := range diff.Edit {
+		err := c.EditChannelPermission(channelID, overwrite.ID, EditChannelPermissionData{
+			Type:           overwrite.Type,
+			Allow:          overwrite.Allow,
+			Deny:           overwrite.Deny,
+			AuditLogReason: reason,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to edit overwrite %d: %w", overwrite.ID, err)
+		}
+	}
+
+	for _, overwrite := range diff.Delete {
+		if err := c.DeleteChannelPermission(channelID, overwrite.ID, reason); err != nil {
+			return fmt.Errorf("failed to delete overwrite %d: %w", overwrite.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// SyncChannelPermissions makes the permission overwrites of the channel match
+// the desired full set of overwrites, fetching the current ones from the API.
+// Only the necessary changes are made, and they are returned. To preview the
+// changes without making them, use DiffOverwrites.
+//
+// Requires the MANAGE_ROLES permission.
+func (c *Client) SyncChannelPermissions(
+	channelID discord.ChannelID,
+	desired []discord.Overwrite, reason AuditLogReason) (OverwritesDiff, error) {
+
+	ch, err := c.Channel(channelID)
+	if err != nil {
+		return OverwritesDiff{}, fmt.Errorf("failed to get channel: %w", err)
+	}
+
+	diff := DiffOverwrites(ch.Overwrites, desired)
+	return diff, c.ApplyOverwritesDiff(channelID, diff, reason)
+}
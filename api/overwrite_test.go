@@ -0,0 +1,49 @@
+package api
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+)
+
+func TestDiffOverwrites(t *testing.T) {
+	current := []discord.Overwrite{
+		{ID: 1, Type: discord.OverwriteRole, Allow: discord.PermissionViewChannel},
+		{ID: 2, Type: discord.OverwriteRole, Deny: discord.PermissionSendMessages},
+		{ID: 3, Type: discord.OverwriteMember, Allow: discord.PermissionSendMessages},
+	}
+
+	desired := []discord.Overwrite{
+		// unchanged
+		{ID: 1, Type: discord.OverwriteRole, Allow: discord.PermissionViewChannel},
+		// changed
+		{ID: 2, Type: discord.OverwriteRole, Deny: discord.PermissionViewChannel},
+		// new
+		{ID: 4, Type: discord.OverwriteMember, Allow: discord.PermissionViewChannel},
+	}
+
+	diff := DiffOverwrites(current, desired)
+
+	expect := OverwritesDiff{
+		Edit:   []discord.Overwrite{desired[1], desired[2]},
+		Delete: []discord.Overwrite{current[2]},
+	}
+
+	if !reflect.DeepEqual(diff, expect) {
+		t.Fatalf("unexpected diff:\n%s", diff)
+	}
+
+	if !DiffOverwrites(current, current).IsEmpty() {
+		t.Fatal("diff of the same overwrites is not empty")
+	}
+
+	const output = "" +
+		"edit role 2: allow 0, deny 1024\n" +
+		"edit member 4: allow 1024, deny 0\n" +
+		"delete member 3\n"
+
+	if s := diff.String(); s != output {
+		t.Fatalf("unexpected dry-run output:\n%s", s)
+	}
+}
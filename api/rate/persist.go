@@ -0,0 +1,186 @@
+package rate
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/utils/json"
+)
+
+// BucketState is the learned state of a rate limit bucket.
+type BucketState struct {
+	// Key is the bucket key of the route, as returned by ParseBucketKey.
+	Key string `json:"key"`
+	// Hash is the bucket hash that Discord gave for the route, if any.
+	Hash string `json:"hash,omitempty"`
+	// Remaining is the number of requests left until Reset.
+	Remaining uint64 `json:"remaining"`
+	// Reset is when the bucket resets.
+	Reset time.Time `json:"reset"`
+}
+
+// Snapshot is the learned state of a Limiter. Only buckets that have not reset
+// yet are included, since the others hold no information.
+type Snapshot struct {
+	// Global is when the global rate limit resets, if it is in effect.
+	Global  time.Time     `json:"global,omitempty"`
+	Buckets []BucketState `json:"buckets"`
+}
+
+// BucketStore persists a Snapshot, so that a Limiter can be restored after a
+// restart instead of learning its buckets again by hitting rate limits.
+type BucketStore interface {
+	// LoadSnapshot returns the saved snapshot. If nothing has been saved yet,
+	// it must return an empty snapshot and no error.
+	LoadSnapshot(ctx context.Context) (*Snapshot, error)
+	// SaveSnapshot saves the snapshot, replacing any previous one.
+	SaveSnapshot(ctx context.Context, snapshot *Snapshot) error
+}
+
+// Snapshot returns the learned state of the limiter. Buckets that are being
+// used by requests in flight are waited on until they are released or ctx is
+// done.
+func (l *Limiter) Snapshot(ctx context.Context) (*Snapshot, error) {
+	now := time.Now()
+	snapshot := Snapshot{}
+
+	if global := time.Unix(0, atomic.LoadInt64(l.global)); global.After(now) {
+		snapshot.Global = global
+	}
+
+	l.bucketMu.Lock()
+	buckets := make(map[string]*bucket, len(l.buckets))
+	for key, b := range l.buckets {
+		// Custom limits are set in code, so they are never learned.
+		if b.custom == nil {
+			buckets[key] = b
+		}
+	}
+	l.bucketMu.Unlock()
+
+	for key, b := range buckets {
+		if err := b.lock.Lock(ctx); err != nil {
+			return nil, err
+		}
+
+		if b.reset.After(now) {
+			snapshot.Buckets = append(snapshot.Buckets, BucketState{
+				Key:       key,
+				Hash:      b.hash,
+				Remaining: b.remaining,
+				Reset:     b.reset,
+			})
+		}
+
+		b.lock.Unlock()
+	}
+
+	return &snapshot, nil
+}
+
+// Restore restores the learned state of the limiter from the given snapshot.
+// Buckets that have already reset are skipped, and buckets that the limiter
+// has already learned are overridden.
+func (l *Limiter) Restore(snapshot *Snapshot) {
+	now := time.Now()
+
+	if snapshot.Global.After(now) {
+		atomic.StoreInt64(l.global, snapshot.Global.UnixNano())
+	}
+
+	for _, state := range snapshot.Buckets {
+		if !state.Reset.After(now) {
+			continue
+		}
+
+		b := l.bucketByKey(state.Key, true)
+		if b.custom != nil {
+			continue
+		}
+
+		// Wait for requests in flight using the bucket.
+		b.lock.Lock(context.Background())
+
+		b.hash = state.Hash
+		b.remaining = state.Remaining
+		b.reset = state.Reset
+
+		b.lock.Unlock()
+	}
+}
+
+// SaveBuckets saves the snapshot of the limiter into the given store.
+func (l *Limiter) SaveBuckets(ctx context.Context, store BucketStore) error {
+	snapshot, err := l.Snapshot(ctx)
+	if err != nil {
+		return err
+	}
+
+	return store.SaveSnapshot(ctx, snapshot)
+}
+
+// LoadBuckets restores the limiter from the snapshot in the given store. It
+// should be called before any request is made.
+func (l *Limiter) LoadBuckets(ctx context.Context, store BucketStore) error {
+	snapshot, err := store.LoadSnapshot(ctx)
+	if err != nil {
+		return err
+	}
+
+	l.Restore(snapshot)
+	return nil
+}
+
+// FileBucketStore is a BucketStore that saves the snapshot as JSON into the
+// file at the given path.
+type FileBucketStore string
+
+var _ BucketStore = FileBucketStore("")
+
+// LoadSnapshot implements BucketStore.
+func (path FileBucketStore) LoadSnapshot(ctx context.Context) (*Snapshot, error) {
+	b, err := ioutil.ReadFile(string(path))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return &Snapshot{}, nil
+		}
+		return nil, err
+	}
+
+	var snapshot Snapshot
+	if err := json.Unmarshal(b, &snapshot); err != nil {
+		return nil, err
+	}
+
+	return &snapshot, nil
+}
+
+// SaveSnapshot implements BucketStore. The file is replaced atomically.
+func (path FileBucketStore) SaveSnapshot(ctx context.Context, snapshot *Snapshot) error {
+	b, err := json.Marshal(snapshot)
+	if err != nil {
+		return err
+	}
+
+	f, err := ioutil.TempFile(filepath.Dir(string(path)), filepath.Base(string(path))+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	if _, err := f.Write(b); err != nil {
+		f.Close()
+		return err
+	}
+
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(f.Name(), string(path))
+}
@@ -0,0 +1,68 @@
+package rate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestLimiterPersistence(t *testing.T) {
+	store := FileBucketStore(filepath.Join(t.TempDir(), "buckets.json"))
+	ctx := context.Background()
+
+	// Loading a store that was never saved to is a no-op.
+	if err := NewLimiter("").LoadBuckets(ctx, store); err != nil {
+		t.Fatal("failed to load empty store:", err)
+	}
+
+	reset := time.Now().Add(time.Minute)
+
+	headers := http.Header{}
+	headers.Set("X-RateLimit-Remaining", "0")
+	headers.Set("X-RateLimit-Reset", fmt.Sprintf("%.3f", float64(reset.UnixNano())/float64(time.Second)))
+	headers.Set("X-RateLimit-Bucket", "abcd1234")
+
+	l := NewLimiter("")
+	mockRequest(t, l, "/channels/1/messages/2", headers)
+	// This bucket has already reset, so it should not be saved.
+	mockRequest(t, l, "/guilds/3/members", nil)
+
+	if err := l.SaveBuckets(ctx, store); err != nil {
+		t.Fatal("failed to save buckets:", err)
+	}
+
+	snapshot, err := store.LoadSnapshot(ctx)
+	if err != nil {
+		t.Fatal("failed to load snapshot:", err)
+	}
+
+	if len(snapshot.Buckets) != 1 {
+		t.Fatalf("expected 1 bucket, got %d", len(snapshot.Buckets))
+	}
+
+	bucket := snapshot.Buckets[0]
+	if bucket.Key != "/channels/1/messages/" || bucket.Hash != "abcd1234" || bucket.Remaining != 0 {
+		t.Fatalf("unexpected bucket %+v", bucket)
+	}
+
+	restored := NewLimiter("")
+	if err := restored.LoadBuckets(ctx, store); err != nil {
+		t.Fatal("failed to load buckets:", err)
+	}
+
+	// The restored bucket is exhausted, so acquiring it must not go through.
+	dontWait := AcquireOptions{DontWait: true}.Context(ctx)
+
+	if err := restored.Acquire(dontWait, "/channels/1/messages/5"); !errors.Is(err, ErrTimedOutEarly) {
+		t.Fatal("expected ErrTimedOutEarly, got", err)
+	}
+
+	// Other buckets are unaffected.
+	if err := restored.Acquire(dontWait, "/guilds/3/members"); err != nil {
+		t.Fatal("unexpected error acquiring unrelated bucket:", err)
+	}
+}
@@ -68,6 +68,7 @@ func (opts AcquireOptions) Context(ctx context.Context) context.Context {
 type bucket struct {
 	lock   moreatomic.CtxMutex
 	custom *CustomRateLimit
+	hash   string
 
 	remaining uint64
 
@@ -92,12 +93,14 @@ func NewLimiter(prefix string) *Limiter {
 }
 
 func (l *Limiter) getBucket(path string, store bool) *bucket {
-	path = ParseBucketKey(strings.TrimPrefix(path, l.Prefix))
+	return l.bucketByKey(ParseBucketKey(strings.TrimPrefix(path, l.Prefix)), store)
+}
 
+func (l *Limiter) bucketByKey(key string, store bool) *bucket {
 	l.bucketMu.Lock()
 	defer l.bucketMu.Unlock()
 
-	bc, ok := l.buckets[path]
+	bc, ok := l.buckets[key]
 	if !ok && !store {
 		return nil
 	}
@@ -106,13 +109,13 @@ func (l *Limiter) getBucket(path string, store bool) *bucket {
 		bc := newBucket()
 
 		for _, limit := range l.CustomLimits {
-			if strings.Contains(path, limit.Contains) {
+			if strings.Contains(key, limit.Contains) {
 				bc.custom = limit
 				break
 			}
 		}
 
-		l.buckets[path] = bc
+		l.buckets[key] = bc
 		return bc
 	}
 
@@ -204,8 +207,13 @@ func (l *Limiter) Release(path string, headers http.Header) error {
 		remaining  = headers.Get("X-RateLimit-Remaining")
 		reset      = headers.Get("X-RateLimit-Reset") // float
 		retryAfter = headers.Get("Retry-After")
+		hash       = headers.Get("X-RateLimit-Bucket")
 	)
 
+	if hash != "" {
+		b.hash = hash
+	}
+
 	switch {
 	case retryAfter != "":
 		i, err := strconv.Atoi(retryAfter)
@@ -1,6 +1,9 @@
 package api
 
 import (
+	"fmt"
+	"sort"
+
 	"github.com/diamondburned/arikawa/v3/discord"
 	"github.com/diamondburned/arikawa/v3/utils/httputil"
 	"github.com/diamondburned/arikawa/v3/utils/json/option"
@@ -46,6 +49,31 @@ func (c *Client) Roles(guildID discord.GuildID) ([]discord.Role, error) {
 	return roles, c.RequestJSON(&roles, "GET", EndpointGuilds+guildID.String()+"/roles")
 }
 
+// GuildRoleMemberCounts returns the number of members that have each role in
+// the guild, keyed by role ID. The @everyone role is not included.
+//
+// This endpoint is undocumented and may change without notice.
+func (c *Client) GuildRoleMemberCounts(guildID discord.GuildID) (map[discord.RoleID]int, error) {
+	var raw map[string]int
+	if err := c.RequestJSON(
+		&raw, "GET",
+		EndpointGuilds+guildID.String()+"/roles/member-counts",
+	); err != nil {
+		return nil, err
+	}
+
+	counts := make(map[discord.RoleID]int, len(raw))
+	for id, count := range raw {
+		sf, err := discord.ParseSnowflake(id)
+		if err != nil {
+			return nil, fmt.Errorf("invalid role ID %q: %w", id, err)
+		}
+		counts[discord.RoleID(sf)] = count
+	}
+
+	return counts, nil
+}
+
 // https://discord.com/developers/docs/resources/guild#create-guild-role-json-params
 type CreateRoleData struct {
 	// Name is the 	name of the role.
@@ -79,21 +107,53 @@ type CreateRoleData struct {
 	// Default: null
 	UnicodeEmoji string `json:"unicode_emoji,omitempty"`
 
+	// Position, if not nil, is the sorting position to move the role to once
+	// it's created. Discord does not support this when creating the role, so
+	// an additional MoveRoles request is made.
+	//
+	// Default: 1
+	Position option.Int `json:"-"`
+
 	AddRoleData `json:"-"`
 }
 
-// CreateRole creates a new role for the guild.
+// CreateRole creates a new role for the guild. If data.Position is set, then
+// the role is moved to that position after it's created.
 //
 // Requires the MANAGE_ROLES permission.
 //
-// Fires a Guild Role Create Gateway event.
+// Fires a Guild Role Create Gateway event, and Guild Role Update Gateway
+// events if the role is moved.
 func (c *Client) CreateRole(guildID discord.GuildID, data CreateRoleData) (*discord.Role, error) {
 	var role *discord.Role
-	return role, c.RequestJSON(
+	err := c.RequestJSON(
 		&role, "POST",
 		EndpointGuilds+guildID.String()+"/roles",
 		httputil.WithJSONBody(data), httputil.WithHeaders(data.Header()),
 	)
+	if err != nil || data.Position == nil || role == nil {
+		return role, err
+	}
+
+	roles, err := c.MoveRoles(guildID, MoveRolesData{
+		Roles: []MoveRoleData{{
+			ID:       role.ID,
+			Position: option.NewNullableInt(*data.Position),
+		}},
+		AuditLogReason: data.AuditLogReason,
+	})
+	if err != nil {
+		return role, fmt.Errorf("role created but cannot be moved: %w", err)
+	}
+
+	for _, r := range roles {
+		if r.ID == role.ID {
+			role.Position = r.Position
+			break
+		}
+	}
+
+	return role, nil
 }
 
 type (
@@ -112,6 +172,50 @@ type (
 	}
 )
 
+// RolePositions computes the MoveRoleData needed to sort the given roles in
+// the given order, which lists role IDs from the highest role to the lowest,
+// like the Discord client does. The roles in order are given the positions
+// that they currently occupy, so roles not in order are not moved. Only the
+// roles whose positions change are returned, so the result can be given
+// directly to MoveRoles.
+//
+// Roles must contain at least all roles in order; IDs in order that are not in
+// roles are ignored. The @everyone role cannot be moved and must not be in
+// order.
+func RolePositions(roles []discord.Role, order []discord.RoleID) []MoveRoleData {
+	current := make(map[discord.RoleID]int, len(roles))
+	for _, role := range roles {
+		current[role.ID] = role.Position
+	}
+
+	ids := make([]discord.RoleID, 0, len(order))
+	positions := make([]int, 0, len(order))
+
+	for _, id := range order {
+		pos, ok := current[id]
+		if !ok {
+			continue
+		}
+		ids = append(ids, id)
+		positions = append(positions, pos)
+	}
+
+	// Highest position first, to match the order.
+	sort.Sort(sort.Reverse(sort.IntSlice(positions)))
+
+	var moves []MoveRoleData
+	for i, id := range ids {
+		if current[id] != positions[i] {
+			moves = append(moves, MoveRoleData{
+				ID:       id,
+				Position: option.NewNullableInt(positions[i]),
+			})
+		}
+	}
+
+	return moves
+}
+
 // MoveRoles modifies the positions of a set of role objects for the guild.
 //
 // Requires the MANAGE_ROLES permission.
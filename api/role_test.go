@@ -0,0 +1,57 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/utils/json/option"
+)
+
+func TestRolePositions(t *testing.T) {
+	roles := []discord.Role{
+		{ID: 1, Position: 0}, // @everyone
+		{ID: 2, Position: 1},
+		{ID: 3, Position: 2},
+		{ID: 4, Position: 3},
+		{ID: 5, Position: 4},
+	}
+
+	// Swap roles 2 and 4 and leave 3 where it is. Role 5 is not touched.
+	moves := RolePositions(roles, []discord.RoleID{2, 3, 4, 99})
+
+	expect := []MoveRoleData{
+		{ID: 2, Position: option.NewNullableInt(3)},
+		{ID: 4, Position: option.NewNullableInt(1)},
+	}
+
+	if !reflect.DeepEqual(moves, expect) {
+		t.Fatalf("unexpected moves %+v", moves)
+	}
+
+	if moves := RolePositions(roles, []discord.RoleID{5, 4}); moves != nil {
+		t.Fatalf("expected no moves, got %+v", moves)
+	}
+}
+
+func TestGuildRoleMemberCounts(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v9/guilds/1/roles/member-counts" {
+			t.Errorf("unexpected path %q", r.URL.Path)
+		}
+		w.Write([]byte(`{"2":5,"3":0}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	counts, err := NewClient("").WithBaseURL(srv.URL).GuildRoleMemberCounts(1)
+	if err != nil {
+		t.Fatal("failed to get counts:", err)
+	}
+
+	expect := map[discord.RoleID]int{2: 5, 3: 0}
+	if !reflect.DeepEqual(counts, expect) {
+		t.Fatalf("unexpected counts %v", counts)
+	}
+}
@@ -1,6 +1,9 @@
 package api
 
 import (
+	"errors"
+	"fmt"
+
 	"github.com/diamondburned/arikawa/v3/discord"
 	"github.com/diamondburned/arikawa/v3/utils/httputil"
 	"github.com/diamondburned/arikawa/v3/utils/json/option"
@@ -26,8 +29,8 @@ type CreateScheduledEventData struct {
 	Description string `json:"description"`
 	// EntityType is the entity type of the scheduled event.
 	EntityType discord.EntityType `json:"entity_type"`
-	// Image is the cover image of the scheduled event.
-	Image Image `json:"image"`
+	// Image is the cover image of the scheduled event, if any.
+	Image *Image `json:"image,omitempty"`
 }
 
 // EditScheduledEventData is the structure for modifying a scheduled event.
@@ -50,7 +53,10 @@ type EditScheduledEventData struct {
 	Description option.NullableString `json:"description,omitempty"`
 	// EntityType is the new entity type of the scheduled event.
 	EntityType discord.EntityType `json:"entity_type,omitempty"`
-	// Status is the new event status of the scheduled event.
+	// Status is the new event status of the scheduled event. Scheduled
+	// events can only be started or cancelled, and active events can only be
+	// completed; see discord.EventStatus.CanTransitionTo. A status cannot be
+	// changed back to discord.ScheduledEvent.
 	Status discord.EventStatus `json:"status,omitempty"`
 	// Image is the new image of the scheduled event.
 	Image *Image `json:"image,omitempty"`
@@ -127,6 +133,10 @@ func (c *Client) CreateScheduledEvent(guildID discord.GuildID, reason AuditLogRe
 // https://discord.com/developers/docs/resources/guild-scheduled-event#modify-guild-scheduled-event
 func (c *Client) EditScheduledEvent(guildID discord.GuildID, eventID discord.EventID, reason AuditLogReason,
 	data EditScheduledEventData) (*discord.GuildScheduledEvent, error) {
+	if data.Status == discord.ScheduledEvent {
+		return nil, errors.New("scheduled event status cannot be changed back to scheduled")
+	}
+
 	var modifiedEvent *discord.GuildScheduledEvent
 	return modifiedEvent, c.RequestJSON(
 		&modifiedEvent,
@@ -136,6 +146,53 @@ func (c *Client) EditScheduledEvent(guildID discord.GuildID, eventID discord.Eve
 	)
 }
 
+// EventStatusTransitionError is returned when the status of a scheduled event
+// is changed in a way that Discord does not allow.
+type EventStatusTransitionError struct {
+	From discord.EventStatus
+	To   discord.EventStatus
+}
+
+// Error formats the EventStatusTransitionError.
+func (err *EventStatusTransitionError) Error() string {
+	return fmt.Sprintf("cannot change scheduled event status from %s to %s", err.From, err.To)
+}
+
+// SetScheduledEventStatus changes the status of the given scheduled event. It
+// returns an *EventStatusTransitionError without making a request if the
+// event's current status cannot be changed to status.
+func (c *Client) SetScheduledEventStatus(event *discord.GuildScheduledEvent,
+	status discord.EventStatus, reason AuditLogReason) (*discord.GuildScheduledEvent, error) {
+	if !event.Status.CanTransitionTo(status) {
+		return nil, &EventStatusTransitionError{From: event.Status, To: status}
+	}
+
+	return c.EditScheduledEvent(event.GuildID, event.ID, reason, EditScheduledEventData{
+		Status: status,
+	})
+}
+
+// StartScheduledEvent starts the given scheduled event, which must have the
+// status discord.ScheduledEvent.
+func (c *Client) StartScheduledEvent(
+	event *discord.GuildScheduledEvent, reason AuditLogReason) (*discord.GuildScheduledEvent, error) {
+	return c.SetScheduledEventStatus(event, discord.ActiveEvent, reason)
+}
+
+// CompleteScheduledEvent ends the given scheduled event, which must have the
+// status discord.ActiveEvent.
+func (c *Client) CompleteScheduledEvent(
+	event *discord.GuildScheduledEvent, reason AuditLogReason) (*discord.GuildScheduledEvent, error) {
+	return c.SetScheduledEventStatus(event, discord.CompletedEvent, reason)
+}
+
+// CancelScheduledEvent cancels the given scheduled event, which must have the
+// status discord.ScheduledEvent.
+func (c *Client) CancelScheduledEvent(
+	event *discord.GuildScheduledEvent, reason AuditLogReason) (*discord.GuildScheduledEvent, error) {
+	return c.SetScheduledEventStatus(event, discord.CancelledEvent, reason)
+}
+
 // DeleteScheduledEvent deletes a scheduled event.
 //
 // https://discord.com/developers/docs/resources/guild-scheduled-event#delete-guild-scheduled-event
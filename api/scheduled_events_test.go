@@ -0,0 +1,37 @@
+package api
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+)
+
+func TestSetScheduledEventStatusInvalid(t *testing.T) {
+	// The client has no token, so any request made would fail differently.
+	client := NewClient("")
+
+	event := discord.GuildScheduledEvent{
+		ID:      1,
+		GuildID: 2,
+		Status:  discord.CompletedEvent,
+	}
+
+	_, err := client.StartScheduledEvent(&event, "")
+
+	var transitionErr *EventStatusTransitionError
+	if !errors.As(err, &transitionErr) {
+		t.Fatalf("expected EventStatusTransitionError, got %v", err)
+	}
+
+	if transitionErr.From != discord.CompletedEvent || transitionErr.To != discord.ActiveEvent {
+		t.Fatalf("unexpected transition %s -> %s", transitionErr.From, transitionErr.To)
+	}
+
+	_, err = client.EditScheduledEvent(2, 1, "", EditScheduledEventData{
+		Status: discord.ScheduledEvent,
+	})
+	if err == nil {
+		t.Fatal("expected error when changing status back to scheduled")
+	}
+}
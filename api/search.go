@@ -3,35 +3,156 @@ package api
 import (
 	"github.com/diamondburned/arikawa/v3/discord"
 	"github.com/diamondburned/arikawa/v3/utils/httputil"
+	"github.com/diamondburned/arikawa/v3/utils/json"
 )
 
+// Types of content that a searched message can have, used in SearchData's Has
+// and HasAll.
+const (
+	SearchHasLink    = "link"
+	SearchHasEmbed   = "embed"
+	SearchHasFile    = "file"
+	SearchHasImage   = "image"
+	SearchHasVideo   = "video"
+	SearchHasSound   = "sound"
+	SearchHasSticker = "sticker"
+)
+
+// Fields that search results can be sorted by, used in SearchData's SortBy.
+const (
+	// SearchSortByTimestamp sorts results by when they were sent. It is the
+	// default.
+	SearchSortByTimestamp = "timestamp"
+	// SearchSortByRelevance sorts results by how relevant they are.
+	SearchSortByRelevance = "relevance"
+)
+
+// Orders that search results can be sorted in, used in SearchData's SortOrder.
+const (
+	// SearchSortDescending sorts results in descending order. It is the
+	// default.
+	SearchSortDescending = "desc"
+	// SearchSortAscending sorts results in ascending order.
+	SearchSortAscending = "asc"
+)
+
+// SearchData is the query of a message search. All fields are optional, and
+// every field that is set must match.
 type SearchData struct {
-	Offset      uint              `schema:"offset,omitempty"`
-	Content     string            `schema:"content,omitempty"`
-	Has         string            `schema:"has,omitempty"`
-	SortBy      string            `schema:"sort_by,omitempty"`
-	SortOrder   string            `schema:"sort_order,omitempty"`
-	ChannelID   discord.ChannelID `schema:"channel_id,omitempty"`
-	AuthorID    discord.UserID    `schema:"author_id,omitempty"`
-	Mentions    discord.UserID    `schema:"mentions,omitempty"`
-	MaxID       discord.MessageID `schema:"max_id,omitempty"`
-	MinID       discord.MessageID `schema:"min_id,omitempty"`
-	IncludeNSFW bool              `schema:"include_nsfw,omitempty"`
+	// Offset is the number of results to skip, used for pagination. Discord
+	// returns 25 results per page.
+	Offset uint `schema:"offset,omitempty"`
+	// Content is the text that messages must contain.
+	Content string `schema:"content,omitempty"`
+	// Has is the type of content that messages must have, such as
+	// SearchHasLink.
+	Has string `schema:"has,omitempty"`
+	// HasAll is the list of content types that messages must have, in addition
+	// to Has.
+	HasAll []string `schema:"-"`
+	// SortBy is the field that results are sorted by, such as
+	// SearchSortByRelevance.
+	SortBy string `schema:"sort_by,omitempty"`
+	// SortOrder is the order that results are sorted in, such as
+	// SearchSortAscending.
+	SortOrder string `schema:"sort_order,omitempty"`
+	// ChannelID is the channel that messages must be sent in. It is only used
+	// when searching a guild.
+	ChannelID discord.ChannelID `schema:"channel_id,omitempty"`
+	// AuthorID is the user that messages must be sent by.
+	AuthorID discord.UserID `schema:"author_id,omitempty"`
+	// Mentions is the user that messages must mention.
+	Mentions discord.UserID `schema:"mentions,omitempty"`
+	// MaxID is the ID that messages must be older than.
+	MaxID discord.MessageID `schema:"max_id,omitempty"`
+	// MinID is the ID that messages must be newer than.
+	MinID discord.MessageID `schema:"min_id,omitempty"`
+	// IncludeNSFW, if true, includes messages from NSFW channels.
+	IncludeNSFW bool `schema:"include_nsfw,omitempty"`
 }
 
+// SearchResponse is the result of a message search.
 type SearchResponse struct {
-	AnalyticsID  string              `json:"analytics_id"`
-	Messages     [][]discord.Message `json:"messages"`
-	TotalResults uint                `json:"total_results"`
+	AnalyticsID string `json:"analytics_id"`
+	// Messages is the list of results. Each result is a list of messages
+	// containing the message that matched along with the messages around it.
+	Messages [][]discord.Message `json:"messages"`
+	// Results is the same as Messages, except each message is marked with
+	// whether or not it matched. It is only filled when the response is
+	// decoded.
+	Results [][]SearchMessage `json:"-"`
+	// TotalResults is the total number of results across all pages.
+	TotalResults uint `json:"total_results"`
+}
+
+// SearchMessage is a message within search results.
+type SearchMessage struct {
+	discord.Message
+	// Hit is true if the message matched the search query, or false if it is
+	// only a message around the one that did.
+	Hit bool `json:"hit"`
+}
+
+// UnmarshalJSON decodes the response into both Messages and Results.
+func (r *SearchResponse) UnmarshalJSON(data []byte) error {
+	type RawSearchResponse SearchResponse
+	resp := struct {
+		*RawSearchResponse
+		Messages [][]SearchMessage `json:"messages"`
+	}{RawSearchResponse: (*RawSearchResponse)(r)}
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return err
+	}
+
+	r.Results = resp.Messages
+	r.Messages = make([][]discord.Message, len(resp.Messages))
+	for i, result := range resp.Messages {
+		r.Messages[i] = make([]discord.Message, len(result))
+		for j := range result {
+			r.Messages[i][j] = result[j].Message
+		}
+	}
+
+	return nil
+}
+
+// Hits returns the messages that matched the search query, in the order that
+// they were returned.
+func (r SearchResponse) Hits() []discord.Message {
+	hits := make([]discord.Message, 0, len(r.Results))
+	for _, result := range r.Results {
+		for _, msg := range result {
+			if msg.Hit {
+				hits = append(hits, msg.Message)
+			}
+		}
+	}
+	return hits
 }
 
 // Search searches through a guild's messages. It only works for user accounts.
 func (c *Client) Search(guildID discord.GuildID, data SearchData) (SearchResponse, error) {
+	return c.search(EndpointGuilds+guildID.String()+"/messages/search", data)
+}
+
+// SearchChannel searches through a channel's messages, which may also be a DM
+// channel. data.ChannelID is ignored. It only works for user accounts.
+func (c *Client) SearchChannel(channelID discord.ChannelID, data SearchData) (SearchResponse, error) {
+	data.ChannelID = 0
+	return c.search(EndpointChannels+channelID.String()+"/messages/search", data)
+}
+
+func (c *Client) search(endpoint string, data SearchData) (SearchResponse, error) {
 	var resp SearchResponse
 
-	return resp, c.RequestJSON(
-		&resp, "GET",
-		EndpointGuilds+guildID.String()+"/messages/search",
-		httputil.WithSchema(c, data),
-	)
+	params, err := c.Encode(data)
+	if err != nil {
+		return resp, err
+	}
+
+	for _, has := range data.HasAll {
+		params.Add("has", has)
+	}
+
+	return resp, c.RequestJSON(&resp, "GET", endpoint, httputil.WithSchema(c, params))
 }
@@ -0,0 +1,92 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+)
+
+func TestSearch(t *testing.T) {
+	var query url.Values
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v9/guilds/1/messages/search", "/api/v9/channels/2/messages/search":
+		default:
+			http.NotFound(w, r)
+			return
+		}
+
+		query = r.URL.Query()
+		w.Write([]byte(`{
+			"analytics_id": "a",
+			"total_results": 2,
+			"messages": [
+				[
+					{"id": "10", "channel_id": "2", "content": "before"},
+					{"id": "11", "channel_id": "2", "content": "hello", "hit": true}
+				],
+				[
+					{"id": "12", "channel_id": "2", "content": "hello again", "hit": true}
+				]
+			]
+		}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	client := NewClient("").WithBaseURL(srv.URL)
+
+	resp, err := client.Search(1, SearchData{
+		Content:   "hello",
+		Has:       SearchHasLink,
+		HasAll:    []string{SearchHasImage, SearchHasFile},
+		SortBy:    SearchSortByRelevance,
+		SortOrder: SearchSortAscending,
+		ChannelID: 2,
+	})
+	if err != nil {
+		t.Fatal("failed to search:", err)
+	}
+
+	expectQuery := url.Values{
+		"content":    {"hello"},
+		"has":        {"link", "image", "file"},
+		"sort_by":    {"relevance"},
+		"sort_order": {"asc"},
+		"channel_id": {"2"},
+	}
+	if !reflect.DeepEqual(query, expectQuery) {
+		t.Fatalf("unexpected query %v", query)
+	}
+
+	if resp.AnalyticsID != "a" || resp.TotalResults != 2 {
+		t.Fatalf("unexpected response %+v", resp)
+	}
+
+	if len(resp.Messages) != 2 || len(resp.Messages[0]) != 2 || resp.Messages[0][1].Content != "hello" {
+		t.Fatalf("unexpected messages %+v", resp.Messages)
+	}
+	if len(resp.Results) != 2 || resp.Results[0][0].Hit || !resp.Results[0][1].Hit {
+		t.Fatalf("unexpected results %+v", resp.Results)
+	}
+
+	var hits []discord.MessageID
+	for _, msg := range resp.Hits() {
+		hits = append(hits, msg.ID)
+	}
+	if !reflect.DeepEqual(hits, []discord.MessageID{11, 12}) {
+		t.Fatalf("unexpected hits %v", hits)
+	}
+
+	if _, err := client.SearchChannel(2, SearchData{Content: "hello", ChannelID: 3}); err != nil {
+		t.Fatal("failed to search channel:", err)
+	}
+
+	if !reflect.DeepEqual(query, url.Values{"content": {"hello"}}) {
+		t.Fatalf("unexpected channel search query %v", query)
+	}
+}
@@ -3,9 +3,15 @@ package api
 import (
 	"errors"
 	"fmt"
+	"io"
 	"mime/multipart"
+	"os"
+	"strings"
+	"time"
+	"unicode/utf8"
 
 	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/internal/intmath"
 	"github.com/diamondburned/arikawa/v3/utils/json/option"
 	"github.com/diamondburned/arikawa/v3/utils/sendpart"
 )
@@ -87,7 +93,8 @@ func (am AllowedMentions) Verify() error {
 }
 
 // ErrEmptyMessage is returned if either a SendMessageData or an
-// ExecuteWebhookData is missing content, embeds, and files.
+// ExecuteWebhookData is missing content, embeds, and files. A SendMessageData
+// with only stickers is not empty.
 var ErrEmptyMessage = errors.New("message is empty")
 
 // SendMessageData is the full structure to send a new message to Discord with.
@@ -119,10 +126,40 @@ type SendMessageData struct {
 	// validated if sent.
 	Reference *discord.MessageReference `json:"message_reference,omitempty"`
 
-	// Flags specifies the message flags to set (only `SuppressEmbeds` and `SuppressNotifications` can be set).
+	// Attachments contains the metadata of the files in Files, such as their
+	// descriptions. It is optional and may describe only some of the files.
+	Attachments []SendAttachment `json:"attachments,omitempty"`
+
+	// StickerIDs is the list of stickers to send with the message (up to 3).
+	// The bot must have access to the stickers, e.g. guild stickers of the
+	// guild the message is sent in.
+	StickerIDs []discord.StickerID `json:"sticker_ids,omitempty"`
+
+	// Flags specifies the message flags to set. Only the flags within
+	// discord.SendableMessageFlags can be set, such as SuppressEmbeds and
+	// SuppressNotifications.
 	Flags discord.MessageFlags `json:"flags"`
 }
 
+// SendAttachment describes a file that is being uploaded alongside a message.
+//
+// https://discord.com/developers/docs/resources/channel#attachment-object
+type SendAttachment struct {
+	// Index is the index of the file in Files that this attachment describes.
+	Index int `json:"id"`
+	// Filename overrides the name of the file.
+	Filename string `json:"filename,omitempty"`
+	// Description is the description of the file, which is used as alt text.
+	Description string `json:"description,omitempty"`
+	// DurationSecs is the duration of the audio file in seconds. It is
+	// required for voice messages.
+	DurationSecs float64 `json:"duration_secs,omitempty"`
+	// Waveform is a sampled waveform of the audio file, with each byte being
+	// the volume of a sample. It is required for voice messages. See
+	// VoiceMessageWaveform.
+	Waveform []byte `json:"waveform,omitempty"`
+}
+
 // NeedsMultipart returns true if the SendMessageData has files.
 func (data SendMessageData) NeedsMultipart() bool {
 	return len(data.Files) > 0
@@ -132,6 +169,241 @@ func (data SendMessageData) WriteMultipart(body *multipart.Writer) error {
 	return sendpart.Write(body, data, data.Files)
 }
 
+// maxMessageStickers is the maximum number of stickers in a message.
+const maxMessageStickers = 3
+
+const (
+	maxMessageContent    = 2000
+	maxMessageEmbeds     = 10
+	maxMessageFiles      = 10
+	maxMessageActionRows = 5
+	maxActionRowItems    = 5
+	maxEmbedsLength      = 6000
+)
+
+// ValidationErrors is a list of problems found while validating data before it
+// is sent. errors.Is and errors.As match any of the errors in the list.
+type ValidationErrors []error
+
+// Error implements error. It joins the messages of all errors.
+func (errs ValidationErrors) Error() string {
+	msgs := make([]string, len(errs))
+	for i, err := range errs {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Is returns true if any of the errors matches target.
+func (errs ValidationErrors) Is(target error) bool {
+	for _, err := range errs {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// As finds the first error that matches target.
+func (errs ValidationErrors) As(target interface{}) bool {
+	for _, err := range errs {
+		if errors.As(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate checks the message against Discord's constraints without sending
+// it, so that all problems can be reported at once. It checks the content
+// length, the embeds and their total length, the components, the stickers,
+// the flags, the allowed mentions and the files. If maxUpload is more than 0,
+// then the size of each file is checked against it as well; see
+// discord.NitroBoost's MaxUploadSize. Files whose size cannot be determined
+// from their readers are not checked.
+//
+// If there are any problems, then a ValidationErrors is returned.
+func (data SendMessageData) Validate(maxUpload int64) error {
+	var errs ValidationErrors
+
+	if data.Content == "" && len(data.Embeds) == 0 && len(data.Files) == 0 &&
+		len(data.StickerIDs) == 0 {
+		errs = append(errs, ErrEmptyMessage)
+	}
+
+	if n := utf8.RuneCountInString(data.Content); n > maxMessageContent {
+		errs = append(errs, &discord.OverboundError{
+			Count: n,
+			Max:   maxMessageContent,
+			Thing: "content",
+		})
+	}
+
+	errs = append(errs, validateEmbeds(data.Embeds)...)
+	errs = append(errs, validateMessageComponents(data.Components)...)
+
+	if len(data.StickerIDs) > maxMessageStickers {
+		errs = append(errs, &discord.OverboundError{
+			Count: len(data.StickerIDs),
+			Max:   maxMessageStickers,
+			Thing: "stickers",
+		})
+	}
+
+	if data.Flags&^discord.SendableMessageFlags != 0 {
+		errs = append(errs, fmt.Errorf(
+			"flags %d cannot be set when sending a message",
+			data.Flags&^discord.SendableMessageFlags))
+	}
+
+	if data.AllowedMentions != nil {
+		if err := data.AllowedMentions.Verify(); err != nil {
+			errs = append(errs, fmt.Errorf("allowedMentions error: %w", err))
+		}
+	}
+
+	errs = append(errs, validateFiles(data.Files, data.Attachments, maxUpload)...)
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+func validateEmbeds(embeds []discord.Embed) []error {
+	var errs []error
+
+	if len(embeds) > maxMessageEmbeds {
+		errs = append(errs, &discord.OverboundError{
+			Count: len(embeds),
+			Max:   maxMessageEmbeds,
+			Thing: "embeds",
+		})
+	}
+
+	sum := 0
+	for i, embed := range embeds {
+		// Validate sets defaults on the embed, so give it a copy.
+		if err := embed.Validate(); err != nil {
+			errs = append(errs, fmt.Errorf("embed error at %d: %w", i, err))
+		}
+		sum += embed.Length()
+	}
+
+	if sum > maxEmbedsLength {
+		errs = append(errs, &discord.OverboundError{
+			Count: sum,
+			Max:   maxEmbedsLength,
+			Thing: "sum of all text in embeds",
+		})
+	}
+
+	return errs
+}
+
+func validateMessageComponents(components discord.ContainerComponents) []error {
+	var errs []error
+
+	if len(components) > maxMessageActionRows {
+		errs = append(errs, &discord.OverboundError{
+			Count: len(components),
+			Max:   maxMessageActionRows,
+			Thing: "action rows",
+		})
+	}
+
+	for i, container := range components {
+		row, ok := container.(*discord.ActionRowComponent)
+		if !ok {
+			continue
+		}
+
+		switch n := len(*row); {
+		case n == 0:
+			errs = append(errs, fmt.Errorf("action row %d is empty", i))
+		case n > maxActionRowItems:
+			errs = append(errs, &discord.OverboundError{
+				Count: n,
+				Max:   maxActionRowItems,
+				Thing: fmt.Sprintf("components in action row %d", i),
+			})
+		}
+
+		for _, component := range *row {
+			switch component.Type() {
+			case discord.TextInputComponentType:
+				errs = append(errs, fmt.Errorf(
+					"action row %d has a text input, which is only allowed in modals", i))
+			case discord.ButtonComponentType:
+			default:
+				if len(*row) > 1 {
+					errs = append(errs, fmt.Errorf(
+						"action row %d has a %v that is not alone", i, component.Type()))
+				}
+			}
+		}
+	}
+
+	return errs
+}
+
+func validateFiles(files []sendpart.File, attachments []SendAttachment, maxUpload int64) []error {
+	var errs []error
+
+	if len(files) > maxMessageFiles {
+		errs = append(errs, &discord.OverboundError{
+			Count: len(files),
+			Max:   maxMessageFiles,
+			Thing: "files",
+		})
+	}
+
+	for _, attachment := range attachments {
+		if attachment.Index < 0 || attachment.Index >= len(files) {
+			errs = append(errs, fmt.Errorf(
+				"attachment describes file %d, but there are %d files",
+				attachment.Index, len(files)))
+		}
+	}
+
+	if maxUpload <= 0 {
+		return errs
+	}
+
+	for _, file := range files {
+		if size, ok := readerSize(file.Reader); ok && size > maxUpload {
+			errs = append(errs, fmt.Errorf(
+				"file %q is %d bytes, which is over the upload limit of %d bytes",
+				file.Name, size, maxUpload))
+		}
+	}
+
+	return errs
+}
+
+// readerSize returns the number of bytes left to read in r, if it can be
+// determined without reading it.
+func readerSize(r io.Reader) (int64, bool) {
+	switch r := r.(type) {
+	case interface{ Len() int }: // bytes.Reader, bytes.Buffer, strings.Reader
+		return int64(r.Len()), true
+	case interface{ Stat() (os.FileInfo, error) }: // os.File
+		stat, err := r.Stat()
+		if err != nil || !stat.Mode().IsRegular() {
+			return 0, false
+		}
+		size := stat.Size()
+		if seeker, ok := r.(io.Seeker); ok {
+			if pos, err := seeker.Seek(0, io.SeekCurrent); err == nil {
+				size -= pos
+			}
+		}
+		return size, true
+	default:
+		return 0, false
+	}
+}
+
 // SendMessageComplex posts a message to a guild text or DM channel. If
 // operating on a guild channel, this endpoint requires the SEND_MESSAGES
 // permission to be present on the current user. If the tts field is set to
@@ -153,10 +425,25 @@ func (data SendMessageData) WriteMultipart(body *multipart.Writer) error {
 // Content-Disposition subpart header MUST contain a filename parameter.
 func (c *Client) SendMessageComplex(
 	channelID discord.ChannelID, data SendMessageData) (*discord.Message, error) {
-	if data.Content == "" && len(data.Embeds) == 0 && len(data.Files) == 0 {
+	if data.Content == "" && len(data.Embeds) == 0 && len(data.Files) == 0 &&
+		len(data.StickerIDs) == 0 {
 		return nil, ErrEmptyMessage
 	}
 
+	if len(data.StickerIDs) > maxMessageStickers {
+		return nil, &discord.OverboundError{
+			Count: len(data.StickerIDs),
+			Max:   maxMessageStickers,
+			Thing: "stickers",
+		}
+	}
+
+	if data.Flags&^discord.SendableMessageFlags != 0 {
+		return nil, fmt.Errorf(
+			"flags %d cannot be set when sending a message",
+			data.Flags&^discord.SendableMessageFlags)
+	}
+
 	if data.AllowedMentions != nil {
 		if err := data.AllowedMentions.Verify(); err != nil {
 			return nil, fmt.Errorf("allowedMentions error: %w", err)
@@ -180,3 +467,93 @@ func (c *Client) SendMessageComplex(
 	var msg *discord.Message
 	return msg, sendpart.POST(c.Client, data, &msg, URL)
 }
+
+// maxWaveformSamples is the maximum number of samples in a voice message
+// waveform, as imposed by Discord.
+const maxWaveformSamples = 256
+
+// SendVoiceMessageData is the data used to send a voice message.
+type SendVoiceMessageData struct {
+	// File is the audio file. Discord only accepts OGG files encoded with
+	// Opus.
+	File sendpart.File
+	// Duration is the duration of the audio.
+	Duration time.Duration
+	// Waveform is a sampled waveform of the audio, with each byte being the
+	// volume of a sample. It must be at most 256 bytes long. See
+	// VoiceMessageWaveform.
+	Waveform []byte
+
+	// Reference allows the voice message to be a reply to another message.
+	Reference *discord.MessageReference
+	// AllowedMentions are the allowed mentions for the reply.
+	AllowedMentions *AllowedMentions
+}
+
+// SendVoiceMessage sends a voice message, which is a message with a single
+// audio attachment that Discord displays with a waveform. Voice messages
+// cannot have any content, embeds or other files.
+//
+// Fires a Message Create Gateway event.
+func (c *Client) SendVoiceMessage(
+	channelID discord.ChannelID, data SendVoiceMessageData) (*discord.Message, error) {
+
+	switch {
+	case data.File.Reader == nil:
+		return nil, errors.New("voice message has no file")
+	case data.Duration <= 0:
+		return nil, errors.New("voice message has no duration")
+	case len(data.Waveform) == 0:
+		return nil, errors.New("voice message has no waveform")
+	case len(data.Waveform) > maxWaveformSamples:
+		return nil, &discord.OverboundError{
+			Count: len(data.Waveform),
+			Max:   maxWaveformSamples,
+			Thing: "voice message waveform samples",
+		}
+	}
+
+	return c.SendMessageComplex(channelID, SendMessageData{
+		Files: []sendpart.File{data.File},
+		Attachments: []SendAttachment{{
+			Index:        0,
+			Filename:     data.File.Name,
+			DurationSecs: data.Duration.Seconds(),
+			Waveform:     data.Waveform,
+		}},
+		Reference:       data.Reference,
+		AllowedMentions: data.AllowedMentions,
+		Flags:           discord.IsVoiceMessage,
+	})
+}
+
+// VoiceMessageWaveform computes a waveform suitable for SendVoiceMessageData
+// from the given PCM samples. The samples are split into at most 256 evenly
+// sized chunks, and the peak volume of each chunk is scaled to a byte.
+func VoiceMessageWaveform(pcm []int16) []byte {
+	n := intmath.Min(len(pcm), maxWaveformSamples)
+	if n == 0 {
+		return nil
+	}
+
+	waveform := make([]byte, n)
+	for i := range waveform {
+		start := i * len(pcm) / n
+		end := (i + 1) * len(pcm) / n
+
+		var peak int
+		for _, sample := range pcm[start:end] {
+			v := int(sample)
+			if v < 0 {
+				v = -v
+			}
+			if v > peak {
+				peak = v
+			}
+		}
+
+		waveform[i] = byte(peak * 255 / 32768)
+	}
+
+	return waveform
+}
@@ -2,6 +2,7 @@ package api
 
 import (
 	"encoding/json"
+	"errors"
 	"strings"
 	"testing"
 
@@ -45,6 +46,16 @@ func TestMarshalAllowedMentions(t *testing.T) {
 	})
 }
 
+func TestMarshalStickerIDs(t *testing.T) {
+	var data = SendMessageData{
+		StickerIDs: []discord.StickerID{1, 2},
+	}
+
+	if j := mustMarshal(t, data); j != `{"sticker_ids":["1","2"],"flags":0}` {
+		t.Fatal("Unexpected JSON:", j)
+	}
+}
+
 func TestVerifyAllowedMentions(t *testing.T) {
 	t.Run("invalid", func(t *testing.T) {
 		var am = AllowedMentions{
@@ -118,6 +129,25 @@ func TestSendMessage(t *testing.T) {
 		}
 	})
 
+	t.Run("stickers only", func(t *testing.T) {
+		var data = SendMessageData{
+			StickerIDs: []discord.StickerID{1},
+		}
+
+		if err := send(data); err != nil {
+			t.Fatal("Unexpected error:", err)
+		}
+	})
+
+	t.Run("too many stickers", func(t *testing.T) {
+		var data = SendMessageData{
+			StickerIDs: []discord.StickerID{1, 2, 3, 4},
+		}
+
+		err := send(data)
+		errMustContain(t, err, "stickers")
+	})
+
 	t.Run("invalid allowed mentions", func(t *testing.T) {
 		var data = SendMessageData{
 			Content: "hime arikawa",
@@ -167,3 +197,123 @@ func mustMarshal(t *testing.T, v interface{}) string {
 	}
 	return string(j)
 }
+
+func TestKeepAttachments(t *testing.T) {
+	t.Run("remove all", func(t *testing.T) {
+		data := EditMessageData{Attachments: KeepAttachments()}
+
+		if j := mustMarshal(t, data); j != `{"attachments":[]}` {
+			t.Fatal("Unexpected JSON:", j)
+		}
+	})
+
+	t.Run("except", func(t *testing.T) {
+		existing := []discord.Attachment{{ID: 1}, {ID: 2}, {ID: 3}}
+
+		attachments := *KeepAttachmentsExcept(existing, 2)
+		if len(attachments) != 2 || attachments[0].ID != 1 || attachments[1].ID != 3 {
+			t.Fatal("Unexpected attachments:", attachments)
+		}
+	})
+}
+
+func TestVoiceMessageWaveform(t *testing.T) {
+	pcm := make([]int16, 1024)
+	for i := range pcm {
+		if i%2 == 0 {
+			pcm[i] = int16(i * 32)
+		} else {
+			pcm[i] = -int16(i * 32)
+		}
+	}
+
+	waveform := VoiceMessageWaveform(pcm)
+	if len(waveform) != 256 {
+		t.Fatal("unexpected waveform length:", len(waveform))
+	}
+	if waveform[0] != 0 || waveform[255] != 254 {
+		t.Fatal("unexpected waveform bounds:", waveform[0], waveform[255])
+	}
+
+	if waveform := VoiceMessageWaveform(pcm[:10]); len(waveform) != 10 {
+		t.Fatal("unexpected short waveform length:", len(waveform))
+	}
+}
+
+func TestSendMessageDataValidate(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		data := SendMessageData{
+			Content: "hi",
+			Files:   []sendpart.File{{Name: "a.txt", Reader: strings.NewReader("abc")}},
+			Components: discord.Components(
+				&discord.ButtonComponent{Label: "a", CustomID: "a", Style: discord.PrimaryButtonStyle()},
+				&discord.ButtonComponent{Label: "b", CustomID: "b", Style: discord.PrimaryButtonStyle()},
+			),
+		}
+
+		if err := data.Validate(discord.DefaultMaxUploadSize); err != nil {
+			t.Fatal("Unexpected error:", err)
+		}
+	})
+
+	t.Run("aggregated", func(t *testing.T) {
+		data := SendMessageData{
+			Content:    strings.Repeat("a", 2001),
+			Embeds:     []discord.Embed{{Description: strings.Repeat("d", 4000)}, {Description: strings.Repeat("d", 4000)}},
+			Files:      []sendpart.File{{Name: "a.bin", Reader: strings.NewReader(strings.Repeat("b", 11))}},
+			StickerIDs: make([]discord.StickerID, 4),
+			Components: discord.ContainerComponents{
+				&discord.ActionRowComponent{
+					&discord.StringSelectComponent{CustomID: "s"},
+					&discord.ButtonComponent{Label: "b", CustomID: "b", Style: discord.PrimaryButtonStyle()},
+				},
+			},
+		}
+
+		err := data.Validate(10)
+
+		var errs ValidationErrors
+		if !errors.As(err, &errs) {
+			t.Fatal("Expected ValidationErrors, got", err)
+		}
+		if len(errs) != 5 {
+			t.Fatalf("Expected 5 errors, got %d: %v", len(errs), err)
+		}
+
+		var overbound *discord.OverboundError
+		if !errors.As(err, &overbound) || overbound.Thing != "content" {
+			t.Fatal("Expected content OverboundError, got", overbound)
+		}
+
+		for _, msg := range []string{
+			"sum of all text in embeds",
+			"stickers",
+			"StringSelect that is not alone",
+			"over the upload limit of 10 bytes",
+		} {
+			errMustContain(t, err, msg)
+		}
+	})
+
+	t.Run("upload limit per file", func(t *testing.T) {
+		data := SendMessageData{
+			Files: []sendpart.File{
+				{Name: "a.bin", Reader: strings.NewReader(strings.Repeat("a", 10))},
+				{Name: "b.bin", Reader: strings.NewReader(strings.Repeat("b", 10))},
+			},
+		}
+
+		if err := data.Validate(10); err != nil {
+			t.Fatal("Unexpected error:", err)
+		}
+
+		data.Files[1].Reader = strings.NewReader(strings.Repeat("b", 11))
+		errMustContain(t, data.Validate(10), `file "b.bin" is 11 bytes`)
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		if err := (SendMessageData{}).Validate(0); !errors.Is(err, ErrEmptyMessage) {
+			t.Fatal("Expected ErrEmptyMessage, got", err)
+		}
+	})
+}
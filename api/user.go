@@ -137,3 +137,35 @@ func (c *Client) SetRelationship(userID discord.UserID, t discord.RelationshipTy
 func (c *Client) DeleteRelationship(userID discord.UserID) error {
 	return c.FastRequest("DELETE", EndpointMe+"/relationships/"+userID.String())
 }
+
+// UserApplicationRoleConnection returns the role connection that the given
+// application has attached to the current user.
+//
+// This endpoint requires a Bearer token with the role_connections.write scope;
+// see package oauth2.
+func (c *Client) UserApplicationRoleConnection(
+	appID discord.AppID) (*discord.ApplicationRoleConnection, error) {
+
+	var conn *discord.ApplicationRoleConnection
+	return conn, c.RequestJSON(
+		&conn, "GET",
+		EndpointMe+"/applications/"+appID.String()+"/role-connection",
+	)
+}
+
+// UpdateUserApplicationRoleConnection replaces the role connection that the
+// given application has attached to the current user.
+//
+// This endpoint requires a Bearer token with the role_connections.write scope;
+// see package oauth2.
+func (c *Client) UpdateUserApplicationRoleConnection(
+	appID discord.AppID,
+	conn discord.ApplicationRoleConnection) (*discord.ApplicationRoleConnection, error) {
+
+	var updated *discord.ApplicationRoleConnection
+	return updated, c.RequestJSON(
+		&updated, "PUT",
+		EndpointMe+"/applications/"+appID.String()+"/role-connection",
+		httputil.WithJSONBody(conn),
+	)
+}
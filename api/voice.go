@@ -0,0 +1,54 @@
+//go:build go1.18
+// +build go1.18
+
+package api
+
+import (
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/utils/httputil"
+	"github.com/diamondburned/arikawa/v3/utils/json/option"
+)
+
+// https://discord.com/developers/docs/resources/voice#modify-current-user-voice-state-json-params
+type ModifyCurrentUserVoiceStateData struct {
+	// ChannelID is the ID of the stage channel that the user is currently in.
+	// It is required.
+	ChannelID discord.ChannelID `json:"channel_id"`
+	// Suppress toggles the user's suppress state.
+	Suppress option.Bool `json:"suppress,omitempty"`
+	// RequestToSpeakTimestamp sets the user's request to speak. It may be set
+	// to a present or future time, or to option.Null to clear the request.
+	RequestToSpeakTimestamp *option.Optional[discord.Timestamp] `json:"request_to_speak_timestamp,omitempty"`
+}
+
+// ModifyCurrentUserVoiceState updates the current user's voice state in a
+// stage channel.
+func (c *Client) ModifyCurrentUserVoiceState(
+	guildID discord.GuildID, data ModifyCurrentUserVoiceStateData) error {
+
+	return c.FastRequest(
+		"PATCH",
+		EndpointGuilds+guildID.String()+"/voice-states/@me",
+		httputil.WithJSONBody(data),
+	)
+}
+
+// https://discord.com/developers/docs/resources/voice#modify-user-voice-state-json-params
+type ModifyUserVoiceStateData struct {
+	// ChannelID is the ID of the stage channel that the user is currently in.
+	// It is required.
+	ChannelID discord.ChannelID `json:"channel_id"`
+	// Suppress toggles the user's suppress state.
+	Suppress option.Bool `json:"suppress,omitempty"`
+}
+
+// ModifyUserVoiceState updates another user's voice state in a stage channel.
+func (c *Client) ModifyUserVoiceState(
+	guildID discord.GuildID, userID discord.UserID, data ModifyUserVoiceStateData) error {
+
+	return c.FastRequest(
+		"PATCH",
+		EndpointGuilds+guildID.String()+"/voice-states/"+userID.String(),
+		httputil.WithJSONBody(data),
+	)
+}
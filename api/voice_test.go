@@ -0,0 +1,31 @@
+//go:build go1.18
+// +build go1.18
+
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/utils/json/option"
+)
+
+func TestModifyCurrentUserVoiceStateDataMarshal(t *testing.T) {
+	data := ModifyCurrentUserVoiceStateData{ChannelID: 1}
+	if j := mustMarshal(t, data); j != `{"channel_id":"1"}` {
+		t.Fatal("unexpected JSON:", j)
+	}
+
+	data.Suppress = option.False
+	data.RequestToSpeakTimestamp = option.Null[discord.Timestamp]()
+	if j := mustMarshal(t, data); j != `{"channel_id":"1","suppress":false,"request_to_speak_timestamp":null}` {
+		t.Fatal("unexpected JSON:", j)
+	}
+
+	ts := discord.NewTimestamp(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC))
+	data.RequestToSpeakTimestamp = option.Some(ts)
+	if j := mustMarshal(t, data); j != `{"channel_id":"1","suppress":false,"request_to_speak_timestamp":"2021-01-01T00:00:00Z"}` {
+		t.Fatal("unexpected JSON:", j)
+	}
+}
@@ -1,6 +1,8 @@
 package api
 
 import (
+	"errors"
+
 	"github.com/diamondburned/arikawa/v3/discord"
 	"github.com/diamondburned/arikawa/v3/utils/httputil"
 	"github.com/diamondburned/arikawa/v3/utils/json/option"
@@ -64,6 +66,10 @@ type ModifyWebhookData struct {
 	ChannelID discord.ChannelID `json:"channel_id,omitempty"`
 }
 
+// ErrWebhookTokenChannel is returned when modifying a webhook using its token
+// with a ChannelID, since only bots with MANAGE_WEBHOOKS can move webhooks.
+var ErrWebhookTokenChannel = errors.New("webhook cannot be moved using its token")
+
 // ModifyWebhook modifies a webhook.
 //
 // Requires the MANAGE_WEBHOOKS permission.
@@ -84,3 +90,37 @@ func (c *Client) ModifyWebhook(
 func (c *Client) DeleteWebhook(webhookID discord.WebhookID) error {
 	return c.FastRequest("DELETE", EndpointWebhooks+webhookID.String())
 }
+
+// WebhookWithToken is the same as Webhook, except this call does not require
+// authentication and returns no user in the webhook object.
+func (c *Client) WebhookWithToken(
+	webhookID discord.WebhookID, token string) (*discord.Webhook, error) {
+
+	var w *discord.Webhook
+	return w, c.RequestJSON(&w, "GET", EndpointWebhooks+webhookID.String()+"/"+token)
+}
+
+// ModifyWebhookWithToken is the same as ModifyWebhook, except this call does
+// not require authentication, does not accept a ChannelID in the data, and
+// returns no user in the webhook object.
+func (c *Client) ModifyWebhookWithToken(
+	webhookID discord.WebhookID,
+	token string, data ModifyWebhookData) (*discord.Webhook, error) {
+
+	if data.ChannelID.IsValid() {
+		return nil, ErrWebhookTokenChannel
+	}
+
+	var w *discord.Webhook
+	return w, c.RequestJSON(
+		&w, "PATCH",
+		EndpointWebhooks+webhookID.String()+"/"+token,
+		httputil.WithJSONBody(data),
+	)
+}
+
+// DeleteWebhookWithToken is the same as DeleteWebhook, except this call does
+// not require authentication.
+func (c *Client) DeleteWebhookWithToken(webhookID discord.WebhookID, token string) error {
+	return c.FastRequest("DELETE", EndpointWebhooks+webhookID.String()+"/"+token)
+}
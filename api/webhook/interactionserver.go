@@ -76,6 +76,67 @@ func (f alwaysDeferInteraction) HandleInteraction(ev *discord.InteractionEvent)
 	}
 }
 
+type alwaysDeferMessageUpdate struct {
+	f func(*discord.InteractionEvent)
+}
+
+// AlwaysDeferMessageUpdate always returns a DeferredMessageUpdate then invokes
+// f in the background. It is like AlwaysDeferInteraction, except it is meant
+// for component interactions whose message is edited later using
+// api.Client.EditInteractionResponse.
+func AlwaysDeferMessageUpdate(f func(*discord.InteractionEvent)) InteractionHandler {
+	return alwaysDeferMessageUpdate{f}
+}
+
+func (f alwaysDeferMessageUpdate) HandleInteraction(ev *discord.InteractionEvent) *api.InteractionResponse {
+	go f.f(ev)
+	return DeferMessageUpdate()
+}
+
+// UpdateMessage returns an UpdateMessage response, which edits the message
+// that the component interaction is attached to using the given data.
+func UpdateMessage(data api.InteractionResponseData) *api.InteractionResponse {
+	return &api.InteractionResponse{
+		Type: api.UpdateMessage,
+		Data: &data,
+	}
+}
+
+// DeferMessageUpdate returns a DeferredMessageUpdate response, which
+// acknowledges a component interaction without editing its message yet.
+func DeferMessageUpdate() *api.InteractionResponse {
+	return &api.InteractionResponse{Type: api.DeferredMessageUpdate}
+}
+
+// UpdateComponents returns an UpdateMessage response that only edits the
+// components of the message that the component interaction is attached to.
+// The components are rebuilt from the message's using fn; see
+// discord.ContainerComponents.Edit. If the interaction has no message, then
+// the response is DeferMessageUpdate's.
+//
+// For example, to disable the button that was clicked:
+//
+//	func(ev *discord.InteractionEvent) *api.InteractionResponse {
+//		data := ev.Data.(*discord.ButtonInteraction)
+//		return webhook.UpdateComponents(ev, func(c discord.InteractiveComponent) {
+//			if c.ID() == data.CustomID {
+//				c.(*discord.ButtonComponent).Disabled = true
+//			}
+//		})
+//	}
+func UpdateComponents(
+	ev *discord.InteractionEvent, fn func(discord.InteractiveComponent)) *api.InteractionResponse {
+
+	if ev.Message == nil {
+		return DeferMessageUpdate()
+	}
+
+	components := ev.Message.Components.Edit(fn)
+	return UpdateMessage(api.InteractionResponseData{
+		Components: &components,
+	})
+}
+
 // InteractionErrorFunc is called to write an error. err may be nil with a
 // non-2xx code.
 type InteractionErrorFunc func(w http.ResponseWriter, r *http.Request, code int, err error)
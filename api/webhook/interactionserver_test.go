@@ -0,0 +1,51 @@
+package webhook
+
+import (
+	"testing"
+
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/discord"
+)
+
+func TestUpdateComponents(t *testing.T) {
+	yes := discord.TextButtonComponent(discord.PrimaryButtonStyle(), "Yes")
+	yes.CustomID = "yes"
+	no := discord.TextButtonComponent(discord.DangerButtonStyle(), "No")
+	no.CustomID = "no"
+
+	ev := &discord.InteractionEvent{
+		Data: &discord.ButtonInteraction{CustomID: "yes"},
+		Message: &discord.Message{
+			Components: discord.Components(&yes, &no),
+		},
+	}
+
+	resp := UpdateComponents(ev, func(c discord.InteractiveComponent) {
+		if c.ID() == "yes" {
+			c.(*discord.ButtonComponent).Disabled = true
+		}
+	})
+
+	if resp.Type != api.UpdateMessage {
+		t.Fatal("unexpected response type", resp.Type)
+	}
+	if resp.Data == nil || resp.Data.Components == nil {
+		t.Fatal("response has no components")
+	}
+
+	components := *resp.Data.Components
+	if b := components.Find("yes").(*discord.ButtonComponent); !b.Disabled {
+		t.Error("clicked button is not disabled")
+	}
+	if b := components.Find("no").(*discord.ButtonComponent); b.Disabled {
+		t.Error("other button is disabled")
+	}
+
+	if b := ev.Message.Components.Find("yes").(*discord.ButtonComponent); b.Disabled {
+		t.Error("original components were changed")
+	}
+
+	if resp := UpdateComponents(&discord.InteractionEvent{}, nil); resp.Type != api.DeferredMessageUpdate {
+		t.Fatal("expected DeferredMessageUpdate without a message, got", resp.Type)
+	}
+}
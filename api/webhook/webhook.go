@@ -23,13 +23,23 @@ import (
 // TODO: if there's ever an Arikawa v3, then a new Client abstraction could be
 // made that wraps around Session being an interface. Just a food for thought.
 
-var webhookURLRe = regexp.MustCompile(`https://discord(?:app)?.com/api/webhooks/(\d+)/(.+)`)
+var webhookURLRe = regexp.MustCompile(
+	`^https://(?:(?:canary|ptb)\.)?discord(?:app)?\.com/api(?:/v\d+)?/webhooks/(\d+)/([\w.-]+)/?(?:\?.*)?$`,
+)
+
+// ErrInvalidURL is returned by ParseURL and ValidateURL if the given URL is not
+// a valid Discord webhook URL.
+var ErrInvalidURL = errors.New("invalid webhook URL")
 
-// ParseURL parses the given Discord webhook URL.
+// redacted replaces webhook tokens in RedactURL and Session.String.
+const redacted = "[REDACTED]"
+
+// ParseURL parses the given Discord webhook URL. URLs from the canary and PTB
+// clients as well as URLs with an API version are accepted.
 func ParseURL(webhookURL string) (id discord.WebhookID, token string, err error) {
 	matches := webhookURLRe.FindStringSubmatch(webhookURL)
 	if matches == nil {
-		return 0, "", errors.New("invalid webhook URL")
+		return 0, "", ErrInvalidURL
 	}
 
 	idInt, err := strconv.ParseUint(matches[1], 10, 64)
@@ -40,6 +50,25 @@ func ParseURL(webhookURL string) (id discord.WebhookID, token string, err error)
 	return discord.WebhookID(idInt), matches[2], nil
 }
 
+// ValidateURL returns ErrInvalidURL if the given URL is not a valid Discord
+// webhook URL.
+func ValidateURL(webhookURL string) error {
+	_, _, err := ParseURL(webhookURL)
+	return err
+}
+
+// RedactURL returns the given webhook URL with its token replaced, so that it
+// can be safely logged. If the URL is not a valid webhook URL, then it is
+// redacted entirely.
+func RedactURL(webhookURL string) string {
+	matches := webhookURLRe.FindStringSubmatchIndex(webhookURL)
+	if matches == nil {
+		return redacted
+	}
+	// Replace the second capture group, which is the token.
+	return webhookURL[:matches[4]] + redacted + webhookURL[matches[5]:]
+}
+
 // Session keeps a single webhook session. It is referenced by other webhook
 // clients using the same session.
 type Session struct {
@@ -53,6 +82,17 @@ type Session struct {
 	Token string
 }
 
+// URL returns the URL of the webhook. The URL contains the token, so use
+// String instead for logging.
+func (s *Session) URL() string {
+	return api.EndpointWebhooks + s.ID.String() + "/" + s.Token
+}
+
+// String returns the URL of the webhook with its token redacted.
+func (s *Session) String() string {
+	return api.EndpointWebhooks + s.ID.String() + "/" + redacted
+}
+
 // OnRequest should be called on each client request to inject itself.
 func (s *Session) OnRequest(r httpdriver.Request) error {
 	return s.Limiter.Acquire(r.GetContext(), r.GetPath())
@@ -140,8 +180,14 @@ func (c *Client) Get() (*discord.Webhook, error) {
 	return w, c.RequestJSON(&w, "GET", api.EndpointWebhooks+c.ID.String()+"/"+c.Token)
 }
 
-// Modify modifies the webhook.
+// Modify modifies the webhook. It doesn't require a bot token. The webhook
+// cannot be moved to another channel this way, so data.ChannelID must be
+// empty; otherwise, api.ErrWebhookTokenChannel is returned.
 func (c *Client) Modify(data api.ModifyWebhookData) (*discord.Webhook, error) {
+	if data.ChannelID.IsValid() {
+		return nil, api.ErrWebhookTokenChannel
+	}
+
 	var w *discord.Webhook
 	return w, c.RequestJSON(
 		&w, "PATCH",
@@ -274,9 +320,15 @@ type EditMessageData struct {
 	Components *discord.ContainerComponents `json:"components,omitempty"`
 	// AllowedMentions are the allowed mentions for a message.
 	AllowedMentions *api.AllowedMentions `json:"allowed_mentions,omitempty"`
-	// Attachments are the attached files to keep
+	// Attachments are the existing attachments to keep. If nil, all existing
+	// attachments are kept. Otherwise, any existing attachment that is not in
+	// the list is removed. See api.KeepAttachments.
+	//
+	// New files given in Files are always added to the message.
 	Attachments *[]discord.Attachment `json:"attachments,omitempty"`
 
+	// Files represents a list of files to upload. They are added to the
+	// message alongside the attachments being kept.
 	Files []sendpart.File `json:"-"`
 }
 
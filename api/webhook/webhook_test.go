@@ -0,0 +1,104 @@
+package webhook
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/utils/httputil"
+	"github.com/diamondburned/arikawa/v3/utils/httputil/httpdriver"
+	"github.com/diamondburned/arikawa/v3/utils/json/option"
+)
+
+func TestParseURL(t *testing.T) {
+	tests := []struct {
+		url   string
+		id    discord.WebhookID
+		token string
+	}{
+		{"https://discord.com/api/webhooks/123/abc-DEF_1", 123, "abc-DEF_1"},
+		{"https://discordapp.com/api/webhooks/123/abc", 123, "abc"},
+		{"https://canary.discord.com/api/v10/webhooks/123/abc/", 123, "abc"},
+		{"https://ptb.discord.com/api/webhooks/123/abc?thread_id=4", 123, "abc"},
+	}
+
+	for _, test := range tests {
+		id, token, err := ParseURL(test.url)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.url, err)
+			continue
+		}
+		if id != test.id || token != test.token {
+			t.Errorf("%s: got %d/%q", test.url, id, token)
+		}
+	}
+
+	invalid := []string{
+		"",
+		"https://example.com/api/webhooks/123/abc",
+		"https://discord.com/api/webhooks/abc/abc",
+		"https://discord.com/api/webhooks/123/",
+	}
+
+	for _, url := range invalid {
+		if err := ValidateURL(url); !errors.Is(err, ErrInvalidURL) {
+			t.Errorf("%q: expected ErrInvalidURL, got %v", url, err)
+		}
+	}
+}
+
+func TestRedactURL(t *testing.T) {
+	const url = "https://discord.com/api/webhooks/123/secret?wait=true"
+
+	redactedURL := RedactURL(url)
+	if redactedURL != "https://discord.com/api/webhooks/123/[REDACTED]?wait=true" {
+		t.Fatalf("unexpected redacted URL %q", redactedURL)
+	}
+
+	if RedactURL("secret") != redacted {
+		t.Fatal("invalid URL not fully redacted")
+	}
+
+	c := New(123, "secret")
+	if strings.Contains(c.String(), "secret") {
+		t.Fatalf("client string leaks token: %q", c.String())
+	}
+	if !strings.HasSuffix(c.URL(), "/123/secret") {
+		t.Fatalf("unexpected client URL %q", c.URL())
+	}
+}
+
+func TestModify(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if auth := r.Header.Get("Authorization"); auth != "" {
+			t.Errorf("unexpected authorization header %q", auth)
+		}
+		if r.Method != "PATCH" || r.URL.Path != "/api/v9/webhooks/1/token" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		w.Write([]byte(`{"id":"1","type":3,"name":"new name"}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	hcl := httputil.NewClient()
+	hcl.Client = httpdriver.WithBaseURL(hcl.Client, api.BaseEndpoint, srv.URL)
+
+	c := NewCustom(1, "token", hcl)
+
+	hook, err := c.Modify(api.ModifyWebhookData{Name: option.NewString("new name")})
+	if err != nil {
+		t.Fatal("failed to modify:", err)
+	}
+	if hook.Name != "new name" || hook.Type != discord.ApplicationWebhook {
+		t.Fatalf("unexpected webhook %+v", hook)
+	}
+
+	_, err = c.Modify(api.ModifyWebhookData{ChannelID: 2})
+	if !errors.Is(err, api.ErrWebhookTokenChannel) {
+		t.Fatal("expected ErrWebhookTokenChannel, got", err)
+	}
+}
@@ -57,6 +57,22 @@ type Application struct {
 	RoleConnectionsVerificationURL string `json:"role_connections_verification_url,omitempty"`
 }
 
+// ApplicationRoleConnection is the role connection that an application has
+// attached to a user. Guilds can require its metadata to match for linked
+// roles.
+//
+// https://discord.com/developers/docs/resources/user#application-role-connection-object
+type ApplicationRoleConnection struct {
+	// PlatformName is the vanity name of the platform, up to 50 characters.
+	PlatformName string `json:"platform_name,omitempty"`
+	// PlatformUsername is the username on the platform, up to 100
+	// characters.
+	PlatformUsername string `json:"platform_username,omitempty"`
+	// Metadata maps the keys of the application's role connection metadata
+	// records to their stringified values, up to 100 characters each.
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
 type ApplicationFlags uint32
 
 const AppFlagAutoModerationRuleCreateBadge ApplicationFlags = 1 << 6
@@ -68,11 +84,47 @@ const (
 	AppFlagGatewayGuildMembersLimited
 	AppFlagVerificationPendingGuildLimit
 	AppFlagEmbedded
+	AppFlagGatewayMessageContent
+	AppFlagGatewayMessageContentLimited
 )
 
+// IsOwner returns true if the user with the given ID owns the application. If
+// the application belongs to a team, then every member that accepted the team
+// invite is considered an owner, unless the member only has the read-only
+// role. Otherwise, only the Owner user is.
+func (a Application) IsOwner(userID UserID) bool {
+	if a.Team != nil {
+		return a.Team.IsOwner(userID)
+	}
+	return a.Owner != nil && a.Owner.ID == userID
+}
+
+// Owners returns the IDs of all users that own the application. See IsOwner
+// for the rules.
+func (a Application) Owners() []UserID {
+	if a.Team == nil {
+		if a.Owner == nil {
+			return nil
+		}
+		return []UserID{a.Owner.ID}
+	}
+
+	owners := make([]UserID, 0, len(a.Team.Members)+1)
+	owners = append(owners, a.Team.OwnerID)
+
+	for _, member := range a.Team.Members {
+		if member.User.ID != a.Team.OwnerID && member.canOwn() {
+			owners = append(owners, member.User.ID)
+		}
+	}
+
+	return owners
+}
+
+// https://discord.com/developers/docs/topics/teams#data-models-team-object
 type Team struct {
 	// Icon is a hash of the image of the team's icon.
-	Icon *Hash `json:"hash"`
+	Icon *Hash `json:"icon"`
 	// ID is the unique ID of the team.
 	ID TeamID `json:"id"`
 	// Members is the members of the team.
@@ -83,6 +135,38 @@ type Team struct {
 	OwnerID UserID `json:"owner_user_id"`
 }
 
+// IconURL returns the URL to the team's icon. An empty string is returned if
+// the team has no icon.
+func (t Team) IconURL() string {
+	if t.Icon == nil || *t.Icon == "" {
+		return ""
+	}
+	return "https://cdn.discordapp.com/team-icons/" + t.ID.String() + "/" + *t.Icon + ".png"
+}
+
+// Member returns the team member with the given user ID, or nil if the user
+// is not a member of the team.
+func (t Team) Member(userID UserID) *TeamMember {
+	for i, member := range t.Members {
+		if member.User.ID == userID {
+			return &t.Members[i]
+		}
+	}
+	return nil
+}
+
+// IsOwner returns true if the user is the team owner or an accepted team
+// member that is not read-only.
+func (t Team) IsOwner(userID UserID) bool {
+	if t.OwnerID == userID {
+		return true
+	}
+
+	member := t.Member(userID)
+	return member != nil && member.canOwn()
+}
+
+// https://discord.com/developers/docs/topics/teams#data-models-team-member-object
 type TeamMember struct {
 	// MembershipState is the user's membership state on the team.
 	MembershipState MembershipState `json:"membership_state"`
@@ -92,8 +176,15 @@ type TeamMember struct {
 	TeamID TeamID `json:"team_id"`
 	// User is the avatar, discriminator, ID, and username of the user.
 	User User `json:"user"`
+	// Role is the role of the team member.
+	Role TeamMemberRole `json:"role"`
 }
 
+func (m TeamMember) canOwn() bool {
+	return m.MembershipState == MembershipAccepted && m.Role != TeamReadOnly
+}
+
+// https://discord.com/developers/docs/topics/teams#data-models-membership-state-enum
 type MembershipState uint8
 
 const (
@@ -101,27 +192,63 @@ const (
 	MembershipAccepted
 )
 
-// https://discord.com/developers/docs/interactions/slash-commands#application-command-permissions-object-guild-application-command-permissions-structure
+// TeamMemberRole is the role of a team member. Team owners are not given a
+// role; use Team.OwnerID to check for them.
+//
+// https://discord.com/developers/docs/topics/teams#team-member-roles
+type TeamMemberRole string
+
+const (
+	// TeamAdmin can manage the team and its applications.
+	TeamAdmin TeamMemberRole = "admin"
+	// TeamDeveloper can access and modify the team's applications.
+	TeamDeveloper TeamMemberRole = "developer"
+	// TeamReadOnly can only view the team's applications.
+	TeamReadOnly TeamMemberRole = "read_only"
+)
+
+// https://discord.com/developers/docs/interactions/application-commands#application-command-permissions-object-guild-application-command-permissions-structure
 type GuildCommandPermissions struct {
+	// ID is the ID of the command, or the ID of the application if the
+	// permissions apply to all commands without explicit overwrites.
 	ID          CommandID            `json:"id"`
 	AppID       AppID                `json:"application_id"`
 	GuildID     GuildID              `json:"guild_id"`
 	Permissions []CommandPermissions `json:"permissions"`
 }
 
-// https://discord.com/developers/docs/interactions/slash-commands#application-command-permissions-object-application-command-permissions-structure
+// https://discord.com/developers/docs/interactions/application-commands#application-command-permissions-object-application-command-permissions-structure
 type CommandPermissions struct {
+	// ID is the ID of the role, user or channel. It can also be
+	// EveryonePermissionID or AllChannelsPermissionID.
 	ID         Snowflake             `json:"id"`
 	Type       CommandPermissionType `json:"type"`
 	Permission bool                  `json:"permission"`
 }
 
+// ApplicationCommandPermission is an alias to CommandPermissions that matches
+// the name used by Discord's documentation.
+type ApplicationCommandPermission = CommandPermissions
+
+// EveryonePermissionID returns the permission ID that targets all members of
+// the given guild. It is the same as the guild's @everyone role ID.
+func EveryonePermissionID(guildID GuildID) Snowflake {
+	return Snowflake(guildID)
+}
+
+// AllChannelsPermissionID returns the permission ID that targets all channels
+// of the given guild.
+func AllChannelsPermissionID(guildID GuildID) Snowflake {
+	return Snowflake(guildID) - 1
+}
+
 type CommandPermissionType uint8
 
-// https://discord.com/developers/docs/interactions/slash-commands#application-command-permissions-object-application-command-permission-type
+// https://discord.com/developers/docs/interactions/application-commands#application-command-permissions-object-application-command-permission-type
 const (
-	RoleCommandPermission = iota + 1
+	RoleCommandPermission CommandPermissionType = iota + 1
 	UserCommandPermission
+	ChannelCommandPermission
 )
 
 // https://discord.com/developers/docs/resources/application#install-params-object
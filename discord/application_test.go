@@ -0,0 +1,38 @@
+package discord
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestApplicationOwners(t *testing.T) {
+	app := Application{
+		Owner: &User{ID: 1},
+		Team: &Team{
+			OwnerID: 2,
+			Members: []TeamMember{
+				{User: User{ID: 2}, MembershipState: MembershipAccepted, Role: TeamAdmin},
+				{User: User{ID: 3}, MembershipState: MembershipAccepted, Role: TeamDeveloper},
+				{User: User{ID: 4}, MembershipState: MembershipInvited, Role: TeamAdmin},
+				{User: User{ID: 5}, MembershipState: MembershipAccepted, Role: TeamReadOnly},
+			},
+		},
+	}
+
+	expect := []UserID{2, 3}
+	if owners := app.Owners(); !reflect.DeepEqual(owners, expect) {
+		t.Fatalf("expected owners %v, got %v", expect, owners)
+	}
+
+	for id := UserID(1); id <= 5; id++ {
+		isOwner := id == 2 || id == 3
+		if app.IsOwner(id) != isOwner {
+			t.Errorf("expected IsOwner(%d) = %v", id, isOwner)
+		}
+	}
+
+	app.Team = nil
+	if !app.IsOwner(1) {
+		t.Error("expected the non-team owner to be an owner")
+	}
+}
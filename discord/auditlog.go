@@ -2,6 +2,7 @@ package discord
 
 import (
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/diamondburned/arikawa/v3/utils/json"
@@ -146,6 +147,152 @@ type AuditEntryInfo struct {
 	IntegrationType string `json:"integration_type"`
 }
 
+// AuditEntryOptions is the typed form of AuditEntryInfo for a specific
+// ActionType. It is returned by AuditLogEntry's TypedOptions method, and it is
+// one of:
+//
+//   - *OverwriteAuditOptions
+//   - *PruneAuditOptions
+//   - *MessageDeleteAuditOptions
+//   - *MessageBulkDeleteAuditOptions
+//   - *MessagePinAuditOptions
+//   - *MemberMoveAuditOptions
+//   - *MemberDisconnectAuditOptions
+type AuditEntryOptions interface {
+	auditEntryOptions()
+}
+
+// OverwriteAuditOptions are the options of ChannelOverwriteCreate,
+// ChannelOverwriteUpdate and ChannelOverwriteDelete entries.
+type OverwriteAuditOptions struct {
+	// ID is the ID of the overwritten role or member.
+	ID Snowflake
+	// Type is the type of the overwritten entity.
+	Type OverwriteType
+	// RoleName is the name of the role if Type is OverwriteRole.
+	RoleName string
+}
+
+// PruneAuditOptions are the options of MemberPrune entries.
+type PruneAuditOptions struct {
+	// DeleteMemberDays is the number of days after which inactive members
+	// were kicked.
+	DeleteMemberDays int
+	// MembersRemoved is the number of members removed by the prune.
+	MembersRemoved int
+}
+
+// MessageDeleteAuditOptions are the options of MessageDelete entries.
+type MessageDeleteAuditOptions struct {
+	// ChannelID is the channel that the messages were deleted in.
+	ChannelID ChannelID
+	// Count is the number of deleted messages.
+	Count int
+}
+
+// MessageBulkDeleteAuditOptions are the options of MessageBulkDelete entries.
+type MessageBulkDeleteAuditOptions struct {
+	// Count is the number of deleted messages.
+	Count int
+}
+
+// MessagePinAuditOptions are the options of MessagePin and MessageUnpin
+// entries.
+type MessagePinAuditOptions struct {
+	// ChannelID is the channel of the message.
+	ChannelID ChannelID
+	// MessageID is the pinned or unpinned message.
+	MessageID MessageID
+}
+
+// MemberMoveAuditOptions are the options of MemberMove entries.
+type MemberMoveAuditOptions struct {
+	// ChannelID is the voice channel that the members were moved to.
+	ChannelID ChannelID
+	// Count is the number of moved members.
+	Count int
+}
+
+// MemberDisconnectAuditOptions are the options of MemberDisconnect entries.
+type MemberDisconnectAuditOptions struct {
+	// Count is the number of disconnected members.
+	Count int
+}
+
+func (*OverwriteAuditOptions) auditEntryOptions()         {}
+func (*PruneAuditOptions) auditEntryOptions()             {}
+func (*MessageDeleteAuditOptions) auditEntryOptions()     {}
+func (*MessageBulkDeleteAuditOptions) auditEntryOptions() {}
+func (*MessagePinAuditOptions) auditEntryOptions()        {}
+func (*MemberMoveAuditOptions) auditEntryOptions()        {}
+func (*MemberDisconnectAuditOptions) auditEntryOptions()  {}
+
+// TypedOptions returns the Options of the entry in the typed form matching its
+// ActionType. Nil is returned for action types without typed options. An error
+// is returned if a number in the options cannot be parsed.
+func (e AuditLogEntry) TypedOptions() (AuditEntryOptions, error) {
+	info := e.Options
+
+	switch e.ActionType {
+	case ChannelOverwriteCreate, ChannelOverwriteUpdate, ChannelOverwriteDelete:
+		return &OverwriteAuditOptions{
+			ID:       info.ID,
+			Type:     info.Type,
+			RoleName: info.RoleName,
+		}, nil
+
+	case MemberPrune:
+		days, err := parseAuditCount(info.DeleteMemberDays)
+		if err != nil {
+			return nil, fmt.Errorf("invalid delete_member_days: %w", err)
+		}
+		removed, err := parseAuditCount(info.MembersRemoved)
+		if err != nil {
+			return nil, fmt.Errorf("invalid members_removed: %w", err)
+		}
+		return &PruneAuditOptions{
+			DeleteMemberDays: days,
+			MembersRemoved:   removed,
+		}, nil
+
+	case MessagePin, MessageUnpin:
+		return &MessagePinAuditOptions{
+			ChannelID: info.ChannelID,
+			MessageID: info.MessageID,
+		}, nil
+
+	case MessageDelete, MessageBulkDelete, MemberMove, MemberDisconnect:
+		// handled below
+	default:
+		return nil, nil
+	}
+
+	count, err := parseAuditCount(info.Count)
+	if err != nil {
+		return nil, fmt.Errorf("invalid count: %w", err)
+	}
+
+	switch e.ActionType {
+	case MessageDelete:
+		return &MessageDeleteAuditOptions{ChannelID: info.ChannelID, Count: count}, nil
+	case MessageBulkDelete:
+		return &MessageBulkDeleteAuditOptions{Count: count}, nil
+	case MemberMove:
+		return &MemberMoveAuditOptions{ChannelID: info.ChannelID, Count: count}, nil
+	default: // MemberDisconnect
+		return &MemberDisconnectAuditOptions{Count: count}, nil
+	}
+}
+
+// parseAuditCount parses the string-encoded numbers in AuditEntryInfo. An
+// empty string is 0.
+func parseAuditCount(s string) (int, error) {
+	if s == "" {
+		return 0, nil
+	}
+	return strconv.Atoi(s)
+}
+
 // AuditLogChange is a single key type to changed value audit log entry. The
 // type can be found in the key's comment. Values can be nil.
 //
@@ -0,0 +1,76 @@
+package discord
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/diamondburned/arikawa/v3/utils/json"
+)
+
+func TestAuditLogEntryTypedOptions(t *testing.T) {
+	tests := []struct {
+		name   string
+		entry  string
+		expect AuditEntryOptions
+	}{
+		{
+			name:  "overwrite",
+			entry: `{"action_type":14,"options":{"id":"1","type":"0","role_name":"mods"}}`,
+			expect: &OverwriteAuditOptions{
+				ID:       1,
+				Type:     OverwriteRole,
+				RoleName: "mods",
+			},
+		},
+		{
+			name:  "prune",
+			entry: `{"action_type":21,"options":{"delete_member_days":"7","members_removed":"42"}}`,
+			expect: &PruneAuditOptions{
+				DeleteMemberDays: 7,
+				MembersRemoved:   42,
+			},
+		},
+		{
+			name:  "message delete",
+			entry: `{"action_type":72,"options":{"channel_id":"2","count":"3"}}`,
+			expect: &MessageDeleteAuditOptions{
+				ChannelID: 2,
+				Count:     3,
+			},
+		},
+		{
+			name:   "no options",
+			entry:  `{"action_type":1}`,
+			expect: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var entry AuditLogEntry
+			if err := json.Unmarshal([]byte(test.entry), &entry); err != nil {
+				t.Fatal("failed to unmarshal entry:", err)
+			}
+
+			opts, err := entry.TypedOptions()
+			if err != nil {
+				t.Fatal("unexpected error:", err)
+			}
+
+			if !reflect.DeepEqual(opts, test.expect) {
+				t.Fatalf("expected %#v, got %#v", test.expect, opts)
+			}
+		})
+	}
+}
+
+func TestAuditLogEntryTypedOptionsInvalid(t *testing.T) {
+	entry := AuditLogEntry{
+		ActionType: MemberDisconnect,
+		Options:    AuditEntryInfo{Count: "many"},
+	}
+
+	if _, err := entry.TypedOptions(); err == nil {
+		t.Fatal("expected error for invalid count")
+	}
+}
@@ -0,0 +1,113 @@
+package discord
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// CDNURLExpiry returns the time that the given signed Discord CDN URL expires
+// at, which is stored in its "ex" query parameter. False is returned if the
+// URL is not signed.
+func CDNURLExpiry(cdnURL URL) (time.Time, bool) {
+	u, err := url.Parse(cdnURL)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	ex := u.Query().Get("ex")
+	if ex == "" {
+		return time.Time{}, false
+	}
+
+	unix, err := strconv.ParseInt(ex, 16, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	return time.Unix(unix, 0), true
+}
+
+// CDNURLRefresher refreshes signed Discord CDN URLs that may have expired.
+// *api.Client implements this interface.
+type CDNURLRefresher interface {
+	// RefreshCDNURL returns the refreshed URLs in the same order as the given
+	// URLs.
+	RefreshCDNURL(urls ...URL) ([]URL, error)
+}
+
+// DownloadError is returned by Attachment.Download if Discord's CDN responds
+// with a non-2xx status code.
+type DownloadError struct {
+	URL        URL
+	StatusCode int
+}
+
+// Error implements error.
+func (err *DownloadError) Error() string {
+	return fmt.Sprintf("failed to download %s: unexpected status %d", err.URL, err.StatusCode)
+}
+
+// Download downloads the attachment's file. The caller must close the returned
+// body. If client is nil, then http.DefaultClient is used.
+//
+// If refresher is not nil and the CDN responds with a 403 or 404, which it does
+// once the URL's signature expires, then the URL is refreshed and the download
+// is retried once.
+func (a Attachment) Download(
+	ctx context.Context, client *http.Client, refresher CDNURLRefresher) (io.ReadCloser, error) {
+
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	body, err := download(ctx, client, a.URL)
+	if err == nil || refresher == nil {
+		return body, err
+	}
+
+	var dlErr *DownloadError
+	if !errors.As(err, &dlErr) {
+		return nil, err
+	}
+
+	switch dlErr.StatusCode {
+	case http.StatusForbidden, http.StatusNotFound:
+	default:
+		return nil, err
+	}
+
+	urls, err := refresher.RefreshCDNURL(a.URL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to refresh attachment URL: %w", err)
+	}
+	if len(urls) != 1 {
+		return nil, fmt.Errorf("unexpected %d refreshed URLs", len(urls))
+	}
+
+	return download(ctx, client, urls[0])
+}
+
+func download(ctx context.Context, client *http.Client, url URL) (io.ReadCloser, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		resp.Body.Close()
+		return nil, &DownloadError{URL: url, StatusCode: resp.StatusCode}
+	}
+
+	return resp.Body, nil
+}
@@ -0,0 +1,58 @@
+package discord
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+type mockRefresher map[URL]URL
+
+func (r mockRefresher) RefreshCDNURL(urls ...URL) ([]URL, error) {
+	out := make([]URL, len(urls))
+	for i, u := range urls {
+		out[i] = r[u]
+	}
+	return out, nil
+}
+
+func TestAttachmentDownload(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("ex") != "ffffffff" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		io.WriteString(w, "hello")
+	}))
+	defer srv.Close()
+
+	a := Attachment{URL: srv.URL + "/file.txt?ex=1"}
+
+	if _, err := a.Download(context.Background(), srv.Client(), nil); err == nil {
+		t.Fatal("unexpected nil error without refresher")
+	}
+
+	refresher := mockRefresher{a.URL: srv.URL + "/file.txt?ex=ffffffff"}
+
+	body, err := a.Download(context.Background(), srv.Client(), refresher)
+	if err != nil {
+		t.Fatal("failed to download:", err)
+	}
+	defer body.Close()
+
+	b, err := io.ReadAll(body)
+	if err != nil {
+		t.Fatal("failed to read body:", err)
+	}
+	if string(b) != "hello" {
+		t.Fatalf("unexpected body %q", b)
+	}
+
+	expiry, ok := CDNURLExpiry(refresher[a.URL])
+	if !ok || !expiry.Equal(time.Unix(0xffffffff, 0)) {
+		t.Fatalf("unexpected expiry %v", expiry)
+	}
+}
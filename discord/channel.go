@@ -93,12 +93,21 @@ type Channel struct {
 	// VideoQualityMode is the camera video quality mode of the voice channel.
 	VideoQualityMode VideoQualityMode `json:"video_quality_mode,omitempty"`
 
-	// MessageCount is an approximate count of messages in a thread. However,
-	// counting stops at 50.
+	// MessageCount is the number of messages in a thread, excluding the
+	// initial message and deleted messages. For threads created before July 1,
+	// 2022, the count is inaccurate once it reaches 50; use TotalMessageSent
+	// for those.
 	MessageCount int `json:"message_count,omitempty"`
+	// TotalMessageSent is the number of messages ever sent in a thread. It is
+	// similar to MessageCount, except it doesn't decrease when messages are
+	// deleted.
+	TotalMessageSent int `json:"total_message_sent,omitempty"`
 	// MemberCount is an approximate count of users in a thread. However,
 	// counting stops at 50.
 	MemberCount int `json:"member_count,omitempty"`
+	// MemberIDsPreview is a preview of the IDs of up to 50 members of a
+	// thread. It is only included in some thread events.
+	MemberIDsPreview []UserID `json:"member_ids_preview,omitempty"`
 
 	// ThreadMetadata contains thread-specific fields not needed by other
 	// channels.
@@ -223,6 +232,16 @@ const (
 	GuildForum
 )
 
+// IsThread returns true if the channel type is a thread type.
+func (t ChannelType) IsThread() bool {
+	switch t {
+	case GuildAnnouncementThread, GuildPublicThread, GuildPrivateThread:
+		return true
+	default:
+		return false
+	}
+}
+
 // GuildNews aliases to GuildAnnouncement.
 //
 // Deprecated: use GuildAnnouncement instead.
@@ -233,6 +252,17 @@ const GuildNews = GuildAnnouncement
 // Deprecated: use GuildAnnouncementThread instead.
 const GuildNewsThread = GuildAnnouncementThread
 
+// FollowedChannel is returned when an announcement channel is followed.
+//
+// https://discord.com/developers/docs/resources/channel#followed-channel-object
+type FollowedChannel struct {
+	// ChannelID is the ID of the source announcement channel.
+	ChannelID ChannelID `json:"channel_id"`
+	// WebhookID is the ID of the webhook created in the target channel, which
+	// crossposts the messages of the source channel.
+	WebhookID WebhookID `json:"webhook_id"`
+}
+
 // https://discord.com/developers/docs/resources/channel#overwrite-object
 type Overwrite struct {
 	// ID is the role or user id.
@@ -18,6 +18,37 @@ const (
 	MessageCommand
 )
 
+// ApplicationIntegrationType is where an application can be installed, also
+// called its supported installation contexts.
+//
+// https://discord.com/developers/docs/resources/application#application-object-application-integration-types
+type ApplicationIntegrationType uint16
+
+const (
+	// GuildInstall means the application is installable to servers.
+	GuildInstall ApplicationIntegrationType = iota
+	// UserInstall means the application is installable to users.
+	UserInstall
+)
+
+// InteractionContextType is the context in Discord where an interaction can be
+// used or was triggered from.
+//
+// https://discord.com/developers/docs/interactions/receiving-and-responding#interaction-object-interaction-context-types
+type InteractionContextType uint16
+
+const (
+	// InteractionContextGuild means the interaction can be used within
+	// servers.
+	InteractionContextGuild InteractionContextType = iota
+	// InteractionContextBotDM means the interaction can be used within DMs
+	// with the application's bot user.
+	InteractionContextBotDM
+	// InteractionContextPrivateChannel means the interaction can be used
+	// within group DMs and DMs other than the application's bot user.
+	InteractionContextPrivateChannel
+)
+
 // Command is the base "command" model that belongs to an application. This is
 // what you are creating when you POST a new command.
 //
@@ -59,6 +90,13 @@ type Command struct {
 	// NoDefaultPermissions defines whether the command is NOT enabled by
 	// default when the app is added to a guild.
 	NoDefaultPermission bool `json:"-"`
+	// IntegrationTypes are the installation contexts where the command is
+	// available, only for globally-scoped commands. Discord defaults to the
+	// application's configured contexts.
+	IntegrationTypes []ApplicationIntegrationType `json:"integration_types,omitempty"`
+	// Contexts are the interaction contexts where the command can be used,
+	// only for globally-scoped commands. Discord defaults to all contexts.
+	Contexts []InteractionContextType `json:"contexts,omitempty"`
 	// Version is an autoincrementing version identifier updated during
 	// substantial record changes
 	Version Snowflake `json:"version,omitempty"`
@@ -75,11 +113,13 @@ type Language string
 type StringLocales map[Language]string
 
 const (
+	Indonesian    Language = "id"
 	Danish        Language = "da"
 	German        Language = "de"
 	EnglishUK     Language = "en-GB"
 	EnglishUS     Language = "en-US"
 	Spanish       Language = "es-ES"
+	SpanishLATAM  Language = "es-419"
 	French        Language = "fr"
 	Croatian      Language = "hr"
 	Italian       Language = "it"
@@ -107,11 +147,49 @@ const (
 	Korean        Language = "ko"
 )
 
+// Languages contains all valid language codes.
+var Languages = []Language{
+	Indonesian, Danish, German, EnglishUK, EnglishUS, Spanish, SpanishLATAM,
+	French, Croatian, Italian, Lithuanian, Hungarian, Dutch, Norwegian, Polish,
+	PortugueseBR, Romanian, Finnish, Swedish, Vietnamese, Turkish, Czech, Greek,
+	Bulgarian, Russian, Ukrainian, Hindi, Thai, ChineseChina, Japanese,
+	ChineseTaiwan, Korean,
+}
+
+// IsValid returns true if l is one of the valid language codes in Languages.
+func (l Language) IsValid() bool {
+	for _, lang := range Languages {
+		if lang == l {
+			return true
+		}
+	}
+	return false
+}
+
 // CreatedAt returns a time object representing when the command was created.
 func (c *Command) CreatedAt() time.Time {
 	return c.ID.Time()
 }
 
+// Mention generates the mention syntax for this command, which shows up as a
+// clickable command in chat. It only works for chat input commands.
+func (c *Command) Mention() string {
+	return "</" + c.Name + ":" + c.ID.String() + ">"
+}
+
+// SubcommandMention generates the mention syntax for a subcommand of this
+// command. path is the names of the subcommand group, if any, and the
+// subcommand, e.g. SubcommandMention("settings", "reset") for
+// "/config settings reset". The path is not checked against the command's
+// options.
+func (c *Command) SubcommandMention(path ...string) string {
+	name := c.Name
+	for _, p := range path {
+		name += " " + p
+	}
+	return "</" + name + ":" + c.ID.String() + ">"
+}
+
 func (c *Command) MarshalJSON() ([]byte, error) {
 	type RawCommand Command
 	cmd := struct {
@@ -481,8 +559,8 @@ type IntegerOption struct {
 	Description              string          `json:"description"`
 	DescriptionLocalizations StringLocales   `json:"description_localizations,omitempty"`
 	Required                 bool            `json:"required"`
-	Min                      option.Int      `json:"min_value,omitempty"`
-	Max                      option.Int      `json:"max_value,omitempty"`
+	Min                      option.Int64    `json:"min_value,omitempty"`
+	Max                      option.Int64    `json:"max_value,omitempty"`
 	Choices                  []IntegerChoice `json:"choices,omitempty"`
 	// Autocomplete must not be true if Choices are present.
 	Autocomplete bool `json:"autocomplete"`
@@ -0,0 +1,52 @@
+package discord
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/diamondburned/arikawa/v3/utils/json"
+)
+
+func TestCommandMention(t *testing.T) {
+	cmd := Command{ID: 123, Name: "config"}
+
+	tests := []struct {
+		got, expected string
+	}{
+		{cmd.Mention(), "</config:123>"},
+		{cmd.SubcommandMention("reset"), "</config reset:123>"},
+		{cmd.SubcommandMention("settings", "reset"), "</config settings reset:123>"},
+	}
+
+	for _, test := range tests {
+		if test.got != test.expected {
+			t.Errorf("expected %q, got %q", test.expected, test.got)
+		}
+	}
+}
+
+func TestCommandMarshalContexts(t *testing.T) {
+	cmd := Command{
+		Name:             "ping",
+		IntegrationTypes: []ApplicationIntegrationType{GuildInstall, UserInstall},
+		Contexts: []InteractionContextType{
+			InteractionContextGuild,
+			InteractionContextBotDM,
+			InteractionContextPrivateChannel,
+		},
+	}
+
+	b, err := json.Marshal(cmd)
+	if err != nil {
+		t.Fatal("failed to marshal command:", err)
+	}
+
+	for _, expect := range []string{
+		`"integration_types":[0,1]`,
+		`"contexts":[0,1,2]`,
+	} {
+		if !strings.Contains(string(b), expect) {
+			t.Errorf("expected %s in %s", expect, b)
+		}
+	}
+}
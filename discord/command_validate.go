@@ -0,0 +1,595 @@
+package discord
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	maxCommandOptions         = 25
+	maxCommandChoices         = 25
+	maxCommandNameLength      = 32
+	maxOptionDescription      = 100
+	maxChoiceNameLength       = 100
+	maxStringChoiceLength     = 100
+	maxStringOptionLength     = 6000
+	maxIntegerOptionMagnitude = 1 << 53
+)
+
+// commandNameRegex matches valid names of chat input commands and their
+// options. The length is checked separately.
+//
+// https://discord.com/developers/docs/interactions/application-commands#application-command-object-application-command-naming
+var commandNameRegex = regexp.MustCompile(`^[-_\p{L}\p{N}\p{Devanagari}\p{Thai}]+$`)
+
+// ValidateCommandName checks that the given name is a valid name for a chat
+// input command or a command option: it must be 1 to 32 characters long,
+// consist of letters, numbers, dashes and underscores, and be lowercase.
+func ValidateCommandName(name string) error {
+	switch n := utf8.RuneCountInString(name); {
+	case n == 0:
+		return errors.New("name is empty")
+	case n > maxCommandNameLength:
+		return &OverboundError{n, maxCommandNameLength, "name"}
+	}
+
+	if !commandNameRegex.MatchString(name) {
+		return fmt.Errorf("name %q contains invalid characters", name)
+	}
+
+	if strings.ToLower(name) != name {
+		return fmt.Errorf("name %q must be lowercase", name)
+	}
+
+	return nil
+}
+
+// ValidateCommandOptions checks the given options of a chat input command
+// against Discord's constraints, so that mistakes are caught before Discord
+// rejects the command with a cryptic error. It checks that:
+//
+//   - there are at most 25 options, and subcommands aren't mixed with other
+//     options;
+//   - names are valid and unique, and descriptions are 1 to 100 characters
+//     long;
+//   - required options come before optional ones;
+//   - there are at most 25 choices, each with a valid name and value, and
+//     choices aren't combined with autocomplete;
+//   - minimum and maximum values and lengths are within Discord's limits and
+//     in order.
+//
+// Subcommands and subcommand groups are checked recursively.
+func ValidateCommandOptions(options CommandOptions) error {
+	if len(options) > maxCommandOptions {
+		return &OverboundError{len(options), maxCommandOptions, "options"}
+	}
+
+	var values []CommandOptionValue
+	var subcommands []CommandOption
+
+	for _, opt := range options {
+		typed := opt
+		if u, ok := opt.(*UnknownCommandOption); ok {
+			typed = u.Data()
+		}
+
+		switch typed.(type) {
+		case *SubcommandOption, *SubcommandGroupOption:
+			subcommands = append(subcommands, typed)
+		case CommandOptionValue:
+			values = append(values, typed.(CommandOptionValue))
+		}
+	}
+
+	if len(subcommands) == 0 {
+		return validateOptionValues(values)
+	}
+
+	if len(values) > 0 {
+		return errors.New("subcommands cannot be mixed with other options")
+	}
+
+	names := make(map[string]struct{}, len(subcommands))
+
+	for _, opt := range subcommands {
+		if err := validateOptionName(opt.Name(), names); err != nil {
+			return err
+		}
+
+		var err error
+		switch opt := opt.(type) {
+		case *SubcommandGroupOption:
+			err = validateSubcommandGroup(opt)
+		case *SubcommandOption:
+			err = validateSubcommand(opt)
+		}
+		if err != nil {
+			return fmt.Errorf("option %q: %w", opt.Name(), err)
+		}
+	}
+
+	return nil
+}
+
+func validateSubcommandGroup(group *SubcommandGroupOption) error {
+	if err := validateOptionDescription(group.Description); err != nil {
+		return err
+	}
+
+	switch n := len(group.Subcommands); {
+	case n == 0:
+		return errors.New("subcommand group has no subcommands")
+	case n > maxCommandOptions:
+		return &OverboundError{n, maxCommandOptions, "subcommands"}
+	}
+
+	names := make(map[string]struct{}, len(group.Subcommands))
+
+	for _, sub := range group.Subcommands {
+		if err := validateOptionName(sub.OptionName, names); err != nil {
+			return err
+		}
+		if err := validateSubcommand(sub); err != nil {
+			return fmt.Errorf("subcommand %q: %w", sub.OptionName, err)
+		}
+	}
+
+	return nil
+}
+
+func validateSubcommand(sub *SubcommandOption) error {
+	if err := validateOptionDescription(sub.Description); err != nil {
+		return err
+	}
+
+	return validateOptionValues(sub.Options)
+}
+
+func validateOptionValues(options []CommandOptionValue) error {
+	if len(options) > maxCommandOptions {
+		return &OverboundError{len(options), maxCommandOptions, "options"}
+	}
+
+	names := make(map[string]struct{}, len(options))
+	var optional string
+
+	for _, opt := range options {
+		if u, ok := opt.(*UnknownCommandOption); ok {
+			v, ok := u.Data().(CommandOptionValue)
+			if !ok || v == CommandOptionValue(u) {
+				// Options of unknown types are sent as-is.
+				continue
+			}
+			opt = v
+		}
+
+		if err := validateOptionName(opt.Name(), names); err != nil {
+			return err
+		}
+
+		if optionRequired(opt) {
+			if optional != "" {
+				return fmt.Errorf(
+					"required option %q must come before optional option %q",
+					opt.Name(), optional)
+			}
+		} else if optional == "" {
+			optional = opt.Name()
+		}
+
+		if err := validateOptionValue(opt); err != nil {
+			return fmt.Errorf("option %q: %w", opt.Name(), err)
+		}
+	}
+
+	return nil
+}
+
+func validateOptionName(name string, seen map[string]struct{}) error {
+	if err := ValidateCommandName(name); err != nil {
+		return fmt.Errorf("invalid option name: %w", err)
+	}
+
+	if _, ok := seen[name]; ok {
+		return fmt.Errorf("duplicate option name %q", name)
+	}
+	seen[name] = struct{}{}
+
+	return nil
+}
+
+// ValidateCommandLocalizations checks that the given name and description
+// localizations of a chat input command or a command option only use valid
+// language codes, and that the localized names and descriptions are valid
+// themselves.
+func ValidateCommandLocalizations(names, descriptions StringLocales) error {
+	for lang, name := range names {
+		if !lang.IsValid() {
+			return fmt.Errorf("invalid language %q in name localizations", lang)
+		}
+		if err := ValidateCommandName(name); err != nil {
+			return fmt.Errorf("invalid %s name localization: %w", lang, err)
+		}
+	}
+
+	for lang, desc := range descriptions {
+		if !lang.IsValid() {
+			return fmt.Errorf("invalid language %q in description localizations", lang)
+		}
+		if err := validateOptionDescription(desc); err != nil {
+			return fmt.Errorf("invalid %s description localization: %w", lang, err)
+		}
+	}
+
+	return nil
+}
+
+// ValidateCommandOptionLocalizations checks the localizations of the given
+// options, including those of subcommands and choices, using
+// ValidateCommandLocalizations. Localized choice names must be 1 to 100
+// characters long.
+func ValidateCommandOptionLocalizations(options CommandOptions) error {
+	for _, opt := range options {
+		if u, ok := opt.(*UnknownCommandOption); ok {
+			opt = u.Data()
+		}
+
+		var err error
+		switch opt := opt.(type) {
+		case *SubcommandGroupOption:
+			err = ValidateCommandLocalizations(opt.OptionNameLocalizations, opt.DescriptionLocalizations)
+			for _, sub := range opt.Subcommands {
+				if err != nil {
+					break
+				}
+				if err = validateSubcommandLocalizations(sub); err != nil {
+					err = fmt.Errorf("subcommand %q: %w", sub.OptionName, err)
+				}
+			}
+		case *SubcommandOption:
+			err = validateSubcommandLocalizations(opt)
+		case CommandOptionValue:
+			err = validateOptionValueLocalizations(opt)
+		}
+
+		if err != nil {
+			return fmt.Errorf("option %q: %w", opt.Name(), err)
+		}
+	}
+
+	return nil
+}
+
+func validateSubcommandLocalizations(sub *SubcommandOption) error {
+	err := ValidateCommandLocalizations(sub.OptionNameLocalizations, sub.DescriptionLocalizations)
+	if err != nil {
+		return err
+	}
+
+	for _, opt := range sub.Options {
+		if err := validateOptionValueLocalizations(opt); err != nil {
+			return fmt.Errorf("option %q: %w", opt.Name(), err)
+		}
+	}
+
+	return nil
+}
+
+func validateOptionValueLocalizations(opt CommandOptionValue) error {
+	if err := ValidateCommandLocalizations(optionLocalizations(opt)); err != nil {
+		return err
+	}
+
+	switch opt := opt.(type) {
+	case *StringOption:
+		for _, choice := range opt.Choices {
+			if err := validateChoiceLocalizations(choice.Name, choice.NameLocalizations); err != nil {
+				return err
+			}
+		}
+	case *IntegerOption:
+		for _, choice := range opt.Choices {
+			if err := validateChoiceLocalizations(choice.Name, choice.NameLocalizations); err != nil {
+				return err
+			}
+		}
+	case *NumberOption:
+		for _, choice := range opt.Choices {
+			if err := validateChoiceLocalizations(choice.Name, choice.NameLocalizations); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func validateOptionDescription(desc string) error {
+	switch n := utf8.RuneCountInString(desc); {
+	case n == 0:
+		return errors.New("description is empty")
+	case n > maxOptionDescription:
+		return &OverboundError{n, maxOptionDescription, "description"}
+	}
+	return nil
+}
+
+// optionRequired returns the Required field of the given option value.
+func optionRequired(opt CommandOptionValue) bool {
+	switch opt := opt.(type) {
+	case *StringOption:
+		return opt.Required
+	case *IntegerOption:
+		return opt.Required
+	case *BooleanOption:
+		return opt.Required
+	case *UserOption:
+		return opt.Required
+	case *ChannelOption:
+		return opt.Required
+	case *RoleOption:
+		return opt.Required
+	case *MentionableOption:
+		return opt.Required
+	case *NumberOption:
+		return opt.Required
+	case *AttachmentOption:
+		return opt.Required
+	}
+	return false
+}
+
+// optionLocalizations returns the OptionNameLocalizations and
+// DescriptionLocalizations fields of the given option value.
+func optionLocalizations(opt CommandOptionValue) (names, descriptions StringLocales) {
+	switch opt := opt.(type) {
+	case *StringOption:
+		return opt.OptionNameLocalizations, opt.DescriptionLocalizations
+	case *IntegerOption:
+		return opt.OptionNameLocalizations, opt.DescriptionLocalizations
+	case *BooleanOption:
+		return opt.OptionNameLocalizations, opt.DescriptionLocalizations
+	case *UserOption:
+		return opt.OptionNameLocalizations, opt.DescriptionLocalizations
+	case *ChannelOption:
+		return opt.OptionNameLocalizations, opt.DescriptionLocalizations
+	case *RoleOption:
+		return opt.OptionNameLocalizations, opt.DescriptionLocalizations
+	case *MentionableOption:
+		return opt.OptionNameLocalizations, opt.DescriptionLocalizations
+	case *NumberOption:
+		return opt.OptionNameLocalizations, opt.DescriptionLocalizations
+	case *AttachmentOption:
+		return opt.OptionNameLocalizations, opt.DescriptionLocalizations
+	}
+	return nil, nil
+}
+
+// optionDescription returns the Description field of the given option value.
+func optionDescription(opt CommandOptionValue) string {
+	switch opt := opt.(type) {
+	case *StringOption:
+		return opt.Description
+	case *IntegerOption:
+		return opt.Description
+	case *BooleanOption:
+		return opt.Description
+	case *UserOption:
+		return opt.Description
+	case *ChannelOption:
+		return opt.Description
+	case *RoleOption:
+		return opt.Description
+	case *MentionableOption:
+		return opt.Description
+	case *NumberOption:
+		return opt.Description
+	case *AttachmentOption:
+		return opt.Description
+	}
+	return ""
+}
+
+func validateOptionValue(opt CommandOptionValue) error {
+	if err := validateOptionDescription(optionDescription(opt)); err != nil {
+		return err
+	}
+
+	switch opt := opt.(type) {
+	case *StringOption:
+		if err := validateChoices(len(opt.Choices), opt.Autocomplete); err != nil {
+			return err
+		}
+		for _, choice := range opt.Choices {
+			if err := validateChoiceName(choice.Name); err != nil {
+				return err
+			}
+			if n := utf8.RuneCountInString(choice.Value); n > maxStringChoiceLength {
+				return &OverboundError{n, maxStringChoiceLength,
+					fmt.Sprintf("value of choice %q", choice.Name)}
+			}
+		}
+		return validateStringLength(opt.MinLength, opt.MaxLength)
+
+	case *IntegerOption:
+		if err := validateChoices(len(opt.Choices), opt.Autocomplete); err != nil {
+			return err
+		}
+		for _, choice := range opt.Choices {
+			if err := validateChoiceName(choice.Name); err != nil {
+				return err
+			}
+			if !integerInRange(int64(choice.Value)) {
+				return fmt.Errorf("value of choice %q is out of range", choice.Name)
+			}
+		}
+		if opt.Min != nil && !integerInRange(*opt.Min) {
+			return fmt.Errorf("min value %d is out of range", *opt.Min)
+		}
+		if opt.Max != nil && !integerInRange(*opt.Max) {
+			return fmt.Errorf("max value %d is out of range", *opt.Max)
+		}
+		if opt.Min != nil && opt.Max != nil && *opt.Min > *opt.Max {
+			return fmt.Errorf("min value %d is greater than max value %d", *opt.Min, *opt.Max)
+		}
+
+	case *NumberOption:
+		if err := validateChoices(len(opt.Choices), opt.Autocomplete); err != nil {
+			return err
+		}
+		for _, choice := range opt.Choices {
+			if err := validateChoiceName(choice.Name); err != nil {
+				return err
+			}
+			if !numberIsFinite(choice.Value) {
+				return fmt.Errorf("value of choice %q is not finite", choice.Name)
+			}
+		}
+		if opt.Min != nil && !numberIsFinite(*opt.Min) {
+			return errors.New("min value is not finite")
+		}
+		if opt.Max != nil && !numberIsFinite(*opt.Max) {
+			return errors.New("max value is not finite")
+		}
+		if opt.Min != nil && opt.Max != nil && *opt.Min > *opt.Max {
+			return fmt.Errorf("min value %g is greater than max value %g", *opt.Min, *opt.Max)
+		}
+	}
+
+	return nil
+}
+
+func validateChoices(n int, autocomplete bool) error {
+	switch {
+	case n > maxCommandChoices:
+		return &OverboundError{n, maxCommandChoices, "choices"}
+	case n > 0 && autocomplete:
+		return errors.New("choices cannot be combined with autocomplete")
+	}
+	return nil
+}
+
+func validateChoiceName(name string) error {
+	switch n := utf8.RuneCountInString(name); {
+	case n == 0:
+		return errors.New("choice name is empty")
+	case n > maxChoiceNameLength:
+		return &OverboundError{n, maxChoiceNameLength, fmt.Sprintf("choice name %q", name)}
+	}
+	return nil
+}
+
+func validateChoiceLocalizations(name string, localizations StringLocales) error {
+	for lang, localized := range localizations {
+		if !lang.IsValid() {
+			return fmt.Errorf("invalid language %q in localizations of choice %q", lang, name)
+		}
+		switch n := utf8.RuneCountInString(localized); {
+		case n == 0:
+			return fmt.Errorf("%s localization of choice %q is empty", lang, name)
+		case n > maxChoiceNameLength:
+			return &OverboundError{n, maxChoiceNameLength,
+				fmt.Sprintf("%s localization of choice %q", lang, name)}
+		}
+	}
+
+	return nil
+}
+
+func validateStringLength(min, max *int) error {
+	if min != nil && (*min < 0 || *min > maxStringOptionLength) {
+		return fmt.Errorf("min length %d is not within 0 and %d", *min, maxStringOptionLength)
+	}
+	if max != nil && (*max < 1 || *max > maxStringOptionLength) {
+		return fmt.Errorf("max length %d is not within 1 and %d", *max, maxStringOptionLength)
+	}
+	if min != nil && max != nil && *min > *max {
+		return fmt.Errorf("min length %d is greater than max length %d", *min, *max)
+	}
+	return nil
+}
+
+// integerInRange returns true if i is within the range of integer options,
+// which is what a double can represent exactly.
+func integerInRange(i int64) bool {
+	return i >= -maxIntegerOptionMagnitude && i <= maxIntegerOptionMagnitude
+}
+
+func numberIsFinite(f float64) bool {
+	return !math.IsNaN(f) && !math.IsInf(f, 0)
+}
+
+// TextLength returns the combined length of the names, descriptions and string
+// choice values of the options, localized into the given language. If lang is
+// empty, then the options aren't localized. Discord limits the combined length
+// of a chat input command's text to 4000 characters in every language.
+func (o CommandOptions) TextLength(lang Language) int {
+	var n int
+
+	for _, opt := range o {
+		if u, ok := opt.(*UnknownCommandOption); ok {
+			opt = u.Data()
+		}
+
+		switch opt := opt.(type) {
+		case *SubcommandGroupOption:
+			n += localizedLength(opt.OptionName, opt.OptionNameLocalizations, lang)
+			n += localizedLength(opt.Description, opt.DescriptionLocalizations, lang)
+			for _, sub := range opt.Subcommands {
+				n += subcommandTextLength(sub, lang)
+			}
+		case *SubcommandOption:
+			n += subcommandTextLength(opt, lang)
+		case CommandOptionValue:
+			n += optionValueTextLength(opt, lang)
+		}
+	}
+
+	return n
+}
+
+func subcommandTextLength(sub *SubcommandOption, lang Language) int {
+	n := localizedLength(sub.OptionName, sub.OptionNameLocalizations, lang)
+	n += localizedLength(sub.Description, sub.DescriptionLocalizations, lang)
+	for _, opt := range sub.Options {
+		n += optionValueTextLength(opt, lang)
+	}
+	return n
+}
+
+func optionValueTextLength(opt CommandOptionValue, lang Language) int {
+	names, descriptions := optionLocalizations(opt)
+
+	n := localizedLength(opt.Name(), names, lang)
+	n += localizedLength(optionDescription(opt), descriptions, lang)
+
+	switch opt := opt.(type) {
+	case *StringOption:
+		for _, choice := range opt.Choices {
+			n += localizedLength(choice.Name, choice.NameLocalizations, lang)
+			n += utf8.RuneCountInString(choice.Value)
+		}
+	case *IntegerOption:
+		for _, choice := range opt.Choices {
+			n += localizedLength(choice.Name, choice.NameLocalizations, lang)
+		}
+	case *NumberOption:
+		for _, choice := range opt.Choices {
+			n += localizedLength(choice.Name, choice.NameLocalizations, lang)
+		}
+	}
+
+	return n
+}
+
+// localizedLength returns the length of s localized into lang.
+func localizedLength(s string, localizations StringLocales, lang Language) int {
+	if localized, ok := localizations[lang]; ok && lang != "" {
+		s = localized
+	}
+	return utf8.RuneCountInString(s)
+}
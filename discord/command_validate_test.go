@@ -0,0 +1,239 @@
+package discord
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/diamondburned/arikawa/v3/utils/json/option"
+)
+
+func TestValidateCommandName(t *testing.T) {
+	valid := []string{"ping", "get-user", "snake_case", "日本語", "नमस्ते", "a1"}
+	for _, name := range valid {
+		if err := ValidateCommandName(name); err != nil {
+			t.Errorf("name %q: unexpected error: %v", name, err)
+		}
+	}
+
+	invalid := []string{"", "Ping", "has space", "emoji😀", strings.Repeat("a", 33)}
+	for _, name := range invalid {
+		if err := ValidateCommandName(name); err == nil {
+			t.Errorf("name %q: expected error", name)
+		}
+	}
+}
+
+func TestValidateCommandOptions(t *testing.T) {
+	tests := []struct {
+		name    string
+		options CommandOptions
+		err     string
+	}{
+		{
+			name: "valid",
+			options: CommandOptions{
+				&StringOption{
+					OptionName:  "query",
+					Description: "What to search for.",
+					Required:    true,
+					Choices:     []StringChoice{{Name: "All", Value: "all"}},
+				},
+				&IntegerOption{
+					OptionName:  "limit",
+					Description: "How many results to show.",
+					Min:         option.NewInt64(-1 << 53),
+					Max:         option.NewInt64(1 << 53),
+				},
+			},
+		},
+		{
+			name: "valid subcommands",
+			options: CommandOptions{
+				&SubcommandGroupOption{
+					OptionName:  "config",
+					Description: "Configure the bot.",
+					Subcommands: []*SubcommandOption{{
+						OptionName:  "get",
+						Description: "Get a setting.",
+						Options: []CommandOptionValue{
+							&StringOption{OptionName: "key", Description: "The key."},
+						},
+					}},
+				},
+				&SubcommandOption{OptionName: "ping", Description: "Ping."},
+			},
+		},
+		{
+			name: "mixed subcommands",
+			options: CommandOptions{
+				&SubcommandOption{OptionName: "ping", Description: "Ping."},
+				&BooleanOption{OptionName: "loud", Description: "Be loud."},
+			},
+			err: "subcommands cannot be mixed with other options",
+		},
+		{
+			name: "required after optional",
+			options: CommandOptions{
+				&UserOption{OptionName: "user", Description: "A user."},
+				&RoleOption{OptionName: "role", Description: "A role.", Required: true},
+			},
+			err: `required option "role" must come before optional option "user"`,
+		},
+		{
+			name: "duplicate name",
+			options: CommandOptions{
+				&UserOption{OptionName: "user", Description: "A user."},
+				&UserOption{OptionName: "user", Description: "Another user."},
+			},
+			err: `duplicate option name "user"`,
+		},
+		{
+			name: "empty description",
+			options: CommandOptions{
+				&UserOption{OptionName: "user"},
+			},
+			err: `option "user": description is empty`,
+		},
+		{
+			name: "long description",
+			options: CommandOptions{
+				&UserOption{OptionName: "user", Description: strings.Repeat("a", 101)},
+			},
+			err: `option "user": description overbound: 101 > 100`,
+		},
+		{
+			name: "too many choices",
+			options: CommandOptions{
+				&NumberOption{
+					OptionName:  "n",
+					Description: "A number.",
+					Choices:     make([]NumberChoice, 26),
+				},
+			},
+			err: `option "n": choices overbound: 26 > 25`,
+		},
+		{
+			name: "choices with autocomplete",
+			options: CommandOptions{
+				&StringOption{
+					OptionName:   "s",
+					Description:  "A string.",
+					Choices:      []StringChoice{{Name: "a", Value: "a"}},
+					Autocomplete: true,
+				},
+			},
+			err: `option "s": choices cannot be combined with autocomplete`,
+		},
+		{
+			name: "integer out of range",
+			options: CommandOptions{
+				&IntegerOption{
+					OptionName:  "n",
+					Description: "A number.",
+					Max:         option.NewInt64(1<<53 + 1),
+				},
+			},
+			err: `option "n": max value 9007199254740993 is out of range`,
+		},
+		{
+			name: "integer min over max",
+			options: CommandOptions{
+				&IntegerOption{
+					OptionName:  "n",
+					Description: "A number.",
+					Min:         option.NewInt64(10),
+					Max:         option.NewInt64(1),
+				},
+			},
+			err: `option "n": min value 10 is greater than max value 1`,
+		},
+		{
+			name: "string length",
+			options: CommandOptions{
+				&StringOption{
+					OptionName:  "s",
+					Description: "A string.",
+					MaxLength:   option.NewInt(6001),
+				},
+			},
+			err: `option "s": max length 6001 is not within 1 and 6000`,
+		},
+		{
+			name: "nested subcommand",
+			options: CommandOptions{
+				&SubcommandGroupOption{
+					OptionName:  "config",
+					Description: "Configure the bot.",
+					Subcommands: []*SubcommandOption{{
+						OptionName:  "get",
+						Description: "Get a setting.",
+						Options: []CommandOptionValue{
+							&StringOption{OptionName: "Key", Description: "The key."},
+						},
+					}},
+				},
+			},
+			err: `option "config": subcommand "get": invalid option name: name "Key" must be lowercase`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := ValidateCommandOptions(test.options)
+			if test.err == "" {
+				if err != nil {
+					t.Fatal("unexpected error:", err)
+				}
+				return
+			}
+
+			if err == nil {
+				t.Fatalf("expected error %q", test.err)
+			}
+			if err.Error() != test.err {
+				t.Fatalf("expected error %q, got %q", test.err, err)
+			}
+		})
+	}
+
+	t.Run("overbound", func(t *testing.T) {
+		err := ValidateCommandOptions(make(CommandOptions, 26))
+
+		var overbound *OverboundError
+		if !errors.As(err, &overbound) {
+			t.Fatal("expected OverboundError, got", err)
+		}
+	})
+}
+
+func TestValidateCommandOptionLocalizations(t *testing.T) {
+	options := CommandOptions{
+		&SubcommandOption{
+			OptionName:  "get",
+			Description: "Get a setting.",
+			Options: []CommandOptionValue{
+				&StringOption{
+					OptionName:  "key",
+					Description: "The key.",
+					Choices: []StringChoice{{
+						Name:              "Prefix",
+						Value:             "prefix",
+						NameLocalizations: StringLocales{"xx-YY": "Präfix"},
+					}},
+				},
+			},
+		},
+	}
+
+	// Languages that Discord adds later must not be rejected when sending.
+	if err := ValidateCommandOptions(options); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	err := ValidateCommandOptionLocalizations(options)
+	expect := `option "get": option "key": invalid language "xx-YY" in localizations of choice "Prefix"`
+	if err == nil || err.Error() != expect {
+		t.Fatalf("expected error %q, got %v", expect, err)
+	}
+}
@@ -66,6 +66,70 @@ func (c *ContainerComponents) Find(customID ComponentID) Component {
 	return nil
 }
 
+// Edit returns a copy of the components with fn called on a shallow copy of
+// every interactive component, in order. The components themselves are left
+// untouched, so it can be used on the components of a received message to
+// build the components of an update. For example, to disable the button that
+// was clicked:
+//
+//	components := ev.Message.Components.Edit(func(c discord.InteractiveComponent) {
+//		if b, ok := c.(*discord.ButtonComponent); ok && b.CustomID == data.CustomID {
+//			b.Disabled = true
+//		}
+//	})
+func (c ContainerComponents) Edit(fn func(InteractiveComponent)) ContainerComponents {
+	edited := make(ContainerComponents, len(c))
+
+	for i, container := range c {
+		row, ok := container.(*ActionRowComponent)
+		if !ok {
+			edited[i] = container
+			continue
+		}
+
+		newRow := make(ActionRowComponent, len(*row))
+		for j, component := range *row {
+			component = copyComponent(component)
+			fn(component)
+			newRow[j] = component
+		}
+
+		edited[i] = &newRow
+	}
+
+	return edited
+}
+
+// copyComponent returns a shallow copy of the given component.
+func copyComponent(c InteractiveComponent) InteractiveComponent {
+	switch c := c.(type) {
+	case *ButtonComponent:
+		cpy := *c
+		return &cpy
+	case *StringSelectComponent:
+		cpy := *c
+		cpy.Options = append([]SelectOption(nil), c.Options...)
+		return &cpy
+	case *TextInputComponent:
+		cpy := *c
+		return &cpy
+	case *UserSelectComponent:
+		cpy := *c
+		return &cpy
+	case *RoleSelectComponent:
+		cpy := *c
+		return &cpy
+	case *MentionableSelectComponent:
+		cpy := *c
+		return &cpy
+	case *ChannelSelectComponent:
+		cpy := *c
+		return &cpy
+	default:
+		return c
+	}
+}
+
 // Unmarshal unmarshals the components into the struct pointer v. Each struct
 // field must be exported and is of a supported type.
 //
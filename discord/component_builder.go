@@ -0,0 +1,187 @@
+package discord
+
+import (
+	"errors"
+	"fmt"
+)
+
+const (
+	maxActionRows     = 5
+	maxRowComponents  = 5
+	maxCustomIDLength = 100
+	maxSelectOptions  = 25
+)
+
+// ActionRowBuilder builds an ActionRowComponent. Its methods only add
+// components; the row is validated when Build or BuildComponents is called.
+//
+// Here's an example of how to use it:
+//
+//	components, err := discord.BuildComponents(
+//	    discord.NewActionRow().
+//	        Button(discord.TextButtonComponent(discord.PrimaryButtonStyle(), "Yes")).
+//	        Button(discord.TextButtonComponent(discord.DangerButtonStyle(), "No")),
+//	    discord.NewActionRow().
+//	        Select(&discord.StringSelectComponent{
+//	            CustomID: "choice",
+//	            Options:  []discord.SelectOption{{Label: "A", Value: "a"}},
+//	        }),
+//	)
+type ActionRowBuilder struct {
+	row ActionRowComponent
+}
+
+// NewActionRow creates a new empty ActionRowBuilder.
+func NewActionRow() *ActionRowBuilder {
+	return &ActionRowBuilder{}
+}
+
+// Button adds a button to the row. A row can have up to 5 buttons.
+func (b *ActionRowBuilder) Button(button ButtonComponent) *ActionRowBuilder {
+	b.row = append(b.row, &button)
+	return b
+}
+
+// Select adds a select menu, such as a *StringSelectComponent or a
+// *UserSelectComponent, to the row. A select menu must be alone in its row.
+func (b *ActionRowBuilder) Select(selectMenu InteractiveComponent) *ActionRowBuilder {
+	b.row = append(b.row, selectMenu)
+	return b
+}
+
+// TextInput adds a text input to the row. Text inputs can only be used in
+// modals, and must be alone in their row.
+func (b *ActionRowBuilder) TextInput(input TextInputComponent) *ActionRowBuilder {
+	b.row = append(b.row, &input)
+	return b
+}
+
+// Build validates the row on its own and returns it.
+func (b *ActionRowBuilder) Build() (*ActionRowComponent, error) {
+	row := make(ActionRowComponent, len(b.row))
+	copy(row, b.row)
+
+	if err := ValidateComponents(ContainerComponents{&row}); err != nil {
+		return nil, err
+	}
+
+	return &row, nil
+}
+
+// BuildComponents builds the given rows into ContainerComponents, validating
+// them with ValidateComponents.
+func BuildComponents(rows ...*ActionRowBuilder) (ContainerComponents, error) {
+	components := make(ContainerComponents, len(rows))
+	for i, b := range rows {
+		row := make(ActionRowComponent, len(b.row))
+		copy(row, b.row)
+		components[i] = &row
+	}
+
+	if err := ValidateComponents(components); err != nil {
+		return nil, err
+	}
+
+	return components, nil
+}
+
+// ValidateComponents checks the given components against Discord's
+// constraints, so that mistakes are caught before Discord rejects them with a
+// generic error. It checks that:
+//
+//   - there are at most 5 action rows, each with 1 to 5 components;
+//   - select menus and text inputs are alone in their row;
+//   - link buttons have no custom ID, and every other component has one;
+//   - custom IDs are at most 100 characters long and unique;
+//   - string select menus have 1 to 25 options.
+func ValidateComponents(components ContainerComponents) error {
+	if len(components) > maxActionRows {
+		return fmt.Errorf("too many action rows: %d > %d", len(components), maxActionRows)
+	}
+
+	ids := make(map[ComponentID]struct{})
+
+	for i, container := range components {
+		row, ok := container.(*ActionRowComponent)
+		if !ok {
+			return fmt.Errorf("row %d: unexpected %s component", i, container.Type())
+		}
+
+		if err := validateActionRow(*row, ids); err != nil {
+			return fmt.Errorf("row %d: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+func validateActionRow(row ActionRowComponent, ids map[ComponentID]struct{}) error {
+	switch {
+	case len(row) == 0:
+		return errors.New("action row is empty")
+	case len(row) > maxRowComponents:
+		return fmt.Errorf("too many components: %d > %d", len(row), maxRowComponents)
+	}
+
+	for i, component := range row {
+		if err := validateComponent(component, len(row), ids); err != nil {
+			return fmt.Errorf("component %d: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+func validateComponent(c InteractiveComponent, rowLen int, ids map[ComponentID]struct{}) error {
+	needsID := true
+
+	switch c := c.(type) {
+	case *ButtonComponent:
+		if _, ok := c.Style.(linkButtonStyle); ok {
+			if c.CustomID != "" {
+				return errors.New("link button cannot have a custom ID")
+			}
+			needsID = false
+		}
+	case *StringSelectComponent:
+		switch {
+		case len(c.Options) == 0:
+			return errors.New("select menu has no options")
+		case len(c.Options) > maxSelectOptions:
+			return fmt.Errorf("select menu has too many options: %d > %d",
+				len(c.Options), maxSelectOptions)
+		}
+		if rowLen > 1 {
+			return errors.New("select menu must be alone in its row")
+		}
+	case *UserSelectComponent, *RoleSelectComponent,
+		*MentionableSelectComponent, *ChannelSelectComponent:
+		if rowLen > 1 {
+			return errors.New("select menu must be alone in its row")
+		}
+	case *TextInputComponent:
+		if rowLen > 1 {
+			return errors.New("text input must be alone in its row")
+		}
+	}
+
+	if !needsID {
+		return nil
+	}
+
+	id := c.ID()
+
+	switch {
+	case id == "":
+		return fmt.Errorf("%s component is missing a custom ID", c.Type())
+	case len(id) > maxCustomIDLength:
+		return fmt.Errorf("custom ID %q is too long: %d > %d", id, len(id), maxCustomIDLength)
+	}
+
+	if _, ok := ids[id]; ok {
+		return fmt.Errorf("duplicate custom ID %q", id)
+	}
+	ids[id] = struct{}{}
+
+	return nil
+}
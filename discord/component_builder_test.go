@@ -0,0 +1,128 @@
+package discord
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBuildComponents(t *testing.T) {
+	components, err := BuildComponents(
+		NewActionRow().
+			Button(TextButtonComponent(PrimaryButtonStyle(), "Yes")).
+			Button(TextButtonComponent(DangerButtonStyle(), "No")).
+			Button(ButtonComponent{Style: LinkButtonStyle("https://example.com"), Label: "Docs"}),
+		NewActionRow().
+			Select(&StringSelectComponent{
+				CustomID: "choice",
+				Options:  []SelectOption{{Label: "A", Value: "a"}},
+			}),
+	)
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if len(components) != 2 {
+		t.Fatalf("expected 2 rows, got %d", len(components))
+	}
+
+	if components.Find("choice") == nil {
+		t.Fatal("select menu not found")
+	}
+}
+
+func TestBuildComponentsErrors(t *testing.T) {
+	button := func(id string) ButtonComponent {
+		return ButtonComponent{Style: PrimaryButtonStyle(), CustomID: ComponentID(id)}
+	}
+	selectMenu := &StringSelectComponent{
+		CustomID: "select",
+		Options:  []SelectOption{{Label: "A", Value: "a"}},
+	}
+
+	tests := []struct {
+		name string
+		rows []*ActionRowBuilder
+		err  string
+	}{
+		{
+			name: "too many rows",
+			rows: []*ActionRowBuilder{
+				NewActionRow().Button(button("1")),
+				NewActionRow().Button(button("2")),
+				NewActionRow().Button(button("3")),
+				NewActionRow().Button(button("4")),
+				NewActionRow().Button(button("5")),
+				NewActionRow().Button(button("6")),
+			},
+			err: "too many action rows",
+		},
+		{
+			name: "too many buttons",
+			rows: []*ActionRowBuilder{
+				NewActionRow().
+					Button(button("1")).Button(button("2")).Button(button("3")).
+					Button(button("4")).Button(button("5")).Button(button("6")),
+			},
+			err: "row 0: too many components",
+		},
+		{
+			name: "empty row",
+			rows: []*ActionRowBuilder{NewActionRow()},
+			err:  "row 0: action row is empty",
+		},
+		{
+			name: "select not alone",
+			rows: []*ActionRowBuilder{
+				NewActionRow().Button(button("1")).Select(selectMenu),
+			},
+			err: "row 0: component 1: select menu must be alone in its row",
+		},
+		{
+			name: "duplicate custom ID",
+			rows: []*ActionRowBuilder{
+				NewActionRow().Button(button("1")),
+				NewActionRow().Button(button("1")),
+			},
+			err: `row 1: component 0: duplicate custom ID "1"`,
+		},
+		{
+			name: "missing custom ID",
+			rows: []*ActionRowBuilder{NewActionRow().Button(button(""))},
+			err:  "missing a custom ID",
+		},
+		{
+			name: "link button with custom ID",
+			rows: []*ActionRowBuilder{
+				NewActionRow().Button(ButtonComponent{
+					Style:    LinkButtonStyle("https://example.com"),
+					CustomID: "link",
+				}),
+			},
+			err: "link button cannot have a custom ID",
+		},
+		{
+			name: "custom ID too long",
+			rows: []*ActionRowBuilder{NewActionRow().Button(button(strings.Repeat("a", 101)))},
+			err:  "is too long",
+		},
+		{
+			name: "select without options",
+			rows: []*ActionRowBuilder{
+				NewActionRow().Select(&StringSelectComponent{CustomID: "select"}),
+			},
+			err: "select menu has no options",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := BuildComponents(test.rows...)
+			if err == nil {
+				t.Fatal("unexpected nil error")
+			}
+			if !strings.Contains(err.Error(), test.err) {
+				t.Fatalf("expected error containing %q, got %q", test.err, err)
+			}
+		})
+	}
+}
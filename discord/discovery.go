@@ -0,0 +1,51 @@
+package discord
+
+// DiscoveryCategoryID is the ID of a discovery category. Unlike most IDs, it is
+// a small integer rather than a snowflake.
+type DiscoveryCategoryID int
+
+// DiscoveryMetadata is the Server Discovery metadata of a guild.
+//
+// https://discord.com/developers/docs/resources/discovery#discovery-metadata-object
+type DiscoveryMetadata struct {
+	// GuildID is the ID of the guild.
+	GuildID GuildID `json:"guild_id"`
+	// PrimaryCategoryID is the ID of the primary discovery category of the
+	// guild.
+	PrimaryCategoryID DiscoveryCategoryID `json:"primary_category_id"`
+	// Keywords are up to 10 discovery search keywords.
+	Keywords []string `json:"keywords"`
+	// EmojiDiscoverabilityEnabled is whether guild info is shown when custom
+	// emojis of the guild are clicked.
+	EmojiDiscoverabilityEnabled bool `json:"emoji_discoverability_enabled"`
+	// PartnerActionedTimestamp is when the guild's partner application was
+	// accepted or denied, for applications via Server Settings.
+	PartnerActionedTimestamp Timestamp `json:"partner_actioned_timestamp,omitempty"`
+	// PartnerApplicationTimestamp is when the guild applied for partnership,
+	// if it has a pending application.
+	PartnerApplicationTimestamp Timestamp `json:"partner_application_timestamp,omitempty"`
+	// CategoryIDs are the IDs of up to 5 discovery subcategories of the guild.
+	CategoryIDs []DiscoveryCategoryID `json:"category_ids"`
+}
+
+// DiscoveryCategory is a category that guilds can be listed under in Server
+// Discovery.
+//
+// https://discord.com/developers/docs/resources/discovery#discovery-category-object
+type DiscoveryCategory struct {
+	// ID is the ID of the category.
+	ID DiscoveryCategoryID `json:"id"`
+	// Name is the name of the category.
+	Name DiscoveryCategoryName `json:"name"`
+	// IsPrimary is whether the category can be set as a guild's primary
+	// category.
+	IsPrimary bool `json:"is_primary"`
+}
+
+// DiscoveryCategoryName is the localized name of a discovery category.
+type DiscoveryCategoryName struct {
+	// Default is the name in English.
+	Default string `json:"default"`
+	// Localizations maps language codes to the localized names.
+	Localizations StringLocales `json:"localizations,omitempty"`
+}
@@ -1,9 +1,13 @@
 package discord
 
 import (
+	"errors"
+	"fmt"
 	"net/url"
 	"strings"
 	"time"
+	"unicode"
+	"unicode/utf8"
 )
 
 // https://discord.com/developers/docs/resources/emoji#emoji-object
@@ -115,7 +119,97 @@ func (e APIEmoji) PathString() string {
 	return url.PathEscape(string(e))
 }
 
-// APIString returns a string usable for sending over to the API.
+// ErrInvalidAPIEmoji is returned by ParseAPIEmoji if the given string is not a
+// valid emoji.
+var ErrInvalidAPIEmoji = errors.New("invalid emoji")
+
+// ParseAPIEmoji parses the given string into an APIEmoji. Other than the
+// APIEmoji format itself, it accepts custom emojis in the format that the
+// client uses, such as "<:name:123>", "<a:name:123>" and ":name:123", and
+// unicode emojis as-is. Emoji shortcodes such as ":thumbsup:" are not
+// supported, since they have no custom emoji ID and are not unicode emojis.
+//
+// The returned error wraps ErrInvalidAPIEmoji.
+func ParseAPIEmoji(s string) (APIEmoji, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return "", fmt.Errorf("%w: empty string", ErrInvalidAPIEmoji)
+	}
+
+	if !strings.Contains(s, ":") {
+		// Unicode emojis always have at least a non-ASCII rune; this catches
+		// names of emojis given without an ID.
+		for _, r := range s {
+			if r >= utf8.RuneSelf {
+				return APIEmoji(s), nil
+			}
+		}
+		return "", fmt.Errorf("%w: %q is neither unicode nor name:id", ErrInvalidAPIEmoji, s)
+	}
+
+	custom := strings.TrimPrefix(s, "<")
+	custom = strings.TrimSuffix(custom, ">")
+	custom = strings.TrimPrefix(custom, "a:")
+	custom = strings.TrimPrefix(custom, ":")
+
+	parts := strings.Split(custom, ":")
+	if len(parts) != 2 {
+		return "", fmt.Errorf("%w: %q is not in the name:id format", ErrInvalidAPIEmoji, s)
+	}
+
+	name := parts[0]
+	if name == "" || strings.IndexFunc(name, unicode.IsSpace) > -1 {
+		return "", fmt.Errorf("%w: %q has an invalid name", ErrInvalidAPIEmoji, s)
+	}
+
+	id, err := ParseSnowflake(parts[1])
+	if err != nil || !id.IsValid() {
+		return "", fmt.Errorf("%w: %q has an invalid ID", ErrInvalidAPIEmoji, s)
+	}
+
+	return NewAPIEmoji(EmojiID(id), name), nil
+}
+
+// IsCustom returns whether the APIEmoji is a custom emoji.
+func (e APIEmoji) IsCustom() bool {
+	return strings.Contains(string(e), ":")
+}
+
+// Name returns the name of the custom emoji, or the unicode emoji itself.
+func (e APIEmoji) Name() string {
+	name, _ := e.split()
+	return name
+}
+
+// ID returns the ID of the custom emoji. If the emoji is a unicode emoji, then
+// an invalid ID is returned.
+func (e APIEmoji) ID() EmojiID {
+	_, id := e.split()
+	return id
+}
+
+// Emoji returns a partial Emoji with only the ID and name.
+func (e APIEmoji) Emoji() Emoji {
+	name, id := e.split()
+	return Emoji{ID: id, Name: name}
+}
+
+func (e APIEmoji) split() (string, EmojiID) {
+	i := strings.LastIndexByte(string(e), ':')
+	if i == -1 {
+		return string(e), 0
+	}
+
+	id, err := ParseSnowflake(string(e[i+1:]))
+	if err != nil {
+		return string(e[:i]), 0
+	}
+
+	return string(e[:i]), EmojiID(id)
+}
+
+// APIString returns a string usable for sending over to the API. It can be
+// used on the emojis of reaction events and reaction objects.
 func (e Emoji) APIString() APIEmoji {
 	if e.IsUnicode() {
 		return APIEmoji(e.Name)
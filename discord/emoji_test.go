@@ -0,0 +1,62 @@
+package discord
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseAPIEmoji(t *testing.T) {
+	tests := []struct {
+		in     string
+		out    APIEmoji
+		path   string
+		errors bool
+	}{
+		{in: "👍", out: "👍", path: "%F0%9F%91%8D"},
+		{in: "1️⃣", out: "1️⃣", path: "1%EF%B8%8F%E2%83%A3"},
+		{in: "arikawa:123", out: "arikawa:123", path: "arikawa:123"},
+		{in: "<:arikawa:123>", out: "arikawa:123", path: "arikawa:123"},
+		{in: "<a:arikawa:123>", out: "arikawa:123", path: "arikawa:123"},
+		{in: ":arikawa:123", out: "arikawa:123", path: "arikawa:123"},
+		{in: "", errors: true},
+		{in: "thumbsup", errors: true},
+		{in: ":thumbsup:", errors: true},
+		{in: "arikawa:abc", errors: true},
+		{in: "<:arikawa:>", errors: true},
+	}
+
+	for _, test := range tests {
+		emoji, err := ParseAPIEmoji(test.in)
+		if test.errors {
+			if !errors.Is(err, ErrInvalidAPIEmoji) {
+				t.Errorf("%q: expected ErrInvalidAPIEmoji, got %v", test.in, err)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("%q: unexpected error: %v", test.in, err)
+			continue
+		}
+
+		if emoji != test.out {
+			t.Errorf("%q: expected %q, got %q", test.in, test.out, emoji)
+		}
+
+		if path := emoji.PathString(); path != test.path {
+			t.Errorf("%q: expected path %q, got %q", test.in, test.path, path)
+		}
+	}
+}
+
+func TestAPIEmojiEmoji(t *testing.T) {
+	custom := Emoji{ID: 123, Name: "arikawa"}
+	if got := custom.APIString().Emoji(); got.ID != custom.ID || got.Name != custom.Name {
+		t.Errorf("expected %v, got %v", custom, got)
+	}
+
+	unicode := Emoji{Name: "👍"}
+	if got := unicode.APIString(); got.IsCustom() || got.ID().IsValid() || got.Name() != "👍" {
+		t.Errorf("unexpected unicode APIEmoji %q", got)
+	}
+}
@@ -3,6 +3,8 @@ package discord
 import (
 	"sort"
 	"time"
+
+	"github.com/diamondburned/arikawa/v3/utils/json"
 )
 
 // https://discord.com/developers/docs/resources/guild#guild-object
@@ -123,6 +125,16 @@ func (g Guild) CreatedAt() time.Time {
 	return g.ID.Time()
 }
 
+// HasFeature returns true if the guild has the given feature enabled.
+func (g Guild) HasFeature(feature GuildFeature) bool {
+	for _, f := range g.Features {
+		if f == feature {
+			return true
+		}
+	}
+	return false
+}
+
 // IconURL returns the URL to the guild icon and auto detects a suitable type.
 // An empty string is returned if there's no icon.
 func (g Guild) IconURL() string {
@@ -198,6 +210,61 @@ func (g Guild) DiscoverySplashURLWithType(t ImageType) string {
 		g.ID.String() + "/" + t.format(g.DiscoverySplash)
 }
 
+// DefaultChannel returns the first text or announcement channel that the
+// given member can read, in the order that the channels are shown in the
+// Discord client: channels outside of categories first, then channels by the
+// position of their categories, each by their own position. The guild's Roles
+// are used to calculate the member's permissions. Nil is returned if the
+// member cannot read any of the channels.
+//
+// Channels with a zero GuildID are treated as the guild's, since Discord omits
+// it in the channels of a guild, such as in GuildCreateEvent. The returned
+// pointer points to an element within channels.
+func (g Guild) DefaultChannel(channels []Channel, member Member) *Channel {
+	categories := make(map[ChannelID]int)
+	for _, ch := range channels {
+		if ch.Type == GuildCategory {
+			categories[ch.ID] = ch.Position
+		}
+	}
+
+	categoryPosition := func(ch *Channel) int {
+		if pos, ok := categories[ch.ParentID]; ok {
+			return pos
+		}
+		return -1
+	}
+
+	less := func(a, b *Channel) bool {
+		if ca, cb := categoryPosition(a), categoryPosition(b); ca != cb {
+			return ca < cb
+		}
+		if a.Position != b.Position {
+			return a.Position < b.Position
+		}
+		return a.ID < b.ID
+	}
+
+	var found *Channel
+
+	for i := range channels {
+		ch := &channels[i]
+		if (ch.GuildID.IsValid() && ch.GuildID != g.ID) || (ch.Type != GuildText && ch.Type != GuildAnnouncement) {
+			continue
+		}
+		if found != nil && !less(ch, found) {
+			continue
+		}
+
+		perms := CalcPermissions(g, *ch, nil, member, g.Roles)
+		if perms.Has(PermissionViewChannel | PermissionReadMessageHistory) {
+			found = ch
+		}
+	}
+
+	return found
+}
+
 // https://discord.com/developers/docs/resources/guild#guild-object-guild-nsfw-level
 type NSFWLevel uint8
 
@@ -329,13 +396,86 @@ type Role struct {
 	Tags RoleTags `json:"tags,omitempty"`
 }
 
+// RoleTags contains the tags of a role, which describe what the role is for.
+//
+// https://discord.com/developers/docs/topics/permissions#role-object-role-tags-structure
 type RoleTags struct {
 	// BotID is the id of the bot this role belongs to.
 	BotID UserID `json:"bot_id,omitempty"`
 	// IntegrationID is the id of the integration this role belongs to.
 	IntegrationID IntegrationID `json:"integration_id,omitempty"`
 	// PremiumSubscriber specifies whether this is the guild's premium subscriber role.
-	PremiumSubscriber bool `json:"premium_subscriber,omitempty"`
+	PremiumSubscriber bool `json:"-"`
+	// SubscriptionListingID is the id of this role's subscription SKU and
+	// listing.
+	SubscriptionListingID Snowflake `json:"subscription_listing_id,omitempty"`
+	// AvailableForPurchase specifies whether this role is available for
+	// purchase.
+	AvailableForPurchase bool `json:"-"`
+	// GuildConnections specifies whether this role is a guild's linked role.
+	GuildConnections bool `json:"-"`
+}
+
+// roleTagsBools contains the boolean tags of a role. Discord represents true
+// by sending the key with a null value and false by omitting the key entirely,
+// so a non-nil Raw means true.
+type roleTagsBools struct {
+	PremiumSubscriber    json.Raw `json:"premium_subscriber,omitempty"`
+	AvailableForPurchase json.Raw `json:"available_for_purchase,omitempty"`
+	GuildConnections     json.Raw `json:"guild_connections,omitempty"`
+}
+
+func roleTagBool(b bool) json.Raw {
+	if b {
+		return json.Raw("null")
+	}
+	return nil
+}
+
+// IsBot returns true if the role is managed by a bot.
+func (t RoleTags) IsBot() bool {
+	return t.BotID.IsValid()
+}
+
+// IsBooster returns true if the role is the guild's booster role.
+func (t RoleTags) IsBooster() bool {
+	return t.PremiumSubscriber
+}
+
+// MarshalJSON implements json.Marshaler.
+func (t RoleTags) MarshalJSON() ([]byte, error) {
+	type rawTags RoleTags
+	return json.Marshal(struct {
+		rawTags
+		roleTagsBools
+	}{
+		rawTags: rawTags(t),
+		roleTagsBools: roleTagsBools{
+			PremiumSubscriber:    roleTagBool(t.PremiumSubscriber),
+			AvailableForPurchase: roleTagBool(t.AvailableForPurchase),
+			GuildConnections:     roleTagBool(t.GuildConnections),
+		},
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (t *RoleTags) UnmarshalJSON(b []byte) error {
+	type rawTags RoleTags
+
+	var tags struct {
+		*rawTags
+		roleTagsBools
+	}
+	tags.rawTags = (*rawTags)(t)
+
+	if err := json.Unmarshal(b, &tags); err != nil {
+		return err
+	}
+
+	t.PremiumSubscriber = tags.roleTagsBools.PremiumSubscriber != nil
+	t.AvailableForPurchase = tags.roleTagsBools.AvailableForPurchase != nil
+	t.GuildConnections = tags.roleTagsBools.GuildConnections != nil
+	return nil
 }
 
 // CreatedAt returns a time object representing when the role was created.
@@ -413,6 +553,12 @@ func (m Member) Mention() string {
 	return "<@" + m.User.ID.String() + ">"
 }
 
+// IsTimedOut returns true if the member is currently timed out.
+func (m Member) IsTimedOut() bool {
+	return m.CommunicationDisabledUntil.IsValid() &&
+		m.CommunicationDisabledUntil.Time().After(time.Now())
+}
+
 // AvatarURL returns the URL of the Avatar Image. It automatically detects a
 // suitable type.
 func (m Member) AvatarURL(guildID GuildID) string {
@@ -20,6 +20,37 @@ const (
 	NitroLevel3
 )
 
+// Size limits of files uploaded to Discord, in bytes.
+const (
+	// MaxEmojiSize is the maximum size of an emoji image.
+	MaxEmojiSize = 256 * 1024
+	// MaxStickerSize is the maximum size of a sticker file.
+	MaxStickerSize = 512 * 1024
+
+	// DefaultMaxUploadSize is the maximum size of each file attached to a
+	// message in DMs and in guilds below NitroLevel2.
+	DefaultMaxUploadSize = 10 * 1024 * 1024
+	// NitroLevel2MaxUploadSize is the maximum size of each file attached to a
+	// message in guilds at NitroLevel2.
+	NitroLevel2MaxUploadSize = 50 * 1024 * 1024
+	// NitroLevel3MaxUploadSize is the maximum size of each file attached to a
+	// message in guilds at NitroLevel3.
+	NitroLevel3MaxUploadSize = 100 * 1024 * 1024
+)
+
+// MaxUploadSize returns the maximum size of each file attached to a message in
+// a guild with this premium tier, in bytes.
+func (n NitroBoost) MaxUploadSize() int64 {
+	switch {
+	case n >= NitroLevel3:
+		return NitroLevel3MaxUploadSize
+	case n == NitroLevel2:
+		return NitroLevel2MaxUploadSize
+	default:
+		return DefaultMaxUploadSize
+	}
+}
+
 // MFALevel is the required MFA level for a guild.
 type MFALevel uint8
 
@@ -39,6 +70,7 @@ const (
 	SuppressPremiumSubscriptions
 )
 
+// GuildFeature is a feature that a guild has enabled.
 type GuildFeature string
 
 // https://discord.com/developers/docs/resources/guild#guild-object-guild-features
@@ -72,8 +104,69 @@ const (
 	AnimatedIcon GuildFeature = "ANIMATED_ICON"
 	// Banner is set, if the guild has access to set a guild banner image.
 	Banner GuildFeature = "BANNER"
+	// AnimatedBanner is set, if the guild has access to set an animated guild
+	// banner image.
+	AnimatedBanner GuildFeature = "ANIMATED_BANNER"
+	// ApplicationCommandPermissionsV2 is set, if the guild is using the old
+	// permissions configuration behavior.
+	ApplicationCommandPermissionsV2 GuildFeature = "APPLICATION_COMMAND_PERMISSIONS_V2"
+	// AutoModeration is set, if the guild has set up auto moderation rules.
+	AutoModeration GuildFeature = "AUTO_MODERATION"
+	// Community is set, if the guild can enable welcome screen, Membership
+	// Screening, stage channels and discovery, and receives community updates.
+	Community GuildFeature = "COMMUNITY"
+	// CreatorMonetizableProvisional is set, if the guild has enabled
+	// monetization.
+	CreatorMonetizableProvisional GuildFeature = "CREATOR_MONETIZABLE_PROVISIONAL"
+	// CreatorStorePage is set, if the guild has enabled the role subscription
+	// promo page.
+	CreatorStorePage GuildFeature = "CREATOR_STORE_PAGE"
+	// DeveloperSupportServer is set, if the guild has been set as a support
+	// server on the App Directory.
+	DeveloperSupportServer GuildFeature = "DEVELOPER_SUPPORT_SERVER"
+	// InvitesDisabled is set, if the guild has paused invites, preventing new
+	// users from joining.
+	InvitesDisabled GuildFeature = "INVITES_DISABLED"
+	// MemberVerificationGateEnabled is set, if the guild has enabled
+	// Membership Screening.
+	MemberVerificationGateEnabled GuildFeature = "MEMBER_VERIFICATION_GATE_ENABLED"
+	// MoreStickers is set, if the guild has increased custom sticker slots.
+	MoreStickers GuildFeature = "MORE_STICKERS"
+	// PreviewEnabled is set, if the guild can be previewed before joining via
+	// Membership Screening or the directory.
+	PreviewEnabled GuildFeature = "PREVIEW_ENABLED"
+	// RaidAlertsDisabled is set, if the guild has disabled alerts for join
+	// raids in the configured safety alerts channel.
+	RaidAlertsDisabled GuildFeature = "RAID_ALERTS_DISABLED"
+	// RoleIcons is set, if the guild is able to set role icons.
+	RoleIcons GuildFeature = "ROLE_ICONS"
+	// RoleSubscriptionsAvailableForPurchase is set, if the guild has role
+	// subscriptions that can be purchased.
+	RoleSubscriptionsAvailableForPurchase GuildFeature = "ROLE_SUBSCRIPTIONS_AVAILABLE_FOR_PURCHASE"
+	// RoleSubscriptionsEnabled is set, if the guild has enabled role
+	// subscriptions.
+	RoleSubscriptionsEnabled GuildFeature = "ROLE_SUBSCRIPTIONS_ENABLED"
+	// TicketedEventsEnabled is set, if the guild has enabled ticketed events.
+	TicketedEventsEnabled GuildFeature = "TICKETED_EVENTS_ENABLED"
+	// WelcomeScreenEnabled is set, if the guild has enabled the welcome
+	// screen.
+	WelcomeScreenEnabled GuildFeature = "WELCOME_SCREEN_ENABLED"
 )
 
+// IsMutable returns true if the feature can be enabled or disabled through
+// the Features field when modifying a guild. Only Community, Discoverable,
+// InvitesDisabled and RaidAlertsDisabled are mutable.
+//
+// https://discord.com/developers/docs/resources/guild#guild-object-mutable-guild-features
+func (f GuildFeature) IsMutable() bool {
+	switch f {
+	case Community, Discoverable, InvitesDisabled, RaidAlertsDisabled:
+		return true
+	default:
+		return false
+	}
+}
+
 // ExplicitFilter is the explicit content filter level of a guild.
 type ExplicitFilter enum.Enum
 
@@ -0,0 +1,119 @@
+package discord
+
+import (
+	"testing"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/utils/json"
+)
+
+func TestGuildDefaultChannel(t *testing.T) {
+	const guildID = GuildID(1)
+
+	guild := Guild{
+		ID:      guildID,
+		OwnerID: 100,
+		Roles: []Role{
+			{ID: RoleID(guildID), Permissions: PermissionViewChannel | PermissionReadMessageHistory},
+		},
+	}
+
+	hidden := []Overwrite{{ID: Snowflake(guildID), Type: OverwriteRole, Deny: PermissionViewChannel}}
+
+	channels := []Channel{
+		{ID: 2, GuildID: guildID, Type: GuildCategory, Position: 1},
+		{ID: 3, GuildID: guildID, Type: GuildCategory, Position: 0},
+		{ID: 4, GuildID: guildID, Type: GuildText, Position: 0, ParentID: 2},
+		{ID: 5, GuildID: guildID, Type: GuildText, Position: 1, ParentID: 3},
+		{ID: 6, GuildID: guildID, Type: GuildText, Position: 0, ParentID: 3, Overwrites: hidden},
+		{ID: 7, GuildID: guildID, Type: GuildVoice, Position: 0},
+	}
+
+	member := Member{User: User{ID: 200}}
+
+	ch := guild.DefaultChannel(channels, member)
+	if ch == nil || ch.ID != 5 {
+		t.Fatalf("expected channel 5, got %v", ch)
+	}
+
+	// The owner can read the hidden channel.
+	owner := Member{User: User{ID: guild.OwnerID}}
+	if ch := guild.DefaultChannel(channels, owner); ch == nil || ch.ID != 6 {
+		t.Fatalf("expected channel 6 for owner, got %v", ch)
+	}
+
+	if ch := guild.DefaultChannel(channels[:2], member); ch != nil {
+		t.Fatalf("expected no channel, got %v", ch.ID)
+	}
+
+	// Channels of other guilds are skipped, while channels without a guild ID
+	// belong to the guild.
+	channels = []Channel{
+		{ID: 8, GuildID: 2, Type: GuildText, Position: 0},
+		{ID: 9, Type: GuildText, Position: 1},
+	}
+	if ch := guild.DefaultChannel(channels, member); ch == nil || ch.ID != 9 {
+		t.Fatalf("expected channel 9, got %v", ch)
+	}
+}
+
+func TestRoleTags(t *testing.T) {
+	const raw = `{"bot_id":"1","premium_subscriber":null,"guild_connections":null}`
+
+	var tags RoleTags
+	if err := json.Unmarshal([]byte(raw), &tags); err != nil {
+		t.Fatal("failed to unmarshal:", err)
+	}
+
+	expect := RoleTags{
+		BotID:             1,
+		PremiumSubscriber: true,
+		GuildConnections:  true,
+	}
+	if tags != expect {
+		t.Fatalf("unexpected tags %+v", tags)
+	}
+	if !tags.IsBot() || !tags.IsBooster() {
+		t.Fatal("expected bot and booster role")
+	}
+
+	b, err := json.Marshal(tags)
+	if err != nil {
+		t.Fatal("failed to marshal:", err)
+	}
+
+	var roundTrip RoleTags
+	if err := json.Unmarshal(b, &roundTrip); err != nil {
+		t.Fatal("failed to unmarshal marshaled tags:", err)
+	}
+	if roundTrip != expect {
+		t.Fatalf("unexpected round-tripped tags %+v from %s", roundTrip, b)
+	}
+
+	var empty RoleTags
+	if err := json.Unmarshal([]byte(`{"bot_id":"1"}`), &empty); err != nil {
+		t.Fatal("failed to unmarshal:", err)
+	}
+	if empty.IsBooster() || empty.GuildConnections || empty.AvailableForPurchase {
+		t.Fatalf("unexpected true boolean tags %+v", empty)
+	}
+}
+
+func TestMemberIsTimedOut(t *testing.T) {
+	tests := []struct {
+		name  string
+		until Timestamp
+		out   bool
+	}{
+		{"none", Timestamp{}, false},
+		{"expired", NewTimestamp(time.Now().Add(-time.Minute)), false},
+		{"active", NewTimestamp(time.Now().Add(time.Minute)), true},
+	}
+
+	for _, test := range tests {
+		member := Member{CommunicationDisabledUntil: test.until}
+		if out := member.IsTimedOut(); out != test.out {
+			t.Errorf("%s: expected %v, got %v", test.name, test.out, out)
+		}
+	}
+}
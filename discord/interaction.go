@@ -44,6 +44,15 @@ type InteractionEvent struct {
 	Locale Language `json:"locale,omitempty"`
 	// GuildLocale is the guild's preferred locale, if invoked in a guild.
 	GuildLocale string `json:"guild_locale,omitempty"`
+
+	// AuthorizingIntegrationOwners maps the installation contexts that the
+	// interaction was authorized for to their owner IDs. For GuildInstall,
+	// the ID is the guild ID, or 0 if the interaction was triggered from the
+	// bot's DM. For UserInstall, the ID is the authorizing user's ID.
+	AuthorizingIntegrationOwners map[ApplicationIntegrationType]Snowflake `json:"authorizing_integration_owners,omitempty"`
+	// Context is the context where the interaction was triggered from. It is
+	// nil if Discord did not send it.
+	Context *InteractionContextType `json:"context,omitempty"`
 }
 
 // Sender returns the sender of this event from either the Member field or the
@@ -333,6 +342,23 @@ func (s *StringSelectInteraction) data() {}
 type ChannelSelectInteraction struct {
 	CustomID ComponentID `json:"custom_id"`
 	Values   []ChannelID `json:"values"`
+	// Resolved contains the full objects of the selected values.
+	Resolved ResolvedData `json:"resolved"`
+}
+
+// ChannelIDs returns the IDs of the selected channels.
+func (s *ChannelSelectInteraction) ChannelIDs() []ChannelID { return s.Values }
+
+// Channels returns the partial channel objects of the selected channels in the
+// same order as Values. Channels that are not resolved are skipped.
+func (s *ChannelSelectInteraction) Channels() []Channel {
+	channels := make([]Channel, 0, len(s.Values))
+	for _, id := range s.Values {
+		if ch, ok := s.Resolved.Channels[id]; ok {
+			channels = append(channels, ch)
+		}
+	}
+	return channels
 }
 
 // ID implements ComponentInteraction.
@@ -353,6 +379,23 @@ func (s *ChannelSelectInteraction) data() {}
 type RoleSelectInteraction struct {
 	CustomID ComponentID `json:"custom_id"`
 	Values   []RoleID    `json:"values"`
+	// Resolved contains the full objects of the selected values.
+	Resolved ResolvedData `json:"resolved"`
+}
+
+// RoleIDs returns the IDs of the selected roles.
+func (s *RoleSelectInteraction) RoleIDs() []RoleID { return s.Values }
+
+// Roles returns the selected roles in the same order as Values. Roles that are
+// not resolved are skipped.
+func (s *RoleSelectInteraction) Roles() []Role {
+	roles := make([]Role, 0, len(s.Values))
+	for _, id := range s.Values {
+		if role, ok := s.Resolved.Roles[id]; ok {
+			roles = append(roles, role)
+		}
+	}
+	return roles
 }
 
 // ID implements ComponentInteraction.
@@ -373,6 +416,30 @@ func (s *RoleSelectInteraction) data() {}
 type UserSelectInteraction struct {
 	CustomID ComponentID `json:"custom_id"`
 	Values   []UserID    `json:"values"`
+	// Resolved contains the full objects of the selected values.
+	Resolved ResolvedData `json:"resolved"`
+}
+
+// UserIDs returns the IDs of the selected users.
+func (s *UserSelectInteraction) UserIDs() []UserID { return s.Values }
+
+// Users returns the selected users in the same order as Values. Users that are
+// not resolved are skipped.
+func (s *UserSelectInteraction) Users() []User {
+	users := make([]User, 0, len(s.Values))
+	for _, id := range s.Values {
+		if user, ok := s.Resolved.Users[id]; ok {
+			users = append(users, user)
+		}
+	}
+	return users
+}
+
+// Member returns the partial member of the selected user with the given ID.
+// The member's User field is filled in from the resolved users. False is
+// returned if the select was not used in a guild or the user is not resolved.
+func (s *UserSelectInteraction) Member(id UserID) (Member, bool) {
+	return s.Resolved.member(id)
 }
 
 // ID implements ComponentInteraction.
@@ -393,6 +460,30 @@ func (s *UserSelectInteraction) data() {}
 type MentionableSelectInteraction struct {
 	CustomID ComponentID `json:"custom_id"`
 	Values   []Snowflake `json:"values"`
+	// Resolved contains the full objects of the selected values.
+	Resolved ResolvedData `json:"resolved"`
+}
+
+// UserIDs returns the IDs of the selected users.
+func (s *MentionableSelectInteraction) UserIDs() []UserID {
+	var ids []UserID
+	for _, id := range s.Values {
+		if _, ok := s.Resolved.Users[UserID(id)]; ok {
+			ids = append(ids, UserID(id))
+		}
+	}
+	return ids
+}
+
+// RoleIDs returns the IDs of the selected roles.
+func (s *MentionableSelectInteraction) RoleIDs() []RoleID {
+	var ids []RoleID
+	for _, id := range s.Values {
+		if _, ok := s.Resolved.Roles[RoleID(id)]; ok {
+			ids = append(ids, RoleID(id))
+		}
+	}
+	return ids
 }
 
 // ID implements ComponentInteraction.
@@ -471,6 +562,38 @@ func ParseComponentInteraction(b []byte) (ComponentInteraction, error) {
 	return d, nil
 }
 
+// ResolvedData contains the full objects of the IDs referenced by an
+// interaction.
+//
+// https://discord.com/developers/docs/interactions/receiving-and-responding#interaction-object-resolved-data-structure
+type ResolvedData struct {
+	// User contains user objects.
+	Users map[UserID]User `json:"users,omitempty"`
+	// Members contains partial member objects (missing User, Deaf and
+	// Mute).
+	Members map[UserID]Member `json:"members,omitempty"`
+	// Role contains role objects.
+	Roles map[RoleID]Role `json:"roles,omitempty"`
+	// Channels contains partial channel objects that only have ID, Name,
+	// Type and Permissions. Threads will also have ThreadMetadata and
+	// ParentID.
+	Channels map[ChannelID]Channel `json:"channels,omitempty"`
+	// Messages contains partial message objects. All fields without
+	// omitempty are presumably present.
+	Messages map[MessageID]Message `json:"messages,omitempty"`
+	// Attachments contains attachments objects.
+	Attachments map[AttachmentID]Attachment `json:"attachments,omitempty"`
+}
+
+func (r ResolvedData) member(id UserID) (Member, bool) {
+	member, ok := r.Members[id]
+	if !ok {
+		return Member{}, false
+	}
+	member.User, ok = r.Users[id]
+	return member, ok
+}
+
 // CommandInteractionOptions is a list of interaction options.
 // Use `Find` to get your named interaction option
 type CommandInteractionOptions []CommandInteractionOption
@@ -487,24 +610,8 @@ type CommandInteraction struct {
 	//
 	// See TargetUserID and TargetMessageID
 	TargetID Snowflake `json:"target_id,omitempty"`
-	Resolved struct {
-		// User contains user objects.
-		Users map[UserID]User `json:"users,omitempty"`
-		// Members contains partial member objects (missing User, Deaf and
-		// Mute).
-		Members map[UserID]Member `json:"members,omitempty"`
-		// Role contains role objects.
-		Roles map[RoleID]Role `json:"roles,omitempty"`
-		// Channels contains partial channel objects that only have ID, Name,
-		// Type and Permissions. Threads will also have ThreadMetadata and
-		// ParentID.
-		Channels map[ChannelID]Channel `json:"channels,omitempty"`
-		// Messages contains partial message objects. All fields without
-		// omitempty are presumably present.
-		Messages map[MessageID]Message `json:"messages,omitempty"`
-		// Attachments contains attachments objects.
-		Attachments map[AttachmentID]Attachment `json:"attachments,omitempty"`
-	}
+	// Resolved contains the objects referenced by the options.
+	Resolved ResolvedData `json:"resolved"`
 }
 
 // InteractionType implements InteractionData.
@@ -0,0 +1,74 @@
+package discord
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSelectInteractionResolved(t *testing.T) {
+	const raw = `{
+		"component_type": 7,
+		"custom_id": "mentions",
+		"values": ["1", "2"],
+		"resolved": {
+			"users": {"1": {"id": "1", "username": "user"}},
+			"members": {"1": {"nick": "nick"}},
+			"roles": {"2": {"id": "2", "name": "role"}}
+		}
+	}`
+
+	data, err := ParseComponentInteraction([]byte(raw))
+	if err != nil {
+		t.Fatal("failed to parse:", err)
+	}
+
+	sel, ok := data.(*MentionableSelectInteraction)
+	if !ok {
+		t.Fatalf("unexpected type %T", data)
+	}
+
+	if ids := sel.UserIDs(); !reflect.DeepEqual(ids, []UserID{1}) {
+		t.Errorf("unexpected user IDs %v", ids)
+	}
+	if ids := sel.RoleIDs(); !reflect.DeepEqual(ids, []RoleID{2}) {
+		t.Errorf("unexpected role IDs %v", ids)
+	}
+
+	users := &UserSelectInteraction{Values: []UserID{1}, Resolved: sel.Resolved}
+	if u := users.Users(); len(u) != 1 || u[0].Username != "user" {
+		t.Errorf("unexpected users %v", u)
+	}
+
+	member, ok := users.Member(1)
+	if !ok || member.Nick != "nick" || member.User.ID != 1 {
+		t.Errorf("unexpected member %+v", member)
+	}
+}
+
+func TestInteractionEventContext(t *testing.T) {
+	const raw = `{
+		"id": "1",
+		"type": 1,
+		"application_id": "2",
+		"token": "token",
+		"version": 1,
+		"authorizing_integration_owners": {"0": "3", "1": "4"},
+		"context": 2
+	}`
+
+	var ev InteractionEvent
+	if err := ev.UnmarshalJSON([]byte(raw)); err != nil {
+		t.Fatal("failed to unmarshal:", err)
+	}
+
+	owners := map[ApplicationIntegrationType]Snowflake{
+		GuildInstall: 3,
+		UserInstall:  4,
+	}
+	if !reflect.DeepEqual(ev.AuthorizingIntegrationOwners, owners) {
+		t.Errorf("unexpected owners %v", ev.AuthorizingIntegrationOwners)
+	}
+	if ev.Context == nil || *ev.Context != InteractionContextPrivateChannel {
+		t.Errorf("unexpected context %v", ev.Context)
+	}
+}
@@ -7,6 +7,8 @@ package discord
 type Invite struct {
 	// Code is the invite code (unique ID).
 	Code string `json:"code"`
+	// Type is the type of the invite.
+	Type InviteType `json:"type"`
 	// Guild is the partial guild this invite is for.
 	Guild *Guild `json:"guild,omitempty"`
 	// Channel is the partial channel this invite is for.
@@ -25,6 +27,19 @@ type Invite struct {
 	// ApproximateMembers is the approximate count of total members
 	ApproximateMembers uint `json:"approximate_member_count,omitempty"`
 
+	// ExpiresAt is the time at which the invite expires. It is only present
+	// when fetching the invite with expiration, and is invalid if the invite
+	// never expires.
+	ExpiresAt Timestamp `json:"expires_at,omitempty"`
+	// StageInstance is the public Stage instance data if there is a public
+	// Stage instance in the Stage channel this invite is for.
+	//
+	// Deprecated: Discord no longer sends this field.
+	StageInstance *InviteStageInstance `json:"stage_instance,omitempty"`
+	// GuildScheduledEvent is the guild scheduled event data. It is only
+	// present when fetching the invite with a scheduled event ID.
+	GuildScheduledEvent *GuildScheduledEvent `json:"guild_scheduled_event,omitempty"`
+
 	// InviteMetadata contains extra information about the invite.
 	// So far, this field is only available when fetching Channel- or
 	// GuildInvites. Additionally the Uses field is filled when getting the
@@ -42,12 +57,44 @@ func (i Invite) LongURL() string {
 	return "https://discord.com/invite/" + i.Code
 }
 
+// InviteType is the type of an invite.
+//
+// https://discord.com/developers/docs/resources/invite#invite-object-invite-types
+type InviteType uint8
+
+const (
+	// GuildInvite is the type of invites to a guild channel.
+	GuildInvite InviteType = iota
+	// GroupDMInvite is the type of invites to a group DM.
+	GroupDMInvite
+	// FriendInvite is the type of invites that add the inviter as a friend.
+	FriendInvite
+)
+
+// String returns the name of the invite type in lower case, or "unknown".
+func (t InviteType) String() string {
+	switch t {
+	case GuildInvite:
+		return "guild"
+	case GroupDMInvite:
+		return "group dm"
+	case FriendInvite:
+		return "friend"
+	default:
+		return "unknown"
+	}
+}
+
 // https://discord.com/developers/docs/resources/invite#invite-object-target-user-types
 type InviteUserType uint8
 
 const (
 	InviteNormalUser InviteUserType = iota
+	// InviteUserStream is the target type of invites to a user's stream.
 	InviteUserStream
+	// InviteEmbeddedApplication is the target type of invites to an embedded
+	// application (Activity).
+	InviteEmbeddedApplication
 )
 
 // Extra information about an invite, will extend the invite object.
@@ -65,3 +112,18 @@ type InviteMetadata struct {
 	// When this invite was created
 	CreatedAt Timestamp `json:"created_at"`
 }
+
+// InviteStageInstance is the public Stage instance of the Stage channel that an
+// invite is for.
+//
+// https://discord.com/developers/docs/resources/invite#invite-stage-instance-object
+type InviteStageInstance struct {
+	// Members are the members speaking in the Stage.
+	Members []Member `json:"members"`
+	// ParticipantCount is the number of users in the Stage.
+	ParticipantCount int `json:"participant_count"`
+	// SpeakerCount is the number of users speaking in the Stage.
+	SpeakerCount int `json:"speaker_count"`
+	// Topic is the topic of the Stage instance (1-120 characters).
+	Topic string `json:"topic"`
+}
@@ -0,0 +1,43 @@
+package discord
+
+import (
+	"testing"
+
+	"github.com/diamondburned/arikawa/v3/utils/json"
+)
+
+func TestInviteUnmarshal(t *testing.T) {
+	const data = `{
+		"type": 0,
+		"code": "0vCdhLbwjZZTWZLD",
+		"channel": {"id": "165176875973476352", "name": "illuminatus", "type": 0},
+		"expires_at": "2021-07-13T20:34:20.000000+00:00",
+		"guild_scheduled_event": {
+			"id": "894270549524201503",
+			"guild_id": "197038439483310086",
+			"name": "Movie night",
+			"status": 1,
+			"entity_type": 2
+		}
+	}`
+
+	var invite Invite
+	if err := json.Unmarshal([]byte(data), &invite); err != nil {
+		t.Fatal("failed to unmarshal invite:", err)
+	}
+
+	if invite.Type != GuildInvite {
+		t.Errorf("expected type %v, got %v", GuildInvite, invite.Type)
+	}
+	if !invite.ExpiresAt.IsValid() || invite.ExpiresAt.Time().Year() != 2021 {
+		t.Errorf("unexpected expires_at %v", invite.ExpiresAt)
+	}
+
+	event := invite.GuildScheduledEvent
+	if event == nil {
+		t.Fatal("missing guild scheduled event")
+	}
+	if event.ID != 894270549524201503 || event.Status != ScheduledEvent {
+		t.Errorf("unexpected guild scheduled event %+v", event)
+	}
+}
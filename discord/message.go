@@ -108,6 +108,25 @@ type Message struct {
 
 	// Stickers contains the sticker "items" sent with the message.
 	Stickers []StickerItem `json:"sticker_items,omitempty"`
+
+	// RoleSubscriptionData is the data of the role subscription purchase or
+	// renewal that prompted a RoleSubscriptionPurchaseMessage.
+	RoleSubscriptionData *RoleSubscriptionData `json:"role_subscription_data,omitempty"`
+}
+
+// https://discord.com/developers/docs/resources/channel#role-subscription-data-object
+type RoleSubscriptionData struct {
+	// ListingID is the ID of the SKU and listing that the user is subscribed
+	// to.
+	ListingID Snowflake `json:"role_subscription_listing_id"`
+	// TierName is the name of the tier that the user is subscribed to.
+	TierName string `json:"tier_name"`
+	// TotalMonthsSubscribed is the cumulative number of months that the user
+	// has been subscribed for.
+	TotalMonthsSubscribed int `json:"total_months_subscribed"`
+	// IsRenewal is whether this notification is for a renewal rather than a
+	// new purchase.
+	IsRenewal bool `json:"is_renewal"`
 }
 
 // URL generates a Discord client URL to the message. If the message doesn't
@@ -124,6 +143,23 @@ func (m Message) URL() string {
 	)
 }
 
+// IsVoiceMessage returns true if the message is a voice message.
+func (m Message) IsVoiceMessage() bool {
+	return m.Flags.Has(IsVoiceMessage)
+}
+
+// IsSilent returns true if the message was sent without triggering
+// notifications, i.e. it is a @silent message.
+func (m Message) IsSilent() bool {
+	return m.Flags.Has(SuppressNotifications)
+}
+
+// HasSnapshot returns true if the message is a forwarded message that carries
+// a snapshot of another message.
+func (m Message) HasSnapshot() bool {
+	return m.Flags.Has(HasSnapshot)
+}
+
 type MessageType uint8
 
 // https://discord.com/developers/docs/resources/channel#message-object-message-types
@@ -174,8 +210,35 @@ const (
 	StageTopicMessage
 
 	GuildApplicationPremiumSubscriptionMessage
+	_
+	_
+	_
+	GuildIncidentAlertModeEnabledMessage
+	GuildIncidentAlertModeDisabledMessage
+	GuildIncidentReportRaidMessage
+	GuildIncidentReportFalseAlarmMessage
+	_
+	_
+	_
+	_
+	_
+	_
+	PollResultMessage
 )
 
+// IsSystem returns true if the message type is a system message, that is, a
+// message that is generated by Discord and rendered by the client in its own
+// way. Use Message.SystemContent to render it.
+func (t MessageType) IsSystem() bool {
+	switch t {
+	case DefaultMessage, InlinedReplyMessage, ChatInputCommandMessage,
+		ContextMenuCommand, ThreadStarterMessage:
+		return false
+	default:
+		return true
+	}
+}
+
 type MessageFlags enum.Enum
 
 // NullMessage is the JSON null value of MessageFlags.
@@ -207,12 +270,36 @@ const (
 	// MessageLoading specifies whether the message is an Interaction Response
 	// and the bot is "thinking"
 	MessageLoading
-	// TODO: add FailedToMentionSomeRolesInThread
+	// FailedToMentionSomeRolesInThread specifies whether the message failed
+	// to mention some roles and add their members to the thread.
+	FailedToMentionSomeRolesInThread
+)
 
-	// SuppressNotifications specifies whether the message will not trigger push and desktop notifications.
-	SuppressNotifications = 1 << 12
+const (
+	// SuppressNotifications specifies whether the message will not trigger
+	// push and desktop notifications. Messages sent with this flag are known
+	// as @silent messages.
+	SuppressNotifications MessageFlags = 1 << (iota + 12)
+	// IsVoiceMessage specifies whether the message is a voice message.
+	IsVoiceMessage
+	// HasSnapshot specifies whether the message has a snapshot (via Message
+	// Forwarding).
+	HasSnapshot
+	// IsComponentsV2 specifies whether the message uses the new components
+	// system. Messages with this flag cannot use the content and embeds
+	// fields.
+	IsComponentsV2
 )
 
+// SendableMessageFlags contains the flags that may be set when sending a
+// message.
+const SendableMessageFlags = SuppressEmbeds | SuppressNotifications | IsVoiceMessage | IsComponentsV2
+
+// Has returns true if f has all of the given flags.
+func (f MessageFlags) Has(flags MessageFlags) bool {
+	return f != NullMessage && f&flags == flags
+}
+
 // StickerItem contains partial data of a Sticker.
 //
 // https://discord.com/developers/docs/resources/sticker#sticker-item-object
@@ -452,6 +539,72 @@ type Attachment struct {
 	// attachments on messages are guaranteed to be available as long as
 	// the message itself exists.
 	Ephemeral bool `json:"ephemeral,omitempty"`
+	// DurationSecs is the duration of the audio file in seconds. It is only
+	// present for voice messages.
+	DurationSecs float64 `json:"duration_secs,omitempty"`
+	// Waveform is a sampled waveform of the audio file, with each byte being
+	// the volume of a sample. It is only present for voice messages.
+	Waveform []byte `json:"waveform,omitempty"`
+	// Title is the title of the file. Unlike Filename, it may contain
+	// characters that are not allowed in filenames.
+	Title string `json:"title,omitempty"`
+	// Flags is the attachment's flags.
+	Flags AttachmentFlags `json:"flags,omitempty"`
+
+	// Placeholder is a thumbhash placeholder of the file, if it is an image
+	// or a video.
+	Placeholder string `json:"placeholder,omitempty"`
+	// PlaceholderVersion is the version of the placeholder.
+	PlaceholderVersion int `json:"placeholder_version,omitempty"`
+
+	// ClipParticipants is the list of users that are in the stream of the
+	// clip. It is only present for clips.
+	ClipParticipants []User `json:"clip_participants,omitempty"`
+	// ClipCreatedAt is the time when the clip was created. It is only present
+	// for clips.
+	ClipCreatedAt Timestamp `json:"clip_created_at,omitempty"`
+	// Application is the application that the clip was recorded in, if any.
+	// It is only present for clips.
+	Application *Application `json:"application,omitempty"`
+}
+
+// IsClip returns true if the attachment is a clip from a stream.
+func (a Attachment) IsClip() bool {
+	return a.Flags.Has(AttachmentIsClip)
+}
+
+// IsVoiceMessage returns true if the attachment is the audio of a voice
+// message.
+func (a Attachment) IsVoiceMessage() bool {
+	return a.DurationSecs > 0 && a.Waveform != nil
+}
+
+// AttachmentFlags is the flags of an attachment.
+//
+// https://discord.com/developers/docs/resources/message#attachment-object-attachment-flags
+type AttachmentFlags uint32
+
+const (
+	// AttachmentIsClip is set if the attachment is a clip from a stream.
+	AttachmentIsClip AttachmentFlags = 1 << iota
+	// AttachmentIsThumbnail is set if the attachment is the thumbnail of a
+	// thread in a media channel.
+	AttachmentIsThumbnail
+	// AttachmentIsRemix is set if the attachment has been edited using the
+	// remix feature on mobile.
+	AttachmentIsRemix
+	// AttachmentIsSpoiler is set if the attachment was marked as a spoiler.
+	AttachmentIsSpoiler
+	// AttachmentContainsExplicitMedia is set if the attachment was flagged
+	// as sensitive content.
+	AttachmentContainsExplicitMedia
+	// AttachmentIsAnimated is set if the attachment is an animated image.
+	AttachmentIsAnimated
+)
+
+// Has returns true if the flags contain all of the given flags.
+func (f AttachmentFlags) Has(flags AttachmentFlags) bool {
+	return f&flags == flags
 }
 
 //
@@ -468,6 +621,16 @@ type Reaction struct {
 	Emoji Emoji `json:"emoji"`
 }
 
+// https://discord.com/developers/docs/resources/channel#get-reactions-reaction-types
+type ReactionType uint8
+
+const (
+	// NormalReaction is a normal reaction.
+	NormalReaction ReactionType = iota
+	// BurstReaction is a super reaction.
+	BurstReaction
+)
+
 // https://discord.com/developers/docs/resources/channel#reaction-count-details-object
 type ReactionCountDetails struct {
 	// Burst is the count of super reactions.
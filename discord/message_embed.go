@@ -182,6 +182,9 @@ const (
 	GIFVEmbed    EmbedType = "gifv"
 	ArticleEmbed EmbedType = "article"
 	LinkEmbed    EmbedType = "link"
+	// PollResultEmbed is the embed of a PollResultMessage. Its fields
+	// describe the poll, e.g. "poll_question_text" and "victor_answer_text".
+	PollResultEmbed EmbedType = "poll_result"
 )
 
 // EmbedFooter is the footer of an embed.
@@ -0,0 +1,192 @@
+package discord
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// joinMessages are the welcome messages that the client picks from for
+// GuildMemberJoinMessage, in the order that the client uses.
+var joinMessages = []string{
+	"%s joined the party.",
+	"%s is here.",
+	"Welcome, %s. We hope you brought pizza.",
+	"A wild %s appeared.",
+	"%s just landed.",
+	"%s just slid into the server.",
+	"%s just showed up!",
+	"Welcome %s. Say hi!",
+	"%s hopped into the server.",
+	"Everyone welcome %s!",
+	"Glad you're here, %s.",
+	"Good to see you, %s.",
+	"Yay you made it, %s!",
+}
+
+// SystemContent renders the system message into a human-readable string
+// similar to what the Discord client shows, such as "A wild user appeared."
+// for GuildMemberJoinMessage. Users are referred to by their display names,
+// and the guild is referred to as "the server", since the message doesn't
+// carry the guild's name.
+//
+// If the message is not a system message, then its content is returned as-is.
+// See MessageType.IsSystem.
+func (m Message) SystemContent() string {
+	author := m.Author.DisplayOrUsername()
+
+	switch m.Type {
+	case RecipientAddMessage:
+		return fmt.Sprintf("%s added %s to the group.", author, m.mentionedName())
+	case RecipientRemoveMessage:
+		if len(m.Mentions) == 0 || m.Mentions[0].ID == m.Author.ID {
+			return fmt.Sprintf("%s left the group.", author)
+		}
+		return fmt.Sprintf("%s removed %s from the group.", author, m.mentionedName())
+	case CallMessage:
+		return fmt.Sprintf("%s started a call.", author)
+	case ChannelNameChangeMessage:
+		return fmt.Sprintf("%s changed the channel name: %s", author, m.Content)
+	case ChannelIconChangeMessage:
+		return fmt.Sprintf("%s changed the channel icon.", author)
+	case ChannelPinnedMessage:
+		return fmt.Sprintf("%s pinned a message to this channel.", author)
+	case GuildMemberJoinMessage:
+		return fmt.Sprintf(joinMessages[m.joinMessageIndex()], author)
+	case NitroBoostMessage:
+		return boostedString(author, m.Content)
+	case NitroTier1Message, NitroTier2Message, NitroTier3Message:
+		level := int(m.Type-NitroTier1Message) + 1
+		return fmt.Sprintf("%s The server has achieved Level %d!",
+			boostedString(author, m.Content), level)
+	case ChannelFollowAddMessage:
+		return fmt.Sprintf(
+			"%s has added %s to this channel. "+
+				"Its most important updates will show up here.",
+			author, m.Content)
+	case GuildDiscoveryDisqualifiedMessage:
+		return "This server has been removed from Server Discovery because it " +
+			"no longer passes all the requirements."
+	case GuildDiscoveryRequalifiedMessage:
+		return "This server is eligible for Server Discovery again and has " +
+			"been automatically relisted!"
+	case GuildDiscoveryGracePeriodInitialWarning:
+		return "This server has failed Discovery activity requirements for 1 " +
+			"week. If this server fails for 4 weeks in a row, it will be " +
+			"automatically removed from Discovery."
+	case GuildDiscoveryGracePeriodFinalWarning:
+		return "This server has failed Discovery activity requirements for 3 " +
+			"weeks in a row. If this server fails for 1 more week, it will be " +
+			"removed from Discovery."
+	case ThreadCreatedMessage:
+		return fmt.Sprintf("%s started a thread: %s.", author, m.Content)
+	case GuildInviteReminderMessage:
+		return "Wondering who to invite? Start by inviting anyone who can " +
+			"help you build the server!"
+	case AutoModerationActionMessage:
+		return "AutoMod has flagged a message."
+	case RoleSubscriptionPurchaseMessage:
+		data := m.RoleSubscriptionData
+		if data == nil {
+			return fmt.Sprintf("%s joined a role subscription.", author)
+		}
+		verb := "joined"
+		if data.IsRenewal {
+			verb = "renewed"
+		}
+		return fmt.Sprintf(
+			"%s %s %s and has been a subscriber of the server for %s!",
+			author, verb, data.TierName, plural(data.TotalMonthsSubscribed, "month"))
+	case InteractionPremiumUpsellMessage:
+		return m.Content
+	case StageStartMessage:
+		return fmt.Sprintf("%s started %s", author, m.Content)
+	case StageEndMessage:
+		return fmt.Sprintf("%s ended %s", author, m.Content)
+	case StageSpeakerMessage:
+		return fmt.Sprintf("%s is now a speaker.", author)
+	case StageTopicMessage:
+		return fmt.Sprintf("%s changed the Stage topic: %s", author, m.Content)
+	case GuildApplicationPremiumSubscriptionMessage:
+		app := "an application"
+		if m.Application != nil && m.Application.Name != "" {
+			app = m.Application.Name
+		}
+		return fmt.Sprintf("%s upgraded %s to premium for this server!", author, app)
+	case GuildIncidentAlertModeEnabledMessage:
+		return fmt.Sprintf("%s enabled security actions until %s.", author, m.Content)
+	case GuildIncidentAlertModeDisabledMessage:
+		return fmt.Sprintf("%s disabled security actions.", author)
+	case GuildIncidentReportRaidMessage:
+		return fmt.Sprintf("%s reported a raid in the server.", author)
+	case GuildIncidentReportFalseAlarmMessage:
+		return fmt.Sprintf("%s reported a false alarm in the server.", author)
+	case PollResultMessage:
+		question := m.embedField(PollResultEmbed, "poll_question_text")
+		s := fmt.Sprintf("%s's poll %s has closed.", author, question)
+		if answer := m.embedField(PollResultEmbed, "victor_answer_text"); answer != "" {
+			s += fmt.Sprintf(" The winning answer was %s.", answer)
+		}
+		return s
+	default:
+		return m.Content
+	}
+}
+
+// joinMessageIndex returns the index of the welcome message in joinMessages,
+// which is picked by Discord from the message's timestamp. The first one is
+// used if the timestamp is invalid.
+func (m Message) joinMessageIndex() int {
+	if !m.Timestamp.IsValid() {
+		return 0
+	}
+
+	ms := m.Timestamp.Time().UnixNano() / int64(time.Millisecond)
+
+	i := ms % int64(len(joinMessages))
+	if i < 0 {
+		i += int64(len(joinMessages))
+	}
+
+	return int(i)
+}
+
+// mentionedName returns the display name of the first mentioned user.
+func (m Message) mentionedName() string {
+	if len(m.Mentions) == 0 {
+		return "someone"
+	}
+	return m.Mentions[0].DisplayOrUsername()
+}
+
+// embedField returns the value of the field with the given name in the first
+// embed of the given type.
+func (m Message) embedField(typ EmbedType, name string) string {
+	for _, embed := range m.Embeds {
+		if embed.Type != typ {
+			continue
+		}
+		for _, field := range embed.Fields {
+			if field.Name == name {
+				return field.Value
+			}
+		}
+	}
+	return ""
+}
+
+// boostedString renders the boost message, where count is the content of the
+// message containing the number of boosts, if more than one.
+func boostedString(author, count string) string {
+	if n, err := strconv.Atoi(count); err == nil && n > 1 {
+		return fmt.Sprintf("%s just boosted the server %d times!", author, n)
+	}
+	return fmt.Sprintf("%s just boosted the server!", author)
+}
+
+func plural(n int, unit string) string {
+	if n == 1 {
+		return "1 " + unit
+	}
+	return strconv.Itoa(n) + " " + unit + "s"
+}
@@ -0,0 +1,119 @@
+package discord
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestMessageTypeValues(t *testing.T) {
+	tests := map[MessageType]MessageType{
+		StageTopicMessage:                          31,
+		GuildApplicationPremiumSubscriptionMessage: 32,
+		GuildIncidentAlertModeEnabledMessage:       36,
+		GuildIncidentReportFalseAlarmMessage:       39,
+		PollResultMessage:                          46,
+	}
+
+	for typ, value := range tests {
+		if typ != value {
+			t.Errorf("expected message type %d, got %d", value, typ)
+		}
+	}
+}
+
+func TestMessageSystemContent(t *testing.T) {
+	author := User{ID: 1, Username: "alice"}
+	bob := GuildUser{User: User{ID: 2, Username: "bob", DisplayName: "Bob"}}
+
+	tests := []struct {
+		name string
+		msg  Message
+		out  string
+	}{
+		{
+			name: "default",
+			msg:  Message{Type: DefaultMessage, Author: author, Content: "hi"},
+			out:  "hi",
+		},
+		{
+			name: "recipient add",
+			msg:  Message{Type: RecipientAddMessage, Author: author, Mentions: []GuildUser{bob}},
+			out:  "alice added Bob to the group.",
+		},
+		{
+			name: "recipient leave",
+			msg: Message{
+				Type:     RecipientRemoveMessage,
+				Author:   author,
+				Mentions: []GuildUser{{User: author}},
+			},
+			out: "alice left the group.",
+		},
+		{
+			name: "join",
+			msg: Message{
+				Type:      GuildMemberJoinMessage,
+				Author:    author,
+				Timestamp: NewTimestamp(time.Unix(0, 3*int64(time.Millisecond))),
+			},
+			out: "A wild alice appeared.",
+		},
+		{
+			name: "join without timestamp",
+			msg:  Message{Type: GuildMemberJoinMessage, Author: author},
+			out:  "alice joined the party.",
+		},
+		{
+			name: "join before 1970",
+			msg: Message{
+				Type:      GuildMemberJoinMessage,
+				Author:    author,
+				Timestamp: NewTimestamp(time.Unix(0, -int64(time.Millisecond))),
+			},
+			out: fmt.Sprintf(joinMessages[len(joinMessages)-1], "alice"),
+		},
+		{
+			name: "boost tier",
+			msg:  Message{Type: NitroTier2Message, Author: author, Content: "3"},
+			out:  "alice just boosted the server 3 times! The server has achieved Level 2!",
+		},
+		{
+			name: "role subscription renewal",
+			msg: Message{
+				Type:   RoleSubscriptionPurchaseMessage,
+				Author: author,
+				RoleSubscriptionData: &RoleSubscriptionData{
+					TierName:              "Gold",
+					TotalMonthsSubscribed: 1,
+					IsRenewal:             true,
+				},
+			},
+			out: "alice renewed Gold and has been a subscriber of the server for 1 month!",
+		},
+		{
+			name: "poll result",
+			msg: Message{
+				Type:   PollResultMessage,
+				Author: author,
+				Embeds: []Embed{{
+					Type: PollResultEmbed,
+					Fields: []EmbedField{
+						{Name: "poll_question_text", Value: "Cats?"},
+						{Name: "victor_answer_text", Value: "Yes"},
+					},
+				}},
+			},
+			out: "alice's poll Cats? has closed. The winning answer was Yes.",
+		},
+	}
+
+	for _, test := range tests {
+		if out := test.msg.SystemContent(); out != test.out {
+			t.Errorf("%s: expected %q, got %q", test.name, test.out, out)
+		}
+		if test.msg.Type.IsSystem() == (test.msg.Type == DefaultMessage) {
+			t.Errorf("%s: unexpected IsSystem for type %d", test.name, test.msg.Type)
+		}
+	}
+}
@@ -170,15 +170,77 @@ func (p Permissions) Add(perm Permissions) Permissions {
 	return p | perm
 }
 
+// PermissionAllTimedOut is the set of permissions that a timed out member
+// keeps in a channel, given that they had them to begin with.
+const PermissionAllTimedOut = PermissionViewChannel | PermissionReadMessageHistory
+
 // CalcOverrides calculates the permissions for a member in the given channel.
 // Most of the time, you should use state.State.Permissions instead.
+//
+// CalcOverrides cannot resolve the permissions of threads, since they inherit
+// the overwrites of their parent channel. Use CalcPermissions for those.
 func CalcOverrides(
 	guild Guild, channel Channel, member Member, roles []Role) Permissions {
 
+	return CalcPermissions(guild, channel, nil, member, roles)
+}
+
+// CalcPermissions calculates the permissions for a member in the given
+// channel. It is done in the following order:
+//
+//  1. The guild owner has all permissions.
+//  2. The base permissions are the ones of the @everyone role and the roles
+//     of the member. If they include Administrator, then the member has all
+//     permissions.
+//  3. The channel overwrites are applied: first the @everyone overwrite, then
+//     the role overwrites all at once, then the member overwrite.
+//  4. If the member is timed out, then only PermissionAllTimedOut is kept.
+//
+// Threads have no overwrites of their own, so parent must be the thread's
+// parent channel if channel is a thread; its overwrites are used instead.
+// Otherwise, parent is ignored and may be nil. If channel is a thread and
+// parent is nil, then no overwrites are applied.
+//
+// Roles must contain at least the @everyone role and the roles of the member;
+// other roles are ignored. Most of the time, you should use
+// state.State.Permissions instead.
+func CalcPermissions(
+	guild Guild, channel Channel, parent *Channel, member Member, roles []Role) Permissions {
+
 	if guild.OwnerID == member.User.ID {
 		return PermissionAll
 	}
 
+	perm := BasePermissions(guild, member, roles)
+	if perm.Has(PermissionAdministrator) {
+		return PermissionAll
+	}
+
+	overwrites := channel.Overwrites
+	if channel.Type.IsThread() {
+		overwrites = nil
+		if parent != nil {
+			overwrites = parent.Overwrites
+		}
+	}
+
+	perm = ApplyOverwrites(perm, guild.ID, member, overwrites)
+
+	if perm.Has(PermissionAdministrator) {
+		return PermissionAll
+	}
+
+	if member.IsTimedOut() {
+		perm &= PermissionAllTimedOut
+	}
+
+	return perm
+}
+
+// BasePermissions calculates the guild-wide permissions of a member, which are
+// the permissions of the @everyone role and the member's roles combined. It
+// does not take ownership, administrator and timeouts into account.
+func BasePermissions(guild Guild, member Member, roles []Role) Permissions {
 	var perm Permissions
 
 	for _, role := range roles {
@@ -197,12 +259,18 @@ func CalcOverrides(
 		}
 	}
 
-	if perm.Has(PermissionAdministrator) {
-		return PermissionAll
-	}
+	return perm
+}
+
+// ApplyOverwrites applies the given channel overwrites on top of the member's
+// base permissions, following the order described in CalcPermissions.
+func ApplyOverwrites(
+	base Permissions, guildID GuildID, member Member, overwrites []Overwrite) Permissions {
+
+	perm := base
 
-	for _, overwrite := range channel.Overwrites {
-		if GuildID(overwrite.ID) == guild.ID {
+	for _, overwrite := range overwrites {
+		if GuildID(overwrite.ID) == guildID {
 			perm &= ^overwrite.Deny
 			perm |= overwrite.Allow
 			break
@@ -211,7 +279,7 @@ func CalcOverrides(
 
 	var deny, allow Permissions
 
-	for _, overwrite := range channel.Overwrites {
+	for _, overwrite := range overwrites {
 		for _, id := range member.RoleIDs {
 			if id == RoleID(overwrite.ID) && overwrite.Type == OverwriteRole {
 				deny |= overwrite.Deny
@@ -224,17 +292,13 @@ func CalcOverrides(
 	perm &= ^deny
 	perm |= allow
 
-	for _, overwrite := range channel.Overwrites {
-		if UserID(overwrite.ID) == member.User.ID {
+	for _, overwrite := range overwrites {
+		if UserID(overwrite.ID) == member.User.ID && overwrite.Type == OverwriteMember {
 			perm &= ^overwrite.Deny
 			perm |= overwrite.Allow
 			break
 		}
 	}
 
-	if perm.Has(PermissionAdministrator) {
-		return PermissionAll
-	}
-
 	return perm
 }
@@ -0,0 +1,113 @@
+package discord
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCalcPermissions(t *testing.T) {
+	const (
+		guildID = GuildID(1)
+		ownerID = UserID(2)
+		userID  = UserID(3)
+		modRole = RoleID(4)
+	)
+
+	guild := Guild{ID: guildID, OwnerID: ownerID}
+	roles := []Role{
+		{ID: RoleID(guildID), Permissions: PermissionViewChannel | PermissionSendMessages},
+		{ID: modRole, Permissions: PermissionKickMembers},
+	}
+
+	parent := Channel{
+		ID:      10,
+		GuildID: guildID,
+		Type:    GuildText,
+		Overwrites: []Overwrite{
+			{ID: Snowflake(guildID), Type: OverwriteRole, Deny: PermissionSendMessages},
+			{ID: Snowflake(modRole), Type: OverwriteRole, Allow: PermissionSendMessages},
+			{ID: Snowflake(userID), Type: OverwriteMember, Allow: PermissionAttachFiles},
+		},
+	}
+	thread := Channel{ID: 11, GuildID: guildID, Type: GuildPublicThread, ParentID: parent.ID}
+
+	member := Member{User: User{ID: userID}}
+	mod := Member{User: User{ID: userID}, RoleIDs: []RoleID{modRole}}
+
+	future := NewTimestamp(time.Now().Add(time.Hour))
+	past := NewTimestamp(time.Now().Add(-time.Hour))
+
+	tests := []struct {
+		name    string
+		channel Channel
+		parent  *Channel
+		member  Member
+		roles   []Role
+		expect  Permissions
+	}{
+		{
+			name:    "owner",
+			channel: parent,
+			member:  Member{User: User{ID: ownerID}},
+			expect:  PermissionAll,
+		},
+		{
+			name:    "administrator",
+			channel: parent,
+			member:  Member{User: User{ID: userID}, RoleIDs: []RoleID{5}},
+			roles:   append(roles, Role{ID: 5, Permissions: PermissionAdministrator}),
+			expect:  PermissionAll,
+		},
+		{
+			name:    "everyone overwrite",
+			channel: parent,
+			member:  member,
+			expect:  PermissionViewChannel | PermissionAttachFiles,
+		},
+		{
+			name:    "role overwrite",
+			channel: parent,
+			member:  mod,
+			expect:  PermissionViewChannel | PermissionSendMessages | PermissionKickMembers | PermissionAttachFiles,
+		},
+		{
+			name:    "thread inherits parent",
+			channel: thread,
+			parent:  &parent,
+			member:  member,
+			expect:  PermissionViewChannel | PermissionAttachFiles,
+		},
+		{
+			name:    "thread without parent",
+			channel: thread,
+			member:  member,
+			expect:  PermissionViewChannel | PermissionSendMessages,
+		},
+		{
+			name:    "timed out",
+			channel: parent,
+			member:  Member{User: mod.User, RoleIDs: mod.RoleIDs, CommunicationDisabledUntil: future},
+			expect:  PermissionViewChannel,
+		},
+		{
+			name:    "timeout expired",
+			channel: parent,
+			member:  Member{User: member.User, CommunicationDisabledUntil: past},
+			expect:  PermissionViewChannel | PermissionAttachFiles,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rs := test.roles
+			if rs == nil {
+				rs = roles
+			}
+
+			perm := CalcPermissions(guild, test.channel, test.parent, test.member, rs)
+			if perm != test.expect {
+				t.Fatalf("expected permissions %b, got %b", test.expect, perm)
+			}
+		})
+	}
+}
@@ -12,6 +12,37 @@ const (
 	CancelledEvent
 )
 
+// String returns the name of the status in lower case, or "unknown".
+func (s EventStatus) String() string {
+	switch s {
+	case ScheduledEvent:
+		return "scheduled"
+	case ActiveEvent:
+		return "active"
+	case CompletedEvent:
+		return "completed"
+	case CancelledEvent:
+		return "cancelled"
+	default:
+		return "unknown"
+	}
+}
+
+// CanTransitionTo returns true if a scheduled event with status s can be
+// changed to the given status. Scheduled events can only be started or
+// cancelled, and active events can only be completed. Completed and cancelled
+// events cannot be changed at all.
+func (s EventStatus) CanTransitionTo(status EventStatus) bool {
+	switch s {
+	case ScheduledEvent:
+		return status == ActiveEvent || status == CancelledEvent
+	case ActiveEvent:
+		return status == CompletedEvent
+	default:
+		return false
+	}
+}
+
 // EntityType describes the different types GuildScheduledEvent can be.
 type EntityType int
 
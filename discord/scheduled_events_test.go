@@ -0,0 +1,22 @@
+package discord
+
+import "testing"
+
+func TestEventStatusCanTransitionTo(t *testing.T) {
+	statuses := []EventStatus{ScheduledEvent, ActiveEvent, CompletedEvent, CancelledEvent}
+
+	allowed := map[[2]EventStatus]bool{
+		{ScheduledEvent, ActiveEvent}:    true,
+		{ScheduledEvent, CancelledEvent}: true,
+		{ActiveEvent, CompletedEvent}:    true,
+	}
+
+	for _, from := range statuses {
+		for _, to := range statuses {
+			expected := allowed[[2]EventStatus{from, to}]
+			if got := from.CanTransitionTo(to); got != expected {
+				t.Errorf("%s -> %s: expected %v, got %v", from, to, expected, got)
+			}
+		}
+	}
+}
@@ -34,6 +34,16 @@ type Snowflake uint64
 // optional and nullable snowflake fields.
 const NullSnowflake = ^Snowflake(0)
 
+// NullableSnowflake is the nullable type for Snowflake. A nil value is omitted
+// with omitempty, while a zero snowflake is encoded into a null, which clears
+// the field. Each snowflake type has its own nullable type, such as
+// NullableChannelID.
+type NullableSnowflake = *Snowflake
+
+// NewNullableSnowflake creates a new NullableSnowflake with the given
+// snowflake. Passing 0 creates a null value.
+func NewNullableSnowflake(s Snowflake) NullableSnowflake { return &s }
+
 // NewSnowflake creates a new snowflake from the given time.
 func NewSnowflake(t time.Time) Snowflake {
 	return Snowflake((DurationSinceEpoch(t) / time.Millisecond) << 22)
@@ -3,6 +3,8 @@ package discord
 import (
 	"testing"
 	"time"
+
+	"github.com/diamondburned/arikawa/v3/utils/json"
 )
 
 func TestSnowflake(t *testing.T) {
@@ -64,3 +66,32 @@ func TestSnowflake(t *testing.T) {
 		}
 	})
 }
+
+func TestNullableSnowflake(t *testing.T) {
+	type data struct {
+		ChannelID NullableChannelID `json:"channel_id,omitempty"`
+	}
+
+	tests := []struct {
+		name   string
+		id     NullableChannelID
+		expect string
+	}{
+		{"omitted", nil, `{}`},
+		{"null", NewNullableChannelID(0), `{"channel_id":null}`},
+		{"value", NewNullableChannelID(123), `{"channel_id":"123"}`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			b, err := json.Marshal(data{test.id})
+			if err != nil {
+				t.Fatal("failed to marshal:", err)
+			}
+
+			if string(b) != test.expect {
+				t.Fatalf("expected %s, got %s", test.expect, b)
+			}
+		})
+	}
+}
@@ -10,6 +10,15 @@ type AppID Snowflake
 // NullAppID gets encoded into a null. This is used for optional and nullable snowflake fields.
 const NullAppID = AppID(NullSnowflake)
 
+// NullableAppID is the nullable type for AppID. A nil value is
+// omitted with omitempty, while a zero ID is encoded into a null, which clears
+// the field.
+type NullableAppID = *AppID
+
+// NewNullableAppID creates a new NullableAppID with the given ID.
+// Passing 0 creates a null value.
+func NewNullableAppID(id AppID) NullableAppID { return &id }
+
 func (s AppID) MarshalJSON() ([]byte, error)  { return Snowflake(s).MarshalJSON() }
 func (s *AppID) UnmarshalJSON(v []byte) error { return (*Snowflake)(s).UnmarshalJSON(v) }
 
@@ -34,6 +43,15 @@ type AttachmentID Snowflake
 // NullAttachmentID gets encoded into a null. This is used for optional and nullable snowflake fields.
 const NullAttachmentID = AttachmentID(NullSnowflake)
 
+// NullableAttachmentID is the nullable type for AttachmentID. A nil value is
+// omitted with omitempty, while a zero ID is encoded into a null, which clears
+// the field.
+type NullableAttachmentID = *AttachmentID
+
+// NewNullableAttachmentID creates a new NullableAttachmentID with the given ID.
+// Passing 0 creates a null value.
+func NewNullableAttachmentID(id AttachmentID) NullableAttachmentID { return &id }
+
 func (s AttachmentID) MarshalJSON() ([]byte, error)  { return Snowflake(s).MarshalJSON() }
 func (s *AttachmentID) UnmarshalJSON(v []byte) error { return (*Snowflake)(s).UnmarshalJSON(v) }
 
@@ -58,6 +76,15 @@ type AuditLogEntryID Snowflake
 // NullAuditLogEntryID gets encoded into a null. This is used for optional and nullable snowflake fields.
 const NullAuditLogEntryID = AuditLogEntryID(NullSnowflake)
 
+// NullableAuditLogEntryID is the nullable type for AuditLogEntryID. A nil value is
+// omitted with omitempty, while a zero ID is encoded into a null, which clears
+// the field.
+type NullableAuditLogEntryID = *AuditLogEntryID
+
+// NewNullableAuditLogEntryID creates a new NullableAuditLogEntryID with the given ID.
+// Passing 0 creates a null value.
+func NewNullableAuditLogEntryID(id AuditLogEntryID) NullableAuditLogEntryID { return &id }
+
 func (s AuditLogEntryID) MarshalJSON() ([]byte, error)  { return Snowflake(s).MarshalJSON() }
 func (s *AuditLogEntryID) UnmarshalJSON(v []byte) error { return (*Snowflake)(s).UnmarshalJSON(v) }
 
@@ -82,6 +109,15 @@ type ChannelID Snowflake
 // NullChannelID gets encoded into a null. This is used for optional and nullable snowflake fields.
 const NullChannelID = ChannelID(NullSnowflake)
 
+// NullableChannelID is the nullable type for ChannelID. A nil value is
+// omitted with omitempty, while a zero ID is encoded into a null, which clears
+// the field.
+type NullableChannelID = *ChannelID
+
+// NewNullableChannelID creates a new NullableChannelID with the given ID.
+// Passing 0 creates a null value.
+func NewNullableChannelID(id ChannelID) NullableChannelID { return &id }
+
 func (s ChannelID) MarshalJSON() ([]byte, error)  { return Snowflake(s).MarshalJSON() }
 func (s *ChannelID) UnmarshalJSON(v []byte) error { return (*Snowflake)(s).UnmarshalJSON(v) }
 
@@ -106,6 +142,15 @@ type CommandID Snowflake
 // NullCommandID gets encoded into a null. This is used for optional and nullable snowflake fields.
 const NullCommandID = CommandID(NullSnowflake)
 
+// NullableCommandID is the nullable type for CommandID. A nil value is
+// omitted with omitempty, while a zero ID is encoded into a null, which clears
+// the field.
+type NullableCommandID = *CommandID
+
+// NewNullableCommandID creates a new NullableCommandID with the given ID.
+// Passing 0 creates a null value.
+func NewNullableCommandID(id CommandID) NullableCommandID { return &id }
+
 func (s CommandID) MarshalJSON() ([]byte, error)  { return Snowflake(s).MarshalJSON() }
 func (s *CommandID) UnmarshalJSON(v []byte) error { return (*Snowflake)(s).UnmarshalJSON(v) }
 
@@ -130,6 +175,15 @@ type EmojiID Snowflake
 // NullEmojiID gets encoded into a null. This is used for optional and nullable snowflake fields.
 const NullEmojiID = EmojiID(NullSnowflake)
 
+// NullableEmojiID is the nullable type for EmojiID. A nil value is
+// omitted with omitempty, while a zero ID is encoded into a null, which clears
+// the field.
+type NullableEmojiID = *EmojiID
+
+// NewNullableEmojiID creates a new NullableEmojiID with the given ID.
+// Passing 0 creates a null value.
+func NewNullableEmojiID(id EmojiID) NullableEmojiID { return &id }
+
 func (s EmojiID) MarshalJSON() ([]byte, error)  { return Snowflake(s).MarshalJSON() }
 func (s *EmojiID) UnmarshalJSON(v []byte) error { return (*Snowflake)(s).UnmarshalJSON(v) }
 
@@ -154,6 +208,15 @@ type GuildID Snowflake
 // NullGuildID gets encoded into a null. This is used for optional and nullable snowflake fields.
 const NullGuildID = GuildID(NullSnowflake)
 
+// NullableGuildID is the nullable type for GuildID. A nil value is
+// omitted with omitempty, while a zero ID is encoded into a null, which clears
+// the field.
+type NullableGuildID = *GuildID
+
+// NewNullableGuildID creates a new NullableGuildID with the given ID.
+// Passing 0 creates a null value.
+func NewNullableGuildID(id GuildID) NullableGuildID { return &id }
+
 func (s GuildID) MarshalJSON() ([]byte, error)  { return Snowflake(s).MarshalJSON() }
 func (s *GuildID) UnmarshalJSON(v []byte) error { return (*Snowflake)(s).UnmarshalJSON(v) }
 
@@ -178,6 +241,15 @@ type IntegrationID Snowflake
 // NullIntegrationID gets encoded into a null. This is used for optional and nullable snowflake fields.
 const NullIntegrationID = IntegrationID(NullSnowflake)
 
+// NullableIntegrationID is the nullable type for IntegrationID. A nil value is
+// omitted with omitempty, while a zero ID is encoded into a null, which clears
+// the field.
+type NullableIntegrationID = *IntegrationID
+
+// NewNullableIntegrationID creates a new NullableIntegrationID with the given ID.
+// Passing 0 creates a null value.
+func NewNullableIntegrationID(id IntegrationID) NullableIntegrationID { return &id }
+
 func (s IntegrationID) MarshalJSON() ([]byte, error)  { return Snowflake(s).MarshalJSON() }
 func (s *IntegrationID) UnmarshalJSON(v []byte) error { return (*Snowflake)(s).UnmarshalJSON(v) }
 
@@ -202,6 +274,15 @@ type InteractionID Snowflake
 // NullInteractionID gets encoded into a null. This is used for optional and nullable snowflake fields.
 const NullInteractionID = InteractionID(NullSnowflake)
 
+// NullableInteractionID is the nullable type for InteractionID. A nil value is
+// omitted with omitempty, while a zero ID is encoded into a null, which clears
+// the field.
+type NullableInteractionID = *InteractionID
+
+// NewNullableInteractionID creates a new NullableInteractionID with the given ID.
+// Passing 0 creates a null value.
+func NewNullableInteractionID(id InteractionID) NullableInteractionID { return &id }
+
 func (s InteractionID) MarshalJSON() ([]byte, error)  { return Snowflake(s).MarshalJSON() }
 func (s *InteractionID) UnmarshalJSON(v []byte) error { return (*Snowflake)(s).UnmarshalJSON(v) }
 
@@ -226,6 +307,15 @@ type MessageID Snowflake
 // NullMessageID gets encoded into a null. This is used for optional and nullable snowflake fields.
 const NullMessageID = MessageID(NullSnowflake)
 
+// NullableMessageID is the nullable type for MessageID. A nil value is
+// omitted with omitempty, while a zero ID is encoded into a null, which clears
+// the field.
+type NullableMessageID = *MessageID
+
+// NewNullableMessageID creates a new NullableMessageID with the given ID.
+// Passing 0 creates a null value.
+func NewNullableMessageID(id MessageID) NullableMessageID { return &id }
+
 func (s MessageID) MarshalJSON() ([]byte, error)  { return Snowflake(s).MarshalJSON() }
 func (s *MessageID) UnmarshalJSON(v []byte) error { return (*Snowflake)(s).UnmarshalJSON(v) }
 
@@ -250,6 +340,15 @@ type RoleID Snowflake
 // NullRoleID gets encoded into a null. This is used for optional and nullable snowflake fields.
 const NullRoleID = RoleID(NullSnowflake)
 
+// NullableRoleID is the nullable type for RoleID. A nil value is
+// omitted with omitempty, while a zero ID is encoded into a null, which clears
+// the field.
+type NullableRoleID = *RoleID
+
+// NewNullableRoleID creates a new NullableRoleID with the given ID.
+// Passing 0 creates a null value.
+func NewNullableRoleID(id RoleID) NullableRoleID { return &id }
+
 func (s RoleID) MarshalJSON() ([]byte, error)  { return Snowflake(s).MarshalJSON() }
 func (s *RoleID) UnmarshalJSON(v []byte) error { return (*Snowflake)(s).UnmarshalJSON(v) }
 
@@ -274,6 +373,15 @@ type StageID Snowflake
 // NullStageID gets encoded into a null. This is used for optional and nullable snowflake fields.
 const NullStageID = StageID(NullSnowflake)
 
+// NullableStageID is the nullable type for StageID. A nil value is
+// omitted with omitempty, while a zero ID is encoded into a null, which clears
+// the field.
+type NullableStageID = *StageID
+
+// NewNullableStageID creates a new NullableStageID with the given ID.
+// Passing 0 creates a null value.
+func NewNullableStageID(id StageID) NullableStageID { return &id }
+
 func (s StageID) MarshalJSON() ([]byte, error)  { return Snowflake(s).MarshalJSON() }
 func (s *StageID) UnmarshalJSON(v []byte) error { return (*Snowflake)(s).UnmarshalJSON(v) }
 
@@ -298,6 +406,15 @@ type StickerID Snowflake
 // NullStickerID gets encoded into a null. This is used for optional and nullable snowflake fields.
 const NullStickerID = StickerID(NullSnowflake)
 
+// NullableStickerID is the nullable type for StickerID. A nil value is
+// omitted with omitempty, while a zero ID is encoded into a null, which clears
+// the field.
+type NullableStickerID = *StickerID
+
+// NewNullableStickerID creates a new NullableStickerID with the given ID.
+// Passing 0 creates a null value.
+func NewNullableStickerID(id StickerID) NullableStickerID { return &id }
+
 func (s StickerID) MarshalJSON() ([]byte, error)  { return Snowflake(s).MarshalJSON() }
 func (s *StickerID) UnmarshalJSON(v []byte) error { return (*Snowflake)(s).UnmarshalJSON(v) }
 
@@ -322,6 +439,15 @@ type StickerPackID Snowflake
 // NullStickerPackID gets encoded into a null. This is used for optional and nullable snowflake fields.
 const NullStickerPackID = StickerPackID(NullSnowflake)
 
+// NullableStickerPackID is the nullable type for StickerPackID. A nil value is
+// omitted with omitempty, while a zero ID is encoded into a null, which clears
+// the field.
+type NullableStickerPackID = *StickerPackID
+
+// NewNullableStickerPackID creates a new NullableStickerPackID with the given ID.
+// Passing 0 creates a null value.
+func NewNullableStickerPackID(id StickerPackID) NullableStickerPackID { return &id }
+
 func (s StickerPackID) MarshalJSON() ([]byte, error)  { return Snowflake(s).MarshalJSON() }
 func (s *StickerPackID) UnmarshalJSON(v []byte) error { return (*Snowflake)(s).UnmarshalJSON(v) }
 
@@ -346,6 +472,15 @@ type TagID Snowflake
 // NullTagID gets encoded into a null. This is used for optional and nullable snowflake fields.
 const NullTagID = TagID(NullSnowflake)
 
+// NullableTagID is the nullable type for TagID. A nil value is
+// omitted with omitempty, while a zero ID is encoded into a null, which clears
+// the field.
+type NullableTagID = *TagID
+
+// NewNullableTagID creates a new NullableTagID with the given ID.
+// Passing 0 creates a null value.
+func NewNullableTagID(id TagID) NullableTagID { return &id }
+
 func (s TagID) MarshalJSON() ([]byte, error)  { return Snowflake(s).MarshalJSON() }
 func (s *TagID) UnmarshalJSON(v []byte) error { return (*Snowflake)(s).UnmarshalJSON(v) }
 
@@ -370,6 +505,15 @@ type TeamID Snowflake
 // NullTeamID gets encoded into a null. This is used for optional and nullable snowflake fields.
 const NullTeamID = TeamID(NullSnowflake)
 
+// NullableTeamID is the nullable type for TeamID. A nil value is
+// omitted with omitempty, while a zero ID is encoded into a null, which clears
+// the field.
+type NullableTeamID = *TeamID
+
+// NewNullableTeamID creates a new NullableTeamID with the given ID.
+// Passing 0 creates a null value.
+func NewNullableTeamID(id TeamID) NullableTeamID { return &id }
+
 func (s TeamID) MarshalJSON() ([]byte, error)  { return Snowflake(s).MarshalJSON() }
 func (s *TeamID) UnmarshalJSON(v []byte) error { return (*Snowflake)(s).UnmarshalJSON(v) }
 
@@ -394,6 +538,15 @@ type UserID Snowflake
 // NullUserID gets encoded into a null. This is used for optional and nullable snowflake fields.
 const NullUserID = UserID(NullSnowflake)
 
+// NullableUserID is the nullable type for UserID. A nil value is
+// omitted with omitempty, while a zero ID is encoded into a null, which clears
+// the field.
+type NullableUserID = *UserID
+
+// NewNullableUserID creates a new NullableUserID with the given ID.
+// Passing 0 creates a null value.
+func NewNullableUserID(id UserID) NullableUserID { return &id }
+
 func (s UserID) MarshalJSON() ([]byte, error)  { return Snowflake(s).MarshalJSON() }
 func (s *UserID) UnmarshalJSON(v []byte) error { return (*Snowflake)(s).UnmarshalJSON(v) }
 
@@ -418,6 +571,15 @@ type WebhookID Snowflake
 // NullWebhookID gets encoded into a null. This is used for optional and nullable snowflake fields.
 const NullWebhookID = WebhookID(NullSnowflake)
 
+// NullableWebhookID is the nullable type for WebhookID. A nil value is
+// omitted with omitempty, while a zero ID is encoded into a null, which clears
+// the field.
+type NullableWebhookID = *WebhookID
+
+// NewNullableWebhookID creates a new NullableWebhookID with the given ID.
+// Passing 0 creates a null value.
+func NewNullableWebhookID(id WebhookID) NullableWebhookID { return &id }
+
 func (s WebhookID) MarshalJSON() ([]byte, error)  { return Snowflake(s).MarshalJSON() }
 func (s *WebhookID) UnmarshalJSON(v []byte) error { return (*Snowflake)(s).UnmarshalJSON(v) }
 
@@ -442,6 +604,15 @@ type EventID Snowflake
 // NullEventID gets encoded into a null. This is used for optional and nullable snowflake fields.
 const NullEventID = EventID(NullSnowflake)
 
+// NullableEventID is the nullable type for EventID. A nil value is
+// omitted with omitempty, while a zero ID is encoded into a null, which clears
+// the field.
+type NullableEventID = *EventID
+
+// NewNullableEventID creates a new NullableEventID with the given ID.
+// Passing 0 creates a null value.
+func NewNullableEventID(id EventID) NullableEventID { return &id }
+
 func (s EventID) MarshalJSON() ([]byte, error)  { return Snowflake(s).MarshalJSON() }
 func (s *EventID) UnmarshalJSON(v []byte) error { return (*Snowflake)(s).UnmarshalJSON(v) }
 
@@ -466,6 +637,15 @@ type EntityID Snowflake
 // NullEntityID gets encoded into a null. This is used for optional and nullable snowflake fields.
 const NullEntityID = EntityID(NullSnowflake)
 
+// NullableEntityID is the nullable type for EntityID. A nil value is
+// omitted with omitempty, while a zero ID is encoded into a null, which clears
+// the field.
+type NullableEntityID = *EntityID
+
+// NewNullableEntityID creates a new NullableEntityID with the given ID.
+// Passing 0 creates a null value.
+func NewNullableEntityID(id EntityID) NullableEntityID { return &id }
+
 func (s EntityID) MarshalJSON() ([]byte, error)  { return Snowflake(s).MarshalJSON() }
 func (s *EntityID) UnmarshalJSON(v []byte) error { return (*Snowflake)(s).UnmarshalJSON(v) }
 
@@ -0,0 +1,123 @@
+package discord
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+// TimestampStyle is the style of a timestamp mention, which determines how the
+// client renders it.
+//
+// https://discord.com/developers/docs/reference#message-formatting-timestamp-styles
+type TimestampStyle string
+
+const (
+	// DefaultTimestampStyle omits the style, which the client renders like
+	// ShortDateTimeStyle.
+	DefaultTimestampStyle TimestampStyle = ""
+	// ShortTimeStyle renders e.g. "16:20".
+	ShortTimeStyle TimestampStyle = "t"
+	// LongTimeStyle renders e.g. "16:20:30".
+	LongTimeStyle TimestampStyle = "T"
+	// ShortDateStyle renders e.g. "20/04/2021".
+	ShortDateStyle TimestampStyle = "d"
+	// LongDateStyle renders e.g. "20 April 2021".
+	LongDateStyle TimestampStyle = "D"
+	// ShortDateTimeStyle renders e.g. "20 April 2021 16:20".
+	ShortDateTimeStyle TimestampStyle = "f"
+	// LongDateTimeStyle renders e.g. "Tuesday, 20 April 2021 16:20".
+	LongDateTimeStyle TimestampStyle = "F"
+	// RelativeTimeStyle renders e.g. "2 months ago".
+	RelativeTimeStyle TimestampStyle = "R"
+)
+
+// FormatTimestamp formats the given time into a timestamp mention with the
+// given style, e.g. "<t:1618953630:R>". The client renders it in the user's
+// timezone and locale.
+func FormatTimestamp(t time.Time, style TimestampStyle) string {
+	s := "<t:" + strconv.FormatInt(t.Unix(), 10)
+	if style != DefaultTimestampStyle {
+		s += ":" + string(style)
+	}
+	return s + ">"
+}
+
+// Mention formats the timestamp into a timestamp mention. See FormatTimestamp.
+func (t Timestamp) Mention(style TimestampStyle) string {
+	return FormatTimestamp(t.Time(), style)
+}
+
+// TimestampMention is a timestamp mention found in a message's content.
+type TimestampMention struct {
+	// Time is the time of the mention. Its precision is in seconds.
+	Time time.Time
+	// Style is the style of the mention.
+	Style TimestampStyle
+	// Index is the byte offset of the mention in the content.
+	Index int
+	// Len is the length of the mention in bytes.
+	Len int
+}
+
+// ErrInvalidTimestampMention is returned by ParseTimestampMention if the string
+// is not a valid timestamp mention.
+var ErrInvalidTimestampMention = errors.New("invalid timestamp mention")
+
+var timestampMentionRegex = regexp.MustCompile(`<t:(-?\d+)(?::([tTdDfFR]))?>`)
+
+// ParseTimestampMention parses a single timestamp mention, such as
+// "<t:1618953630:R>". The Index of the returned mention is always 0.
+func ParseTimestampMention(s string) (TimestampMention, error) {
+	match := timestampMentionRegex.FindStringSubmatchIndex(s)
+	if match == nil || match[0] != 0 || match[1] != len(s) {
+		return TimestampMention{}, ErrInvalidTimestampMention
+	}
+
+	m, ok := timestampMentionFromMatch(s, match)
+	if !ok {
+		return TimestampMention{}, ErrInvalidTimestampMention
+	}
+
+	return m, nil
+}
+
+// ParseTimestampMentions returns all timestamp mentions in the given content,
+// e.g. a message's content, in the order that they appear.
+func ParseTimestampMentions(content string) []TimestampMention {
+	matches := timestampMentionRegex.FindAllStringSubmatchIndex(content, -1)
+	if len(matches) == 0 {
+		return nil
+	}
+
+	mentions := make([]TimestampMention, 0, len(matches))
+
+	for _, match := range matches {
+		if m, ok := timestampMentionFromMatch(content, match); ok {
+			mentions = append(mentions, m)
+		}
+	}
+
+	return mentions
+}
+
+func timestampMentionFromMatch(s string, match []int) (TimestampMention, bool) {
+	unix, err := strconv.ParseInt(s[match[2]:match[3]], 10, 64)
+	if err != nil {
+		// The number overflows.
+		return TimestampMention{}, false
+	}
+
+	m := TimestampMention{
+		Time:  time.Unix(unix, 0),
+		Index: match[0],
+		Len:   match[1] - match[0],
+	}
+
+	if match[4] >= 0 {
+		m.Style = TimestampStyle(s[match[4]:match[5]])
+	}
+
+	return m, true
+}
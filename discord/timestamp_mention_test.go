@@ -0,0 +1,45 @@
+package discord
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestFormatTimestamp(t *testing.T) {
+	tm := time.Unix(1618953630, 0)
+
+	if s := FormatTimestamp(tm, RelativeTimeStyle); s != "<t:1618953630:R>" {
+		t.Errorf("unexpected relative mention %q", s)
+	}
+	if s := NewTimestamp(tm).Mention(DefaultTimestampStyle); s != "<t:1618953630>" {
+		t.Errorf("unexpected default mention %q", s)
+	}
+}
+
+func TestParseTimestampMentions(t *testing.T) {
+	content := "starts <t:1618953630:R>, ends <t:1618957230> <t:1:x> <t:99999999999999999999>"
+
+	expect := []TimestampMention{
+		{Time: time.Unix(1618953630, 0), Style: RelativeTimeStyle, Index: 7, Len: 16},
+		{Time: time.Unix(1618957230, 0), Index: 30, Len: 14},
+	}
+
+	if got := ParseTimestampMentions(content); !reflect.DeepEqual(got, expect) {
+		t.Fatalf("unexpected mentions:\n%+v\nexpected:\n%+v", got, expect)
+	}
+
+	m, err := ParseTimestampMention("<t:-60:d>")
+	if err != nil {
+		t.Fatal("failed to parse:", err)
+	}
+	if m.Time.Unix() != -60 || m.Style != ShortDateStyle {
+		t.Fatalf("unexpected mention %+v", m)
+	}
+
+	for _, invalid := range []string{"<t:60:d> ", "t:60", "<t:60:x>"} {
+		if _, err := ParseTimestampMention(invalid); err != ErrInvalidTimestampMention {
+			t.Errorf("%q: expected ErrInvalidTimestampMention, got %v", invalid, err)
+		}
+	}
+}
@@ -41,14 +41,35 @@ func (u User) Mention() string {
 	return u.ID.Mention()
 }
 
-// Tag returns a tag of the user.
+// Tag returns a tag of the user. Users that have migrated to the new username
+// system don't have a discriminator, so their tag is only their username.
 func (u User) Tag() string {
-	if u.Discriminator == "0" || u.Discriminator == "0000" {
+	if u.IsMigrated() {
 		return u.Username
 	}
 	return u.Username + "#" + u.Discriminator
 }
 
+// IsMigrated returns true if the user has migrated to the new username system,
+// meaning that their username is unique and they no longer have a
+// discriminator.
+func (u User) IsMigrated() bool {
+	switch u.Discriminator {
+	case "", "0", "0000":
+		return true
+	default:
+		return false
+	}
+}
+
+// HasBadge returns true if the user has the given flag, which is usually one of
+// the badges displayed on their profile. Both Flags and PublicFlags are
+// checked, since only PublicFlags is sent for users other than the current
+// user.
+func (u User) HasBadge(f UserFlags) bool {
+	return (u.Flags | u.PublicFlags).Has(f)
+}
+
 // DisplayOrUsername returns the DisplayName if it is set, otherwise the
 // Username.
 func (u User) DisplayOrUsername() string {
@@ -84,7 +105,7 @@ func (u User) AvatarURLWithType(t ImageType) string {
 		}
 
 		var picNo string
-		if u.Discriminator != "0" {
+		if !u.IsMigrated() {
 			disc, err := strconv.Atoi(u.Discriminator)
 			if err != nil { // this should never happen
 				return ""
@@ -116,6 +137,10 @@ func (u User) BannerURLWithType(t ImageType) string {
 	return "https://cdn.discordapp.com/banners/" + u.ID.String() + "/" + t.format(u.Banner)
 }
 
+// UserFlags are the flags on a user's account. Most of them are displayed as
+// badges on the user's profile.
+//
+// https://discord.com/developers/docs/resources/user#user-object-user-flags
 type UserFlags uint32
 
 const NoFlag UserFlags = 0
@@ -140,9 +165,17 @@ const (
 	VerifiedBot
 	VerifiedBotDeveloper
 	CertifiedModerator
+	BotHTTPInteractions
 	LikelySpammer // undocumented
+	_
+	ActiveDeveloper
 )
 
+// Has returns true if f has all of the given flags.
+func (f UserFlags) Has(flags UserFlags) bool {
+	return f&flags == flags
+}
+
 type UserNitro uint8
 
 const (
@@ -0,0 +1,50 @@
+package discord
+
+import "testing"
+
+func TestUserTag(t *testing.T) {
+	tests := []struct {
+		name string
+		user User
+		tag  string
+	}{
+		{"legacy", User{Username: "arikawa", Discriminator: "1234"}, "arikawa#1234"},
+		{"migrated", User{Username: "arikawa", Discriminator: "0"}, "arikawa"},
+		{"partial", User{Username: "arikawa"}, "arikawa"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if tag := test.user.Tag(); tag != test.tag {
+				t.Fatalf("expected tag %q, got %q", test.tag, tag)
+			}
+		})
+	}
+}
+
+func TestUserDefaultAvatar(t *testing.T) {
+	migrated := User{ID: 1 << 22, Discriminator: "0"}
+	if url := migrated.AvatarURL(); url != "https://cdn.discordapp.com/embed/avatars/1.png" {
+		t.Fatalf("unexpected default avatar for migrated user: %q", url)
+	}
+
+	legacy := User{ID: 1 << 22, Discriminator: "0007"}
+	if url := legacy.AvatarURL(); url != "https://cdn.discordapp.com/embed/avatars/2.png" {
+		t.Fatalf("unexpected default avatar for legacy user: %q", url)
+	}
+}
+
+func TestUserHasBadge(t *testing.T) {
+	u := User{PublicFlags: ActiveDeveloper | VerifiedBotDeveloper}
+
+	if !u.HasBadge(ActiveDeveloper) {
+		t.Error("user is missing the ActiveDeveloper badge")
+	}
+	if u.HasBadge(Partner) {
+		t.Error("user unexpectedly has the Partner badge")
+	}
+
+	if ActiveDeveloper != 1<<22 {
+		t.Errorf("ActiveDeveloper has unexpected value %d", ActiveDeveloper)
+	}
+}
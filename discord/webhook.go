@@ -50,10 +50,18 @@ func (w Webhook) CreatedAt() time.Time {
 	return w.ID.Time()
 }
 
+// https://discord.com/developers/docs/resources/webhook#webhook-object-webhook-types
 type WebhookType uint8
 
 const (
 	_ WebhookType = iota
+	// IncomingWebhook is a webhook that can post messages to channels with a
+	// generated token.
 	IncomingWebhook
+	// ChannelFollowerWebhook is an internal webhook used with Channel
+	// Following to post new messages into channels. Its SourceGuild and
+	// SourceChannel fields are filled.
 	ChannelFollowerWebhook
+	// ApplicationWebhook is a webhook used with interactions.
+	ApplicationWebhook
 )
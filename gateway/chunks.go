@@ -0,0 +1,158 @@
+package gateway
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// DefaultMemberChunkTimeout is the default MemberChunkRouter.Timeout.
+const DefaultMemberChunkTimeout = 30 * time.Second
+
+// MemberChunkRouter routes GuildMembersChunkEvents to the requesters that sent
+// the matching RequestGuildMembersCommand nonces. It allows several
+// concurrent requests to not steal each other's chunks. A zero-value
+// MemberChunkRouter is a valid router.
+//
+// The router must be given all GuildMembersChunkEvents through Route, usually
+// by adding it as a synchronous handler.
+type MemberChunkRouter struct {
+	// Timeout is how long a route waits for its next chunk before it is
+	// closed, since chunks may never arrive, such as when the gateway
+	// reconnects. If it is 0, DefaultMemberChunkTimeout is used.
+	Timeout time.Duration
+
+	mutex  sync.Mutex
+	routes map[string]*memberChunkRoute
+	serial uint64
+}
+
+type memberChunkRoute struct {
+	mutex  sync.Mutex
+	queue  []*GuildMembersChunkEvent
+	notify chan struct{}
+	guilds int // guilds left to receive the last chunk of
+	cancel context.CancelFunc
+}
+
+// Register registers a new route for a request of the given number of guilds
+// and returns the nonce that must be used in the RequestGuildMembersCommand.
+// The returned channel receives all chunks with that nonce, and it is closed
+// once the last chunk of every guild is received, once no chunk is received
+// for Timeout, once ctx is done, or once cancel or CancelAll is called.
+func (r *MemberChunkRouter) Register(
+	ctx context.Context, guilds int) (nonce string, ch <-chan *GuildMembersChunkEvent, cancel func()) {
+
+	ctx, cancel = context.WithCancel(ctx)
+
+	route := &memberChunkRoute{
+		notify: make(chan struct{}, 1),
+		guilds: guilds,
+		cancel: cancel,
+	}
+
+	timeout := r.Timeout
+	if timeout == 0 {
+		timeout = DefaultMemberChunkTimeout
+	}
+
+	r.mutex.Lock()
+	if r.routes == nil {
+		r.routes = make(map[string]*memberChunkRoute)
+	}
+	r.serial++
+	nonce = "arikawa-" + strconv.FormatUint(r.serial, 36)
+	r.routes[nonce] = route
+	r.mutex.Unlock()
+
+	out := make(chan *GuildMembersChunkEvent)
+	go func() {
+		defer cancel()
+		defer close(out)
+		defer r.remove(nonce)
+
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-timer.C:
+				return
+			case <-route.notify:
+			}
+
+			route.mutex.Lock()
+			queue := route.queue
+			last := route.guilds <= 0
+			route.queue = nil
+			route.mutex.Unlock()
+
+			for _, ev := range queue {
+				select {
+				case out <- ev:
+				case <-ctx.Done():
+					return
+				}
+			}
+
+			if last {
+				return
+			}
+
+			// Restart the timeout, draining the timer if it fired while the
+			// chunks were being sent.
+			if !timer.Stop() {
+				<-timer.C
+			}
+			timer.Reset(timeout)
+		}
+	}()
+
+	return nonce, out, cancel
+}
+
+// CancelAll cancels every registered route, closing their channels. It is
+// usually called when the gateway is closed for good.
+func (r *MemberChunkRouter) CancelAll() {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for _, route := range r.routes {
+		route.cancel()
+	}
+}
+
+func (r *MemberChunkRouter) remove(nonce string) {
+	r.mutex.Lock()
+	delete(r.routes, nonce)
+	r.mutex.Unlock()
+}
+
+// Route routes the given event to its requester. It never blocks. False is
+// returned if the event's nonce was not registered.
+func (r *MemberChunkRouter) Route(ev *GuildMembersChunkEvent) bool {
+	r.mutex.Lock()
+	route, ok := r.routes[ev.Nonce]
+	r.mutex.Unlock()
+
+	if !ok {
+		return false
+	}
+
+	route.mutex.Lock()
+	route.queue = append(route.queue, ev)
+	if ev.ChunkIndex >= ev.ChunkCount-1 {
+		route.guilds--
+	}
+	route.mutex.Unlock()
+
+	select {
+	case route.notify <- struct{}{}:
+	default:
+	}
+
+	return true
+}
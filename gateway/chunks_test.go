@@ -0,0 +1,113 @@
+package gateway
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestMemberChunkRouter(t *testing.T) {
+	var r MemberChunkRouter
+
+	nonce1, ch1, cancel1 := r.Register(context.Background(), 1)
+	defer cancel1()
+
+	nonce2, ch2, cancel2 := r.Register(context.Background(), 2)
+	defer cancel2()
+
+	if nonce1 == nonce2 {
+		t.Fatal("nonces are not unique:", nonce1)
+	}
+
+	events := []*GuildMembersChunkEvent{
+		{GuildID: 1, Nonce: nonce2, ChunkIndex: 0, ChunkCount: 1},
+		{GuildID: 1, Nonce: nonce1, ChunkIndex: 0, ChunkCount: 2},
+		{GuildID: 1, Nonce: "unknown", ChunkIndex: 0, ChunkCount: 1},
+		{GuildID: 1, Nonce: nonce1, ChunkIndex: 1, ChunkCount: 2},
+		{GuildID: 2, Nonce: nonce2, ChunkIndex: 0, ChunkCount: 1},
+	}
+
+	for _, ev := range events {
+		routed := r.Route(ev)
+		if routed != (ev.Nonce != "unknown") {
+			t.Errorf("unexpected route result %v for nonce %q", routed, ev.Nonce)
+		}
+	}
+
+	expectChunks := func(ch <-chan *GuildMembersChunkEvent, nonce string, n int) {
+		t.Helper()
+
+		var got int
+		for ev := range ch {
+			if ev.Nonce != nonce {
+				t.Errorf("got chunk with nonce %q, expected %q", ev.Nonce, nonce)
+			}
+			got++
+		}
+
+		if got != n {
+			t.Errorf("expected %d chunks for %q, got %d", n, nonce, got)
+		}
+	}
+
+	expectChunks(ch1, nonce1, 2)
+	expectChunks(ch2, nonce2, 2)
+}
+
+func TestMemberChunkRouterTimeout(t *testing.T) {
+	r := MemberChunkRouter{Timeout: 50 * time.Millisecond}
+
+	nonce, ch, cancel := r.Register(context.Background(), 1)
+	defer cancel()
+
+	// Each chunk restarts the timeout.
+	for i := 0; i < 3; i++ {
+		time.Sleep(30 * time.Millisecond)
+		r.Route(&GuildMembersChunkEvent{Nonce: nonce, ChunkIndex: i, ChunkCount: 5})
+
+		select {
+		case _, ok := <-ch:
+			if !ok {
+				t.Fatal("route closed before the timeout")
+			}
+		case <-time.After(time.Second):
+			t.Fatal("timed out waiting for chunk", i)
+		}
+	}
+
+	select {
+	case _, ok := <-ch:
+		if ok {
+			t.Fatal("unexpected chunk")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("route was not closed after the timeout")
+	}
+
+	if r.Route(&GuildMembersChunkEvent{Nonce: nonce, ChunkIndex: 3, ChunkCount: 5}) {
+		t.Fatal("chunk was routed after the timeout")
+	}
+}
+
+func TestMemberChunkRouterCancelAll(t *testing.T) {
+	var r MemberChunkRouter
+
+	_, ch1, cancel1 := r.Register(context.Background(), 1)
+	defer cancel1()
+
+	_, ch2, cancel2 := r.Register(context.Background(), 1)
+	defer cancel2()
+
+	r.CancelAll()
+
+	for _, ch := range []<-chan *GuildMembersChunkEvent{ch1, ch2} {
+		select {
+		case _, ok := <-ch:
+			if ok {
+				t.Fatal("unexpected chunk")
+			}
+		case <-time.After(time.Second):
+			t.Fatal("route was not closed by CancelAll")
+		}
+	}
+}
@@ -0,0 +1,153 @@
+package gateway
+
+import (
+	"fmt"
+
+	"github.com/diamondburned/arikawa/v3/utils/ws"
+)
+
+// CloseCode is a close code that Discord sends when it closes the gateway. For
+// more information, see
+// https://discord.com/developers/docs/topics/opcodes-and-status-codes#gateway-gateway-close-event-codes.
+type CloseCode int
+
+// These constants are the close codes that Discord may send. They are untyped
+// so they can be compared against ws.CloseEvent's Code field directly.
+const (
+	// CodeUnknownError is the code returned by Discord when something went
+	// wrong on its end.
+	CodeUnknownError = 4000
+	// CodeUnknownOpcode is the code returned by Discord when an invalid opcode
+	// or payload was sent.
+	CodeUnknownOpcode = 4001
+	// CodeDecodeError is the code returned by Discord when an invalid payload
+	// was sent.
+	CodeDecodeError = 4002
+	// CodeNotAuthenticated is the code returned by Discord when a payload was
+	// sent before identifying.
+	CodeNotAuthenticated = 4003
+	// CodeAuthenticationFailed is the code returned by Discord when the token
+	// sent with the identify payload is incorrect.
+	CodeAuthenticationFailed = 4004
+	// CodeAlreadyAuthenticated is the code returned by Discord when more than
+	// one identify payload was sent.
+	CodeAlreadyAuthenticated = 4005
+	// CodeInvalidSequence is the code returned by Discord to signal that the
+	// given sequence number is invalid.
+	CodeInvalidSequence = 4007
+	// CodeRateLimited is the code returned by Discord when payloads are sent
+	// too quickly.
+	CodeRateLimited = 4008
+	// CodeSessionTimedOut is the code returned by Discord when the session has
+	// timed out.
+	CodeSessionTimedOut = 4009
+	// CodeInvalidShard is the code returned by Discord when an invalid shard
+	// was sent when identifying.
+	CodeInvalidShard = 4010
+	// CodeShardingRequired is the code returned by Discord to signal that the
+	// bot must reshard before proceeding.
+	CodeShardingRequired = 4011
+	// CodeInvalidAPIVersion is the code returned by Discord when an invalid
+	// gateway version was used.
+	CodeInvalidAPIVersion = 4012
+	// CodeInvalidIntents is the code returned by Discord when an invalid
+	// intent was sent.
+	CodeInvalidIntents = 4013
+	// CodeDisallowedIntents is the code returned by Discord to signal that the
+	// bot has requested privileged intents that are not enabled for it in the
+	// Developer Portal.
+	CodeDisallowedIntents = 4014
+)
+
+var closeCodeNames = map[CloseCode]string{
+	CodeUnknownError:         "unknown error",
+	CodeUnknownOpcode:        "unknown opcode",
+	CodeDecodeError:          "decode error",
+	CodeNotAuthenticated:     "not authenticated",
+	CodeAuthenticationFailed: "authentication failed",
+	CodeAlreadyAuthenticated: "already authenticated",
+	CodeInvalidSequence:      "invalid sequence",
+	CodeRateLimited:          "rate limited",
+	CodeSessionTimedOut:      "session timed out",
+	CodeInvalidShard:         "invalid shard",
+	CodeShardingRequired:     "sharding required",
+	CodeInvalidAPIVersion:    "invalid API version",
+	CodeInvalidIntents:       "invalid intents",
+	CodeDisallowedIntents:    "disallowed intents",
+}
+
+// String returns the name of the close code.
+func (c CloseCode) String() string {
+	if name, ok := closeCodeNames[c]; ok {
+		return name
+	}
+	return fmt.Sprintf("close code %d", int(c))
+}
+
+// IsDiscord returns true if the close code is within the range of close codes
+// that are reserved for Discord.
+func (c CloseCode) IsDiscord() bool {
+	return c >= 4000 && c < 5000
+}
+
+// IsFatal returns true if Discord does not allow reconnecting after closing
+// the gateway with this close code. Such close codes are usually caused by a
+// misconfiguration that must be fixed by the user.
+func (c CloseCode) IsFatal() bool {
+	switch c {
+	case
+		CodeAuthenticationFailed,
+		CodeInvalidShard,
+		CodeShardingRequired,
+		CodeInvalidAPIVersion,
+		CodeInvalidIntents,
+		CodeDisallowedIntents:
+		return true
+	default:
+		return false
+	}
+}
+
+// CloseError is returned by LastError when Discord closes the gateway with a
+// close code. It is what Open and Connect in package session return in that
+// case. To check for this error, use errors.As.
+type CloseError struct {
+	// Code is the close code that Discord sent.
+	Code CloseCode
+	// Err is the underlying close event.
+	Err *ws.CloseEvent
+}
+
+// Unwrap returns err.Err.
+func (err *CloseError) Unwrap() error { return err.Err }
+
+// Error formats the CloseError.
+func (err *CloseError) Error() string {
+	return fmt.Sprintf("gateway closed with code %d (%s): %s", int(err.Code), err.Code, err.Err.Err)
+}
+
+// IsFatal returns true if the gateway cannot be reconnected after this error.
+// See CloseCode.IsFatal.
+func (err *CloseError) IsFatal() bool {
+	return err.Code.IsFatal()
+}
+
+// DisallowedIntentsError is returned by LastError when Discord closes the
+// gateway with CodeDisallowedIntents. To check for this error, use errors.As.
+type DisallowedIntentsError struct {
+	// Privileged contains the privileged intents that were requested. At
+	// least one of these intents is not enabled in the Developer Portal.
+	Privileged Intents
+	// Err is the close error.
+	Err *CloseError
+}
+
+// Unwrap returns err.Err.
+func (err *DisallowedIntentsError) Unwrap() error { return err.Err }
+
+// Error formats the DisallowedIntentsError.
+func (err *DisallowedIntentsError) Error() string {
+	return fmt.Sprintf(
+		"privileged intents %d must be enabled in the Developer Portal: %s",
+		err.Privileged, err.Err)
+}
@@ -0,0 +1,66 @@
+package gateway
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/diamondburned/arikawa/v3/utils/ws"
+)
+
+func TestCloseCode(t *testing.T) {
+	tests := []struct {
+		code    CloseCode
+		name    string
+		discord bool
+		fatal   bool
+	}{
+		{CodeUnknownError, "unknown error", true, false},
+		{CodeUnknownOpcode, "unknown opcode", true, false},
+		{CodeDecodeError, "decode error", true, false},
+		{CodeNotAuthenticated, "not authenticated", true, false},
+		{CodeAuthenticationFailed, "authentication failed", true, true},
+		{CodeAlreadyAuthenticated, "already authenticated", true, false},
+		{CodeInvalidSequence, "invalid sequence", true, false},
+		{CodeRateLimited, "rate limited", true, false},
+		{CodeSessionTimedOut, "session timed out", true, false},
+		{CodeInvalidShard, "invalid shard", true, true},
+		{CodeShardingRequired, "sharding required", true, true},
+		{CodeInvalidAPIVersion, "invalid API version", true, true},
+		{CodeInvalidIntents, "invalid intents", true, true},
+		{CodeDisallowedIntents, "disallowed intents", true, true},
+		{4006, "close code 4006", true, false},
+		{4999, "close code 4999", true, false},
+		{1000, "close code 1000", false, false},
+		{1006, "close code 1006", false, false},
+		{5000, "close code 5000", false, false},
+		{-1, "close code -1", false, false},
+	}
+
+	for _, test := range tests {
+		if name := test.code.String(); name != test.name {
+			t.Errorf("%d: expected name %q, got %q", test.code, test.name, name)
+		}
+		if discord := test.code.IsDiscord(); discord != test.discord {
+			t.Errorf("%d: expected IsDiscord %v, got %v", test.code, test.discord, discord)
+		}
+		if fatal := test.code.IsFatal(); fatal != test.fatal {
+			t.Errorf("%d: expected IsFatal %v, got %v", test.code, test.fatal, fatal)
+		}
+
+		// The default options must exit on the same codes.
+		closeEv := &ws.CloseEvent{Err: errors.New("closed"), Code: int(test.code)}
+		if fatal := DefaultGatewayOpts.ErrorIsFatalClose(closeEv); fatal != test.fatal {
+			t.Errorf("%d: expected ErrorIsFatalClose %v, got %v", test.code, test.fatal, fatal)
+		}
+
+		closeErr := &CloseError{Code: test.code, Err: closeEv}
+		if closeErr.IsFatal() != test.fatal {
+			t.Errorf("%d: expected CloseError.IsFatal %v", test.code, test.fatal)
+		}
+
+		var unwrapped *ws.CloseEvent
+		if !errors.As(closeErr, &unwrapped) || unwrapped != closeEv {
+			t.Errorf("%d: CloseError does not unwrap to its CloseEvent", test.code)
+		}
+	}
+}
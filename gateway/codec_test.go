@@ -0,0 +1,52 @@
+package gateway
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/diamondburned/arikawa/v3/utils/ws"
+)
+
+func TestBrokenEvent(t *testing.T) {
+	defer func(include bool, limit int) {
+		ws.IncludeBrokenEventPayload = include
+		ws.BrokenEventPayloadLimit = limit
+	}(ws.IncludeBrokenEventPayload, ws.BrokenEventPayloadLimit)
+
+	ws.IncludeBrokenEventPayload = true
+	ws.BrokenEventPayloadLimit = 8
+
+	const payload = `{"op":0,"t":"MESSAGE_CREATE","s":42,"d":{"id":"not a snowflake"}}`
+
+	codec := ws.NewCodec(OpUnmarshalers)
+	buf := ws.NewDecodeBuffer(0)
+	out := make(chan ws.Op, 1)
+
+	if err := codec.DecodeInto(context.Background(), strings.NewReader(payload), &buf, out); err != nil {
+		t.Fatal("failed to decode:", err)
+	}
+
+	op := <-out
+
+	broken, ok := op.Data.(*ws.BrokenEventError)
+	if !ok {
+		t.Fatalf("expected *ws.BrokenEventError, got %T", op.Data)
+	}
+
+	if broken.Type != "MESSAGE_CREATE" || broken.Code != dispatchOp || broken.Sequence != 42 {
+		t.Errorf("unexpected event info: %s (op %d, seq %d)", broken.Type, broken.Code, broken.Sequence)
+	}
+
+	if op.Sequence != 42 {
+		t.Errorf("expected op sequence 42, got %d", op.Sequence)
+	}
+
+	if string(broken.Payload) != `{"id":"n` || !broken.Truncated {
+		t.Errorf("unexpected payload %q (truncated: %v)", broken.Payload, broken.Truncated)
+	}
+
+	if !strings.Contains(broken.Error(), "MESSAGE_CREATE") {
+		t.Errorf("error %q does not contain the event type", broken.Error())
+	}
+}
@@ -0,0 +1,145 @@
+package gateway
+
+import (
+	"bytes"
+	"compress/zlib"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/utils/etf"
+	"github.com/diamondburned/arikawa/v3/utils/json"
+	"github.com/diamondburned/arikawa/v3/utils/ws"
+)
+
+func TestETFEncoding(t *testing.T) {
+	doLog(t)
+
+	identified := make(chan IdentifyCommand, 1)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if enc := r.URL.Query().Get("encoding"); enc != "etf" {
+			t.Errorf("expected etf encoding, got %q", enc)
+		}
+
+		conn, err := (&websocket.Upgrader{}).Upgrade(w, r, nil)
+		if err != nil {
+			t.Error("failed to upgrade:", err)
+			return
+		}
+		defer conn.Close()
+
+		send := func(payload string, compress bool) {
+			term, err := etf.FromJSON(nil, []byte(payload))
+			if err != nil {
+				t.Error("failed to encode payload:", err)
+				return
+			}
+
+			if compress {
+				var buf bytes.Buffer
+				z := zlib.NewWriter(&buf)
+				z.Write(term)
+				z.Close()
+				term = buf.Bytes()
+			}
+
+			if err := conn.WriteMessage(websocket.BinaryMessage, term); err != nil {
+				t.Error("failed to send payload:", err)
+			}
+		}
+
+		send(`{"op":10,"d":{"heartbeat_interval":45000}}`, false)
+
+		for {
+			msgType, b, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+
+			if msgType != websocket.BinaryMessage {
+				t.Errorf("expected binary message, got type %d", msgType)
+				return
+			}
+
+			jsonb, err := etf.ToJSON(nil, b)
+			if err != nil {
+				t.Error("client sent invalid ETF:", err)
+				return
+			}
+
+			var op struct {
+				Code int      `json:"op"`
+				Data json.Raw `json:"d"`
+			}
+			if err := json.Unmarshal(jsonb, &op); err != nil {
+				t.Error("failed to unmarshal op:", err)
+				return
+			}
+
+			if op.Code != 2 {
+				continue
+			}
+
+			var cmd IdentifyCommand
+			if err := op.Data.UnmarshalTo(&cmd); err != nil {
+				t.Error("failed to unmarshal identify:", err)
+				return
+			}
+			identified <- cmd
+
+			// Snowflakes are integers in ETF.
+			send(`{
+				"op": 0,
+				"t": "READY",
+				"s": 1,
+				"d": {
+					"v": 10,
+					"user": {"id": 175928847299117063, "username": "bot"},
+					"session_id": "session",
+					"guilds": []
+				}
+			}`, true)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	t.Cleanup(cancel)
+
+	url := "ws" + strings.TrimPrefix(srv.URL, "http") + "/?v=" + Version + "&encoding=etf"
+	g := NewCustom(url, "Bot token")
+
+	for op := range g.Connect(ctx) {
+		switch data := op.Data.(type) {
+		case *ReadyEvent:
+			if data.User.ID != discord.UserID(175928847299117063) {
+				t.Errorf("unexpected user ID %d", data.User.ID)
+			}
+			if data.SessionID != "session" {
+				t.Errorf("unexpected session ID %q", data.SessionID)
+			}
+
+			select {
+			case cmd := <-identified:
+				if cmd.Token != "Bot token" {
+					t.Errorf("unexpected identify token %q", cmd.Token)
+				}
+			default:
+				t.Error("Ready received before Identify")
+			}
+			return
+
+		case *ws.BackgroundErrorEvent:
+			t.Fatal("gateway error:", data)
+		}
+	}
+
+	t.Fatal("gateway closed before Ready:", ctx.Err())
+}
@@ -63,6 +63,7 @@ func init() {
 		func() ws.Event { return new(VoiceServerUpdateEvent) },
 		func() ws.Event { return new(WebhooksUpdateEvent) },
 		func() ws.Event { return new(InteractionCreateEvent) },
+		func() ws.Event { return new(ApplicationCommandPermissionsUpdateEvent) },
 		func() ws.Event { return new(UserGuildSettingsUpdateEvent) },
 		func() ws.Event { return new(UserSettingsUpdateEvent) },
 		func() ws.Event { return new(UserNoteUpdateEvent) },
@@ -80,282 +81,655 @@ func init() {
 	)
 }
 
+var eventNames = []string{
+	"HEARTBEAT",
+	"HEARTBEAT_ACK",
+	"RECONNECT",
+	"HELLO",
+	"RESUME",
+	"INVALID_SESSION",
+	"REQUEST_GUILD_MEMBERS",
+	"UPDATE_VOICE_STATE",
+	"UPDATE_PRESENCE",
+	"GUILD_SUBSCRIBE",
+	"RESUMED",
+	"CHANNEL_CREATE",
+	"CHANNEL_UPDATE",
+	"CHANNEL_DELETE",
+	"CHANNEL_PINS_UPDATE",
+	"CHANNEL_UNREAD_UPDATE",
+	"THREAD_CREATE",
+	"THREAD_UPDATE",
+	"THREAD_DELETE",
+	"THREAD_LIST_SYNC",
+	"THREAD_MEMBER_UPDATE",
+	"THREAD_MEMBERS_UPDATE",
+	"GUILD_CREATE",
+	"GUILD_UPDATE",
+	"GUILD_DELETE",
+	"GUILD_AUDIT_LOG_ENTRY_CREATE",
+	"GUILD_BAN_ADD",
+	"GUILD_BAN_REMOVE",
+	"GUILD_EMOJIS_UPDATE",
+	"GUILD_INTEGRATIONS_UPDATE",
+	"GUILD_MEMBER_ADD",
+	"GUILD_MEMBER_REMOVE",
+	"GUILD_MEMBER_UPDATE",
+	"GUILD_MEMBERS_CHUNK",
+	"GUILD_ROLE_CREATE",
+	"GUILD_ROLE_UPDATE",
+	"GUILD_ROLE_DELETE",
+	"INVITE_CREATE",
+	"INVITE_DELETE",
+	"MESSAGE_CREATE",
+	"MESSAGE_UPDATE",
+	"MESSAGE_DELETE",
+	"MESSAGE_DELETE_BULK",
+	"MESSAGE_REACTION_ADD",
+	"MESSAGE_REACTION_REMOVE",
+	"MESSAGE_REACTION_REMOVE_ALL",
+	"MESSAGE_REACTION_REMOVE_EMOJI",
+	"MESSAGE_ACK",
+	"PRESENCE_UPDATE",
+	"PRESENCES_REPLACE",
+	"SESSIONS_REPLACE",
+	"TYPING_START",
+	"USER_UPDATE",
+	"VOICE_STATE_UPDATE",
+	"VOICE_SERVER_UPDATE",
+	"WEBHOOKS_UPDATE",
+	"INTERACTION_CREATE",
+	"APPLICATION_COMMAND_PERMISSIONS_UPDATE",
+	"USER_GUILD_SETTINGS_UPDATE",
+	"USER_SETTINGS_UPDATE",
+	"USER_NOTE_UPDATE",
+	"RELATIONSHIP_ADD",
+	"RELATIONSHIP_REMOVE",
+	"CONVERSATION_SUMMARY_UPDATE",
+	"READY",
+	"READY_SUPPLEMENTAL",
+	"GUILD_SCHEDULED_EVENT_CREATE",
+	"GUILD_SCHEDULED_EVENT_UPDATE",
+	"GUILD_SCHEDULED_EVENT_DELETE",
+	"GUILD_SCHEDULED_EVENT_USER_ADD",
+	"GUILD_SCHEDULED_EVENT_USER_REMOVE",
+	"IDENTIFY",
+}
+
+// AllEventNames returns the names of all events and commands in this package,
+// in the order that they're registered in OpUnmarshalers.
+func AllEventNames() []string {
+	return append([]string(nil), eventNames...)
+}
+
+// EventType returns the name of the given event, which is the same as what its
+// EventName method returns. An empty string is returned if the event does not
+// belong to this package.
+func EventType(ev ws.Event) string {
+	switch ev.(type) {
+	case *HeartbeatCommand:
+		return "HEARTBEAT"
+	case *HeartbeatAckEvent:
+		return "HEARTBEAT_ACK"
+	case *ReconnectEvent:
+		return "RECONNECT"
+	case *HelloEvent:
+		return "HELLO"
+	case *ResumeCommand:
+		return "RESUME"
+	case *InvalidSessionEvent:
+		return "INVALID_SESSION"
+	case *RequestGuildMembersCommand:
+		return "REQUEST_GUILD_MEMBERS"
+	case *UpdateVoiceStateCommand:
+		return "UPDATE_VOICE_STATE"
+	case *UpdatePresenceCommand:
+		return "UPDATE_PRESENCE"
+	case *GuildSubscribeCommand:
+		return "GUILD_SUBSCRIBE"
+	case *ResumedEvent:
+		return "RESUMED"
+	case *ChannelCreateEvent:
+		return "CHANNEL_CREATE"
+	case *ChannelUpdateEvent:
+		return "CHANNEL_UPDATE"
+	case *ChannelDeleteEvent:
+		return "CHANNEL_DELETE"
+	case *ChannelPinsUpdateEvent:
+		return "CHANNEL_PINS_UPDATE"
+	case *ChannelUnreadUpdateEvent:
+		return "CHANNEL_UNREAD_UPDATE"
+	case *ThreadCreateEvent:
+		return "THREAD_CREATE"
+	case *ThreadUpdateEvent:
+		return "THREAD_UPDATE"
+	case *ThreadDeleteEvent:
+		return "THREAD_DELETE"
+	case *ThreadListSyncEvent:
+		return "THREAD_LIST_SYNC"
+	case *ThreadMemberUpdateEvent:
+		return "THREAD_MEMBER_UPDATE"
+	case *ThreadMembersUpdateEvent:
+		return "THREAD_MEMBERS_UPDATE"
+	case *GuildCreateEvent:
+		return "GUILD_CREATE"
+	case *GuildUpdateEvent:
+		return "GUILD_UPDATE"
+	case *GuildDeleteEvent:
+		return "GUILD_DELETE"
+	case *GuildAuditLogEntryCreateEvent:
+		return "GUILD_AUDIT_LOG_ENTRY_CREATE"
+	case *GuildBanAddEvent:
+		return "GUILD_BAN_ADD"
+	case *GuildBanRemoveEvent:
+		return "GUILD_BAN_REMOVE"
+	case *GuildEmojisUpdateEvent:
+		return "GUILD_EMOJIS_UPDATE"
+	case *GuildIntegrationsUpdateEvent:
+		return "GUILD_INTEGRATIONS_UPDATE"
+	case *GuildMemberAddEvent:
+		return "GUILD_MEMBER_ADD"
+	case *GuildMemberRemoveEvent:
+		return "GUILD_MEMBER_REMOVE"
+	case *GuildMemberUpdateEvent:
+		return "GUILD_MEMBER_UPDATE"
+	case *GuildMembersChunkEvent:
+		return "GUILD_MEMBERS_CHUNK"
+	case *GuildRoleCreateEvent:
+		return "GUILD_ROLE_CREATE"
+	case *GuildRoleUpdateEvent:
+		return "GUILD_ROLE_UPDATE"
+	case *GuildRoleDeleteEvent:
+		return "GUILD_ROLE_DELETE"
+	case *InviteCreateEvent:
+		return "INVITE_CREATE"
+	case *InviteDeleteEvent:
+		return "INVITE_DELETE"
+	case *MessageCreateEvent:
+		return "MESSAGE_CREATE"
+	case *MessageUpdateEvent:
+		return "MESSAGE_UPDATE"
+	case *MessageDeleteEvent:
+		return "MESSAGE_DELETE"
+	case *MessageDeleteBulkEvent:
+		return "MESSAGE_DELETE_BULK"
+	case *MessageReactionAddEvent:
+		return "MESSAGE_REACTION_ADD"
+	case *MessageReactionRemoveEvent:
+		return "MESSAGE_REACTION_REMOVE"
+	case *MessageReactionRemoveAllEvent:
+		return "MESSAGE_REACTION_REMOVE_ALL"
+	case *MessageReactionRemoveEmojiEvent:
+		return "MESSAGE_REACTION_REMOVE_EMOJI"
+	case *MessageAckEvent:
+		return "MESSAGE_ACK"
+	case *PresenceUpdateEvent:
+		return "PRESENCE_UPDATE"
+	case *PresencesReplaceEvent:
+		return "PRESENCES_REPLACE"
+	case *SessionsReplaceEvent:
+		return "SESSIONS_REPLACE"
+	case *TypingStartEvent:
+		return "TYPING_START"
+	case *UserUpdateEvent:
+		return "USER_UPDATE"
+	case *VoiceStateUpdateEvent:
+		return "VOICE_STATE_UPDATE"
+	case *VoiceServerUpdateEvent:
+		return "VOICE_SERVER_UPDATE"
+	case *WebhooksUpdateEvent:
+		return "WEBHOOKS_UPDATE"
+	case *InteractionCreateEvent:
+		return "INTERACTION_CREATE"
+	case *ApplicationCommandPermissionsUpdateEvent:
+		return "APPLICATION_COMMAND_PERMISSIONS_UPDATE"
+	case *UserGuildSettingsUpdateEvent:
+		return "USER_GUILD_SETTINGS_UPDATE"
+	case *UserSettingsUpdateEvent:
+		return "USER_SETTINGS_UPDATE"
+	case *UserNoteUpdateEvent:
+		return "USER_NOTE_UPDATE"
+	case *RelationshipAddEvent:
+		return "RELATIONSHIP_ADD"
+	case *RelationshipRemoveEvent:
+		return "RELATIONSHIP_REMOVE"
+	case *ConversationSummaryUpdateEvent:
+		return "CONVERSATION_SUMMARY_UPDATE"
+	case *ReadyEvent:
+		return "READY"
+	case *ReadySupplementalEvent:
+		return "READY_SUPPLEMENTAL"
+	case *GuildScheduledEventCreateEvent:
+		return "GUILD_SCHEDULED_EVENT_CREATE"
+	case *GuildScheduledEventUpdateEvent:
+		return "GUILD_SCHEDULED_EVENT_UPDATE"
+	case *GuildScheduledEventDeleteEvent:
+		return "GUILD_SCHEDULED_EVENT_DELETE"
+	case *GuildScheduledEventUserAddEvent:
+		return "GUILD_SCHEDULED_EVENT_USER_ADD"
+	case *GuildScheduledEventUserRemoveEvent:
+		return "GUILD_SCHEDULED_EVENT_USER_REMOVE"
+	case *IdentifyCommand:
+		return "IDENTIFY"
+	default:
+		return ""
+	}
+}
+
 // Op implements Event. It always returns Op 1.
 func (*HeartbeatCommand) Op() ws.OpCode { return 1 }
 
 // EventType implements Event.
 func (*HeartbeatCommand) EventType() ws.EventType { return "" }
 
+// EventName returns the stable name of the event, which is "HEARTBEAT".
+func (*HeartbeatCommand) EventName() string { return "HEARTBEAT" }
+
 // Op implements Event. It always returns Op 11.
 func (*HeartbeatAckEvent) Op() ws.OpCode { return 11 }
 
 // EventType implements Event.
 func (*HeartbeatAckEvent) EventType() ws.EventType { return "" }
 
+// EventName returns the stable name of the event, which is "HEARTBEAT_ACK".
+func (*HeartbeatAckEvent) EventName() string { return "HEARTBEAT_ACK" }
+
 // Op implements Event. It always returns Op 7.
 func (*ReconnectEvent) Op() ws.OpCode { return 7 }
 
 // EventType implements Event.
 func (*ReconnectEvent) EventType() ws.EventType { return "" }
 
+// EventName returns the stable name of the event, which is "RECONNECT".
+func (*ReconnectEvent) EventName() string { return "RECONNECT" }
+
 // Op implements Event. It always returns Op 10.
 func (*HelloEvent) Op() ws.OpCode { return 10 }
 
 // EventType implements Event.
 func (*HelloEvent) EventType() ws.EventType { return "" }
 
+// EventName returns the stable name of the event, which is "HELLO".
+func (*HelloEvent) EventName() string { return "HELLO" }
+
 // Op implements Event. It always returns Op 6.
 func (*ResumeCommand) Op() ws.OpCode { return 6 }
 
 // EventType implements Event.
 func (*ResumeCommand) EventType() ws.EventType { return "" }
 
+// EventName returns the stable name of the event, which is "RESUME".
+func (*ResumeCommand) EventName() string { return "RESUME" }
+
 // Op implements Event. It always returns Op 9.
 func (*InvalidSessionEvent) Op() ws.OpCode { return 9 }
 
 // EventType implements Event.
 func (*InvalidSessionEvent) EventType() ws.EventType { return "" }
 
+// EventName returns the stable name of the event, which is "INVALID_SESSION".
+func (*InvalidSessionEvent) EventName() string { return "INVALID_SESSION" }
+
 // Op implements Event. It always returns Op 8.
 func (*RequestGuildMembersCommand) Op() ws.OpCode { return 8 }
 
 // EventType implements Event.
 func (*RequestGuildMembersCommand) EventType() ws.EventType { return "" }
 
+// EventName returns the stable name of the event, which is "REQUEST_GUILD_MEMBERS".
+func (*RequestGuildMembersCommand) EventName() string { return "REQUEST_GUILD_MEMBERS" }
+
 // Op implements Event. It always returns Op 4.
 func (*UpdateVoiceStateCommand) Op() ws.OpCode { return 4 }
 
 // EventType implements Event.
 func (*UpdateVoiceStateCommand) EventType() ws.EventType { return "" }
 
+// EventName returns the stable name of the event, which is "UPDATE_VOICE_STATE".
+func (*UpdateVoiceStateCommand) EventName() string { return "UPDATE_VOICE_STATE" }
+
 // Op implements Event. It always returns Op 3.
 func (*UpdatePresenceCommand) Op() ws.OpCode { return 3 }
 
 // EventType implements Event.
 func (*UpdatePresenceCommand) EventType() ws.EventType { return "" }
 
+// EventName returns the stable name of the event, which is "UPDATE_PRESENCE".
+func (*UpdatePresenceCommand) EventName() string { return "UPDATE_PRESENCE" }
+
 // Op implements Event. It always returns Op 14.
 func (*GuildSubscribeCommand) Op() ws.OpCode { return 14 }
 
 // EventType implements Event.
 func (*GuildSubscribeCommand) EventType() ws.EventType { return "" }
 
+// EventName returns the stable name of the event, which is "GUILD_SUBSCRIBE".
+func (*GuildSubscribeCommand) EventName() string { return "GUILD_SUBSCRIBE" }
+
 // Op implements Event. It always returns 0.
 func (*ResumedEvent) Op() ws.OpCode { return dispatchOp }
 
 // EventType implements Event.
 func (*ResumedEvent) EventType() ws.EventType { return "RESUMED" }
 
+// EventName returns the stable name of the event, which is "RESUMED".
+func (*ResumedEvent) EventName() string { return "RESUMED" }
+
 // Op implements Event. It always returns 0.
 func (*ChannelCreateEvent) Op() ws.OpCode { return dispatchOp }
 
 // EventType implements Event.
 func (*ChannelCreateEvent) EventType() ws.EventType { return "CHANNEL_CREATE" }
 
+// EventName returns the stable name of the event, which is "CHANNEL_CREATE".
+func (*ChannelCreateEvent) EventName() string { return "CHANNEL_CREATE" }
+
 // Op implements Event. It always returns 0.
 func (*ChannelUpdateEvent) Op() ws.OpCode { return dispatchOp }
 
 // EventType implements Event.
 func (*ChannelUpdateEvent) EventType() ws.EventType { return "CHANNEL_UPDATE" }
 
+// EventName returns the stable name of the event, which is "CHANNEL_UPDATE".
+func (*ChannelUpdateEvent) EventName() string { return "CHANNEL_UPDATE" }
+
 // Op implements Event. It always returns 0.
 func (*ChannelDeleteEvent) Op() ws.OpCode { return dispatchOp }
 
 // EventType implements Event.
 func (*ChannelDeleteEvent) EventType() ws.EventType { return "CHANNEL_DELETE" }
 
+// EventName returns the stable name of the event, which is "CHANNEL_DELETE".
+func (*ChannelDeleteEvent) EventName() string { return "CHANNEL_DELETE" }
+
 // Op implements Event. It always returns 0.
 func (*ChannelPinsUpdateEvent) Op() ws.OpCode { return dispatchOp }
 
 // EventType implements Event.
 func (*ChannelPinsUpdateEvent) EventType() ws.EventType { return "CHANNEL_PINS_UPDATE" }
 
+// EventName returns the stable name of the event, which is "CHANNEL_PINS_UPDATE".
+func (*ChannelPinsUpdateEvent) EventName() string { return "CHANNEL_PINS_UPDATE" }
+
 // Op implements Event. It always returns 0.
 func (*ChannelUnreadUpdateEvent) Op() ws.OpCode { return dispatchOp }
 
 // EventType implements Event.
 func (*ChannelUnreadUpdateEvent) EventType() ws.EventType { return "CHANNEL_UNREAD_UPDATE" }
 
+// EventName returns the stable name of the event, which is "CHANNEL_UNREAD_UPDATE".
+func (*ChannelUnreadUpdateEvent) EventName() string { return "CHANNEL_UNREAD_UPDATE" }
+
 // Op implements Event. It always returns 0.
 func (*ThreadCreateEvent) Op() ws.OpCode { return dispatchOp }
 
 // EventType implements Event.
 func (*ThreadCreateEvent) EventType() ws.EventType { return "THREAD_CREATE" }
 
+// EventName returns the stable name of the event, which is "THREAD_CREATE".
+func (*ThreadCreateEvent) EventName() string { return "THREAD_CREATE" }
+
 // Op implements Event. It always returns 0.
 func (*ThreadUpdateEvent) Op() ws.OpCode { return dispatchOp }
 
 // EventType implements Event.
 func (*ThreadUpdateEvent) EventType() ws.EventType { return "THREAD_UPDATE" }
 
+// EventName returns the stable name of the event, which is "THREAD_UPDATE".
+func (*ThreadUpdateEvent) EventName() string { return "THREAD_UPDATE" }
+
 // Op implements Event. It always returns 0.
 func (*ThreadDeleteEvent) Op() ws.OpCode { return dispatchOp }
 
 // EventType implements Event.
 func (*ThreadDeleteEvent) EventType() ws.EventType { return "THREAD_DELETE" }
 
+// EventName returns the stable name of the event, which is "THREAD_DELETE".
+func (*ThreadDeleteEvent) EventName() string { return "THREAD_DELETE" }
+
 // Op implements Event. It always returns 0.
 func (*ThreadListSyncEvent) Op() ws.OpCode { return dispatchOp }
 
 // EventType implements Event.
 func (*ThreadListSyncEvent) EventType() ws.EventType { return "THREAD_LIST_SYNC" }
 
+// EventName returns the stable name of the event, which is "THREAD_LIST_SYNC".
+func (*ThreadListSyncEvent) EventName() string { return "THREAD_LIST_SYNC" }
+
 // Op implements Event. It always returns 0.
 func (*ThreadMemberUpdateEvent) Op() ws.OpCode { return dispatchOp }
 
 // EventType implements Event.
 func (*ThreadMemberUpdateEvent) EventType() ws.EventType { return "THREAD_MEMBER_UPDATE" }
 
+// EventName returns the stable name of the event, which is "THREAD_MEMBER_UPDATE".
+func (*ThreadMemberUpdateEvent) EventName() string { return "THREAD_MEMBER_UPDATE" }
+
 // Op implements Event. It always returns 0.
 func (*ThreadMembersUpdateEvent) Op() ws.OpCode { return dispatchOp }
 
 // EventType implements Event.
 func (*ThreadMembersUpdateEvent) EventType() ws.EventType { return "THREAD_MEMBERS_UPDATE" }
 
+// EventName returns the stable name of the event, which is "THREAD_MEMBERS_UPDATE".
+func (*ThreadMembersUpdateEvent) EventName() string { return "THREAD_MEMBERS_UPDATE" }
+
 // Op implements Event. It always returns 0.
 func (*GuildCreateEvent) Op() ws.OpCode { return dispatchOp }
 
 // EventType implements Event.
 func (*GuildCreateEvent) EventType() ws.EventType { return "GUILD_CREATE" }
 
+// EventName returns the stable name of the event, which is "GUILD_CREATE".
+func (*GuildCreateEvent) EventName() string { return "GUILD_CREATE" }
+
 // Op implements Event. It always returns 0.
 func (*GuildUpdateEvent) Op() ws.OpCode { return dispatchOp }
 
 // EventType implements Event.
 func (*GuildUpdateEvent) EventType() ws.EventType { return "GUILD_UPDATE" }
 
+// EventName returns the stable name of the event, which is "GUILD_UPDATE".
+func (*GuildUpdateEvent) EventName() string { return "GUILD_UPDATE" }
+
 // Op implements Event. It always returns 0.
 func (*GuildDeleteEvent) Op() ws.OpCode { return dispatchOp }
 
 // EventType implements Event.
 func (*GuildDeleteEvent) EventType() ws.EventType { return "GUILD_DELETE" }
 
+// EventName returns the stable name of the event, which is "GUILD_DELETE".
+func (*GuildDeleteEvent) EventName() string { return "GUILD_DELETE" }
+
 // Op implements Event. It always returns 0.
 func (*GuildAuditLogEntryCreateEvent) Op() ws.OpCode { return dispatchOp }
 
 // EventType implements Event.
 func (*GuildAuditLogEntryCreateEvent) EventType() ws.EventType { return "GUILD_AUDIT_LOG_ENTRY_CREATE" }
 
+// EventName returns the stable name of the event, which is "GUILD_AUDIT_LOG_ENTRY_CREATE".
+func (*GuildAuditLogEntryCreateEvent) EventName() string { return "GUILD_AUDIT_LOG_ENTRY_CREATE" }
+
 // Op implements Event. It always returns 0.
 func (*GuildBanAddEvent) Op() ws.OpCode { return dispatchOp }
 
 // EventType implements Event.
 func (*GuildBanAddEvent) EventType() ws.EventType { return "GUILD_BAN_ADD" }
 
+// EventName returns the stable name of the event, which is "GUILD_BAN_ADD".
+func (*GuildBanAddEvent) EventName() string { return "GUILD_BAN_ADD" }
+
 // Op implements Event. It always returns 0.
 func (*GuildBanRemoveEvent) Op() ws.OpCode { return dispatchOp }
 
 // EventType implements Event.
 func (*GuildBanRemoveEvent) EventType() ws.EventType { return "GUILD_BAN_REMOVE" }
 
+// EventName returns the stable name of the event, which is "GUILD_BAN_REMOVE".
+func (*GuildBanRemoveEvent) EventName() string { return "GUILD_BAN_REMOVE" }
+
 // Op implements Event. It always returns 0.
 func (*GuildEmojisUpdateEvent) Op() ws.OpCode { return dispatchOp }
 
 // EventType implements Event.
 func (*GuildEmojisUpdateEvent) EventType() ws.EventType { return "GUILD_EMOJIS_UPDATE" }
 
+// EventName returns the stable name of the event, which is "GUILD_EMOJIS_UPDATE".
+func (*GuildEmojisUpdateEvent) EventName() string { return "GUILD_EMOJIS_UPDATE" }
+
 // Op implements Event. It always returns 0.
 func (*GuildIntegrationsUpdateEvent) Op() ws.OpCode { return dispatchOp }
 
 // EventType implements Event.
 func (*GuildIntegrationsUpdateEvent) EventType() ws.EventType { return "GUILD_INTEGRATIONS_UPDATE" }
 
+// EventName returns the stable name of the event, which is "GUILD_INTEGRATIONS_UPDATE".
+func (*GuildIntegrationsUpdateEvent) EventName() string { return "GUILD_INTEGRATIONS_UPDATE" }
+
 // Op implements Event. It always returns 0.
 func (*GuildMemberAddEvent) Op() ws.OpCode { return dispatchOp }
 
 // EventType implements Event.
 func (*GuildMemberAddEvent) EventType() ws.EventType { return "GUILD_MEMBER_ADD" }
 
+// EventName returns the stable name of the event, which is "GUILD_MEMBER_ADD".
+func (*GuildMemberAddEvent) EventName() string { return "GUILD_MEMBER_ADD" }
+
 // Op implements Event. It always returns 0.
 func (*GuildMemberRemoveEvent) Op() ws.OpCode { return dispatchOp }
 
 // EventType implements Event.
 func (*GuildMemberRemoveEvent) EventType() ws.EventType { return "GUILD_MEMBER_REMOVE" }
 
+// EventName returns the stable name of the event, which is "GUILD_MEMBER_REMOVE".
+func (*GuildMemberRemoveEvent) EventName() string { return "GUILD_MEMBER_REMOVE" }
+
 // Op implements Event. It always returns 0.
 func (*GuildMemberUpdateEvent) Op() ws.OpCode { return dispatchOp }
 
 // EventType implements Event.
 func (*GuildMemberUpdateEvent) EventType() ws.EventType { return "GUILD_MEMBER_UPDATE" }
 
+// EventName returns the stable name of the event, which is "GUILD_MEMBER_UPDATE".
+func (*GuildMemberUpdateEvent) EventName() string { return "GUILD_MEMBER_UPDATE" }
+
 // Op implements Event. It always returns 0.
 func (*GuildMembersChunkEvent) Op() ws.OpCode { return dispatchOp }
 
 // EventType implements Event.
 func (*GuildMembersChunkEvent) EventType() ws.EventType { return "GUILD_MEMBERS_CHUNK" }
 
+// EventName returns the stable name of the event, which is "GUILD_MEMBERS_CHUNK".
+func (*GuildMembersChunkEvent) EventName() string { return "GUILD_MEMBERS_CHUNK" }
+
 // Op implements Event. It always returns 0.
 func (*GuildRoleCreateEvent) Op() ws.OpCode { return dispatchOp }
 
 // EventType implements Event.
 func (*GuildRoleCreateEvent) EventType() ws.EventType { return "GUILD_ROLE_CREATE" }
 
+// EventName returns the stable name of the event, which is "GUILD_ROLE_CREATE".
+func (*GuildRoleCreateEvent) EventName() string { return "GUILD_ROLE_CREATE" }
+
 // Op implements Event. It always returns 0.
 func (*GuildRoleUpdateEvent) Op() ws.OpCode { return dispatchOp }
 
 // EventType implements Event.
 func (*GuildRoleUpdateEvent) EventType() ws.EventType { return "GUILD_ROLE_UPDATE" }
 
+// EventName returns the stable name of the event, which is "GUILD_ROLE_UPDATE".
+func (*GuildRoleUpdateEvent) EventName() string { return "GUILD_ROLE_UPDATE" }
+
 // Op implements Event. It always returns 0.
 func (*GuildRoleDeleteEvent) Op() ws.OpCode { return dispatchOp }
 
 // EventType implements Event.
 func (*GuildRoleDeleteEvent) EventType() ws.EventType { return "GUILD_ROLE_DELETE" }
 
+// EventName returns the stable name of the event, which is "GUILD_ROLE_DELETE".
+func (*GuildRoleDeleteEvent) EventName() string { return "GUILD_ROLE_DELETE" }
+
 // Op implements Event. It always returns 0.
 func (*InviteCreateEvent) Op() ws.OpCode { return dispatchOp }
 
 // EventType implements Event.
 func (*InviteCreateEvent) EventType() ws.EventType { return "INVITE_CREATE" }
 
+// EventName returns the stable name of the event, which is "INVITE_CREATE".
+func (*InviteCreateEvent) EventName() string { return "INVITE_CREATE" }
+
 // Op implements Event. It always returns 0.
 func (*InviteDeleteEvent) Op() ws.OpCode { return dispatchOp }
 
 // EventType implements Event.
 func (*InviteDeleteEvent) EventType() ws.EventType { return "INVITE_DELETE" }
 
+// EventName returns the stable name of the event, which is "INVITE_DELETE".
+func (*InviteDeleteEvent) EventName() string { return "INVITE_DELETE" }
+
 // Op implements Event. It always returns 0.
 func (*MessageCreateEvent) Op() ws.OpCode { return dispatchOp }
 
 // EventType implements Event.
 func (*MessageCreateEvent) EventType() ws.EventType { return "MESSAGE_CREATE" }
 
+// EventName returns the stable name of the event, which is "MESSAGE_CREATE".
+func (*MessageCreateEvent) EventName() string { return "MESSAGE_CREATE" }
+
 // Op implements Event. It always returns 0.
 func (*MessageUpdateEvent) Op() ws.OpCode { return dispatchOp }
 
 // EventType implements Event.
 func (*MessageUpdateEvent) EventType() ws.EventType { return "MESSAGE_UPDATE" }
 
+// EventName returns the stable name of the event, which is "MESSAGE_UPDATE".
+func (*MessageUpdateEvent) EventName() string { return "MESSAGE_UPDATE" }
+
 // Op implements Event. It always returns 0.
 func (*MessageDeleteEvent) Op() ws.OpCode { return dispatchOp }
 
 // EventType implements Event.
 func (*MessageDeleteEvent) EventType() ws.EventType { return "MESSAGE_DELETE" }
 
+// EventName returns the stable name of the event, which is "MESSAGE_DELETE".
+func (*MessageDeleteEvent) EventName() string { return "MESSAGE_DELETE" }
+
 // Op implements Event. It always returns 0.
 func (*MessageDeleteBulkEvent) Op() ws.OpCode { return dispatchOp }
 
 // EventType implements Event.
 func (*MessageDeleteBulkEvent) EventType() ws.EventType { return "MESSAGE_DELETE_BULK" }
 
+// EventName returns the stable name of the event, which is "MESSAGE_DELETE_BULK".
+func (*MessageDeleteBulkEvent) EventName() string { return "MESSAGE_DELETE_BULK" }
+
 // Op implements Event. It always returns 0.
 func (*MessageReactionAddEvent) Op() ws.OpCode { return dispatchOp }
 
 // EventType implements Event.
 func (*MessageReactionAddEvent) EventType() ws.EventType { return "MESSAGE_REACTION_ADD" }
 
+// EventName returns the stable name of the event, which is "MESSAGE_REACTION_ADD".
+func (*MessageReactionAddEvent) EventName() string { return "MESSAGE_REACTION_ADD" }
+
 // Op implements Event. It always returns 0.
 func (*MessageReactionRemoveEvent) Op() ws.OpCode { return dispatchOp }
 
 // EventType implements Event.
 func (*MessageReactionRemoveEvent) EventType() ws.EventType { return "MESSAGE_REACTION_REMOVE" }
 
+// EventName returns the stable name of the event, which is "MESSAGE_REACTION_REMOVE".
+func (*MessageReactionRemoveEvent) EventName() string { return "MESSAGE_REACTION_REMOVE" }
+
 // Op implements Event. It always returns 0.
 func (*MessageReactionRemoveAllEvent) Op() ws.OpCode { return dispatchOp }
 
 // EventType implements Event.
 func (*MessageReactionRemoveAllEvent) EventType() ws.EventType { return "MESSAGE_REACTION_REMOVE_ALL" }
 
+// EventName returns the stable name of the event, which is "MESSAGE_REACTION_REMOVE_ALL".
+func (*MessageReactionRemoveAllEvent) EventName() string { return "MESSAGE_REACTION_REMOVE_ALL" }
+
 // Op implements Event. It always returns 0.
 func (*MessageReactionRemoveEmojiEvent) Op() ws.OpCode { return dispatchOp }
 
@@ -364,114 +738,184 @@ func (*MessageReactionRemoveEmojiEvent) EventType() ws.EventType {
 	return "MESSAGE_REACTION_REMOVE_EMOJI"
 }
 
+// EventName returns the stable name of the event, which is "MESSAGE_REACTION_REMOVE_EMOJI".
+func (*MessageReactionRemoveEmojiEvent) EventName() string { return "MESSAGE_REACTION_REMOVE_EMOJI" }
+
 // Op implements Event. It always returns 0.
 func (*MessageAckEvent) Op() ws.OpCode { return dispatchOp }
 
 // EventType implements Event.
 func (*MessageAckEvent) EventType() ws.EventType { return "MESSAGE_ACK" }
 
+// EventName returns the stable name of the event, which is "MESSAGE_ACK".
+func (*MessageAckEvent) EventName() string { return "MESSAGE_ACK" }
+
 // Op implements Event. It always returns 0.
 func (*PresenceUpdateEvent) Op() ws.OpCode { return dispatchOp }
 
 // EventType implements Event.
 func (*PresenceUpdateEvent) EventType() ws.EventType { return "PRESENCE_UPDATE" }
 
+// EventName returns the stable name of the event, which is "PRESENCE_UPDATE".
+func (*PresenceUpdateEvent) EventName() string { return "PRESENCE_UPDATE" }
+
 // Op implements Event. It always returns 0.
 func (*PresencesReplaceEvent) Op() ws.OpCode { return dispatchOp }
 
 // EventType implements Event.
 func (*PresencesReplaceEvent) EventType() ws.EventType { return "PRESENCES_REPLACE" }
 
+// EventName returns the stable name of the event, which is "PRESENCES_REPLACE".
+func (*PresencesReplaceEvent) EventName() string { return "PRESENCES_REPLACE" }
+
 // Op implements Event. It always returns 0.
 func (*SessionsReplaceEvent) Op() ws.OpCode { return dispatchOp }
 
 // EventType implements Event.
 func (*SessionsReplaceEvent) EventType() ws.EventType { return "SESSIONS_REPLACE" }
 
+// EventName returns the stable name of the event, which is "SESSIONS_REPLACE".
+func (*SessionsReplaceEvent) EventName() string { return "SESSIONS_REPLACE" }
+
 // Op implements Event. It always returns 0.
 func (*TypingStartEvent) Op() ws.OpCode { return dispatchOp }
 
 // EventType implements Event.
 func (*TypingStartEvent) EventType() ws.EventType { return "TYPING_START" }
 
+// EventName returns the stable name of the event, which is "TYPING_START".
+func (*TypingStartEvent) EventName() string { return "TYPING_START" }
+
 // Op implements Event. It always returns 0.
 func (*UserUpdateEvent) Op() ws.OpCode { return dispatchOp }
 
 // EventType implements Event.
 func (*UserUpdateEvent) EventType() ws.EventType { return "USER_UPDATE" }
 
+// EventName returns the stable name of the event, which is "USER_UPDATE".
+func (*UserUpdateEvent) EventName() string { return "USER_UPDATE" }
+
 // Op implements Event. It always returns 0.
 func (*VoiceStateUpdateEvent) Op() ws.OpCode { return dispatchOp }
 
 // EventType implements Event.
 func (*VoiceStateUpdateEvent) EventType() ws.EventType { return "VOICE_STATE_UPDATE" }
 
+// EventName returns the stable name of the event, which is "VOICE_STATE_UPDATE".
+func (*VoiceStateUpdateEvent) EventName() string { return "VOICE_STATE_UPDATE" }
+
 // Op implements Event. It always returns 0.
 func (*VoiceServerUpdateEvent) Op() ws.OpCode { return dispatchOp }
 
 // EventType implements Event.
 func (*VoiceServerUpdateEvent) EventType() ws.EventType { return "VOICE_SERVER_UPDATE" }
 
+// EventName returns the stable name of the event, which is "VOICE_SERVER_UPDATE".
+func (*VoiceServerUpdateEvent) EventName() string { return "VOICE_SERVER_UPDATE" }
+
 // Op implements Event. It always returns 0.
 func (*WebhooksUpdateEvent) Op() ws.OpCode { return dispatchOp }
 
 // EventType implements Event.
 func (*WebhooksUpdateEvent) EventType() ws.EventType { return "WEBHOOKS_UPDATE" }
 
+// EventName returns the stable name of the event, which is "WEBHOOKS_UPDATE".
+func (*WebhooksUpdateEvent) EventName() string { return "WEBHOOKS_UPDATE" }
+
 // Op implements Event. It always returns 0.
 func (*InteractionCreateEvent) Op() ws.OpCode { return dispatchOp }
 
 // EventType implements Event.
 func (*InteractionCreateEvent) EventType() ws.EventType { return "INTERACTION_CREATE" }
 
+// EventName returns the stable name of the event, which is "INTERACTION_CREATE".
+func (*InteractionCreateEvent) EventName() string { return "INTERACTION_CREATE" }
+
+// Op implements Event. It always returns 0.
+func (*ApplicationCommandPermissionsUpdateEvent) Op() ws.OpCode { return dispatchOp }
+
+// EventType implements Event.
+func (*ApplicationCommandPermissionsUpdateEvent) EventType() ws.EventType {
+	return "APPLICATION_COMMAND_PERMISSIONS_UPDATE"
+}
+
+// EventName returns the stable name of the event, which is "APPLICATION_COMMAND_PERMISSIONS_UPDATE".
+func (*ApplicationCommandPermissionsUpdateEvent) EventName() string {
+	return "APPLICATION_COMMAND_PERMISSIONS_UPDATE"
+}
+
 // Op implements Event. It always returns 0.
 func (*UserGuildSettingsUpdateEvent) Op() ws.OpCode { return dispatchOp }
 
 // EventType implements Event.
 func (*UserGuildSettingsUpdateEvent) EventType() ws.EventType { return "USER_GUILD_SETTINGS_UPDATE" }
 
+// EventName returns the stable name of the event, which is "USER_GUILD_SETTINGS_UPDATE".
+func (*UserGuildSettingsUpdateEvent) EventName() string { return "USER_GUILD_SETTINGS_UPDATE" }
+
 // Op implements Event. It always returns 0.
 func (*UserSettingsUpdateEvent) Op() ws.OpCode { return dispatchOp }
 
 // EventType implements Event.
 func (*UserSettingsUpdateEvent) EventType() ws.EventType { return "USER_SETTINGS_UPDATE" }
 
+// EventName returns the stable name of the event, which is "USER_SETTINGS_UPDATE".
+func (*UserSettingsUpdateEvent) EventName() string { return "USER_SETTINGS_UPDATE" }
+
 // Op implements Event. It always returns 0.
 func (*UserNoteUpdateEvent) Op() ws.OpCode { return dispatchOp }
 
 // EventType implements Event.
 func (*UserNoteUpdateEvent) EventType() ws.EventType { return "USER_NOTE_UPDATE" }
 
+// EventName returns the stable name of the event, which is "USER_NOTE_UPDATE".
+func (*UserNoteUpdateEvent) EventName() string { return "USER_NOTE_UPDATE" }
+
 // Op implements Event. It always returns 0.
 func (*RelationshipAddEvent) Op() ws.OpCode { return dispatchOp }
 
 // EventType implements Event.
 func (*RelationshipAddEvent) EventType() ws.EventType { return "RELATIONSHIP_ADD" }
 
+// EventName returns the stable name of the event, which is "RELATIONSHIP_ADD".
+func (*RelationshipAddEvent) EventName() string { return "RELATIONSHIP_ADD" }
+
 // Op implements Event. It always returns 0.
 func (*RelationshipRemoveEvent) Op() ws.OpCode { return dispatchOp }
 
 // EventType implements Event.
 func (*RelationshipRemoveEvent) EventType() ws.EventType { return "RELATIONSHIP_REMOVE" }
 
+// EventName returns the stable name of the event, which is "RELATIONSHIP_REMOVE".
+func (*RelationshipRemoveEvent) EventName() string { return "RELATIONSHIP_REMOVE" }
+
 // Op implements Event. It always returns 0.
 func (*ConversationSummaryUpdateEvent) Op() ws.OpCode { return dispatchOp }
 
 // EventType implements Event.
 func (*ConversationSummaryUpdateEvent) EventType() ws.EventType { return "CONVERSATION_SUMMARY_UPDATE" }
 
+// EventName returns the stable name of the event, which is "CONVERSATION_SUMMARY_UPDATE".
+func (*ConversationSummaryUpdateEvent) EventName() string { return "CONVERSATION_SUMMARY_UPDATE" }
+
 // Op implements Event. It always returns 0.
 func (*ReadyEvent) Op() ws.OpCode { return dispatchOp }
 
 // EventType implements Event.
 func (*ReadyEvent) EventType() ws.EventType { return "READY" }
 
+// EventName returns the stable name of the event, which is "READY".
+func (*ReadyEvent) EventName() string { return "READY" }
+
 // Op implements Event. It always returns 0.
 func (*ReadySupplementalEvent) Op() ws.OpCode { return dispatchOp }
 
 // EventType implements Event.
 func (*ReadySupplementalEvent) EventType() ws.EventType { return "READY_SUPPLEMENTAL" }
 
+// EventName returns the stable name of the event, which is "READY_SUPPLEMENTAL".
+func (*ReadySupplementalEvent) EventName() string { return "READY_SUPPLEMENTAL" }
+
 // Op implements Event. It always returns 0.
 func (*GuildScheduledEventCreateEvent) Op() ws.OpCode { return dispatchOp }
 
@@ -480,6 +924,9 @@ func (*GuildScheduledEventCreateEvent) EventType() ws.EventType {
 	return "GUILD_SCHEDULED_EVENT_CREATE"
 }
 
+// EventName returns the stable name of the event, which is "GUILD_SCHEDULED_EVENT_CREATE".
+func (*GuildScheduledEventCreateEvent) EventName() string { return "GUILD_SCHEDULED_EVENT_CREATE" }
+
 // Op implements Event. It always returns 0.
 func (*GuildScheduledEventUpdateEvent) Op() ws.OpCode { return dispatchOp }
 
@@ -488,6 +935,9 @@ func (*GuildScheduledEventUpdateEvent) EventType() ws.EventType {
 	return "GUILD_SCHEDULED_EVENT_UPDATE"
 }
 
+// EventName returns the stable name of the event, which is "GUILD_SCHEDULED_EVENT_UPDATE".
+func (*GuildScheduledEventUpdateEvent) EventName() string { return "GUILD_SCHEDULED_EVENT_UPDATE" }
+
 // Op implements Event. It always returns 0.
 func (*GuildScheduledEventDeleteEvent) Op() ws.OpCode { return dispatchOp }
 
@@ -496,6 +946,9 @@ func (*GuildScheduledEventDeleteEvent) EventType() ws.EventType {
 	return "GUILD_SCHEDULED_EVENT_DELETE"
 }
 
+// EventName returns the stable name of the event, which is "GUILD_SCHEDULED_EVENT_DELETE".
+func (*GuildScheduledEventDeleteEvent) EventName() string { return "GUILD_SCHEDULED_EVENT_DELETE" }
+
 // Op implements Event. It always returns 0.
 func (*GuildScheduledEventUserAddEvent) Op() ws.OpCode { return dispatchOp }
 
@@ -504,6 +957,9 @@ func (*GuildScheduledEventUserAddEvent) EventType() ws.EventType {
 	return "GUILD_SCHEDULED_EVENT_USER_ADD"
 }
 
+// EventName returns the stable name of the event, which is "GUILD_SCHEDULED_EVENT_USER_ADD".
+func (*GuildScheduledEventUserAddEvent) EventName() string { return "GUILD_SCHEDULED_EVENT_USER_ADD" }
+
 // Op implements Event. It always returns 0.
 func (*GuildScheduledEventUserRemoveEvent) Op() ws.OpCode { return dispatchOp }
 
@@ -512,8 +968,16 @@ func (*GuildScheduledEventUserRemoveEvent) EventType() ws.EventType {
 	return "GUILD_SCHEDULED_EVENT_USER_REMOVE"
 }
 
+// EventName returns the stable name of the event, which is "GUILD_SCHEDULED_EVENT_USER_REMOVE".
+func (*GuildScheduledEventUserRemoveEvent) EventName() string {
+	return "GUILD_SCHEDULED_EVENT_USER_REMOVE"
+}
+
 // Op implements Event. It always returns Op 2.
 func (*IdentifyCommand) Op() ws.OpCode { return 2 }
 
 // EventType implements Event.
 func (*IdentifyCommand) EventType() ws.EventType { return "" }
+
+// EventName returns the stable name of the event, which is "IDENTIFY".
+func (*IdentifyCommand) EventName() string { return "IDENTIFY" }
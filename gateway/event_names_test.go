@@ -0,0 +1,53 @@
+package gateway
+
+import (
+	"testing"
+
+	"github.com/diamondburned/arikawa/v3/utils/ws"
+)
+
+func TestEventType(t *testing.T) {
+	tests := []struct {
+		event ws.Event
+		name  string
+	}{
+		{&MessageCreateEvent{}, "MESSAGE_CREATE"},
+		{&ReadyEvent{}, "READY"},
+		{new(HeartbeatCommand), "HEARTBEAT"},
+		{&HeartbeatAckEvent{}, "HEARTBEAT_ACK"},
+		{&IdentifyCommand{}, "IDENTIFY"},
+		{&ws.CloseEvent{}, ""},
+	}
+
+	for _, test := range tests {
+		if name := EventType(test.event); name != test.name {
+			t.Errorf("EventType(%T) = %q, expected %q", test.event, name, test.name)
+		}
+	}
+}
+
+func TestAllEventNames(t *testing.T) {
+	seen := make(map[string]bool)
+	OpUnmarshalers.Each(func(op ws.OpCode, _ ws.EventType, fn ws.OpFunc) bool {
+		ev := fn()
+		name := EventType(ev)
+		if name == "" {
+			t.Errorf("%T has no name", ev)
+		}
+		if named, ok := ev.(interface{ EventName() string }); !ok || named.EventName() != name {
+			t.Errorf("%T.EventName() does not match EventType", ev)
+		}
+		seen[name] = true
+		return false
+	})
+
+	names := AllEventNames()
+	if len(names) != len(seen) {
+		t.Errorf("AllEventNames returned %d names, expected %d unique", len(names), len(seen))
+	}
+	for _, name := range names {
+		if !seen[name] {
+			t.Errorf("name %q is not registered", name)
+		}
+	}
+}
@@ -480,6 +480,10 @@ type InviteCreateEvent struct {
 	Target     *discord.User          `json:"target_user,omitempty"`
 	TargetType discord.InviteUserType `json:"target_user_type,omitempty"`
 
+	// ExpiresAt is the time at which the invite expires, or an invalid
+	// timestamp if it never expires.
+	ExpiresAt discord.Timestamp `json:"expires_at,omitempty"`
+
 	discord.InviteMetadata
 }
 
@@ -639,6 +643,15 @@ type InteractionCreateEvent struct {
 	discord.InteractionEvent
 }
 
+// ApplicationCommandPermissionsUpdateEvent is a dispatch event. It is sent
+// when the permissions of an application command are updated. Its ID is the
+// application ID if the permissions of all commands are updated.
+//
+// https://discord.com/developers/docs/topics/gateway-events#application-command-permissions-update
+type ApplicationCommandPermissionsUpdateEvent struct {
+	discord.GuildCommandPermissions
+}
+
 // Undocumented
 
 // UserGuildSettingsUpdateEvent is a dispatch event. It is undocumented.
@@ -9,6 +9,7 @@ package gateway
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math/rand"
 	"net/url"
@@ -21,7 +22,10 @@ import (
 )
 
 var (
-	Version  = api.Version
+	Version = api.Version
+	// Encoding is the payload encoding that AddGatewayParams asks for. It is
+	// either "json", the default, or "etf". ETF payloads are smaller, which may
+	// help bots in a large number of guilds.
 	Encoding = "json"
 )
 
@@ -30,15 +34,6 @@ var (
 // resumable.
 const deadbeatDuration = 15 * time.Minute
 
-// CodeInvalidSequence is the code returned by Discord to signal that the given
-// sequence number is invalid.
-const CodeInvalidSequence = 4007
-
-// CodeShardingRequired is the code returned by Discord to signal that the bot
-// must reshard before proceeding. For more information, see
-// https://discord.com/developers/docs/topics/opcodes-and-status-codes#gateway-gateway-close-event-codes.
-const CodeShardingRequired = 4011
-
 // URL asks Discord for a Websocket URL to the Gateway.
 func URL(ctx context.Context) (string, error) {
 	return api.GatewayURL(ctx)
@@ -61,6 +56,21 @@ func AddGatewayParams(baseURL string) string {
 	return baseURL + "?" + param.Encode()
 }
 
+// urlEncoding returns the encoding asked for by the encoding parameter of the
+// gateway URL. JSON is used if the parameter is missing or unknown.
+func urlEncoding(gatewayURL string) ws.Encoding {
+	u, err := url.Parse(gatewayURL)
+	if err != nil {
+		return ws.JSONEncoding
+	}
+
+	if enc := ws.EncodingByName(u.Query().Get("encoding")); enc != nil {
+		return enc
+	}
+
+	return ws.JSONEncoding
+}
+
 // State contains the gateway state. It is a piece of data that can be shared
 // across gateways during construction to be used for resuming a connection or
 // starting a new one with the previous data.
@@ -87,6 +97,18 @@ type Gateway struct {
 	sentBeat   time.Time
 	echoBeat   time.Time
 	retryTimer lazytime.Timer
+
+	sendHooksMutex sync.RWMutex
+	sendHooks      []sendHook
+	sendHookSerial int
+
+	seqMutex sync.Mutex
+	seqStats SequenceStats
+}
+
+type sendHook struct {
+	id int
+	fn func(ws.Event)
 }
 
 // NewWithIntents creates a new Gateway with the given intents and the default
@@ -113,15 +135,26 @@ func New(ctx context.Context, token string) (*Gateway, error) {
 
 // NewWithIdentifier creates a new Gateway with the given gateway identifier and
 // the default everything. Sharded bots should prefer this function for the
-// shared identifier. The given Identifier will be modified.
+// shared identifier. The given Identifier will be modified. An error is
+// returned if the IdentifyCommand is invalid; see IdentifyCommand.Validate.
 func NewWithIdentifier(ctx context.Context, id Identifier) (*Gateway, error) {
+	return NewWithIdentifierOpts(ctx, id, nil)
+}
+
+// NewWithIdentifierOpts is NewWithIdentifier, but with the given gateway
+// options. If opts is nil, then DefaultGatewayOpts is used.
+func NewWithIdentifierOpts(ctx context.Context, id Identifier, opts *ws.GatewayOpts) (*Gateway, error) {
+	if err := id.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid identify command: %w", err)
+	}
+
 	gatewayURL, err := id.QueryGateway(ctx)
 	if err != nil {
 		return nil, err
 	}
 
 	gatewayURL = AddGatewayParams(gatewayURL)
-	gateway := NewCustomWithIdentifier(gatewayURL, id, nil)
+	gateway := NewCustomWithIdentifier(gatewayURL, id, opts)
 
 	return gateway, nil
 }
@@ -144,12 +177,12 @@ var DefaultGatewayOpts = ws.GatewayOpts{
 	// the gateway to exit. In other words, it's a list of unrecoverable close
 	// codes.
 	FatalCloseCodes: []int{
-		4004, // authentication failed
-		4010, // invalid shard sent
-		4011, // sharding required
-		4012, // invalid API version
-		4013, // invalid intents
-		4014, // disallowed intents
+		CodeAuthenticationFailed,
+		CodeInvalidShard,
+		CodeShardingRequired,
+		CodeInvalidAPIVersion,
+		CodeInvalidIntents,
+		CodeDisallowedIntents,
 	},
 	DialTimeout:           0,
 	ReconnectAttempt:      0,
@@ -163,13 +196,17 @@ func NewCustomWithIdentifier(gatewayURL string, id Identifier, opts *ws.GatewayO
 }
 
 // NewFromState creates a new gateway from the given state and optionally
-// gateway options. If opts is nil, then DefaultGatewayOpts is used.
+// gateway options. If opts is nil, then DefaultGatewayOpts is used. Payloads
+// are encoded as asked for by the encoding parameter of gatewayURL.
 func NewFromState(gatewayURL string, state State, opts *ws.GatewayOpts) *Gateway {
 	if opts == nil {
 		opts = &DefaultGatewayOpts
 	}
 
-	gw := ws.NewGateway(ws.NewWebsocket(ws.NewCodec(OpUnmarshalers), gatewayURL), opts)
+	codec := ws.NewCodec(OpUnmarshalers)
+	codec.Encoding = urlEncoding(gatewayURL)
+
+	gw := ws.NewGateway(ws.NewWebsocket(codec, gatewayURL), opts)
 	return &Gateway{
 		gateway: gw,
 		state:   state,
@@ -202,6 +239,23 @@ func (g *Gateway) AddIntents(i Intents) {
 	g.state.Identifier.AddIntents(i)
 }
 
+// SetIdentifyPresence sets the presence sent in the IdentifyCommand. This
+// function will only work before Connect() is called. Calling it once
+// Connect() is called will result in a panic.
+func (g *Gateway) SetIdentifyPresence(presence *UpdatePresenceCommand) {
+	g.gateway.AssertIsNotRunning()
+	g.state.Identifier.Presence = presence
+}
+
+// SetIdentifyProperties sets the properties sent in the IdentifyCommand, which
+// tell Discord what the connecting client is. This function will only work
+// before Connect() is called. Calling it once Connect() is called will result
+// in a panic.
+func (g *Gateway) SetIdentifyProperties(props IdentifyProperties) {
+	g.gateway.AssertIsNotRunning()
+	g.state.Identifier.Properties = props
+}
+
 // SentBeat returns the last time that the heart was beaten. If the gateway has
 // never connected, then a zero-value time is returned.
 func (g *Gateway) SentBeat() time.Time {
@@ -232,13 +286,79 @@ func (g *Gateway) Latency() time.Duration {
 // LastError returns the last error that the gateway has received. It only
 // returns a valid error if the gateway's event loop as exited. If the event
 // loop hasn't been started AND stopped, the function will panic.
+//
+// If Discord closed the gateway with a close code, then the error is a
+// *CloseError, or a *DisallowedIntentsError if the close code is
+// CodeDisallowedIntents. Use errors.As to check for these errors.
 func (g *Gateway) LastError() error {
-	return g.gateway.LastError()
+	err := g.gateway.LastError()
+
+	var closeEv *ws.CloseEvent
+	if !errors.As(err, &closeEv) || !CloseCode(closeEv.Code).IsDiscord() {
+		return err
+	}
+
+	closeErr := &CloseError{
+		Code: CloseCode(closeEv.Code),
+		Err:  closeEv,
+	}
+
+	if closeErr.Code == CodeDisallowedIntents {
+		var intents Intents
+		if g.state.Identifier.Intents != nil {
+			intents = Intents(*g.state.Identifier.Intents)
+		}
+
+		return &DisallowedIntentsError{
+			Privileged: intents.Privileged(),
+			Err:        closeErr,
+		}
+	}
+
+	return closeErr
 }
 
 // Send is a function to send an Op payload to the Gateway.
 func (g *Gateway) Send(ctx context.Context, data ws.Event) error {
-	return g.gateway.Send(ctx, data)
+	if err := g.gateway.Send(ctx, data); err != nil {
+		return err
+	}
+
+	g.sendHooksMutex.RLock()
+	defer g.sendHooksMutex.RUnlock()
+
+	for _, hook := range g.sendHooks {
+		hook.fn(data)
+	}
+
+	return nil
+}
+
+// OnSendCommand adds fn to be called with every command that is successfully
+// sent over the gateway. This includes the commands sent internally, such as
+// heartbeats, IdentifyCommand and ResumeCommand; beware that the latter two
+// contain the token. It is useful for debugging and auditing.
+//
+// fn is called synchronously after each send, so it must not block. The
+// returned rm function removes the hook.
+func (g *Gateway) OnSendCommand(fn func(ws.Event)) (rm func()) {
+	g.sendHooksMutex.Lock()
+	g.sendHookSerial++
+	id := g.sendHookSerial
+	g.sendHooks = append(g.sendHooks, sendHook{id, fn})
+	g.sendHooksMutex.Unlock()
+
+	return func() {
+		g.sendHooksMutex.Lock()
+		defer g.sendHooksMutex.Unlock()
+
+		for i, hook := range g.sendHooks {
+			if hook.id == id {
+				g.sendHooks = append(g.sendHooks[:i], g.sendHooks[i+1:]...)
+				return
+			}
+		}
+	}
 }
 
 // Connect starts the background goroutine that tries its best to maintain a
@@ -268,6 +388,9 @@ func (g *Gateway) Send(ctx context.Context, data ws.Event) error {
 //	    log.Println("gateway error:", data.Error)
 //	}
 //
+// Events that cannot be unmarshaled are sent as a ws.BrokenEventError in place
+// of the event, which carries the event's type and sequence number.
+//
 // # Closing
 //
 // As outlined in the first paragraph, closing the gateway would involve
@@ -312,9 +435,13 @@ type gatewayImpl struct {
 	*Gateway
 	heartrate    time.Duration
 	lastSentBeat time.Time
+	// resuming is true from when a Resume is sent until Discord is done
+	// replaying the missed events.
+	resuming bool
 }
 
 func (g *gatewayImpl) invalidate() {
+	g.resuming = false
 	g.state.SessionID = ""
 	g.state.Sequence = 0
 }
@@ -326,11 +453,12 @@ func (g *gatewayImpl) sendIdentify(ctx context.Context) error {
 		return fmt.Errorf("can't wait for identify(): %w", err)
 	}
 
-	return g.gateway.Send(ctx, &g.state.Identifier.IdentifyCommand)
+	return g.Send(ctx, &g.state.Identifier.IdentifyCommand)
 }
 
 func (g *gatewayImpl) sendResume(ctx context.Context) error {
-	return g.gateway.Send(ctx, &ResumeCommand{
+	g.resuming = true
+	return g.Send(ctx, &ResumeCommand{
 		Token:     g.state.Identifier.Token,
 		SessionID: g.state.SessionID,
 		Sequence:  g.state.Sequence,
@@ -338,7 +466,13 @@ func (g *gatewayImpl) sendResume(ctx context.Context) error {
 }
 
 func (g *gatewayImpl) OnOp(ctx context.Context, op ws.Op) bool {
-	if op.Code == dispatchOp {
+	// Dispatch events that failed to unmarshal still count towards the
+	// sequence.
+	broken, _ := op.Data.(*ws.BrokenEventError)
+	if op.Code == dispatchOp || (broken != nil && broken.Code == dispatchOp) {
+		if gap := g.checkSequence(op.Sequence); gap != nil {
+			g.gateway.SendEvent(gap)
+		}
 		g.state.Sequence = op.Sequence
 	}
 
@@ -423,6 +557,7 @@ func (g *gatewayImpl) OnOp(ctx context.Context, op ws.Op) bool {
 		g.useLastSentBeat()
 
 	case *ResumedEvent:
+		g.resuming = false
 		g.useLastSentBeat()
 	}
 
@@ -467,7 +602,7 @@ func (g *gatewayImpl) SendHeartbeat(ctx context.Context) {
 	}
 
 	sequence := HeartbeatCommand(g.state.Sequence)
-	if err := g.gateway.Send(ctx, &sequence); err != nil {
+	if err := g.Send(ctx, &sequence); err != nil {
 		g.gateway.SendErrorWrap(err, "heartbeat error")
 		g.gateway.QueueReconnect()
 		return
@@ -2,6 +2,7 @@ package gateway
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"runtime"
 	"strings"
@@ -24,6 +25,11 @@ type Identifier struct {
 
 	IdentifyShortLimit  *rate.Limiter `json:"-"` // optional
 	IdentifyGlobalLimit *rate.Limiter `json:"-"` // optional
+
+	// GatewayURL, if not empty, overrides the gateway URL returned by
+	// QueryGateway. It is useful for connecting to a gateway proxy. Discord
+	// is not queried for the URL nor the session start limits when it is set.
+	GatewayURL string `json:"-"` // optional
 }
 
 // DefaultIdentifier creates a new default Identifier
@@ -61,7 +67,13 @@ func (id *Identifier) Wait(ctx context.Context) error {
 
 // QueryGateway queries the gateway for the URL and updates the Identifier with
 // the appropriate information.
+//
+// If GatewayURL is set, then it is returned as-is.
 func (id *Identifier) QueryGateway(ctx context.Context) (gatewayURL string, err error) {
+	if id.GatewayURL != "" {
+		return id.GatewayURL, nil
+	}
+
 	var botData *api.BotData
 
 	if strings.HasPrefix(id.Token, "Bot ") {
@@ -108,7 +120,10 @@ type IdentifyCommand struct {
 	Token      string             `json:"token"`
 	Properties IdentifyProperties `json:"properties"`
 
-	Compress       bool `json:"compress,omitempty"`        // true
+	Compress bool `json:"compress,omitempty"` // true
+	// LargeThreshold is the member count above which Discord stops sending
+	// offline members in the guild member list. It must be between 50 and
+	// 250; 0 lets Discord use its default of 50.
 	LargeThreshold uint `json:"large_threshold,omitempty"` // 50
 
 	Shard *Shard `json:"shard,omitempty"` // [ shard_id, num_shards ]
@@ -119,6 +134,12 @@ type IdentifyCommand struct {
 	// NOT touch this field.
 	ClientState *ClientState `json:"client_state,omitempty"`
 
+	// GuildSubscriptions, if false, disables presence and typing events for
+	// a user account, which greatly reduces the traffic of large accounts. It
+	// is nil by default, which lets Discord enable them. Bot accounts should
+	// use Intents instead.
+	GuildSubscriptions option.Bool `json:"guild_subscriptions,omitempty"`
+
 	// Capabilities defines the client's capabilities when connecting to the
 	// gateway with a user account. Bot accounts should NOT touch this field.
 	// The official client sets this at 125 at the time of this commit.
@@ -134,6 +155,41 @@ type IdentifyCommand struct {
 	Intents option.Uint `json:"intents"`
 }
 
+// Limits of IdentifyCommand.LargeThreshold.
+const (
+	MinLargeThreshold = 50
+	MaxLargeThreshold = 250
+)
+
+// Validate checks the IdentifyCommand for fields that Discord would reject,
+// or fields that don't apply to the account type of the token. It is called
+// by NewWithIdentifier.
+func (i *IdentifyCommand) Validate() error {
+	if i.LargeThreshold != 0 &&
+		(i.LargeThreshold < MinLargeThreshold || i.LargeThreshold > MaxLargeThreshold) {
+		return fmt.Errorf(
+			"large threshold %d is not between %d and %d",
+			i.LargeThreshold, MinLargeThreshold, MaxLargeThreshold)
+	}
+
+	if i.Shard != nil && (i.Shard.NumShards() < 1 || i.Shard.ShardID() >= i.Shard.NumShards()) {
+		return fmt.Errorf("invalid shard %d out of %d", i.Shard.ShardID(), i.Shard.NumShards())
+	}
+
+	if strings.HasPrefix(i.Token, "Bot ") {
+		switch {
+		case i.Capabilities != 0:
+			return errors.New("capabilities are only for user accounts")
+		case i.ClientState != nil:
+			return errors.New("client state is only for user accounts")
+		case i.GuildSubscriptions != nil:
+			return errors.New("guild subscriptions are only for user accounts, use intents instead")
+		}
+	}
+
+	return nil
+}
+
 // DefaultIdentifyCommand creates a default IdentifyCommand with the given token.
 func DefaultIdentifyCommand(token string) IdentifyCommand {
 	return IdentifyCommand{
@@ -155,6 +211,20 @@ func (i *IdentifyCommand) SetShard(id, num int) {
 	i.Shard[0], i.Shard[1] = id, num
 }
 
+// SetPresence sets the presence that the gateway connects with. Setting it
+// avoids the session briefly appearing online without any status or
+// activities before the first UpdatePresenceCommand is sent.
+func (i *IdentifyCommand) SetPresence(status discord.Status, activities ...discord.Activity) {
+	if activities == nil {
+		activities = []discord.Activity{}
+	}
+
+	i.Presence = &UpdatePresenceCommand{
+		Status:     status,
+		Activities: activities,
+	}
+}
+
 // AddIntents adds gateway intents into the identify data.
 func (i *IdentifyCommand) AddIntents(intents Intents) {
 	if i.Intents == nil {
@@ -188,6 +258,73 @@ type IdentifyProperties struct {
 	OSVersion        string `json:"os_version,omitempty"`
 	Referrer         string `json:"referrer,omitempty"`
 	ReferringDomain  string `json:"referring_domain,omitempty"`
+
+	// User accounts only
+	ReleaseChannel    string `json:"release_channel,omitempty"`
+	ClientBuildNumber int    `json:"client_build_number,omitempty"`
+	SystemLocale      string `json:"system_locale,omitempty"`
+}
+
+// IdentifyPreset is a preset of IdentifyProperties. Presets other than
+// DefaultIdentifyPreset describe the official clients, and they are only
+// meant for user accounts.
+type IdentifyPreset uint8
+
+const (
+	// DefaultIdentifyPreset uses DefaultIdentity.
+	DefaultIdentifyPreset IdentifyPreset = iota
+	// WebIdentifyPreset presents the session as the web client running in
+	// Chrome on Windows.
+	WebIdentifyPreset
+	// DesktopIdentifyPreset presents the session as the desktop client on
+	// Windows.
+	DesktopIdentifyPreset
+	// MobileIdentifyPreset presents the session as the Android client.
+	MobileIdentifyPreset
+)
+
+const webUserAgent = "Mozilla/5.0 (Windows NT 10.0; Win64; x64) " +
+	"AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36"
+
+// Properties returns the IdentifyProperties of the preset. ClientBuildNumber
+// is never set, since it changes with every release of the official clients;
+// set it manually if needed.
+func (p IdentifyPreset) Properties() IdentifyProperties {
+	switch p {
+	case WebIdentifyPreset:
+		return IdentifyProperties{
+			OS:               "Windows",
+			OSVersion:        "10",
+			Browser:          "Chrome",
+			BrowserVersion:   "120.0.0.0",
+			BrowserUserAgent: webUserAgent,
+			ReleaseChannel:   "stable",
+			SystemLocale:     "en-US",
+		}
+	case DesktopIdentifyPreset:
+		return IdentifyProperties{
+			OS:             "Windows",
+			OSVersion:      "10.0.19045",
+			Browser:        "Discord Client",
+			ReleaseChannel: "stable",
+			SystemLocale:   "en-US",
+		}
+	case MobileIdentifyPreset:
+		return IdentifyProperties{
+			OS:             "Android",
+			Browser:        "Discord Android",
+			Device:         "Android",
+			ReleaseChannel: "googleRelease",
+			SystemLocale:   "en-US",
+		}
+	default:
+		return DefaultIdentity
+	}
+}
+
+// UsePreset sets the identify properties to the given preset's.
+func (i *IdentifyCommand) UsePreset(preset IdentifyPreset) {
+	i.Properties = preset.Properties()
 }
 
 // Shard is a type for two numbers that represent the Bot's shard configuration.
@@ -0,0 +1,51 @@
+package gateway
+
+import (
+	"context"
+	"testing"
+
+	"github.com/diamondburned/arikawa/v3/utils/json/option"
+)
+
+func TestIdentifyValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*IdentifyCommand)
+		valid  bool
+	}{
+		{"default", func(*IdentifyCommand) {}, true},
+		{"threshold too low", func(i *IdentifyCommand) { i.LargeThreshold = 10 }, false},
+		{"threshold too high", func(i *IdentifyCommand) { i.LargeThreshold = 251 }, false},
+		{"threshold max", func(i *IdentifyCommand) { i.LargeThreshold = 250 }, true},
+		{"shard out of range", func(i *IdentifyCommand) { i.SetShard(2, 2) }, false},
+		{"shard", func(i *IdentifyCommand) { i.SetShard(1, 2) }, true},
+		{"bot capabilities", func(i *IdentifyCommand) { i.Capabilities = 125 }, false},
+		{"bot guild subscriptions", func(i *IdentifyCommand) { i.GuildSubscriptions = option.False }, false},
+		{"user guild subscriptions", func(i *IdentifyCommand) {
+			i.Token = "user token"
+			i.GuildSubscriptions = option.False
+		}, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			id := DefaultIdentifyCommand("Bot token")
+			test.modify(&id)
+
+			if err := id.Validate(); (err == nil) != test.valid {
+				t.Fatalf("expected valid to be %v, got error %v", test.valid, err)
+			}
+		})
+	}
+}
+
+func TestNewWithIdentifierValidates(t *testing.T) {
+	id := DefaultIdentifier("Bot token")
+	id.LargeThreshold = 1
+	// The gateway URL must not be queried for an invalid identifier.
+	id.GatewayURL = "ws://invalid"
+
+	if _, err := NewWithIdentifier(context.Background(), id); err == nil {
+		t.Fatal("expected error for invalid identifier")
+	}
+}
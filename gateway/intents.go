@@ -39,6 +39,7 @@ const IntentGuildBans = IntentGuildModeration
 var PrivilegedIntents = []Intents{
 	IntentGuildPresences,
 	IntentGuildMembers,
+	IntentMessageContent,
 }
 
 // Has returns true if i has the given intents.
@@ -53,6 +54,30 @@ func (i Intents) IsPrivileged() (presences, member bool) {
 	return i.Has(IntentGuildPresences), i.Has(IntentGuildMembers)
 }
 
+// Privileged returns only the privileged intents within i. See
+// PrivilegedIntents.
+func (i Intents) Privileged() Intents {
+	var privileged Intents
+	for _, intent := range PrivilegedIntents {
+		privileged |= intent
+	}
+	return i & privileged
+}
+
+// MissingFor returns the event types within the given list that will never be
+// received with only the intents in i, according to EventIntents. Event types
+// that are not in EventIntents are assumed to not require any intent.
+func (i Intents) MissingFor(eventTypes []ws.EventType) []ws.EventType {
+	var missing []ws.EventType
+	for _, eventType := range eventTypes {
+		required, ok := EventIntents[eventType]
+		if ok && i&required == 0 {
+			missing = append(missing, eventType)
+		}
+	}
+	return missing
+}
+
 // EventIntents maps event types to intents.
 var EventIntents = map[ws.EventType]Intents{
 	"GUILD_CREATE":        IntentGuilds,
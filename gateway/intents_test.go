@@ -0,0 +1,32 @@
+package gateway
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/diamondburned/arikawa/v3/utils/ws"
+)
+
+func TestIntentsMissingFor(t *testing.T) {
+	intents := IntentGuilds | IntentGuildMessages
+
+	missing := intents.MissingFor([]ws.EventType{
+		"GUILD_CREATE",
+		"MESSAGE_CREATE",
+		"GUILD_MEMBER_ADD",
+		"PRESENCE_UPDATE",
+		"READY",
+	})
+
+	expect := []ws.EventType{"GUILD_MEMBER_ADD", "PRESENCE_UPDATE"}
+	if !reflect.DeepEqual(missing, expect) {
+		t.Fatalf("expected missing %v, got %v", expect, missing)
+	}
+}
+
+func TestIntentsPrivileged(t *testing.T) {
+	intents := IntentGuilds | IntentGuildMembers | IntentMessageContent
+	if p := intents.Privileged(); p != IntentGuildMembers|IntentMessageContent {
+		t.Fatalf("unexpected privileged intents %d", p)
+	}
+}
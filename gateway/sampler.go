@@ -0,0 +1,281 @@
+package gateway
+
+import (
+	"sync"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/utils/ws"
+)
+
+// samplerSweepInterval is how often EventSampler forgets about keys that
+// haven't been seen for longer than their rule's interval.
+const samplerSweepInterval = time.Minute
+
+// SampleRule describes how EventSampler thins out events of one type.
+type SampleRule struct {
+	// Interval is the minimum time between two events with the same key. Events
+	// that arrive sooner are dropped or coalesced.
+	Interval time.Duration
+	// Coalesce, if true, makes EventSampler hold on to the last event that
+	// arrived too soon and deliver it once the interval is over, instead of
+	// dropping it. This way, the latest state is never lost.
+	Coalesce bool
+	// Key returns the key that events are sampled by, which must be comparable.
+	// If it is nil, then all events of the type share the same key.
+	Key func(ws.Event) interface{}
+}
+
+// TypingStartRule returns a SampleRule that drops TypingStartEvents of a user
+// in a channel that arrive within interval of the last one.
+func TypingStartRule(interval time.Duration) SampleRule {
+	type typingKey struct {
+		channelID discord.ChannelID
+		userID    discord.UserID
+	}
+
+	return SampleRule{
+		Interval: interval,
+		Key: func(ev ws.Event) interface{} {
+			typing := ev.(*TypingStartEvent)
+			return typingKey{typing.ChannelID, typing.UserID}
+		},
+	}
+}
+
+// PresenceUpdateRule returns a SampleRule that coalesces PresenceUpdateEvents
+// of a user in a guild, so that at most one is delivered every interval.
+func PresenceUpdateRule(interval time.Duration) SampleRule {
+	type presenceKey struct {
+		guildID discord.GuildID
+		userID  discord.UserID
+	}
+
+	return SampleRule{
+		Interval: interval,
+		Coalesce: true,
+		Key: func(ev ws.Event) interface{} {
+			presence := ev.(*PresenceUpdateEvent)
+			return presenceKey{presence.GuildID, presence.User.ID}
+		},
+	}
+}
+
+// EventSampler drops or coalesces high-frequency events, such as
+// TypingStartEvent and PresenceUpdateEvent, before they reach handlers. It
+// sits between the Op channel of a Gateway and whatever reads from it; see
+// Loop. Events of types without a rule are passed through untouched.
+//
+// An EventSampler is safe to use concurrently, and its rules may be changed
+// while it is running.
+type EventSampler struct {
+	mu      sync.Mutex
+	rules   map[ws.EventType]SampleRule
+	dropped map[ws.EventType]uint64
+}
+
+// NewEventSampler creates a new EventSampler with the given rules.
+func NewEventSampler(rules map[ws.EventType]SampleRule) *EventSampler {
+	s := &EventSampler{
+		rules:   make(map[ws.EventType]SampleRule, len(rules)),
+		dropped: make(map[ws.EventType]uint64),
+	}
+
+	for t, rule := range rules {
+		s.rules[t] = rule
+	}
+
+	return s
+}
+
+// SetRule sets the rule for events of the given type. A rule with a zero
+// Interval removes the rule.
+func (s *EventSampler) SetRule(t ws.EventType, rule SampleRule) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if rule.Interval <= 0 {
+		delete(s.rules, t)
+	} else {
+		s.rules[t] = rule
+	}
+}
+
+// Dropped returns the number of events of the given type that were dropped,
+// including coalesced events that were replaced by newer ones.
+func (s *EventSampler) Dropped(t ws.EventType) uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.dropped[t]
+}
+
+// DroppedCounts returns a copy of the number of dropped events of every type
+// that had events dropped.
+func (s *EventSampler) DroppedCounts() map[ws.EventType]uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	counts := make(map[ws.EventType]uint64, len(s.dropped))
+	for t, n := range s.dropped {
+		counts[t] = n
+	}
+
+	return counts
+}
+
+func (s *EventSampler) rule(t ws.EventType) (SampleRule, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rule, ok := s.rules[t]
+	return rule, ok
+}
+
+func (s *EventSampler) drop(t ws.EventType) {
+	s.mu.Lock()
+	s.dropped[t]++
+	s.mu.Unlock()
+}
+
+type sampleKey struct {
+	typ ws.EventType
+	key interface{}
+}
+
+type sampleEntry struct {
+	last     time.Time
+	interval time.Duration
+	pending  *ws.Op
+}
+
+func (e *sampleEntry) deadline() time.Time {
+	return e.last.Add(e.interval)
+}
+
+// Loop starts a background goroutine that reads from src and sends the events
+// that pass the sampler's rules into the returned channel, which is closed once
+// src is closed. Coalesced events that are still held back when src is closed
+// are dropped.
+//
+// To sample the events of a Session, set its EventSampler field instead.
+func (s *EventSampler) Loop(src <-chan ws.Op) <-chan ws.Op {
+	dst := make(chan ws.Op)
+	go s.loop(src, dst)
+	return dst
+}
+
+func (s *EventSampler) loop(src <-chan ws.Op, dst chan<- ws.Op) {
+	defer close(dst)
+
+	entries := make(map[sampleKey]*sampleEntry)
+
+	flush := time.NewTimer(0)
+	defer flush.Stop()
+	<-flush.C
+
+	var flushAt time.Time
+
+	// resetFlush makes the flush timer fire at the earliest deadline of the
+	// pending events.
+	resetFlush := func() {
+		var earliest time.Time
+		for _, entry := range entries {
+			if entry.pending != nil && (earliest.IsZero() || entry.deadline().Before(earliest)) {
+				earliest = entry.deadline()
+			}
+		}
+
+		if earliest.Equal(flushAt) {
+			return
+		}
+
+		if !flushAt.IsZero() && !flush.Stop() {
+			<-flush.C
+		}
+
+		flushAt = earliest
+		if !flushAt.IsZero() {
+			flush.Reset(time.Until(flushAt))
+		}
+	}
+
+	sweep := time.NewTicker(samplerSweepInterval)
+	defer sweep.Stop()
+
+	for {
+		select {
+		case op, ok := <-src:
+			if !ok {
+				for _, entry := range entries {
+					if entry.pending != nil {
+						s.drop(entry.pending.Type)
+					}
+				}
+				return
+			}
+
+			rule, ok := s.rule(op.Type)
+			if !ok || op.Data == nil {
+				dst <- op
+				continue
+			}
+
+			key := sampleKey{typ: op.Type}
+			if rule.Key != nil {
+				key.key = rule.Key(op.Data)
+			}
+
+			now := time.Now()
+
+			entry, ok := entries[key]
+			if !ok {
+				entry = &sampleEntry{}
+				entries[key] = entry
+			}
+			entry.interval = rule.Interval
+
+			if entry.pending == nil && !now.Before(entry.deadline()) {
+				entry.last = now
+				dst <- op
+				continue
+			}
+
+			if !rule.Coalesce {
+				s.drop(op.Type)
+				continue
+			}
+
+			if entry.pending != nil {
+				s.drop(op.Type)
+			}
+
+			entry.pending = &op
+			resetFlush()
+
+		case <-flush.C:
+			flushAt = time.Time{}
+			now := time.Now()
+
+			for _, entry := range entries {
+				if entry.pending != nil && !now.Before(entry.deadline()) {
+					op := *entry.pending
+					entry.pending = nil
+					entry.last = now
+					dst <- op
+				}
+			}
+
+			resetFlush()
+
+		case <-sweep.C:
+			now := time.Now()
+
+			for key, entry := range entries {
+				if entry.pending == nil && !now.Before(entry.deadline()) {
+					delete(entries, key)
+				}
+			}
+		}
+	}
+}
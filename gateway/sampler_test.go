@@ -0,0 +1,86 @@
+package gateway
+
+import (
+	"testing"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/utils/ws"
+)
+
+func sampledOp(ev ws.Event) ws.Op {
+	return ws.Op{Code: ev.Op(), Type: ev.EventType(), Data: ev}
+}
+
+func TestEventSamplerDrop(t *testing.T) {
+	sampler := NewEventSampler(map[ws.EventType]SampleRule{
+		"TYPING_START": TypingStartRule(time.Hour),
+	})
+
+	src := make(chan ws.Op)
+	dst := sampler.Loop(src)
+
+	go func() {
+		src <- sampledOp(&TypingStartEvent{ChannelID: 1, UserID: 1})
+		src <- sampledOp(&TypingStartEvent{ChannelID: 1, UserID: 1})
+		src <- sampledOp(&TypingStartEvent{ChannelID: 1, UserID: 2})
+		src <- sampledOp(&MessageDeleteEvent{ID: 1})
+		close(src)
+	}()
+
+	var got []ws.Event
+	for op := range dst {
+		got = append(got, op.Data)
+	}
+
+	if len(got) != 3 {
+		t.Fatalf("expected 3 events, got %d", len(got))
+	}
+	if typing := got[1].(*TypingStartEvent); typing.UserID != 2 {
+		t.Fatal("unexpected second event from user", typing.UserID)
+	}
+	if n := sampler.Dropped("TYPING_START"); n != 1 {
+		t.Fatal("expected 1 dropped event, got", n)
+	}
+}
+
+func TestEventSamplerCoalesce(t *testing.T) {
+	const interval = 20 * time.Millisecond
+
+	sampler := NewEventSampler(map[ws.EventType]SampleRule{
+		"PRESENCE_UPDATE": PresenceUpdateRule(interval),
+	})
+
+	src := make(chan ws.Op)
+	dst := sampler.Loop(src)
+	defer close(src)
+
+	presence := func(status discord.Status) ws.Op {
+		return sampledOp(&PresenceUpdateEvent{discord.Presence{
+			User:    discord.User{ID: 1},
+			GuildID: 1,
+			Status:  status,
+		}})
+	}
+
+	src <- presence(discord.OnlineStatus)
+	if ev := (<-dst).Data.(*PresenceUpdateEvent); ev.Status != discord.OnlineStatus {
+		t.Fatal("unexpected first status", ev.Status)
+	}
+
+	src <- presence(discord.IdleStatus)
+	src <- presence(discord.DoNotDisturbStatus)
+
+	start := time.Now()
+
+	if ev := (<-dst).Data.(*PresenceUpdateEvent); ev.Status != discord.DoNotDisturbStatus {
+		t.Fatal("expected coalesced status to be the latest, got", ev.Status)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatal("coalesced event took too long:", elapsed)
+	}
+
+	if n := sampler.DroppedCounts()["PRESENCE_UPDATE"]; n != 1 {
+		t.Fatal("expected 1 dropped event, got", n)
+	}
+}
@@ -0,0 +1,80 @@
+package gateway
+
+import "github.com/diamondburned/arikawa/v3/utils/ws"
+
+// SequenceGapEvent is sent into the gateway's event channel when the sequence
+// number of a dispatch event skips ahead of the last one received, which means
+// that Discord never delivered the events in between. It is most commonly seen
+// right after a RESUME, when Discord could not replay everything that was
+// missed.
+//
+// Since the missing events are lost, any cached state may now be stale. The
+// user may want to reconnect with a fresh Identify and refetch whatever state
+// it relies on.
+type SequenceGapEvent struct {
+	// Last is the last sequence number received before the gap.
+	Last int64
+	// Received is the sequence number that revealed the gap.
+	Received int64
+	// Resuming is true if the gap was found while Discord was replaying events
+	// after a RESUME.
+	Resuming bool
+	// Stats is a snapshot of the gateway's sequence statistics, including this
+	// gap.
+	Stats SequenceStats
+}
+
+var _ ws.Event = (*SequenceGapEvent)(nil)
+
+// Missed returns the number of events that were lost in the gap.
+func (e *SequenceGapEvent) Missed() int64 { return e.Received - e.Last - 1 }
+
+// Op implements Op. It returns -1.
+func (e *SequenceGapEvent) Op() ws.OpCode { return -1 }
+
+// EventType implements Op. It returns an opaque unique string.
+func (e *SequenceGapEvent) EventType() ws.EventType {
+	return "__gateway.SequenceGapEvent"
+}
+
+// SequenceStats contains counters of the sequence gaps that a gateway has
+// found over its lifetime.
+type SequenceStats struct {
+	// Gaps is the number of gaps found.
+	Gaps uint64
+	// Missed is the total number of events lost across all gaps.
+	Missed uint64
+}
+
+// SequenceStats returns the counters of the sequence gaps that the gateway has
+// found so far. It is safe to call while the gateway is running.
+func (g *Gateway) SequenceStats() SequenceStats {
+	g.seqMutex.Lock()
+	defer g.seqMutex.Unlock()
+
+	return g.seqStats
+}
+
+// checkSequence checks the sequence number of a dispatch event against the last
+// one and returns a SequenceGapEvent if any events were skipped. The gap is
+// counted in the gateway's SequenceStats.
+func (g *gatewayImpl) checkSequence(seq int64) *SequenceGapEvent {
+	last := g.state.Sequence
+	if last == 0 || seq <= last+1 {
+		return nil
+	}
+
+	ev := &SequenceGapEvent{
+		Last:     last,
+		Received: seq,
+		Resuming: g.resuming,
+	}
+
+	g.seqMutex.Lock()
+	g.seqStats.Gaps++
+	g.seqStats.Missed += uint64(ev.Missed())
+	ev.Stats = g.seqStats
+	g.seqMutex.Unlock()
+
+	return ev
+}
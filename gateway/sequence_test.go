@@ -0,0 +1,77 @@
+package gateway
+
+import (
+	"context"
+	"testing"
+
+	"github.com/diamondburned/arikawa/v3/utils/ws"
+)
+
+func TestCheckSequence(t *testing.T) {
+	g := &gatewayImpl{Gateway: &Gateway{}}
+
+	next := func(seq int64) *SequenceGapEvent {
+		t.Helper()
+
+		gap := g.checkSequence(seq)
+		g.state.Sequence = seq
+		return gap
+	}
+
+	for seq := int64(1); seq <= 3; seq++ {
+		if gap := next(seq); gap != nil {
+			t.Fatalf("unexpected gap at in-order sequence %d: %+v", seq, gap)
+		}
+	}
+
+	gap := next(7)
+	if gap == nil {
+		t.Fatal("expected a gap from 3 to 7")
+	}
+	if gap.Last != 3 || gap.Received != 7 || gap.Resuming {
+		t.Fatalf("unexpected gap %+v", gap)
+	}
+	if missed := gap.Missed(); missed != 3 {
+		t.Fatal("expected 3 missed events, got", missed)
+	}
+
+	// Replays after a resume may repeat sequence numbers.
+	if gap := next(7); gap != nil {
+		t.Fatalf("unexpected gap for a repeated sequence: %+v", gap)
+	}
+
+	g.resuming = true
+
+	gap = next(10)
+	if gap == nil || !gap.Resuming || gap.Missed() != 2 {
+		t.Fatalf("unexpected gap while resuming %+v", gap)
+	}
+	if gap.Stats != (SequenceStats{Gaps: 2, Missed: 5}) {
+		t.Fatalf("unexpected stats in gap %+v", gap.Stats)
+	}
+
+	g.OnOp(context.Background(), ws.Op{Code: dispatchOp, Sequence: 11, Data: &ResumedEvent{}})
+	if g.resuming {
+		t.Fatal("expected Resumed to stop resuming")
+	}
+	if gap := next(13); gap == nil || gap.Resuming {
+		t.Fatalf("unexpected gap after resuming %+v", gap)
+	}
+
+	// A new session starts over from the first sequence.
+	g.invalidate()
+
+	if g.resuming {
+		t.Fatal("expected invalidate to stop resuming")
+	}
+	if gap := next(1); gap != nil {
+		t.Fatalf("unexpected gap after reset: %+v", gap)
+	}
+	if gap := next(2); gap != nil {
+		t.Fatalf("unexpected gap after reset: %+v", gap)
+	}
+
+	if stats := g.SequenceStats(); stats != (SequenceStats{Gaps: 3, Missed: 6}) {
+		t.Fatalf("unexpected stats %+v", stats)
+	}
+}
@@ -37,3 +37,14 @@ func (sm *Map) LoadOrStore(k interface{}) (lv interface{}, loaded bool) {
 func (sm *Map) Load(k interface{}) (lv interface{}, ok bool) {
 	return sm.val.Load().(*syncmod.Map).Load(k)
 }
+
+// Delete deletes the value with the given key, if any.
+func (sm *Map) Delete(k interface{}) {
+	sm.val.Load().(*syncmod.Map).Delete(k)
+}
+
+// Range calls f for each key and value in the map until f returns false. See
+// sync.Map's Range.
+func (sm *Map) Range(f func(k, v interface{}) bool) {
+	sm.val.Load().(*syncmod.Map).Range(f)
+}
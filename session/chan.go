@@ -0,0 +1,69 @@
+//go:build go1.18
+// +build go1.18
+
+package session
+
+import "sync"
+
+// Chan returns a channel that receives all events of type T, which must be a
+// pointer to an event type or an interface, just like handlers given to
+// AddHandler. The handler is registered immediately.
+//
+// Events are sent in the order they are received, since the handler is
+// synchronous. This also means that once the buffer is full, the session's
+// event loop is blocked until the channel is read from or stop is called.
+//
+// Calling the returned stop function removes the handler and closes the
+// channel. Events that are still waiting to be sent are dropped. The stop
+// function may be called multiple times.
+//
+//	msgs, stop := session.Chan[*gateway.MessageCreateEvent](s, 10)
+//	defer stop()
+//
+//	for {
+//		select {
+//		case msg := <-msgs:
+//			log.Println(msg.Content)
+//		case <-ctx.Done():
+//			return
+//		}
+//	}
+func Chan[T any](s *Session, buffer int) (ch <-chan T, stop func()) {
+	out := make(chan T, buffer)
+	done := make(chan struct{})
+
+	// mut guards closing out against ongoing sends.
+	var mut sync.RWMutex
+	var closed bool
+
+	rm := s.AddSyncHandler(func(ev T) {
+		mut.RLock()
+		defer mut.RUnlock()
+
+		if closed {
+			return
+		}
+
+		select {
+		case out <- ev:
+		case <-done:
+		}
+	})
+
+	var once sync.Once
+	stop = func() {
+		once.Do(func() {
+			// Unblock any ongoing send before removing the handler, since
+			// removing it waits for the handlers being called.
+			close(done)
+			rm()
+
+			mut.Lock()
+			closed = true
+			close(out)
+			mut.Unlock()
+		})
+	}
+
+	return out, stop
+}
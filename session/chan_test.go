@@ -0,0 +1,91 @@
+//go:build go1.18
+// +build go1.18
+
+package session
+
+import (
+	"testing"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/gateway"
+)
+
+func TestChan(t *testing.T) {
+	s := New("")
+
+	ch, stop := Chan[*gateway.MessageCreateEvent](s, 1)
+
+	ev := &gateway.MessageCreateEvent{}
+	s.Handler.Call(ev)
+	s.Handler.Call(&gateway.ReadyEvent{})
+
+	select {
+	case got := <-ch:
+		if got != ev {
+			t.Fatal("received unexpected event")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for event")
+	}
+
+	stop()
+	stop()
+
+	if _, ok := <-ch; ok {
+		t.Fatal("channel not closed after stop")
+	}
+
+	// Events after stop must not panic.
+	s.Handler.Call(&gateway.MessageCreateEvent{})
+}
+
+func TestChanOrder(t *testing.T) {
+	s := New("")
+
+	ch, stop := Chan[*gateway.MessageCreateEvent](s, 0)
+	defer stop()
+
+	const n = 100
+
+	go func() {
+		for i := 0; i < n; i++ {
+			ev := &gateway.MessageCreateEvent{}
+			ev.ID = discord.MessageID(i + 1)
+			s.Handler.Call(ev)
+		}
+	}()
+
+	for i := 0; i < n; i++ {
+		select {
+		case got := <-ch:
+			if got.ID != discord.MessageID(i+1) {
+				t.Fatalf("expected message %d, got %d", i+1, got.ID)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("timed out waiting for event", i)
+		}
+	}
+}
+
+func TestChanStopBlocked(t *testing.T) {
+	s := New("")
+
+	_, stop := Chan[*gateway.MessageCreateEvent](s, 0)
+
+	called := make(chan struct{})
+	go func() {
+		s.Handler.Call(&gateway.MessageCreateEvent{})
+		close(called)
+	}()
+
+	// Give the handler time to block on the unbuffered channel.
+	time.Sleep(10 * time.Millisecond)
+	stop()
+
+	select {
+	case <-called:
+	case <-time.After(time.Second):
+		t.Fatal("stop did not unblock the handler")
+	}
+}
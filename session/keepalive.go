@@ -0,0 +1,64 @@
+package session
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/gateway"
+)
+
+// keepAlivePresence returns the presence to be sent by the presence keep-alive,
+// which is the last presence sent over the gateway, or the identify presence if
+// none was sent yet.
+func (s *Session) keepAlivePresence() *gateway.UpdatePresenceCommand {
+	s.state.presenceMu.Lock()
+	defer s.state.presenceMu.Unlock()
+
+	if s.state.presence != nil {
+		cpy := *s.state.presence
+		return &cpy
+	}
+
+	if s.state.id.Presence != nil {
+		cpy := *s.state.id.Presence
+		return &cpy
+	}
+
+	return &gateway.UpdatePresenceCommand{
+		Status:     discord.OnlineStatus,
+		Activities: []discord.Activity{},
+	}
+}
+
+// setPresence records the presence last sent over the gateway.
+func (s *Session) setPresence(presence *gateway.UpdatePresenceCommand) {
+	cpy := *presence
+
+	s.state.presenceMu.Lock()
+	s.state.presence = &cpy
+	s.state.presenceMu.Unlock()
+}
+
+// isUserAccount returns true if the session is identified with a user token.
+func (s *Session) isUserAccount() bool {
+	return !strings.HasPrefix(s.state.id.Token, "Bot ")
+}
+
+// keepPresenceAlive sends the presence every interval until ctx is done.
+func (s *Session) keepPresenceAlive(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			// Errors are not fatal here: the gateway reconnects on its own,
+			// and the presence is sent again on the next tick.
+			s.SendGateway(ctx, s.keepAlivePresence())
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package session
+
+import (
+	"testing"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/gateway"
+)
+
+func TestKeepAlivePresence(t *testing.T) {
+	id := gateway.DefaultIdentifier("token")
+	id.UsePreset(gateway.WebIdentifyPreset)
+
+	s := NewWithIdentifier(id)
+	if !s.isUserAccount() {
+		t.Fatal("session with a user token is not a user account")
+	}
+
+	if p := s.keepAlivePresence(); p.Status != discord.OnlineStatus {
+		t.Fatalf("expected online presence by default, got %q", p.Status)
+	}
+
+	s.state.id.Presence = &gateway.UpdatePresenceCommand{Status: discord.IdleStatus}
+	if p := s.keepAlivePresence(); p.Status != discord.IdleStatus {
+		t.Fatalf("expected identify presence, got %q", p.Status)
+	}
+
+	s.setPresence(&gateway.UpdatePresenceCommand{Status: discord.DoNotDisturbStatus})
+	if p := s.keepAlivePresence(); p.Status != discord.DoNotDisturbStatus {
+		t.Fatalf("expected last sent presence, got %q", p.Status)
+	}
+
+	if New("Bot token").isUserAccount() {
+		t.Fatal("session with a bot token is a user account")
+	}
+}
+
+func TestSetIdentifyPresence(t *testing.T) {
+	id := gateway.DefaultIdentifier("Bot token")
+	id.SetPresence(discord.IdleStatus, discord.Activity{Name: "arikawa"})
+
+	if id.Presence == nil || id.Presence.Status != discord.IdleStatus {
+		t.Fatalf("unexpected identify presence %+v", id.Presence)
+	}
+
+	s := NewWithIdentifier(id)
+	s.SetIdentifyPresence(&gateway.UpdatePresenceCommand{Status: discord.DoNotDisturbStatus})
+
+	if p := s.state.id.Presence; p == nil || p.Status != discord.DoNotDisturbStatus {
+		t.Fatalf("unexpected session identify presence %+v", p)
+	}
+
+	s.setPresence(&gateway.UpdatePresenceCommand{Status: discord.OnlineStatus})
+	s.SetIdentifyPresence(&gateway.UpdatePresenceCommand{Status: discord.InvisibleStatus})
+
+	if s.state.presence != nil {
+		t.Fatalf("expected the last sent presence to be cleared, got %+v", s.state.presence)
+	}
+}
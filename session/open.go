@@ -0,0 +1,137 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/diamondburned/arikawa/v3/gateway"
+	"github.com/diamondburned/arikawa/v3/utils/ws"
+)
+
+// OpenStage is the stage of the gateway handshake that Open is at.
+type OpenStage uint8
+
+const (
+	// OpenConnecting means that the gateway is being dialed, or that no
+	// Identify or Resume command was sent yet. The latter usually means that
+	// the identify rate limits are being waited on.
+	OpenConnecting OpenStage = iota
+	// OpenIdentifying means that an Identify or Resume command was sent, and
+	// the session is waiting for the Ready or Resumed event.
+	OpenIdentifying
+	// OpenReady means that the Ready or Resumed event was received.
+	OpenReady
+)
+
+// String returns a description of the stage.
+func (s OpenStage) String() string {
+	switch s {
+	case OpenConnecting:
+		return "connecting"
+	case OpenIdentifying:
+		return "waiting for ready"
+	case OpenReady:
+		return "ready"
+	default:
+		return fmt.Sprintf("OpenStage(%d)", uint8(s))
+	}
+}
+
+// OpenTimeoutError is returned by OpenAndWait if the context expires before
+// the session is ready. It describes how far the handshake went.
+type OpenTimeoutError struct {
+	// Stage is the last handshake stage reached.
+	Stage OpenStage
+	// LastEvent is the name of the last event received from the gateway, or
+	// an empty string if none was received. See gateway.EventType.
+	LastEvent string
+	// Err is the context error.
+	Err error
+}
+
+// Error implements error.
+func (err *OpenTimeoutError) Error() string {
+	lastEvent := err.LastEvent
+	if lastEvent == "" {
+		lastEvent = "none"
+	}
+
+	return fmt.Sprintf("session not ready while %s (last event: %s): %v",
+		err.Stage, lastEvent, err.Err)
+}
+
+// Unwrap returns the context error.
+func (err *OpenTimeoutError) Unwrap() error {
+	return err.Err
+}
+
+// OpenAndWait opens the session like Open, but it always waits until the Ready
+// or Resumed event is received, regardless of DontWaitForReady. Once it
+// returns without an error, the state is populated, so e.g. Me no longer
+// fails.
+//
+// If ctx expires before then, an *OpenTimeoutError describing the handshake
+// progress is returned. It unwraps to the context error.
+func (s *Session) OpenAndWait(ctx context.Context) error {
+	var progress openProgress
+
+	err := s.open(ctx, &progress)
+	if err != nil && ctx.Err() != nil && errors.Is(err, ctx.Err()) {
+		stage, lastEvent := progress.get()
+		return &OpenTimeoutError{
+			Stage:     stage,
+			LastEvent: lastEvent,
+			Err:       err,
+		}
+	}
+
+	return err
+}
+
+// openProgress tracks the progress of the gateway handshake.
+type openProgress struct {
+	mu        sync.Mutex
+	stage     OpenStage
+	lastEvent string
+}
+
+func (p *openProgress) get() (OpenStage, string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.stage, p.lastEvent
+}
+
+// advance sets the stage if it's further than the current one.
+func (p *openProgress) advance(stage OpenStage) {
+	if p.stage < stage {
+		p.stage = stage
+	}
+}
+
+func (p *openProgress) onSend(cmd ws.Event) {
+	switch cmd.(type) {
+	case *gateway.IdentifyCommand, *gateway.ResumeCommand:
+		p.mu.Lock()
+		p.advance(OpenIdentifying)
+		p.mu.Unlock()
+	}
+}
+
+func (p *openProgress) onEvent(ev interface{}) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if ev, ok := ev.(ws.Event); ok {
+		if name := gateway.EventType(ev); name != "" {
+			p.lastEvent = name
+		}
+	}
+
+	switch ev.(type) {
+	case *gateway.ReadyEvent, *gateway.ResumedEvent:
+		p.advance(OpenReady)
+	}
+}
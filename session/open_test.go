@@ -0,0 +1,46 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/diamondburned/arikawa/v3/gateway"
+)
+
+func TestOpenProgress(t *testing.T) {
+	var p openProgress
+
+	// The gateway sends Identify before Hello reaches the handlers.
+	p.onSend(&gateway.IdentifyCommand{})
+	p.onEvent(&gateway.HelloEvent{})
+
+	stage, lastEvent := p.get()
+	if stage != OpenIdentifying || lastEvent != "HELLO" {
+		t.Fatalf("unexpected progress %v, %q", stage, lastEvent)
+	}
+
+	p.onEvent(&gateway.ReadyEvent{})
+
+	if stage, _ := p.get(); stage != OpenReady {
+		t.Fatalf("expected ready, got %v", stage)
+	}
+}
+
+func TestOpenTimeoutError(t *testing.T) {
+	var err error = &OpenTimeoutError{
+		Stage:     OpenIdentifying,
+		LastEvent: "HELLO",
+		Err:       context.DeadlineExceeded,
+	}
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatal("error does not unwrap to the context error")
+	}
+
+	const msg = "session not ready while waiting for ready (last event: HELLO): " +
+		"context deadline exceeded"
+	if err.Error() != msg {
+		t.Fatalf("unexpected error message %q", err.Error())
+	}
+}
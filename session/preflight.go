@@ -0,0 +1,134 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/gateway"
+	"github.com/diamondburned/arikawa/v3/utils/httputil"
+)
+
+// InvalidTokenError is returned by Preflight if Discord rejects the token.
+type InvalidTokenError struct {
+	Err error
+}
+
+// Unwrap returns the underlying error.
+func (err *InvalidTokenError) Unwrap() error { return err.Err }
+
+// Error implements error.
+func (err *InvalidTokenError) Error() string {
+	return "invalid token: " + err.Err.Error()
+}
+
+// PrivilegedIntentsError is returned by Preflight if privileged intents are
+// requested but are not enabled for the bot in the Developer Portal.
+type PrivilegedIntentsError struct {
+	// Missing is the requested privileged intents that are not enabled.
+	Missing gateway.Intents
+}
+
+// Error implements error.
+func (err *PrivilegedIntentsError) Error() string {
+	return fmt.Sprintf(
+		"privileged intents %d are requested but are not enabled for this bot "+
+			"in the Developer Portal", err.Missing)
+}
+
+// SessionStartLimitError is returned by Preflight if the bot has no session
+// starts left. Opening the gateway would fail until ResetAfter has passed.
+type SessionStartLimitError struct {
+	Limit api.SessionStartLimit
+}
+
+// ResetAfter returns the duration after which the session start limit is
+// reset.
+func (err *SessionStartLimitError) ResetAfter() time.Duration {
+	return err.Limit.ResetAfter.Duration()
+}
+
+// Error implements error.
+func (err *SessionStartLimitError) Error() string {
+	return fmt.Sprintf(
+		"all %d session starts are used up, resetting after %v",
+		err.Limit.Total, err.ResetAfter())
+}
+
+// privilegedAppFlags maps each privileged intent to the application flags
+// that enable it.
+var privilegedAppFlags = map[gateway.Intents]discord.ApplicationFlags{
+	gateway.IntentGuildPresences: discord.AppFlagGatewayPresence |
+		discord.AppFlagGatewayPresenceLimited,
+	gateway.IntentGuildMembers: discord.AppFlagGatewayGuildMembers |
+		discord.AppFlagGatewayGuildMembersLimited,
+	gateway.IntentMessageContent: discord.AppFlagGatewayMessageContent |
+		discord.AppFlagGatewayMessageContentLimited,
+}
+
+// Preflight checks that the Session can be opened before opening it, so that
+// startup problems are reported as actionable errors. It checks that:
+//
+//   - the token is valid, or an *InvalidTokenError is returned;
+//   - the requested privileged intents are enabled for the bot, or a
+//     *PrivilegedIntentsError is returned; and
+//   - the bot has session starts left, or a *SessionStartLimitError is
+//     returned.
+//
+// The last two checks are only done for bot accounts. Preflight is optional
+// and does not need to be called before Open.
+func (s *Session) Preflight(ctx context.Context) error {
+	client := s.Client.WithContext(ctx)
+
+	me, err := client.Me()
+	if err != nil {
+		var httpErr *httputil.HTTPError
+		if errors.As(err, &httpErr) && httpErr.Status == http.StatusUnauthorized {
+			return &InvalidTokenError{err}
+		}
+		return fmt.Errorf("failed to get current user: %w", err)
+	}
+
+	if !me.Bot {
+		return nil
+	}
+
+	s.state.Lock()
+	intents := s.state.id.Intents
+	s.state.Unlock()
+
+	if intents != nil {
+		if privileged := gateway.Intents(*intents).Privileged(); privileged != 0 {
+			app, err := client.CurrentApplication()
+			if err != nil {
+				return fmt.Errorf("failed to get current application: %w", err)
+			}
+
+			var missing gateway.Intents
+			for intent, flags := range privilegedAppFlags {
+				if privileged.Has(intent) && app.Flags&flags == 0 {
+					missing |= intent
+				}
+			}
+
+			if missing != 0 {
+				return &PrivilegedIntentsError{Missing: missing}
+			}
+		}
+	}
+
+	botData, err := client.BotURL()
+	if err != nil {
+		return fmt.Errorf("failed to get gateway bot data: %w", err)
+	}
+
+	if botData.StartLimit != nil && botData.StartLimit.Remaining <= 0 {
+		return &SessionStartLimitError{Limit: *botData.StartLimit}
+	}
+
+	return nil
+}
@@ -0,0 +1,73 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/gateway"
+	"github.com/diamondburned/arikawa/v3/utils/handler"
+	"github.com/diamondburned/arikawa/v3/utils/json/option"
+)
+
+func TestPreflight(t *testing.T) {
+	var (
+		meStatus  = http.StatusOK
+		appFlags  = "0"
+		remaining = "1"
+	)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(api.Path+"/users/@me", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(meStatus)
+		w.Write([]byte(`{"id":"1","username":"arikawa","bot":true}`))
+	})
+	mux.HandleFunc(api.Path+"/oauth2/applications/@me", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id":"2","flags":` + appFlags + `}`))
+	})
+	mux.HandleFunc(api.Path+"/gateway/bot", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"url":"wss://localhost","session_start_limit":{` +
+			`"total":1000,"remaining":` + remaining + `,"reset_after":1000}}`))
+	})
+
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	id := gateway.DefaultIdentifier("Bot token")
+	id.Intents = option.NewUint(uint(gateway.IntentGuildMembers | gateway.IntentGuildMessages))
+
+	client := api.NewClient(id.Token).WithBaseURL(srv.URL).WithoutRateLimit()
+	client.Retries = 1
+
+	s := NewCustom(id, client, handler.New())
+	ctx := context.Background()
+
+	if err := s.Preflight(ctx); err == nil {
+		t.Fatal("expected error for disabled privileged intents")
+	} else {
+		var intentsErr *PrivilegedIntentsError
+		if !errors.As(err, &intentsErr) || intentsErr.Missing != gateway.IntentGuildMembers {
+			t.Fatal("unexpected error:", err)
+		}
+	}
+
+	appFlags = "32768" // AppFlagGatewayGuildMembersLimited
+	if err := s.Preflight(ctx); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	remaining = "0"
+	var limitErr *SessionStartLimitError
+	if err := s.Preflight(ctx); !errors.As(err, &limitErr) {
+		t.Fatal("expected SessionStartLimitError, got", err)
+	}
+
+	meStatus = http.StatusUnauthorized
+	var tokenErr *InvalidTokenError
+	if err := s.Preflight(ctx); !errors.As(err, &tokenErr) {
+		t.Fatal("expected InvalidTokenError, got", err)
+	}
+}
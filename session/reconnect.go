@@ -0,0 +1,177 @@
+package session
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/gateway"
+	"github.com/diamondburned/arikawa/v3/internal/lazytime"
+	"github.com/diamondburned/arikawa/v3/utils/ws"
+)
+
+// ReconnectingEvent is dispatched into the Session's handler right before it
+// waits to reconnect, either in Connect after the gateway died or failed to
+// open, or in the gateway itself when it reconnects on its own.
+type ReconnectingEvent struct {
+	// Attempt is the number of the upcoming reconnection attempt. It starts at
+	// 1 and is reset once the gateway is ready or resumed.
+	Attempt int
+	// Backoff is how long the session waits before the attempt.
+	Backoff time.Duration
+	// Err is the error that caused the reconnection. It is nil if Discord
+	// asked for the reconnection.
+	Err error
+}
+
+// ReconnectPolicy describes how a Session reconnects the gateway.
+//
+// If a Session has a ReconnectPolicy, then the gateways that it creates give up
+// after a single failed dial instead of retrying by themselves, and they ask
+// the policy before reconnecting on their own, so that every reconnection goes
+// through the policy. Gateways given to NewWithGateway keep their own options,
+// so only the reconnections done by Connect go through the policy.
+type ReconnectPolicy struct {
+	// MaxRetries is the maximum number of consecutive reconnection attempts
+	// before the session gives up and returns the last error. If it is 0, then
+	// the session retries forever.
+	MaxRetries int
+	// Backoff returns how long to wait before the given attempt, which starts
+	// at 1. If it is nil, then the session reconnects immediately. See
+	// ExponentialBackoff.
+	Backoff func(attempt int) time.Duration
+	// FatalCloseCodes, if not nil, replaces the gateway's list of close codes
+	// that the session doesn't reconnect after. See gateway.DefaultGatewayOpts.
+	FatalCloseCodes []int
+}
+
+// reconnectsExhaustedError is returned once the reconnect policy gives up.
+type reconnectsExhaustedError struct {
+	attempts int
+	err      error
+}
+
+func (err *reconnectsExhaustedError) Error() string {
+	if err.err == nil {
+		return fmt.Sprintf("failed to reconnect after %d attempts", err.attempts)
+	}
+	return fmt.Sprintf("failed to reconnect after %d attempts: %s", err.attempts, err.err)
+}
+
+func (err *reconnectsExhaustedError) Unwrap() error {
+	return err.err
+}
+
+// ExponentialBackoff returns a backoff schedule for ReconnectPolicy that starts
+// at min and doubles for every attempt until it reaches max.
+func ExponentialBackoff(min, max time.Duration) func(attempt int) time.Duration {
+	return func(attempt int) time.Duration {
+		d := min
+		for i := 1; i < attempt && d < max; i++ {
+			d *= 2
+		}
+		if d > max {
+			d = max
+		}
+		return d
+	}
+}
+
+func (p *ReconnectPolicy) backoff(attempt int) time.Duration {
+	if p == nil || p.Backoff == nil {
+		return 0
+	}
+	return p.Backoff(attempt)
+}
+
+func (p *ReconnectPolicy) exhausted(attempt int) bool {
+	return p != nil && p.MaxRetries > 0 && attempt > p.MaxRetries
+}
+
+// gatewayOpts returns the default gateway options changed to let the reconnect
+// policy handle reconnections.
+func (s *Session) gatewayOpts() *ws.GatewayOpts {
+	opts := gateway.DefaultGatewayOpts
+	opts.ReconnectAttempt = 1
+	opts.ReconnectDelay = func(int) time.Duration { return 0 }
+	opts.OnReconnect = s.nextReconnect
+
+	if s.ReconnectPolicy.FatalCloseCodes != nil {
+		opts.FatalCloseCodes = s.ReconnectPolicy.FatalCloseCodes
+	}
+
+	return &opts
+}
+
+// newGateway creates a new gateway for the Session's identifier, using the
+// reconnect policy's gateway options if there is one.
+func (s *Session) newGateway(ctx context.Context) (*gateway.Gateway, error) {
+	if s.ReconnectPolicy == nil {
+		return gateway.NewWithIdentifier(ctx, s.state.id)
+	}
+
+	s.trackReconnects()
+	return gateway.NewWithIdentifierOpts(ctx, s.state.id, s.gatewayOpts())
+}
+
+// trackReconnects adds a handler that resets the reconnection attempts once
+// the gateway is ready or resumed.
+func (s *Session) trackReconnects() {
+	s.state.reconnectsOnce.Do(func() {
+		s.AddSyncHandler(func(ev interface{}) {
+			switch ev.(type) {
+			case *gateway.ReadyEvent, *gateway.ResumedEvent:
+				atomic.StoreInt32(&s.state.reconnects, 0)
+			}
+		})
+	})
+}
+
+// errorIsFatal returns true if Connect must not reconnect after err.
+func (s *Session) errorIsFatal(err error) bool {
+	opts := s.GatewayOpts()
+	if s.ReconnectPolicy != nil && s.ReconnectPolicy.FatalCloseCodes != nil {
+		opts.FatalCloseCodes = s.ReconnectPolicy.FatalCloseCodes
+	}
+	return opts.ErrorIsFatalClose(err)
+}
+
+// nextReconnect counts a reconnection attempt caused by err and dispatches a
+// ReconnectingEvent for it. It returns the backoff to wait before the attempt,
+// or a *reconnectsExhaustedError if the policy gives up. It is also used as the
+// gateway's OnReconnect hook.
+func (s *Session) nextReconnect(err error) (time.Duration, error) {
+	attempt := int(atomic.AddInt32(&s.state.reconnects, 1))
+	if s.ReconnectPolicy.exhausted(attempt) {
+		return 0, &reconnectsExhaustedError{attempt - 1, err}
+	}
+
+	backoff := s.ReconnectPolicy.backoff(attempt)
+	s.Handler.Call(&ReconnectingEvent{
+		Attempt: attempt,
+		Backoff: backoff,
+		Err:     err,
+	})
+
+	return backoff, nil
+}
+
+// waitReconnect counts a reconnection attempt caused by err, then waits for its
+// backoff.
+func (s *Session) waitReconnect(ctx context.Context, err error) error {
+	backoff, err := s.nextReconnect(err)
+	if err != nil {
+		return err
+	}
+
+	if backoff <= 0 {
+		return ctx.Err()
+	}
+
+	var timer lazytime.Timer
+	defer timer.Stop()
+
+	timer.Reset(backoff)
+	return timer.Wait(ctx)
+}
@@ -0,0 +1,224 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/gateway"
+	"github.com/diamondburned/arikawa/v3/utils/handler"
+	"github.com/diamondburned/arikawa/v3/utils/ws"
+)
+
+func TestExponentialBackoff(t *testing.T) {
+	backoff := ExponentialBackoff(time.Second, 5*time.Second)
+
+	expect := []time.Duration{
+		time.Second,
+		2 * time.Second,
+		4 * time.Second,
+		5 * time.Second,
+		5 * time.Second,
+	}
+
+	for i, d := range expect {
+		if got := backoff(i + 1); got != d {
+			t.Errorf("attempt %d: expected %v, got %v", i+1, d, got)
+		}
+	}
+}
+
+func TestConnectReconnectPolicy(t *testing.T) {
+	policy := &ReconnectPolicy{
+		MaxRetries: 2,
+		Backoff:    ExponentialBackoff(time.Millisecond, 2*time.Millisecond),
+	}
+
+	// Nothing listens on port 1, so every dial fails.
+	s := newPolicySession("ws://127.0.0.1:1/?v=9&encoding=json", policy)
+
+	var events []ReconnectingEvent
+	s.AddSyncHandler(func(ev *ReconnectingEvent) {
+		events = append(events, *ev)
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	err := s.Connect(ctx)
+
+	var connErr ws.ConnectionError
+	if !errors.As(err, &connErr) {
+		t.Fatal("expected ConnectionError, got", err)
+	}
+
+	if len(events) != 2 {
+		t.Fatalf("expected 2 reconnecting events, got %d", len(events))
+	}
+
+	for i, ev := range events {
+		if ev.Attempt != i+1 {
+			t.Errorf("event %d: unexpected attempt %d", i, ev.Attempt)
+		}
+		if expect := policy.Backoff(i + 1); ev.Backoff != expect {
+			t.Errorf("event %d: expected backoff %v, got %v", i, expect, ev.Backoff)
+		}
+		if ev.Err == nil {
+			t.Errorf("event %d: missing error", i)
+		}
+	}
+}
+
+func TestGatewayReconnectPolicy(t *testing.T) {
+	policy := &ReconnectPolicy{
+		MaxRetries: 5,
+		Backoff:    ExponentialBackoff(time.Millisecond, 2*time.Millisecond),
+	}
+
+	var conns int32
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := (&websocket.Upgrader{}).Upgrade(w, r, nil)
+		if err != nil {
+			t.Error("failed to upgrade:", err)
+			return
+		}
+		defer conn.Close()
+
+		send := func(payload string) {
+			if err := conn.WriteMessage(websocket.TextMessage, []byte(payload)); err != nil {
+				t.Error("failed to send payload:", err)
+			}
+		}
+
+		closeWith := func(code int) {
+			msg := websocket.FormatCloseMessage(code, "")
+			conn.WriteControl(websocket.CloseMessage, msg, time.Now().Add(time.Second))
+		}
+
+		if atomic.AddInt32(&conns, 1) == 1 {
+			// Get ready, then close with a code that can be reconnected
+			// after.
+			send(`{"op":10,"d":{"heartbeat_interval":45000}}`)
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+			send(`{"op":0,"t":"READY","s":1,"d":{"v":9,"session_id":"session","user":{"id":"1"}}}`)
+			closeWith(int(gateway.CodeUnknownError))
+		} else {
+			closeWith(int(gateway.CodeAuthenticationFailed))
+		}
+
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}))
+	defer srv.Close()
+
+	s := newPolicySession("ws"+strings.TrimPrefix(srv.URL, "http")+"/?v=9&encoding=json", policy)
+
+	var events []ReconnectingEvent
+	s.AddSyncHandler(func(ev *ReconnectingEvent) {
+		events = append(events, *ev)
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	err := s.Connect(ctx)
+
+	var closeErr *gateway.CloseError
+	if !errors.As(err, &closeErr) || closeErr.Code != gateway.CodeAuthenticationFailed {
+		t.Fatal("expected authentication failed close error, got", err)
+	}
+
+	// The gateway reconnects on its own after the first close.
+	if len(events) != 1 {
+		t.Fatalf("expected 1 reconnecting event, got %d", len(events))
+	}
+
+	if events[0].Attempt != 1 {
+		t.Errorf("unexpected attempt %d", events[0].Attempt)
+	}
+	if expect := policy.Backoff(1); events[0].Backoff != expect {
+		t.Errorf("expected backoff %v, got %v", expect, events[0].Backoff)
+	}
+
+	var closeEv *ws.CloseEvent
+	if !errors.As(events[0].Err, &closeEv) || closeEv.Code != int(gateway.CodeUnknownError) {
+		t.Error("expected unknown error close event, got", events[0].Err)
+	}
+}
+
+func TestGatewayReconnectPolicyExhausted(t *testing.T) {
+	policy := &ReconnectPolicy{MaxRetries: 1}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := (&websocket.Upgrader{}).Upgrade(w, r, nil)
+		if err != nil {
+			t.Error("failed to upgrade:", err)
+			return
+		}
+		defer conn.Close()
+
+		// Never get ready and keep asking for reconnections.
+		conn.WriteMessage(websocket.TextMessage, []byte(`{"op":7,"d":null}`))
+
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}))
+	defer srv.Close()
+
+	s := newPolicySession("ws"+strings.TrimPrefix(srv.URL, "http")+"/?v=9&encoding=json", policy)
+
+	var events []ReconnectingEvent
+	s.AddSyncHandler(func(ev *ReconnectingEvent) {
+		events = append(events, *ev)
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	// Open never returns successfully, since the gateway is never ready.
+	err := s.Connect(ctx)
+
+	var exhaustedErr *reconnectsExhaustedError
+	if !errors.As(err, &exhaustedErr) {
+		t.Fatal("expected the policy to give up, got", err)
+	}
+
+	if len(events) != 1 {
+		t.Fatalf("expected 1 reconnecting event, got %d", len(events))
+	}
+
+	// The reconnection is asked for by Discord.
+	if events[0].Err != nil {
+		t.Error("unexpected error:", events[0].Err)
+	}
+}
+
+// newPolicySession creates a session with the given reconnect policy whose
+// gateway connects to gatewayURL.
+func newPolicySession(gatewayURL string, policy *ReconnectPolicy) *Session {
+	id := gateway.DefaultIdentifier("token")
+
+	s := NewCustom(id, api.NewClient(id.Token), handler.New())
+	s.ReconnectPolicy = policy
+	s.trackReconnects()
+	s.state.gateway = gateway.NewCustomWithIdentifier(gatewayURL, id, s.gatewayOpts())
+
+	return s
+}
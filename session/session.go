@@ -8,7 +8,10 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"reflect"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/diamondburned/arikawa/v3/api"
 	"github.com/diamondburned/arikawa/v3/api/webhook"
@@ -40,11 +43,34 @@ type Session struct {
 	// console.
 	OnInteractionError func(*gateway.InteractionCreateEvent, error)
 
+	// OnIntentsWarning is called by Open for each problem found with the
+	// requested gateway intents, such as when a handler is added for an event
+	// that the requested intents will never deliver, or when Discord refuses
+	// the requested privileged intents. By default, it logs into the console.
+	OnIntentsWarning func(warning string)
+
 	// DontWaitForReady makes Open not wait for the Ready event. This is useful
 	// for non-bots, since Discord may send over a READY_SUPPLEMENT instead. If
 	// this is true, then any event sent by Discord will unblock Open (usually
 	// HELLO).
 	DontWaitForReady bool // false
+
+	// PresenceKeepAlive, if non-zero, makes a session with a user account send
+	// its presence again every PresenceKeepAlive while it is open. The presence
+	// sent is the last one sent using SendGateway, or the identify presence if
+	// none was. It has no effect on bot accounts. Use gateway.IdentifyPreset to
+	// make the identify properties match an official client as well.
+	PresenceKeepAlive time.Duration // 0
+
+	// ReconnectPolicy, if not nil, governs how Connect reconnects the gateway.
+	// If it is nil, then Connect reopens the gateway immediately and forever,
+	// leaving the retries to the gateway's own options.
+	ReconnectPolicy *ReconnectPolicy // nil
+
+	// EventSampler, if not nil, drops or coalesces high-frequency events before
+	// they reach the handlers, including those of the state. It takes effect
+	// the next time the session is opened.
+	EventSampler *gateway.EventSampler // nil
 }
 
 type sessionState struct {
@@ -52,11 +78,37 @@ type sessionState struct {
 	id      gateway.Identifier
 	gateway *gateway.Gateway
 
+	memberChunks     gateway.MemberChunkRouter
+	memberChunksOnce sync.Once
+
+	// reconnects is the number of consecutive reconnection attempts. It is
+	// accessed atomically, since the gateway also counts its own reconnections.
+	reconnects     int32
+	reconnectsOnce sync.Once
+
 	ctx    context.Context
 	cancel context.CancelFunc
 	doneCh <-chan struct{}
+
+	// handlerCtx is ctx, but it is guarded by its own mutex so that handlers
+	// can get it while Open is holding the state lock. Unlike ctx, it is not
+	// reset on close.
+	handlerCtxMu sync.RWMutex
+	handlerCtx   context.Context
+
+	// presence is the last presence sent using SendGateway.
+	presenceMu sync.Mutex
+	presence   *gateway.UpdatePresenceCommand
 }
 
+// closedCtx is an already-cancelled context returned by Context before the
+// Session is ever opened.
+var closedCtx = func() context.Context {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	return ctx
+}()
+
 // NewWithIntents is similar to New but adds the given intents in during
 // construction.
 func NewWithIntents(token string, intents ...gateway.Intents) *Session {
@@ -143,6 +195,9 @@ func newCustom(
 			// https://github.com/diamondburned/arikawa/issues/361.
 			log.Printf("session: error handling interaction %v: %v", ev.ID, err)
 		},
+		OnIntentsWarning: func(warning string) {
+			log.Println("session:", warning)
+		},
 	}
 }
 
@@ -160,6 +215,53 @@ func (s *Session) AddIntents(intents gateway.Intents) {
 	s.state.Unlock()
 }
 
+// SetIdentifyPresence sets the presence that the session connects with, which
+// is sent in the IdentifyCommand. Calling it after Open has already been called
+// will result in a panic.
+//
+// Once a presence is sent using SendGateway, it is used instead when the
+// session is opened again, until SetIdentifyPresence is called again.
+func (s *Session) SetIdentifyPresence(presence *gateway.UpdatePresenceCommand) {
+	s.state.Lock()
+
+	s.state.id.Presence = presence
+
+	// Forget the last presence sent, since it would override this one.
+	s.state.presenceMu.Lock()
+	s.state.presence = nil
+	s.state.presenceMu.Unlock()
+
+	if s.state.gateway != nil {
+		s.state.gateway.SetIdentifyPresence(presence)
+	}
+
+	s.state.Unlock()
+}
+
+// SetIdentifyProperties sets the properties that the session identifies with,
+// such as the OS, browser and device names. Libraries embedding arikawa should
+// use it to identify themselves distinctly; gateway.DefaultIdentity is used
+// otherwise. Calling it after Open has already been called will result in a
+// panic.
+func (s *Session) SetIdentifyProperties(props gateway.IdentifyProperties) {
+	s.state.Lock()
+
+	s.state.id.Properties = props
+
+	if s.state.gateway != nil {
+		s.state.gateway.SetIdentifyProperties(props)
+	}
+
+	s.state.Unlock()
+}
+
+// SetUserAgent sets the User-Agent header sent in REST API requests, which is
+// api.UserAgent by default. It replaces the session's Client with a copy using
+// the given User-Agent, so it must be called before the session is used.
+func (s *Session) SetUserAgent(userAgent string) {
+	s.Client = s.Client.WithUserAgent(userAgent)
+}
+
 // HasIntents reports if the Gateway has the passed Intents.
 //
 // If no intents are set, e.g. if using a user account, HasIntents will always
@@ -242,37 +344,61 @@ func (s *Session) gatewayIsAlive() bool {
 // connecting, then a nil error will be returned (unless the gateway has an
 // error). This is contrary to the common behavior of a ctx function returning
 // ctx.Err().
+//
+// If Discord closes the gateway with a fatal close code, then the returned
+// error is a *gateway.CloseError (or a *gateway.DisallowedIntentsError), which
+// can be checked using errors.As.
+//
+// Before every reconnection, a *ReconnectingEvent is dispatched into the
+// handler. See ReconnectPolicy for tuning the reconnections. If the policy gives
+// up, then the last error is returned wrapped.
 func (s *Session) Connect(ctx context.Context) error {
-	opts := s.GatewayOpts()
-
 	for {
-		if err := s.Open(ctx); err != nil {
-			if opts.ErrorIsFatalClose(err) || ctx.Err() != nil {
-				// Fatal error or context is done, return.
-				return err
-			}
-			// Non-fatal error, retry.
-			continue
-		}
+		err := s.Open(ctx)
+		if err == nil {
+			atomic.StoreInt32(&s.state.reconnects, 0)
 
-		if err := s.Wait(ctx); err != nil {
-			if opts.ErrorIsFatalClose(err) {
-				// Gateway returned a fatal error, so we can't recover.
-				return err
+			err = s.Wait(ctx)
+			if err == nil {
+				continue
 			}
-			if ctx.Err() != nil {
+			if !s.errorIsFatal(err) && ctx.Err() != nil {
 				// Context was done, so we can't recover. Exit with no error,
 				// since we're just waiting.
 				return nil
 			}
-			// Non-fatal error, retry.
+		}
+
+		if s.errorIsFatal(err) || ctx.Err() != nil {
+			// Fatal error or context is done, return.
+			return err
+		}
+
+		// The gateway may have already given up on its own.
+		var exhaustedErr *reconnectsExhaustedError
+		if errors.As(err, &exhaustedErr) {
+			return exhaustedErr
+		}
+
+		if err := s.waitReconnect(ctx, err); err != nil {
+			return err
 		}
 	}
 }
 
 // Open opens the Discord gateway and its handler, then waits until either the
 // Ready or Resumed event gets through. Prefer using Connect instead of Open.
+//
+// If Discord closes the gateway before that, then the returned error is a
+// *gateway.CloseError whose Code field describes why. Refer to
+// gateway.CloseCode for the list of close codes.
 func (s *Session) Open(ctx context.Context) error {
+	return s.open(ctx, nil)
+}
+
+// open opens the session. If progress is not nil, then the handshake progress
+// is recorded into it and Open always waits for Ready.
+func (s *Session) open(ctx context.Context, progress *openProgress) error {
 	evCh := make(chan interface{})
 
 	s.state.Lock()
@@ -285,23 +411,50 @@ func (s *Session) Open(ctx context.Context) error {
 	}
 
 	if s.state.gateway == nil {
-		g, err := gateway.NewWithIdentifier(ctx, s.state.id)
+		g, err := s.newGateway(ctx)
 		if err != nil {
 			return err
 		}
 		s.state.gateway = g
 	}
 
+	s.checkIntents()
+
+	// Identify with the last presence sent, if any, so that reopening the
+	// session doesn't revert it to the initial one.
+	s.state.presenceMu.Lock()
+	if s.state.presence != nil {
+		cpy := *s.state.presence
+		s.state.gateway.SetIdentifyPresence(&cpy)
+	}
+	s.state.presenceMu.Unlock()
+
 	// Make a context that's stored in state so this can be used throughout.
 	s.state.ctx, s.state.cancel = context.WithCancel(context.Background())
 
+	s.state.handlerCtxMu.Lock()
+	s.state.handlerCtx = s.state.ctx
+	s.state.handlerCtxMu.Unlock()
+
 	// TODO: change this to AddSyncHandler.
 	rm := s.AddHandler(evCh)
 	defer rm()
 
+	if progress != nil {
+		rmHook := s.state.gateway.OnSendCommand(progress.onSend)
+		defer rmHook()
+	}
+
 	opCh := s.state.gateway.Connect(s.state.ctx)
+	if s.EventSampler != nil {
+		opCh = s.EventSampler.Loop(opCh)
+	}
 	s.state.doneCh = ophandler.Loop(opCh, s.Handler)
 
+	if s.PresenceKeepAlive > 0 && s.isUserAccount() {
+		go s.keepPresenceAlive(s.state.ctx, s.PresenceKeepAlive)
+	}
+
 	for {
 		select {
 		case <-ctx.Done():
@@ -314,10 +467,22 @@ func (s *Session) Open(ctx context.Context) error {
 
 		case <-s.state.doneCh:
 			// Event loop died.
-			return s.state.gateway.LastError()
+			err := s.state.gateway.LastError()
+
+			var intentsErr *gateway.DisallowedIntentsError
+			if errors.As(err, &intentsErr) && s.OnIntentsWarning != nil {
+				s.OnIntentsWarning(fmt.Sprintf(
+					"privileged intents %d were requested but are not enabled "+
+						"for this bot in the Developer Portal",
+					intentsErr.Privileged))
+			}
+
+			return err
 
 		case ev := <-evCh:
-			if s.DontWaitForReady {
+			if progress != nil {
+				progress.onEvent(ev)
+			} else if s.DontWaitForReady {
 				return nil
 			}
 
@@ -329,6 +494,32 @@ func (s *Session) Open(ctx context.Context) error {
 	}
 }
 
+// checkIntents does a best-effort check of the requested intents against the
+// event types of the added handlers. Warnings are given to OnIntentsWarning.
+func (s *Session) checkIntents() {
+	if s.OnIntentsWarning == nil || s.state.id.Intents == nil {
+		return
+	}
+
+	intents := gateway.Intents(*s.state.id.Intents)
+
+	var eventTypes []ws.EventType
+	for _, t := range s.Handler.EventTypes() {
+		if t.Kind() != reflect.Ptr {
+			continue
+		}
+		if ev, ok := reflect.New(t.Elem()).Interface().(ws.Event); ok {
+			eventTypes = append(eventTypes, ev.EventType())
+		}
+	}
+
+	for _, eventType := range intents.MissingFor(eventTypes) {
+		s.OnIntentsWarning(fmt.Sprintf(
+			"handler added for %s, but intents %d required for it are not requested",
+			eventType, gateway.EventIntents[eventType]))
+	}
+}
+
 // Wait blocks until either ctx is done or the gateway stumbles on an
 // unrecoverable error.
 func (s *Session) Wait(ctx context.Context) error {
@@ -369,6 +560,40 @@ func (s *Session) WithContext(ctx context.Context) *Session {
 	return &cpy
 }
 
+// Context returns a context that is cancelled once the Session is closed. The
+// context is replaced every time the Session is opened, so it should be
+// obtained again after reopening. If the Session has never been opened, then
+// an already-cancelled context is returned.
+//
+// This method is safe to be called from within handlers.
+func (s *Session) Context() context.Context {
+	s.state.handlerCtxMu.RLock()
+	ctx := s.state.handlerCtx
+	s.state.handlerCtxMu.RUnlock()
+
+	if ctx == nil {
+		return closedCtx
+	}
+	return ctx
+}
+
+// AddHandlerCtx is similar to AddHandler, except the handler must be a
+// function taking a context.Context before the event. The context is the one
+// returned by Context at the time the event is dispatched, so it is cancelled
+// once the Session is closed. This allows long-running handler work to be tied
+// to the lifetime of the Session.
+//
+//	s.AddHandlerCtx(func(ctx context.Context, ev *gateway.MessageCreateEvent) {
+//		s := s.WithContext(ctx)
+//		// ...
+//	})
+//
+// AddHandlerCtx panics if the handler is not a function of the right
+// signature.
+func (s *Session) AddHandlerCtx(fn interface{}) (rm func()) {
+	return s.AddHandler(handler.WithContext(fn, s.Context))
+}
+
 // AddInteractionHandler adds an interaction handler function to be handled with
 // the gateway and the API client. Use this as a compatibility layer for bots
 // that support both methods of hosting.
@@ -402,9 +627,42 @@ func (s *Session) SendGateway(ctx context.Context, m ws.Event) error {
 		return ErrClosed
 	}
 
+	if presence, ok := m.(*gateway.UpdatePresenceCommand); ok {
+		s.setPresence(presence)
+	}
+
 	return s.Gateway().Send(ctx, m)
 }
 
+// RequestGuildMembers sends the given command over the gateway and returns a
+// channel that receives only the GuildMembersChunkEvents replying to it. The
+// command's Nonce is overridden so that concurrent requests do not steal each
+// other's chunks. The channel is closed once all chunks are received, once no
+// chunk is received for gateway.DefaultMemberChunkTimeout, once ctx is done or
+// once the Session is closed.
+//
+// Handlers added for GuildMembersChunkEvent still receive all chunks.
+func (s *Session) RequestGuildMembers(
+	ctx context.Context,
+	cmd gateway.RequestGuildMembersCommand) (<-chan *gateway.GuildMembersChunkEvent, error) {
+
+	s.state.memberChunksOnce.Do(func() {
+		s.AddSyncHandler(func(ev *gateway.GuildMembersChunkEvent) {
+			s.state.memberChunks.Route(ev)
+		})
+	})
+
+	nonce, ch, cancel := s.state.memberChunks.Register(ctx, len(cmd.GuildIDs))
+	cmd.Nonce = nonce
+
+	if err := s.SendGateway(ctx, &cmd); err != nil {
+		cancel()
+		return nil, err
+	}
+
+	return ch, nil
+}
+
 // Close closes the underlying Websocket connection, invalidating the session
 // ID. It will send a closing frame before ending the connection, closing it
 // gracefully. This will cause the bot to appear as offline instantly. To
@@ -425,6 +683,8 @@ func (s *Session) close() error {
 	s.state.cancel = nil
 	s.state.ctx = nil
 
+	s.state.memberChunks.CancelAll()
+
 	<-s.state.doneCh
 	s.state.doneCh = nil
 
@@ -92,3 +92,27 @@ func TestSessionConnect(t *testing.T) {
 		time.Sleep(time.Second)
 	}
 }
+
+func TestSessionAddHandlerCtx(t *testing.T) {
+	s := New("")
+
+	ctxCh := make(chan context.Context, 1)
+	s.AddHandlerCtx(func(ctx context.Context, ev *gateway.ReadyEvent) {
+		ctxCh <- ctx
+	})
+
+	s.Handler.Call(&gateway.ReadyEvent{})
+	if ctx := <-ctxCh; ctx.Err() == nil {
+		t.Fatal("expected a cancelled context before Open")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s.state.handlerCtx = ctx
+
+	s.Handler.Call(&gateway.ReadyEvent{})
+	if got := <-ctxCh; got != ctx {
+		t.Fatal("handler was not given the session context")
+	}
+
+	cancel()
+}
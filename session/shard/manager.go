@@ -10,6 +10,7 @@ import (
 	"github.com/diamondburned/arikawa/v3/discord"
 	"github.com/diamondburned/arikawa/v3/gateway"
 	"github.com/diamondburned/arikawa/v3/internal/backoff"
+	"github.com/diamondburned/arikawa/v3/utils/handler"
 )
 
 func updateIdentifier(ctx context.Context, id *gateway.Identifier) (url string, err error) {
@@ -48,7 +49,8 @@ type Manager struct {
 
 	rescaling *rescalingState // nil unless rescaling
 
-	new NewShardFunc
+	new     NewShardFunc
+	handler *handler.Handler
 }
 
 type rescalingState struct {
@@ -103,6 +105,7 @@ func NewIdentifiedManagerWithURL(
 		gatewayURL: gateway.AddGatewayParams(url),
 		shards:     make([]ShardState, id.Shard.NumShards()),
 		new:        fn,
+		handler:    handler.New(),
 	}
 
 	var err error
@@ -116,6 +119,7 @@ func NewIdentifiedManagerWithURL(
 				IdentifyCommand:     data,
 				IdentifyShortLimit:  id.IdentifyShortLimit,
 				IdentifyGlobalLimit: id.IdentifyGlobalLimit,
+				GatewayURL:          id.GatewayURL,
 			},
 		}
 
@@ -128,6 +132,39 @@ func NewIdentifiedManagerWithURL(
 	return &m, nil
 }
 
+// Handler returns the merged handler of all shards. Shards created using
+// NewSessionShard or state.NewShardFunc dispatch all their events into it, so
+// handlers only need to be added once instead of once per shard. Custom shards
+// can do the same using MergeHandler.
+//
+// Each event is dispatched twice: once as-is, and once wrapped in an *Event
+// that holds the ID of the shard that the event came from. This means that
+// handlers taking an interface{} receive both.
+//
+//	m.Handler().AddHandler(func(ev *gateway.MessageCreateEvent) {
+//		log.Println("message:", ev.Content)
+//	})
+//	m.Handler().AddHandler(func(ev *shard.Event) {
+//		log.Printf("shard %d received %T", ev.ShardID, ev.Event)
+//	})
+func (m *Manager) Handler() *handler.Handler {
+	return m.handler
+}
+
+// MergeHandler dispatches all events of the given shard handler into the
+// merged handler returned by Handler. It should be called in the NewShardFunc
+// with the given Identifier, which is used to tag events with their shard ID.
+// The returned function stops dispatching.
+func (m *Manager) MergeHandler(id *gateway.Identifier, h *handler.Handler) (rm func()) {
+	shardID := id.Shard.ShardID()
+
+	// Use a synchronous handler to preserve the order of events.
+	return h.AddSyncHandler(func(ev interface{}) {
+		m.handler.Call(ev)
+		m.handler.Call(&Event{ShardID: shardID, Event: ev})
+	})
+}
+
 // GatewayURL returns the URL to the gateway. The URL will always have the
 // needed gateway parameters appended.
 func (m *Manager) GatewayURL() string {
@@ -164,10 +201,16 @@ func (m *Manager) FromGuildID(guildID discord.GuildID) (shard Shard, ix int) {
 	m.mutex.RLock()
 	defer m.mutex.RUnlock()
 
-	ix = int(uint64(guildID>>22) % uint64(len(m.shards)))
+	ix = m.guildShard(guildID)
 	return m.shards[ix].Shard, ix
 }
 
+// guildShard returns the ID of the shard that the guild belongs to. The caller
+// must hold the mutex.
+func (m *Manager) guildShard(guildID discord.GuildID) int {
+	return int(uint64(guildID>>22) % uint64(len(m.shards)))
+}
+
 // ForEach calls the given function on each shard from first to last. The caller
 // can safely access the number of shards by either asserting Shard to get the
 // IdentifyData or call m.NumShards.
@@ -180,6 +223,70 @@ func (m *Manager) ForEach(f func(shard Shard)) {
 	}
 }
 
+// RequestGuildMembers requests the members of the guilds in the given command
+// from the shards that the guilds belong to. If the guilds belong to different
+// shards, then the command is split so that each shard only requests its own
+// guilds. Members are received in GuildMembersChunkEvents.
+//
+// The shards must implement GatewaySender.
+func (m *Manager) RequestGuildMembers(
+	ctx context.Context, cmd gateway.RequestGuildMembersCommand) error {
+
+	m.mutex.RLock()
+
+	var order []int
+	guildIDs := make(map[int][]discord.GuildID)
+	shards := make(map[int]Shard)
+
+	for _, guildID := range cmd.GuildIDs {
+		ix := m.guildShard(guildID)
+		if _, ok := guildIDs[ix]; !ok {
+			order = append(order, ix)
+			shards[ix] = m.shards[ix].Shard
+		}
+		guildIDs[ix] = append(guildIDs[ix], guildID)
+	}
+
+	m.mutex.RUnlock()
+
+	// Don't hold the mutex while sending, since sending may be throttled.
+	for _, ix := range order {
+		cmd := cmd
+		cmd.GuildIDs = guildIDs[ix]
+
+		if err := sendGateway(ctx, shards[ix], &cmd); err != nil {
+			return fmt.Errorf("failed to request guild members on shard %d: %w", ix, err)
+		}
+	}
+
+	return nil
+}
+
+// UpdatePresenceAll updates the presence of the bot on all shards. All shards
+// are tried even if some of them fail, in which case the first error is
+// returned.
+//
+// The shards must implement GatewaySender.
+func (m *Manager) UpdatePresenceAll(ctx context.Context, cmd gateway.UpdatePresenceCommand) error {
+	m.mutex.RLock()
+	shards := make([]Shard, len(m.shards))
+	for i, state := range m.shards {
+		shards[i] = state.Shard
+	}
+	m.mutex.RUnlock()
+
+	var firstErr error
+
+	for i, shard := range shards {
+		cmd := cmd
+		if err := sendGateway(ctx, shard, &cmd); err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("failed to update presence on shard %d: %w", i, err)
+		}
+	}
+
+	return firstErr
+}
+
 // Open opens all gateways handled by this Manager. If an error occurs, Open
 // will attempt to close all previously opened gateways before returning.
 func (m *Manager) Open(ctx context.Context) error {
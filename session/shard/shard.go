@@ -2,12 +2,14 @@ package shard
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/diamondburned/arikawa/v3/api"
 	"github.com/diamondburned/arikawa/v3/gateway"
 	"github.com/diamondburned/arikawa/v3/session"
 	"github.com/diamondburned/arikawa/v3/utils/handler"
+	"github.com/diamondburned/arikawa/v3/utils/ws"
 )
 
 // Shard defines a shard gateway interface that the shard manager can use.
@@ -16,6 +18,27 @@ type Shard interface {
 	Close() error
 }
 
+// GatewaySender is a Shard that can send gateway commands. *session.Session
+// and *state.State implement it.
+type GatewaySender interface {
+	Shard
+	SendGateway(context.Context, ws.Event) error
+}
+
+var _ GatewaySender = (*session.Session)(nil)
+
+// ErrNotGatewaySender is returned by Manager methods that send gateway
+// commands if a shard doesn't implement GatewaySender.
+var ErrNotGatewaySender = errors.New("shard does not implement GatewaySender")
+
+func sendGateway(ctx context.Context, shard Shard, ev ws.Event) error {
+	sender, ok := shard.(GatewaySender)
+	if !ok {
+		return ErrNotGatewaySender
+	}
+	return sender.SendGateway(ctx, ev)
+}
+
 // NewShardFunc is the constructor to create a new gateway. For examples, see
 // package session and state's. The constructor must manually connect the
 // Manager's Rescale method appropriately.
@@ -26,16 +49,26 @@ type Shard interface {
 // methods without deadlocking.
 type NewShardFunc func(m *Manager, id *gateway.Identifier) (Shard, error)
 
-// NewSessionShard creates a shard constructor for a session.
-// Accessing any shard and adding a handler will add a handler for all shards.
+// NewSessionShard creates a shard constructor for a session. The events of
+// each session are also dispatched into the Manager's merged handler.
 func NewSessionShard(f func(m *Manager, s *session.Session)) NewShardFunc {
 	return func(m *Manager, id *gateway.Identifier) (Shard, error) {
 		s := session.NewCustom(*id, api.NewClient(id.Token), handler.New())
+		m.MergeHandler(id, s.Handler)
 		f(m, s)
 		return s, nil
 	}
 }
 
+// Event is an event dispatched into the Manager's merged handler, wrapped with
+// the ID of the shard that it came from.
+type Event struct {
+	// ShardID is the ID of the shard that the event came from.
+	ShardID int
+	// Event is the event.
+	Event interface{}
+}
+
 // ShardState wraps around the Gateway interface to provide additional state.
 type ShardState struct {
 	Shard Shard
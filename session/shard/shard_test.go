@@ -2,13 +2,17 @@ package shard_test
 
 import (
 	"context"
+	"reflect"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/diamondburned/arikawa/v3/discord"
 	"github.com/diamondburned/arikawa/v3/gateway"
 	"github.com/diamondburned/arikawa/v3/internal/testenv"
 	"github.com/diamondburned/arikawa/v3/session"
 	"github.com/diamondburned/arikawa/v3/session/shard"
+	"github.com/diamondburned/arikawa/v3/utils/ws"
 )
 
 func TestSharding(t *testing.T) {
@@ -72,3 +76,112 @@ shardLoop:
 		t.Error("failed to close:", err)
 	}
 }
+
+func TestManagerHandler(t *testing.T) {
+	id := gateway.DefaultIdentifier("Bot token")
+	id.Shard = &gateway.Shard{0, 2}
+
+	var sessions []*session.Session
+
+	m, err := shard.NewIdentifiedManagerWithURL("wss://localhost", id, shard.NewSessionShard(
+		func(m *shard.Manager, s *session.Session) { sessions = append(sessions, s) },
+	))
+	if err != nil {
+		t.Fatal("failed to make shard manager:", err)
+	}
+
+	msgCh := make(chan *gateway.MessageCreateEvent, 1)
+	m.Handler().AddHandler(msgCh)
+
+	shardCh := make(chan *shard.Event, 1)
+	m.Handler().AddHandler(shardCh)
+
+	ev := &gateway.MessageCreateEvent{}
+	sessions[1].Handler.Call(ev)
+
+	select {
+	case got := <-msgCh:
+		if got != ev {
+			t.Fatal("unexpected event from merged handler")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for merged event")
+	}
+
+	select {
+	case got := <-shardCh:
+		if got.ShardID != 1 || got.Event != ev {
+			t.Fatalf("unexpected shard event %+v", got)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for shard event")
+	}
+}
+
+type senderShard struct {
+	mu   sync.Mutex
+	sent []ws.Event
+}
+
+func (s *senderShard) Open(context.Context) error { return nil }
+func (s *senderShard) Close() error               { return nil }
+
+func (s *senderShard) SendGateway(ctx context.Context, ev ws.Event) error {
+	s.mu.Lock()
+	s.sent = append(s.sent, ev)
+	s.mu.Unlock()
+	return nil
+}
+
+func TestManagerCommands(t *testing.T) {
+	id := gateway.DefaultIdentifier("Bot token")
+	id.Shard = &gateway.Shard{0, 2}
+
+	var shards []*senderShard
+
+	m, err := shard.NewIdentifiedManagerWithURL("wss://localhost", id,
+		func(m *shard.Manager, id *gateway.Identifier) (shard.Shard, error) {
+			s := &senderShard{}
+			shards = append(shards, s)
+			return s, nil
+		},
+	)
+	if err != nil {
+		t.Fatal("failed to make shard manager:", err)
+	}
+
+	ctx := context.Background()
+
+	// Guild 1<<22 belongs to shard 1, while the others belong to shard 0.
+	err = m.RequestGuildMembers(ctx, gateway.RequestGuildMembersCommand{
+		GuildIDs: []discord.GuildID{1 << 22, 2 << 22, 4 << 22},
+		Nonce:    "nonce",
+	})
+	if err != nil {
+		t.Fatal("failed to request guild members:", err)
+	}
+
+	expect := [][]discord.GuildID{{2 << 22, 4 << 22}, {1 << 22}}
+	for i, s := range shards {
+		if len(s.sent) != 1 {
+			t.Fatalf("shard %d: expected 1 command, got %d", i, len(s.sent))
+		}
+
+		cmd := s.sent[0].(*gateway.RequestGuildMembersCommand)
+		if !reflect.DeepEqual(cmd.GuildIDs, expect[i]) || cmd.Nonce != "nonce" {
+			t.Fatalf("shard %d: unexpected command %+v", i, cmd)
+		}
+	}
+
+	err = m.UpdatePresenceAll(ctx, gateway.UpdatePresenceCommand{Status: discord.IdleStatus})
+	if err != nil {
+		t.Fatal("failed to update presence:", err)
+	}
+
+	for i, s := range shards {
+		cmd, ok := s.sent[len(s.sent)-1].(*gateway.UpdatePresenceCommand)
+		if !ok || cmd.Status != discord.IdleStatus {
+			t.Fatalf("shard %d: unexpected command %+v", i, s.sent[len(s.sent)-1])
+		}
+	}
+}
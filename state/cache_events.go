@@ -0,0 +1,146 @@
+package state
+
+import (
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/state/store"
+)
+
+// CacheResource is the type of resource that a CacheEvent is about.
+type CacheResource uint8
+
+const (
+	CacheMe CacheResource = iota + 1
+	CacheGuild
+	CacheChannel
+	CacheEmoji
+	CacheMember
+	CacheMessage
+	CachePresence
+	CacheRole
+	CacheVoiceState
+)
+
+var cacheResourceNames = [...]string{
+	CacheMe:         "me",
+	CacheGuild:      "guild",
+	CacheChannel:    "channel",
+	CacheEmoji:      "emoji",
+	CacheMember:     "member",
+	CacheMessage:    "message",
+	CachePresence:   "presence",
+	CacheRole:       "role",
+	CacheVoiceState: "voice state",
+}
+
+// String returns the name of the resource.
+func (r CacheResource) String() string {
+	if int(r) < len(cacheResourceNames) && cacheResourceNames[r] != "" {
+		return cacheResourceNames[r]
+	}
+	return "unknown"
+}
+
+// CacheOp is the operation that a CacheEvent describes.
+type CacheOp uint8
+
+const (
+	// CacheSet means that the resource was added to the cache, or replaced if
+	// it already existed.
+	CacheSet CacheOp = iota + 1
+	// CacheUpdate means that an existing resource in the cache was updated.
+	CacheUpdate
+	// CacheRemove means that the resource was removed from the cache. Removing
+	// a guild also removes everything that belongs to it.
+	CacheRemove
+	// CacheReset means that the whole cache was cleared. Every ID in the
+	// CacheEvent is zero.
+	CacheReset
+)
+
+// String returns the name of the operation.
+func (op CacheOp) String() string {
+	switch op {
+	case CacheSet:
+		return "set"
+	case CacheUpdate:
+		return "update"
+	case CacheRemove:
+		return "remove"
+	case CacheReset:
+		return "reset"
+	default:
+		return "unknown"
+	}
+}
+
+// CacheEvent describes a change that a gateway event caused in the State's
+// cache. It only carries IDs, so consumers can get the new value from the
+// State's Cabinet, or from the gateway event itself if it's handled in the
+// same handler.
+//
+// CacheEvents are dispatched into the State's CacheEvents handler, if any.
+type CacheEvent struct {
+	Resource CacheResource
+	Op       CacheOp
+	// GuildID is the guild that the resource belongs to, if any. For
+	// CacheGuild, it is the guild itself.
+	GuildID discord.GuildID
+	// ChannelID is the channel that the resource belongs to, if any. For
+	// CacheChannel, it is the channel itself.
+	ChannelID discord.ChannelID
+	// ID is the ID of the resource. It is the user ID for members, presences,
+	// voice states and CacheMe. It is zero for CacheEmoji, since emojis are
+	// always replaced for the whole guild at once.
+	ID discord.Snowflake
+}
+
+// cacheChanged records a change that onEvent made to the cache, so that it can
+// be dispatched into CacheEvents once the event is applied. Changes to stores
+// that are Noop are not recorded, since nothing was cached. s.cacheMutex must
+// be held.
+func (s *State) cacheChanged(
+	res CacheResource, op CacheOp,
+	guildID discord.GuildID, chID discord.ChannelID, id discord.Snowflake) {
+
+	if s.CacheEvents == nil || s.cacheStoreIsNoop(res) {
+		return
+	}
+
+	s.cacheChanges = append(s.cacheChanges, CacheEvent{res, op, guildID, chID, id})
+}
+
+func (s *State) cacheStoreIsNoop(res CacheResource) bool {
+	var st interface{}
+
+	switch res {
+	case CacheMe:
+		st = s.Cabinet.MeStore
+	case CacheGuild:
+		st = s.Cabinet.GuildStore
+	case CacheChannel:
+		st = s.Cabinet.ChannelStore
+	case CacheEmoji:
+		st = s.Cabinet.EmojiStore
+	case CacheMember:
+		st = s.Cabinet.MemberStore
+	case CacheMessage:
+		st = s.Cabinet.MessageStore
+	case CachePresence:
+		st = s.Cabinet.PresenceStore
+	case CacheRole:
+		st = s.Cabinet.RoleStore
+	case CacheVoiceState:
+		st = s.Cabinet.VoiceStateStore
+	}
+
+	_, ok := st.(store.NoopStore)
+	return ok
+}
+
+// dispatchCacheChanges dispatches the changes recorded by cacheChanged into
+// CacheEvents. It must be called after s.cacheMutex is released.
+func (s *State) dispatchCacheChanges(changes []CacheEvent) {
+	for i := range changes {
+		s.CacheEvents.Call(&changes[i])
+	}
+}
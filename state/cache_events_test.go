@@ -0,0 +1,160 @@
+package state
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/gateway"
+	"github.com/diamondburned/arikawa/v3/state/store"
+	"github.com/diamondburned/arikawa/v3/state/store/defaultstore"
+	"github.com/diamondburned/arikawa/v3/utils/handler"
+)
+
+func TestCacheEvents(t *testing.T) {
+	s := New("Bot token")
+	s.CacheEvents = handler.New()
+
+	var events []CacheEvent
+	s.CacheEvents.AddSyncHandler(func(ev *CacheEvent) {
+		events = append(events, *ev)
+	})
+
+	// CacheEvents must be dispatched before the handlers are called.
+	var handlerSaw int
+	s.AddSyncHandler(func(*gateway.MessageCreateEvent) {
+		handlerSaw = len(events)
+	})
+
+	tests := []struct {
+		name   string
+		event  interface{}
+		expect []CacheEvent
+	}{
+		{
+			name:  "ready",
+			event: &gateway.ReadyEvent{User: discord.User{ID: 100}},
+			expect: []CacheEvent{
+				{Op: CacheReset},
+				{CacheMe, CacheSet, 0, 0, 100},
+			},
+		},
+		{
+			name: "guild create",
+			event: &gateway.GuildCreateEvent{
+				Guild:    discord.Guild{ID: 1, Roles: []discord.Role{{ID: 20}}},
+				Channels: []discord.Channel{{ID: 10, GuildID: 1}},
+				Members:  []discord.Member{{User: discord.User{ID: 100}}},
+			},
+			expect: []CacheEvent{
+				{CacheGuild, CacheSet, 1, 0, 1},
+				{CacheMember, CacheSet, 1, 0, 100},
+				{CacheChannel, CacheSet, 1, 10, 10},
+				{CacheRole, CacheSet, 1, 0, 20},
+			},
+		},
+		{
+			name: "message create",
+			event: &gateway.MessageCreateEvent{
+				Message: discord.Message{ID: 30, ChannelID: 10, GuildID: 1},
+			},
+			expect: []CacheEvent{
+				{CacheMessage, CacheSet, 1, 10, 30},
+			},
+		},
+		{
+			name:  "message delete",
+			event: &gateway.MessageDeleteEvent{ID: 30, ChannelID: 10, GuildID: 1},
+			expect: []CacheEvent{
+				{CacheMessage, CacheRemove, 1, 10, 30},
+			},
+		},
+		{
+			name:  "role delete",
+			event: &gateway.GuildRoleDeleteEvent{GuildID: 1, RoleID: 20},
+			expect: []CacheEvent{
+				{CacheRole, CacheRemove, 1, 0, 20},
+			},
+		},
+		{
+			name:  "member remove",
+			event: &gateway.GuildMemberRemoveEvent{GuildID: 1, User: discord.User{ID: 100}},
+			expect: []CacheEvent{
+				{CacheMember, CacheRemove, 1, 0, 100},
+				{CacheVoiceState, CacheRemove, 1, 0, 100},
+			},
+		},
+		{
+			name:  "channel delete",
+			event: &gateway.ChannelDeleteEvent{Channel: discord.Channel{ID: 10, GuildID: 1}},
+			expect: []CacheEvent{
+				{CacheChannel, CacheRemove, 1, 10, 10},
+			},
+		},
+		{
+			name:  "guild delete",
+			event: &gateway.GuildDeleteEvent{ID: 1},
+			expect: []CacheEvent{
+				{CacheGuild, CacheRemove, 1, 0, 1},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		events = nil
+		s.Session.Handler.Call(test.event)
+
+		if !reflect.DeepEqual(events, test.expect) {
+			t.Errorf("%s: expected events %+v, got %+v", test.name, test.expect, events)
+		}
+	}
+
+	if handlerSaw != 1 {
+		t.Errorf("expected the handler to see 1 cache event, saw %d", handlerSaw)
+	}
+}
+
+// failingMessageStore is a MessageStore whose writes always fail.
+type failingMessageStore struct {
+	store.MessageStore
+}
+
+var errStoreFailed = errors.New("store failed")
+
+func (failingMessageStore) MessageSet(*discord.Message, bool) error {
+	return errStoreFailed
+}
+
+func (failingMessageStore) MessageRemove(discord.ChannelID, discord.MessageID) error {
+	return errStoreFailed
+}
+
+func TestCacheEventsStoreFailed(t *testing.T) {
+	cabinet := defaultstore.New()
+	cabinet.MessageStore = failingMessageStore{cabinet.MessageStore}
+
+	s := NewWithStore("Bot token", cabinet)
+	s.CacheEvents = handler.New()
+
+	var logged []error
+	s.StateLog = func(err error) { logged = append(logged, err) }
+
+	var events []CacheEvent
+	s.CacheEvents.AddSyncHandler(func(ev *CacheEvent) {
+		events = append(events, *ev)
+	})
+
+	s.Session.Handler.Call(&gateway.MessageCreateEvent{
+		Message: discord.Message{ID: 30, ChannelID: 10, GuildID: 1},
+	})
+	s.Session.Handler.Call(&gateway.MessageDeleteEvent{ID: 30, ChannelID: 10, GuildID: 1})
+
+	if len(events) != 0 {
+		t.Fatalf("expected no cache events for failed writes, got %+v", events)
+	}
+
+	if len(logged) != 2 || !errors.Is(logged[0], errStoreFailed) || !errors.Is(logged[1], errStoreFailed) {
+		t.Fatalf("expected the failed writes to be logged, got %v", logged)
+	}
+}
@@ -1,26 +1,111 @@
 package state
 
 import (
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/discord"
 	"github.com/diamondburned/arikawa/v3/gateway"
 )
 
-func (s *State) handleReady(ev *gateway.ReadyEvent) {
+// handleReady starts tracking the guilds of the Ready event. wait waits for
+// the Ready handlers to return.
+func (s *State) handleReady(ev *gateway.ReadyEvent, wait func()) {
 	s.guildMutex.Lock()
-	defer s.guildMutex.Unlock()
 
 	for chID := range s.fewMessages {
 		delete(s.fewMessages, chID)
 	}
 
+	// Guilds of the previous Ready event are no longer expected.
+	for guildID := range s.unreadyGuilds {
+		delete(s.unreadyGuilds, guildID)
+	}
+
 	for _, g := range ev.Guilds {
 		s.unreadyGuilds[g.ID] = struct{}{}
 	}
+
+	if s.readyTimer != nil {
+		s.readyTimer.Stop()
+		s.readyTimer = nil
+	}
+
+	if len(ev.Guilds) == 0 {
+		s.readyGuilds = nil
+		s.guildMutex.Unlock()
+
+		// Without guilds, the event would be dispatched right away, racing
+		// the asynchronous Ready handlers. Wait for them in another goroutine
+		// to not block the gateway.
+		go func() {
+			wait()
+			s.Handler.Call(&AllGuildsReadyEvent{})
+		}()
+		return
+	}
+
+	s.readyGuilds = make([]discord.GuildID, 0, len(ev.Guilds))
+	s.resetReadyTimer()
+
+	s.guildMutex.Unlock()
+}
+
+// resetReadyTimer (re)starts the timer that dispatches AllGuildsReadyEvent once
+// no guild is received for GuildsReadyTimeout. The guild mutex must be
+// acquired.
+func (s *State) resetReadyTimer() {
+	timeout := s.GuildsReadyTimeout
+	if timeout == 0 {
+		timeout = DefaultGuildsReadyTimeout
+	}
+
+	if s.readyTimer != nil {
+		s.readyTimer.Stop()
+	}
+
+	var timer *time.Timer
+	timer = time.AfterFunc(timeout, func() {
+		s.guildMutex.Lock()
+
+		// Ignore timers that were stopped or replaced in the meantime.
+		if s.readyTimer != timer {
+			s.guildMutex.Unlock()
+			return
+		}
+
+		ev := s.allGuildsReady()
+		s.guildMutex.Unlock()
+
+		s.Handler.Call(ev)
+	})
+
+	s.readyTimer = timer
+}
+
+// allGuildsReady creates the AllGuildsReadyEvent and stops tracking guilds.
+// The guild mutex must be acquired.
+func (s *State) allGuildsReady() *AllGuildsReadyEvent {
+	ev := &AllGuildsReadyEvent{Guilds: s.readyGuilds}
+
+	for guildID := range s.unreadyGuilds {
+		ev.TimedOut = append(ev.TimedOut, guildID)
+	}
+
+	if s.readyTimer != nil {
+		s.readyTimer.Stop()
+		s.readyTimer = nil
+	}
+
+	s.readyGuilds = nil
+
+	return ev
 }
 
 func (s *State) handleGuildCreate(ev *gateway.GuildCreateEvent) {
 	s.guildMutex.Lock()
 
 	var derivedEvent interface{}
+	var allReadyEvent *AllGuildsReadyEvent
 
 	// The guild was previously announced to us in the ready event, and has now
 	// become available.
@@ -28,6 +113,16 @@ func (s *State) handleGuildCreate(ev *gateway.GuildCreateEvent) {
 		delete(s.unreadyGuilds, ev.ID)
 		derivedEvent = &GuildReadyEvent{GuildCreateEvent: ev}
 
+		if s.readyGuilds != nil {
+			s.readyGuilds = append(s.readyGuilds, ev.ID)
+
+			if len(s.unreadyGuilds) == 0 {
+				allReadyEvent = s.allGuildsReady()
+			} else {
+				s.resetReadyTimer()
+			}
+		}
+
 		// The guild was previously announced as unavailable through a guild
 		// delete event, and has now become available again.
 	} else if _, ok = s.unavailableGuilds[ev.ID]; ok {
@@ -43,6 +138,10 @@ func (s *State) handleGuildCreate(ev *gateway.GuildCreateEvent) {
 	// long-blocking synchronous handlers.
 	s.guildMutex.Unlock()
 	s.Handler.Call(derivedEvent)
+
+	if allReadyEvent != nil {
+		s.Handler.Call(allReadyEvent)
+	}
 }
 
 func (s *State) handleGuildDelete(ev *gateway.GuildDeleteEvent) {
@@ -64,3 +163,19 @@ func (s *State) handleGuildDelete(ev *gateway.GuildDeleteEvent) {
 		s.Handler.Call(&GuildLeaveEvent{GuildDeleteEvent: ev})
 	}
 }
+
+func (s *State) handleMessageUpdate(ev *gateway.MessageUpdateEvent, old *discord.Message) {
+	updated := &MessageUpdatedEvent{MessageUpdateEvent: ev, Old: old}
+
+	if old != nil {
+		if m, err := s.Cabinet.Message(ev.ChannelID, ev.ID); err == nil {
+			updated.New = m
+		}
+	}
+
+	if updated.New == nil {
+		updated.New = &ev.Message
+	}
+
+	s.Handler.Call(updated)
+}
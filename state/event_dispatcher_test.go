@@ -0,0 +1,169 @@
+package state
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/gateway"
+)
+
+func readyWithGuilds(ids ...discord.GuildID) *gateway.ReadyEvent {
+	ready := &gateway.ReadyEvent{}
+	for _, id := range ids {
+		ready.Guilds = append(ready.Guilds, gateway.GuildCreateEvent{
+			Guild: discord.Guild{ID: id},
+		})
+	}
+	return ready
+}
+
+func TestAllGuildsReadyEvent(t *testing.T) {
+	newState := func() (*State, <-chan *AllGuildsReadyEvent) {
+		s := New("Bot token")
+		s.GuildsReadyTimeout = 50 * time.Millisecond
+
+		ch := make(chan *AllGuildsReadyEvent, 1)
+		s.AddSyncHandler(func(ev *AllGuildsReadyEvent) { ch <- ev })
+
+		return s, ch
+	}
+
+	guildCreate := func(id discord.GuildID) *gateway.GuildCreateEvent {
+		return &gateway.GuildCreateEvent{Guild: discord.Guild{ID: id}}
+	}
+
+	t.Run("all guilds", func(t *testing.T) {
+		s, ch := newState()
+
+		s.Session.Handler.Call(readyWithGuilds(1, 2))
+		s.Session.Handler.Call(guildCreate(2))
+
+		select {
+		case ev := <-ch:
+			t.Fatalf("unexpected early event %+v", ev)
+		default:
+		}
+
+		s.Session.Handler.Call(guildCreate(1))
+
+		select {
+		case ev := <-ch:
+			expect := &AllGuildsReadyEvent{Guilds: []discord.GuildID{2, 1}}
+			if !reflect.DeepEqual(ev, expect) {
+				t.Fatalf("expected %+v, got %+v", expect, ev)
+			}
+		default:
+			t.Fatal("expected AllGuildsReadyEvent after the last guild")
+		}
+	})
+
+	t.Run("no guilds", func(t *testing.T) {
+		s, ch := newState()
+
+		release := make(chan struct{})
+		s.AddHandler(func(*gateway.ReadyEvent) { <-release })
+
+		s.Session.Handler.Call(readyWithGuilds())
+
+		select {
+		case ev := <-ch:
+			t.Fatalf("unexpected event %+v before the Ready handler returned", ev)
+		case <-time.After(10 * time.Millisecond):
+		}
+
+		close(release)
+
+		select {
+		case ev := <-ch:
+			if len(ev.Guilds) != 0 || len(ev.TimedOut) != 0 {
+				t.Fatalf("unexpected event %+v", ev)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("timed out waiting for AllGuildsReadyEvent")
+		}
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		s, ch := newState()
+
+		s.Session.Handler.Call(readyWithGuilds(1, 2))
+		s.Session.Handler.Call(guildCreate(1))
+
+		select {
+		case ev := <-ch:
+			expect := &AllGuildsReadyEvent{
+				Guilds:   []discord.GuildID{1},
+				TimedOut: []discord.GuildID{2},
+			}
+			if !reflect.DeepEqual(ev, expect) {
+				t.Fatalf("expected %+v, got %+v", expect, ev)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("timed out waiting for AllGuildsReadyEvent")
+		}
+	})
+}
+
+func TestMessageUpdatedEvent(t *testing.T) {
+	s := New("Bot token")
+
+	var events []*MessageUpdatedEvent
+	s.AddSyncHandler(func(ev *MessageUpdatedEvent) {
+		events = append(events, ev)
+	})
+
+	author := discord.User{ID: 2}
+
+	s.Session.Handler.Call(&gateway.MessageCreateEvent{
+		Message: discord.Message{
+			ID:        1,
+			ChannelID: 1,
+			Author:    author,
+			Content:   "old",
+			Flags:     discord.SuppressEmbeds,
+		},
+	})
+
+	// The update unsuppresses the embeds, which clears the flag.
+	s.Session.Handler.Call(&gateway.MessageUpdateEvent{
+		Message: discord.Message{
+			ID:        1,
+			ChannelID: 1,
+			Author:    author,
+			Content:   "new",
+		},
+	})
+
+	// The message isn't cached.
+	uncached := &gateway.MessageUpdateEvent{
+		Message: discord.Message{ID: 2, ChannelID: 1, Content: "uncached"},
+	}
+	s.Session.Handler.Call(uncached)
+
+	if len(events) != 2 {
+		t.Fatalf("expected 2 MessageUpdatedEvents, got %d", len(events))
+	}
+
+	ev := events[0]
+	if ev.Old == nil || ev.Old.Content != "old" || ev.Old.Flags != discord.SuppressEmbeds {
+		t.Fatalf("unexpected old message %+v", ev.Old)
+	}
+	if ev.New.Content != "new" || ev.New.Flags != 0 || ev.New.Author.ID != author.ID {
+		t.Fatalf("unexpected new message %+v", ev.New)
+	}
+
+	cached, err := s.Cabinet.Message(1, 1)
+	if err != nil || cached.Flags != 0 {
+		t.Fatalf("expected the flags to be cleared in the cache, got %+v (%v)", cached, err)
+	}
+
+	ev = events[1]
+	if ev.Old != nil {
+		t.Fatalf("unexpected old message for an uncached message %+v", ev.Old)
+	}
+	if ev.New != &uncached.Message {
+		t.Fatalf("expected the new message to be the update, got %+v", ev.New)
+	}
+}
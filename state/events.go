@@ -1,6 +1,9 @@
 package state
 
-import "github.com/diamondburned/arikawa/v3/gateway"
+import (
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/gateway"
+)
 
 // events that originated from GuildCreate:
 type (
@@ -40,3 +43,35 @@ type (
 		*gateway.GuildDeleteEvent
 	}
 )
+
+// AllGuildsReadyEvent gets fired once after each Ready event, when every guild
+// listed in the Ready event has been received through a GuildReadyEvent, or
+// when no guild has been received for State.GuildsReadyTimeout. It is useful
+// for deferring startup tasks until the state is fully primed.
+//
+// AllGuildsReadyEvent is fired after the GuildReadyEvent of the last guild. If
+// the Ready event has no guilds, then it is fired once every Ready handler has
+// returned, including asynchronous ones.
+type AllGuildsReadyEvent struct {
+	// Guilds is the list of guilds that were received, in the order they
+	// were received.
+	Guilds []discord.GuildID
+	// TimedOut is the list of guilds that were not received before the
+	// timeout, usually because they are unavailable. It is empty if every
+	// guild was received.
+	TimedOut []discord.GuildID
+}
+
+// MessageUpdatedEvent gets fired after every Message Update event, once the
+// State has merged the update into its cache. Since Message Update events may
+// only carry the changed fields, it is the recommended way to get the complete
+// message and what it looked like before the update.
+type MessageUpdatedEvent struct {
+	*gateway.MessageUpdateEvent
+	// Old is the message as it was cached before the update. It is nil if
+	// the message wasn't cached.
+	Old *discord.Message
+	// New is the message after the update was applied. If the message wasn't
+	// cached, it is the message from the Message Update event.
+	New *discord.Message
+}
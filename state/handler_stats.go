@@ -0,0 +1,175 @@
+package state
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/utils/ws"
+)
+
+// handlerStatsSamples is the number of most recent durations kept per event
+// type to compute the percentiles in HandlerStats.
+const handlerStatsSamples = 1024
+
+// HandlerStats describes how long the handlers in State's Handler took to
+// handle the events of one type. Only the time spent in synchronous handlers
+// is measured, since that is what delays the processing of the next gateway
+// event; asynchronous handlers only count the time taken to start them.
+type HandlerStats struct {
+	// Count is the number of events handled.
+	Count uint64
+	// Total is the total time spent handling the events.
+	Total time.Duration
+	// Max is the longest time spent handling a single event.
+	Max time.Duration
+	// P50 and P99 are the 50th and 99th percentiles of the time spent handling
+	// the most recent events.
+	P50 time.Duration
+	P99 time.Duration
+}
+
+// Mean returns the average time spent handling an event.
+func (s HandlerStats) Mean() time.Duration {
+	if s.Count == 0 {
+		return 0
+	}
+	return s.Total / time.Duration(s.Count)
+}
+
+// handlerStats records handler timings per event type.
+type handlerStats struct {
+	mu     sync.Mutex
+	events map[ws.EventType]*eventTimings
+}
+
+type eventTimings struct {
+	count   uint64
+	total   time.Duration
+	max     time.Duration
+	samples []time.Duration // ring buffer
+	next    int
+}
+
+func newHandlerStats() *handlerStats {
+	return &handlerStats{events: make(map[ws.EventType]*eventTimings)}
+}
+
+func (s *handlerStats) record(t ws.EventType, took time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	timings, ok := s.events[t]
+	if !ok {
+		timings = &eventTimings{}
+		s.events[t] = timings
+	}
+
+	timings.count++
+	timings.total += took
+	if took > timings.max {
+		timings.max = took
+	}
+
+	if len(timings.samples) < handlerStatsSamples {
+		timings.samples = append(timings.samples, took)
+	} else {
+		timings.samples[timings.next] = took
+		timings.next = (timings.next + 1) % handlerStatsSamples
+	}
+}
+
+func (s *handlerStats) snapshot() map[ws.EventType]HandlerStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stats := make(map[ws.EventType]HandlerStats, len(s.events))
+
+	for t, timings := range s.events {
+		sorted := make([]time.Duration, len(timings.samples))
+		copy(sorted, timings.samples)
+		sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+		stats[t] = HandlerStats{
+			Count: timings.count,
+			Total: timings.total,
+			Max:   timings.max,
+			P50:   percentile(sorted, 50),
+			P99:   percentile(sorted, 99),
+		}
+	}
+
+	return stats
+}
+
+func (s *handlerStats) reset() {
+	s.mu.Lock()
+	s.events = make(map[ws.EventType]*eventTimings)
+	s.mu.Unlock()
+}
+
+// percentile returns the p-th percentile of the sorted durations using the
+// nearest-rank method.
+func percentile(sorted []time.Duration, p int) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+
+	rank := (p*len(sorted) + 99) / 100 // ceil(p/100 * n)
+	if rank < 1 {
+		rank = 1
+	}
+
+	return sorted[rank-1]
+}
+
+// HandlerStats returns how long the handlers took to handle each type of
+// gateway event so far. It is empty unless MeasureHandlers is true.
+func (s *State) HandlerStats() map[ws.EventType]HandlerStats {
+	if s.handlerStats == nil {
+		return map[ws.EventType]HandlerStats{}
+	}
+	return s.handlerStats.snapshot()
+}
+
+// ResetHandlerStats clears the statistics returned by HandlerStats.
+func (s *State) ResetHandlerStats() {
+	if s.handlerStats != nil {
+		s.handlerStats.reset()
+	}
+}
+
+// callHandler calls the Handler with the given event, measuring how long it
+// takes if needed.
+func (s *State) callHandler(event interface{}) {
+	s.measureHandler(event, func() { s.Handler.Call(event) })
+}
+
+// callHandlerWait is like callHandler, except it returns a function that waits
+// for the asynchronous handlers to return. See Handler.CallWait.
+func (s *State) callHandlerWait(event interface{}) (wait func()) {
+	s.measureHandler(event, func() { wait = s.Handler.CallWait(event) })
+	return wait
+}
+
+// measureHandler calls call, which calls the Handler with the given event,
+// measuring how long it takes if needed.
+func (s *State) measureHandler(event interface{}, call func()) {
+	wsEvent, ok := event.(ws.Event)
+	if !ok || (!s.MeasureHandlers && s.OnHandlerTiming == nil) {
+		call()
+		return
+	}
+
+	start := time.Now()
+	call()
+	took := time.Since(start)
+
+	if s.MeasureHandlers && s.handlerStats != nil {
+		s.handlerStats.record(wsEvent.EventType(), took)
+	}
+
+	if s.OnHandlerTiming != nil {
+		s.OnHandlerTiming(wsEvent, took)
+	}
+}
@@ -0,0 +1,158 @@
+package state
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/gateway"
+	"github.com/diamondburned/arikawa/v3/utils/ws"
+)
+
+func TestPercentile(t *testing.T) {
+	// durations returns 1ms to nms.
+	durations := func(n int) []time.Duration {
+		d := make([]time.Duration, n)
+		for i := range d {
+			d[i] = time.Duration(i+1) * time.Millisecond
+		}
+		return d
+	}
+
+	tests := []struct {
+		name   string
+		sorted []time.Duration
+		p      int
+		expect time.Duration
+	}{
+		{"empty", nil, 50, 0},
+		{"n=1 p50", durations(1), 50, time.Millisecond},
+		{"n=1 p99", durations(1), 99, time.Millisecond},
+		{"n=2 p50", durations(2), 50, time.Millisecond},
+		{"n=2 p99", durations(2), 99, 2 * time.Millisecond},
+		{"n=10 p50", durations(10), 50, 5 * time.Millisecond},
+		{"n=10 p99", durations(10), 99, 10 * time.Millisecond},
+		{"n=100 p0", durations(100), 0, time.Millisecond},
+		{"n=100 p50", durations(100), 50, 50 * time.Millisecond},
+		{"n=100 p99", durations(100), 99, 99 * time.Millisecond},
+		{"n=100 p100", durations(100), 100, 100 * time.Millisecond},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := percentile(test.sorted, test.p); got != test.expect {
+				t.Fatalf("expected %v, got %v", test.expect, got)
+			}
+		})
+	}
+}
+
+func TestHandlerStatsWrap(t *testing.T) {
+	const eventType ws.EventType = "TEST"
+
+	stats := newHandlerStats()
+
+	// Fill the buffer with 1ms, then overwrite all but 10 samples with 2ms.
+	for i := 0; i < handlerStatsSamples; i++ {
+		stats.record(eventType, time.Millisecond)
+	}
+	for i := 0; i < handlerStatsSamples-10; i++ {
+		stats.record(eventType, 2*time.Millisecond)
+	}
+
+	s := stats.snapshot()[eventType]
+
+	if s.Count != 2*handlerStatsSamples-10 {
+		t.Fatalf("unexpected count %d", s.Count)
+	}
+	if s.Max != 2*time.Millisecond {
+		t.Fatalf("unexpected max %v", s.Max)
+	}
+	if expect := time.Duration(3*handlerStatsSamples-20) * time.Millisecond; s.Total != expect {
+		t.Fatalf("expected total %v, got %v", expect, s.Total)
+	}
+	if s.P50 != 2*time.Millisecond || s.P99 != 2*time.Millisecond {
+		t.Fatalf("unexpected percentiles %v and %v", s.P50, s.P99)
+	}
+
+	// The buffer wraps around past the remaining 1ms samples and starts
+	// overwriting the oldest 2ms ones.
+	for i := 0; i < handlerStatsSamples/2+10; i++ {
+		stats.record(eventType, 3*time.Millisecond)
+	}
+
+	samples := stats.events[eventType].samples
+	if len(samples) != handlerStatsSamples {
+		t.Fatalf("expected %d samples, got %d", handlerStatsSamples, len(samples))
+	}
+
+	counts := make(map[time.Duration]int)
+	for _, d := range samples {
+		counts[d]++
+	}
+
+	expect := map[time.Duration]int{
+		2 * time.Millisecond: handlerStatsSamples/2 - 10,
+		3 * time.Millisecond: handlerStatsSamples/2 + 10,
+	}
+	if !reflect.DeepEqual(counts, expect) {
+		t.Fatalf("expected samples %v, got %v", expect, counts)
+	}
+
+	s = stats.snapshot()[eventType]
+	if s.P50 != 3*time.Millisecond || s.Max != 3*time.Millisecond {
+		t.Fatalf("unexpected p50 %v and max %v after wrapping", s.P50, s.Max)
+	}
+}
+
+func TestStateMeasureHandlers(t *testing.T) {
+	const sleep = 5 * time.Millisecond
+
+	s := New("Bot token")
+	s.MeasureHandlers = true
+
+	var timings []time.Duration
+	s.OnHandlerTiming = func(ev ws.Event, took time.Duration) {
+		if ev.EventType() != "MESSAGE_CREATE" {
+			t.Errorf("unexpected event %s", ev.EventType())
+		}
+		timings = append(timings, took)
+	}
+
+	s.AddSyncHandler(func(*gateway.MessageCreateEvent) {
+		time.Sleep(sleep)
+	})
+
+	for i := 0; i < 3; i++ {
+		s.Session.Handler.Call(&gateway.MessageCreateEvent{
+			Message: discord.Message{ID: discord.MessageID(i + 1), ChannelID: 1},
+		})
+	}
+
+	stats, ok := s.HandlerStats()["MESSAGE_CREATE"]
+	if !ok {
+		t.Fatal("missing stats for MESSAGE_CREATE")
+	}
+
+	if stats.Count != 3 {
+		t.Fatalf("expected 3 events, got %d", stats.Count)
+	}
+	if stats.P50 < sleep || stats.Max < sleep || stats.Mean() < sleep {
+		t.Fatalf("handler time not measured: %+v", stats)
+	}
+
+	if len(timings) != 3 {
+		t.Fatalf("expected OnHandlerTiming to be called 3 times, got %d", len(timings))
+	}
+	for _, took := range timings {
+		if took < sleep {
+			t.Fatalf("OnHandlerTiming got %v, expected at least %v", took, sleep)
+		}
+	}
+
+	s.ResetHandlerStats()
+	if stats := s.HandlerStats(); len(stats) != 0 {
+		t.Fatalf("expected no stats after reset, got %v", stats)
+	}
+}
@@ -6,7 +6,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
 	"sync"
+	"time"
 
 	"github.com/diamondburned/arikawa/v3/api"
 	"github.com/diamondburned/arikawa/v3/discord"
@@ -16,6 +18,7 @@ import (
 	"github.com/diamondburned/arikawa/v3/state/store"
 	"github.com/diamondburned/arikawa/v3/state/store/defaultstore"
 	"github.com/diamondburned/arikawa/v3/utils/handler"
+	"github.com/diamondburned/arikawa/v3/utils/ws"
 )
 
 var (
@@ -25,11 +28,14 @@ var (
 
 // NewShardFunc creates a shard constructor with its own state registry and
 // handlers. The given opts function is called everytime the State is created.
-// The user should initialize handlers and intents in the opts function.
+// The user should initialize handlers and intents in the opts function. The
+// events of each State are also dispatched into the Manager's merged handler
+// after the State has handled them.
 func NewShardFunc(opts func(*shard.Manager, *State)) shard.NewShardFunc {
 	return func(m *shard.Manager, id *gateway.Identifier) (shard.Shard, error) {
 		sessn := session.NewCustom(*id, api.NewClient(id.Token), handler.New())
 		state := NewFromSession(sessn, defaultstore.New())
+		m.MergeHandler(id, state.Handler)
 		opts(m, state)
 		return state, nil
 	}
@@ -71,6 +77,10 @@ func NewShardFunc(opts func(*shard.Manager, *State)) shard.NewShardFunc {
 // The Message Create and Message Update events with the Member field provided
 // will have the User field copied from Author. This is because the User field
 // will be empty, while the Member structure expects it to be there.
+//
+// The Message Update event is followed by a Message Updated event, which
+// carries the cached message before and after the update. Refer to
+// MessageUpdatedEvent for more information.
 type State struct {
 	*session.Session
 	*store.Cabinet
@@ -80,6 +90,21 @@ type State struct {
 	readyMu *sync.Mutex
 	ready   gateway.ReadyEvent
 
+	// GuildsReadyTimeout is the maximum duration to wait for the next guild
+	// listed in the Ready event before giving up and dispatching an
+	// AllGuildsReadyEvent. If it is 0, DefaultGuildsReadyTimeout is used.
+	GuildsReadyTimeout time.Duration
+
+	// KeepUnavailableGuilds, if true, keeps the cached data of guilds that
+	// become unavailable due to an outage, so that it can still be read until
+	// the guild is available again. By default, such guilds are purged like
+	// guilds that the user has left, since Discord sends them again in full
+	// once they're available.
+	//
+	// Guilds that the user leaves or is removed from are always purged. See
+	// store.Cabinet.GuildPurge.
+	KeepUnavailableGuilds bool
+
 	// StateLog logs all errors that come from the state cache. This includes
 	// not found errors. Defaults to a no-op, as state errors aren't that
 	// important.
@@ -90,6 +115,25 @@ type State struct {
 	// It's recommended to set Synchronous to true if you mutate the events.
 	PreHandler *handler.Handler // default nil
 
+	// CacheEvents, if not nil, is called with a *CacheEvent for every change
+	// that a gateway event makes to the cache, right after the State has
+	// updated itself and before Handler is called. It lets external consumers,
+	// such as search indexes, mirror the cache without handling gateway events
+	// themselves. Changes made by caching API responses and changes to stores
+	// that are store.Noop are not reported.
+	CacheEvents *handler.Handler // default nil
+
+	// MeasureHandlers, if true, makes the State measure how long the handlers
+	// in Handler take to handle each gateway event, so that slow handlers
+	// which delay the processing of gateway events can be found. See
+	// HandlerStats.
+	MeasureHandlers bool // default false
+
+	// OnHandlerTiming, if not nil, is called with every gateway event and how
+	// long the handlers in Handler took to handle it, right after they're
+	// done. It is called from the event loop, so it must be fast.
+	OnHandlerTiming func(ev ws.Event, took time.Duration) // default nil
+
 	// Command handler with inherited methods. Ran after PreHandler. You should
 	// most of the time use this instead of Session's, to avoid race conditions
 	// with the State.
@@ -100,6 +144,13 @@ type State struct {
 	fewMessages map[discord.ChannelID]struct{}
 	fewMutex    *sync.Mutex
 
+	// cacheMutex is held while an event is being applied to the Cabinet. It
+	// is used to read multiple stores coherently; see MemberAndPresence.
+	cacheMutex *sync.RWMutex
+	// cacheChanges is the list of changes that the event being applied made to
+	// the Cabinet. It is guarded by cacheMutex.
+	cacheChanges []CacheEvent
+
 	// unavailableGuilds is a set of discord.GuildIDs of guilds that became
 	// unavailable after connecting to the gateway, i.e. they were sent in a
 	// GuildUnavailableEvent.
@@ -108,9 +159,19 @@ type State struct {
 	// the Ready event. After receiving guild create events for those guilds,
 	// they will be removed.
 	unreadyGuilds map[discord.GuildID]struct{}
-	guildMutex    *sync.Mutex
+	// readyGuilds is the list of guilds received after the last Ready event,
+	// or nil if AllGuildsReadyEvent was already dispatched.
+	readyGuilds []discord.GuildID
+	readyTimer  *time.Timer
+	guildMutex  *sync.Mutex
+
+	// handlerStats is recorded into if MeasureHandlers is true.
+	handlerStats *handlerStats
 }
 
+// DefaultGuildsReadyTimeout is the default value of State.GuildsReadyTimeout.
+var DefaultGuildsReadyTimeout = 15 * time.Second
+
 // New creates a new state.
 func New(token string) *State {
 	return NewWithStore(token, defaultstore.New())
@@ -145,9 +206,11 @@ func NewFromSession(s *session.Session, cabinet *store.Cabinet) *State {
 		readyMu:           new(sync.Mutex),
 		fewMessages:       map[discord.ChannelID]struct{}{},
 		fewMutex:          new(sync.Mutex),
+		cacheMutex:        new(sync.RWMutex),
 		unavailableGuilds: make(map[discord.GuildID]struct{}),
 		unreadyGuilds:     make(map[discord.GuildID]struct{}),
 		guildMutex:        new(sync.Mutex),
+		handlerStats:      newHandlerStats(),
 	}
 	state.hookSession()
 	return state
@@ -161,10 +224,11 @@ func NewFromSession(s *session.Session, cabinet *store.Cabinet) *State {
 // work, which is expected.
 func NewAPIOnlyState(token string, h *handler.Handler) *State {
 	return &State{
-		Session:  session.NewCustom(gateway.DefaultIdentifier(token), api.NewClient(token), h),
-		Handler:  h,
-		Cabinet:  store.NoopCabinet,
-		StateLog: func(err error) {},
+		Session:    session.NewCustom(gateway.DefaultIdentifier(token), api.NewClient(token), h),
+		Handler:    h,
+		Cabinet:    store.NoopCabinet,
+		StateLog:   func(err error) {},
+		cacheMutex: new(sync.RWMutex),
 	}
 }
 
@@ -178,6 +242,13 @@ func (s *State) WithContext(ctx context.Context) *State {
 	return &copied
 }
 
+// AddHandlerCtx is similar to AddHandler, except the handler must be a
+// function taking a context.Context before the event. The context is cancelled
+// once the State is closed. See Session.AddHandlerCtx.
+func (s *State) AddHandlerCtx(fn interface{}) (rm func()) {
+	return s.Handler.AddHandler(handler.WithContext(fn, s.Session.Context))
+}
+
 // Ready returns a copy of the Ready event. Although this function is safe to
 // call concurrently, its values should still not be changed, as certain types
 // like slices are not concurrent-safe.
@@ -323,7 +394,9 @@ roleSearch:
 ////
 
 // Permissions gets the user's permissions in the given channel. If the channel
-// is not in any guild, then an error is returned.
+// is not in any guild, then an error is returned. If the channel is a thread,
+// then the overwrites of its parent channel are used. See
+// discord.CalcPermissions for how the permissions are calculated.
 func (s *State) Permissions(
 	channelID discord.ChannelID, userID discord.UserID) (discord.Permissions, error) {
 
@@ -336,6 +409,14 @@ func (s *State) Permissions(
 		return 0, errors.New("channel is not in a guild")
 	}
 
+	var parent *discord.Channel
+	if ch.Type.IsThread() {
+		parent, err = s.Channel(ch.ParentID)
+		if err != nil {
+			return 0, fmt.Errorf("failed to get thread parent channel: %w", err)
+		}
+	}
+
 	var wg sync.WaitGroup
 
 	var (
@@ -406,7 +487,7 @@ func (s *State) Permissions(
 		return 0, fmt.Errorf("failed to get roles: %w", rerr)
 	}
 
-	return discord.CalcOverrides(*g, *ch, *m, rs), nil
+	return discord.CalcPermissions(*g, *ch, parent, *m, rs), nil
 }
 
 ////
@@ -447,6 +528,21 @@ func (s *State) Channel(id discord.ChannelID) (c *discord.Channel, err error) {
 	return
 }
 
+// SyncChannelPermissions is like api.Client.SyncChannelPermissions, but it
+// diffs against the cached channel if it's available.
+func (s *State) SyncChannelPermissions(
+	channelID discord.ChannelID,
+	desired []discord.Overwrite, reason api.AuditLogReason) (api.OverwritesDiff, error) {
+
+	ch, err := s.Channel(channelID)
+	if err != nil {
+		return api.OverwritesDiff{}, fmt.Errorf("failed to get channel: %w", err)
+	}
+
+	diff := api.DiffOverwrites(ch.Overwrites, desired)
+	return diff, s.ApplyOverwritesDiff(channelID, diff, reason)
+}
+
 func (s *State) Channels(guildID discord.GuildID) (cs []discord.Channel, err error) {
 	if s.HasIntents(gateway.IntentGuilds) {
 		cs, err = s.Cabinet.Channels(guildID)
@@ -485,6 +581,20 @@ func (s *State) CreatePrivateChannel(recipient discord.UserID) (*discord.Channel
 	return c, nil
 }
 
+// SendDirectMessage sends a message to the user with the given ID in their DM
+// channel. Unlike the api.Client method, the DM channel is looked up using
+// CreatePrivateChannel, which reuses the private channels in the state.
+func (s *State) SendDirectMessage(
+	userID discord.UserID, data api.SendMessageData) (*discord.Message, error) {
+
+	ch, err := s.CreatePrivateChannel(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.SendMessageComplex(ch.ID, data)
+}
+
 // PrivateChannels gets the direct messages of the user.
 // This is not supported for bots.
 func (s *State) PrivateChannels() ([]discord.Channel, error) {
@@ -626,6 +736,26 @@ func (s *State) Members(guildID discord.GuildID) (ms []discord.Member, err error
 	return
 }
 
+// SearchMembers searches the members of the guild using the API. Since search
+// results depend on the query, the cache is never used to answer it, but the
+// returned members are cached as if they were fetched using Member.
+func (s *State) SearchMembers(
+	guildID discord.GuildID, query string, limit uint) ([]discord.Member, error) {
+
+	ms, err := s.Session.SearchMembers(guildID, query, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.HasIntents(gateway.IntentGuildMembers) {
+		for i := range ms {
+			s.Cabinet.MemberSet(guildID, &ms[i], false)
+		}
+	}
+
+	return ms, nil
+}
+
 ////
 
 func (s *State) Message(
@@ -744,12 +874,212 @@ func (s *State) Messages(channelID discord.ChannelID, limit uint) ([]discord.Mes
 		return storeMessages, nil
 	}
 
+	s.fillMessagesGuildID(channelID, storeMessages, apiMessages)
+
+	if s.tracksMessage(&apiMessages[0]) && len(storeMessages) < s.MaxMessages() {
+		// Only add as many messages as the store can hold.
+		i := s.MaxMessages() - len(storeMessages)
+		if i > len(apiMessages) {
+			i = len(apiMessages)
+		}
+
+		msgs := apiMessages[:i]
+		for i := range msgs {
+			s.Cabinet.MessageSet(&msgs[i], false)
+		}
+	}
+
+	msgs := append(storeMessages, apiMessages...)
+	if limit > 0 && len(msgs) > int(limit) {
+		msgs = msgs[:limit]
+	}
+
+	return msgs, nil
+}
+
+// MessagesBefore returns messages older than the given message, ordered from
+// latest to earliest. If before is within the cached messages, then the cached
+// messages older than it are returned first, and only the rest is fetched from
+// the API and added to the cache. Otherwise, it behaves like
+// api.Client.MessagesBefore. If before is 0, then it behaves like Messages.
+func (s *State) MessagesBefore(
+	channelID discord.ChannelID, before discord.MessageID, limit uint) ([]discord.Message, error) {
+
+	if !before.IsValid() {
+		return s.Messages(channelID, limit)
+	}
+
+	cached, complete := s.cachedMessages(channelID)
+
+	// The cached messages are the latest ones, so the ones older than before
+	// are only the ones directly before it if before is in the cached range.
+	var msgs []discord.Message
+	contiguous := len(cached) > 0 && before >= cached[len(cached)-1].ID
+
+	if contiguous {
+		i := sort.Search(len(cached), func(i int) bool { return cached[i].ID < before })
+		msgs = cached[i:len(cached):len(cached)]
+
+		if complete || (limit > 0 && len(msgs) >= int(limit)) {
+			if limit > 0 && len(msgs) > int(limit) {
+				msgs = msgs[:limit]
+			}
+			return msgs, nil
+		}
+	} else if complete {
+		// Every message of the channel is cached, and none are older.
+		return nil, nil
+	}
+
+	fetchBefore := before
+	if len(msgs) > 0 {
+		fetchBefore = msgs[len(msgs)-1].ID
+	}
+
+	var fetchLimit uint
+	if limit > 0 {
+		fetchLimit = limit - uint(len(msgs))
+	}
+
+	apiMessages, err := s.Session.MessagesBefore(channelID, fetchBefore, fetchLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	s.fillMessagesGuildID(channelID, cached, apiMessages)
+
+	if contiguous {
+		s.cacheOlderMessages(cached, apiMessages)
+	}
+
+	return append(msgs, apiMessages...), nil
+}
+
+// MessagesAfter returns messages newer than the given message, ordered from
+// latest to earliest. If limit is not 0, then only the limit messages closest
+// to after are returned, like api.Client.MessagesAfter. The cached messages are
+// returned if after is within them, since they are the latest messages.
+// Otherwise, the messages are fetched from the API, and the ones that extend
+// the cached messages are added to the cache.
+func (s *State) MessagesAfter(
+	channelID discord.ChannelID, after discord.MessageID, limit uint) ([]discord.Message, error) {
+
+	cached, complete := s.cachedMessages(channelID)
+
+	if len(cached) > 0 && (complete || after >= cached[len(cached)-1].ID) {
+		i := sort.Search(len(cached), func(i int) bool { return cached[i].ID <= after })
+		msgs := cached[:i]
+
+		// The messages closest to after are the oldest ones.
+		if limit > 0 && len(msgs) > int(limit) {
+			msgs = msgs[len(msgs)-int(limit):]
+		}
+
+		if len(msgs) == 0 {
+			return nil, nil
+		}
+
+		return msgs, nil
+	}
+
+	apiMessages, err := s.Session.MessagesAfter(channelID, after, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	s.fillMessagesGuildID(channelID, cached, apiMessages)
+	s.cacheOlderMessages(cached, olderThanCached(cached, apiMessages))
+
+	return apiMessages, nil
+}
+
+// MessagesAround returns messages around the given message, ordered from
+// latest to earliest. Like api.Client.MessagesAround, limit defaults to 50 and
+// is capped at 100. The cached messages are only used if they contain all
+// messages to be returned, which are half of limit messages older than around
+// and the rest starting from around. Otherwise, the messages are fetched from
+// the API, and the ones that extend the cached messages are added to the
+// cache.
+func (s *State) MessagesAround(
+	channelID discord.ChannelID, around discord.MessageID, limit uint) ([]discord.Message, error) {
+
+	switch {
+	case limit == 0:
+		limit = 50
+	case limit > 100:
+		limit = 100
+	}
+
+	cached, complete := s.cachedMessages(channelID)
+
+	if len(cached) > 0 && around >= cached[len(cached)-1].ID {
+		older := int(limit / 2)
+		newer := int(limit) - older
+
+		// i is the index of the first message older than around.
+		i := sort.Search(len(cached), func(i int) bool { return cached[i].ID < around })
+
+		if complete || len(cached)-i >= older {
+			start := i - newer
+			if start < 0 {
+				start = 0
+			}
+
+			end := i + older
+			if end > len(cached) {
+				end = len(cached)
+			}
+
+			if start == end {
+				return nil, nil
+			}
+
+			return cached[start:end], nil
+		}
+	}
+
+	apiMessages, err := s.Session.MessagesAround(channelID, around, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	s.fillMessagesGuildID(channelID, cached, apiMessages)
+	s.cacheOlderMessages(cached, olderThanCached(cached, apiMessages))
+
+	return apiMessages, nil
+}
+
+// cachedMessages returns the cached messages of the channel, ordered from
+// latest to earliest, and whether they are all of the channel's messages. The
+// cached messages are always the latest messages of the channel without any
+// gaps.
+func (s *State) cachedMessages(channelID discord.ChannelID) ([]discord.Message, bool) {
+	msgs, err := s.Cabinet.Messages(channelID)
+	if err != nil || len(msgs) == 0 || !s.tracksMessage(&msgs[0]) {
+		return nil, false
+	}
+
+	s.fewMutex.Lock()
+	_, few := s.fewMessages[channelID]
+	s.fewMutex.Unlock()
+
+	return msgs, few
+}
+
+// fillMessagesGuildID fills in the GuildID of messages fetched from the API.
+func (s *State) fillMessagesGuildID(
+	channelID discord.ChannelID, cached, apiMessages []discord.Message) {
+
+	if len(apiMessages) == 0 {
+		return
+	}
+
 	// New messages fetched weirdly does not have GuildID filled. If we have
 	// cached messages, we can use their GuildID. Otherwise, we need to fetch
 	// it from the api.
 	var guildID discord.GuildID
-	if len(storeMessages) > 0 {
-		guildID = storeMessages[0].GuildID
+	if len(cached) > 0 {
+		guildID = cached[0].GuildID
 	} else {
 		c, err := s.Channel(channelID)
 		if err == nil {
@@ -761,30 +1091,80 @@ func (s *State) Messages(channelID discord.ChannelID, limit uint) ([]discord.Mes
 	for i := range apiMessages {
 		apiMessages[i].GuildID = guildID
 	}
+}
 
-	if s.tracksMessage(&apiMessages[0]) && len(storeMessages) < s.MaxMessages() {
-		// Only add as many messages as the store can hold.
-		i := s.MaxMessages() - len(storeMessages)
-		if i > len(apiMessages) {
-			i = len(apiMessages)
-		}
+// olderThanCached returns the messages in msgs that are older than the cached
+// messages, if msgs reaches the cached messages. Otherwise, there would be a
+// gap between them and the cached messages, so nil is returned. Both must be
+// ordered from latest to earliest.
+func olderThanCached(cached, msgs []discord.Message) []discord.Message {
+	if len(cached) == 0 || len(msgs) == 0 {
+		return nil
+	}
 
-		msgs := apiMessages[:i]
-		for i := range msgs {
-			s.Cabinet.MessageSet(&msgs[i], false)
-		}
+	oldest := cached[len(cached)-1].ID
+	if msgs[0].ID < oldest {
+		return nil
 	}
 
-	msgs := append(storeMessages, apiMessages...)
-	if limit > 0 && len(msgs) > int(limit) {
-		msgs = msgs[:limit]
+	i := sort.Search(len(msgs), func(i int) bool { return msgs[i].ID < oldest })
+	return msgs[i:]
+}
+
+// cacheOlderMessages adds messages directly older than the cached messages to
+// the cache, as many as the cache can hold. older must be ordered from latest
+// to earliest.
+func (s *State) cacheOlderMessages(cached, older []discord.Message) {
+	if len(cached) == 0 || len(older) == 0 || !s.tracksMessage(&older[0]) {
+		return
 	}
 
-	return msgs, nil
+	n := s.MaxMessages() - len(cached)
+	if n <= 0 {
+		return
+	}
+	if n > len(older) {
+		n = len(older)
+	}
+
+	for i := range older[:n] {
+		s.Cabinet.MessageSet(&older[i], false)
+	}
 }
 
 ////
 
+// MemberAndPresence returns the cached member and their presence in the guild.
+// Unlike calling Member and Presence separately, both are read while no event
+// is being applied to the cache, so the result is never torn: for example, it
+// won't return a presence from a GuildMembersChunkEvent whose members haven't
+// been cached yet.
+//
+// Neither is fetched from the API. If the member isn't cached, then
+// store.ErrNotFound is returned. If only the presence isn't cached, such as when
+// the IntentGuildPresences intent is missing, then the presence is nil.
+func (s *State) MemberAndPresence(
+	guildID discord.GuildID, userID discord.UserID) (*discord.Member, *discord.Presence, error) {
+
+	s.cacheMutex.RLock()
+	defer s.cacheMutex.RUnlock()
+
+	m, err := s.Cabinet.Member(guildID, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	p, err := s.Cabinet.Presence(guildID, userID)
+	if err != nil {
+		if !errors.Is(err, store.ErrNotFound) {
+			return nil, nil, err
+		}
+		p = nil
+	}
+
+	return m, p, nil
+}
+
 // Presence checks the state for user presences. If no guildID is given, it
 // will look for the presence in all cached guilds.
 func (s *State) Presence(gID discord.GuildID, uID discord.UserID) (*discord.Presence, error) {
@@ -817,6 +1197,64 @@ func (s *State) Presence(gID discord.GuildID, uID discord.UserID) (*discord.Pres
 
 ////
 
+// VoiceStatesInChannel returns the cached voice states of the users in the
+// given voice channel, which may be empty. The voice states are kept up to date
+// using gateway events, so the IntentGuildVoiceStates intent is needed for
+// guild channels. Neither the voice states nor the channel are ever fetched
+// from the API, so the channel must be cached as well.
+func (s *State) VoiceStatesInChannel(channelID discord.ChannelID) ([]discord.VoiceState, error) {
+	ch, err := s.Cabinet.Channel(channelID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get channel: %w", err)
+	}
+
+	if ch.GuildID.IsValid() && !s.HasIntents(gateway.IntentGuildVoiceStates) {
+		return nil, store.ErrNotFound
+	}
+
+	states, err := s.Cabinet.VoiceStates(ch.GuildID)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var filtered []discord.VoiceState
+	for _, vs := range states {
+		if vs.ChannelID == channelID {
+			filtered = append(filtered, vs)
+		}
+	}
+
+	return filtered, nil
+}
+
+// UserVoiceChannel returns the ID of the voice channel that the user is
+// connected to in the given guild. store.ErrNotFound is returned if the user
+// isn't in any. Like VoiceStatesInChannel, it needs the IntentGuildVoiceStates
+// intent and only uses the cache.
+func (s *State) UserVoiceChannel(
+	guildID discord.GuildID, userID discord.UserID) (discord.ChannelID, error) {
+
+	if !s.HasIntents(gateway.IntentGuildVoiceStates) {
+		return 0, store.ErrNotFound
+	}
+
+	vs, err := s.Cabinet.VoiceState(guildID, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	if !vs.ChannelID.IsValid() {
+		return 0, store.ErrNotFound
+	}
+
+	return vs.ChannelID, nil
+}
+
+////
+
 func (s *State) Role(guildID discord.GuildID, roleID discord.RoleID) (target *discord.Role, err error) {
 	if s.HasIntents(gateway.IntentGuilds) {
 		target, err = s.Cabinet.Role(guildID, roleID)
@@ -859,6 +1297,37 @@ func (s *State) Roles(guildID discord.GuildID) ([]discord.Role, error) {
 	return s.fetchRoles(guildID)
 }
 
+// GuildRoleMemberCounts returns the number of members that have each role in
+// the guild, keyed by role ID. The @everyone role is not included.
+//
+// The counts are fetched from the API. If that fails, then they are computed
+// from the member cache instead, which is only accurate if all of the guild's
+// members are cached, e.g. after requesting them over the gateway.
+func (s *State) GuildRoleMemberCounts(guildID discord.GuildID) (map[discord.RoleID]int, error) {
+	counts, err := s.Session.GuildRoleMemberCounts(guildID)
+	if err == nil {
+		return counts, nil
+	}
+
+	if !s.HasIntents(gateway.IntentGuildMembers) {
+		return nil, err
+	}
+
+	members, cacheErr := s.Cabinet.Members(guildID)
+	if cacheErr != nil {
+		return nil, err
+	}
+
+	counts = make(map[discord.RoleID]int)
+	for _, m := range members {
+		for _, roleID := range m.RoleIDs {
+			counts[roleID]++
+		}
+	}
+
+	return counts, nil
+}
+
 func (s *State) fetchGuild(id discord.GuildID) (g *discord.Guild, err error) {
 	g, err = s.Session.Guild(id)
 	if err == nil && s.HasIntents(gateway.IntentGuilds) {
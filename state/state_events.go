@@ -5,7 +5,6 @@ import (
 
 	"github.com/diamondburned/arikawa/v3/discord"
 	"github.com/diamondburned/arikawa/v3/gateway"
-	"github.com/diamondburned/arikawa/v3/state/store"
 )
 
 func (s *State) hookSession() {
@@ -15,18 +14,31 @@ func (s *State) hookSession() {
 			s.PreHandler.Call(event)
 		}
 
+		// Keep the cached message around, since the state handler will patch
+		// it with the update.
+		var oldMessage *discord.Message
+		if ev, ok := event.(*gateway.MessageUpdateEvent); ok {
+			oldMessage, _ = s.Cabinet.Message(ev.ChannelID, ev.ID)
+		}
+
 		// Run the state handler.
+		s.cacheMutex.Lock()
 		s.onEvent(event)
+		changes := s.cacheChanges
+		s.cacheChanges = nil
+		s.cacheMutex.Unlock()
+
+		s.dispatchCacheChanges(changes)
 
 		switch event := event.(type) {
 		case *gateway.ReadyEvent:
-			s.Handler.Call(event)
-			s.handleReady(event)
+			wait := s.callHandlerWait(event)
+			s.handleReady(event, wait)
 		case *gateway.GuildCreateEvent:
-			s.Handler.Call(event)
+			s.callHandler(event)
 			s.handleGuildCreate(event)
 		case *gateway.GuildDeleteEvent:
-			s.Handler.Call(event)
+			s.callHandler(event)
 			s.handleGuildDelete(event)
 
 		// https://github.com/discord/discord-api-docs/commit/01665c4
@@ -34,16 +46,17 @@ func (s *State) hookSession() {
 			if event.Member != nil {
 				event.Member.User = event.Author
 			}
-			s.Handler.Call(event)
+			s.callHandler(event)
 
 		case *gateway.MessageUpdateEvent:
 			if event.Member != nil {
 				event.Member.User = event.Author
 			}
-			s.Handler.Call(event)
+			s.callHandler(event)
+			s.handleMessageUpdate(event, oldMessage)
 
 		default:
-			s.Handler.Call(event)
+			s.callHandler(event)
 		}
 	})
 }
@@ -60,30 +73,39 @@ func (s *State) onEvent(iface interface{}) {
 		// Reset the store before proceeding.
 		if err := s.Cabinet.Reset(); err != nil {
 			s.stateErr(err, "failed to reset state in Ready")
+		} else {
+			s.cacheChanged(0, CacheReset, 0, 0, 0)
 		}
 
 		// Handle guilds
 		for i := range ev.Guilds {
-			s.batchLog(storeGuildCreate(s.Cabinet, &ev.Guilds[i]))
+			s.batchLog(s.storeGuildCreate(&ev.Guilds[i]))
 		}
 
 		// Handle guild presences
 		for i, presence := range ev.Presences {
 			if err := s.Cabinet.PresenceSet(presence.GuildID, &ev.Presences[i], false); err != nil {
 				s.stateErr(err, "failed to set presence in Ready")
+			} else {
+				s.cacheChanged(CachePresence, CacheSet, presence.GuildID, 0, discord.Snowflake(presence.User.ID))
 			}
 		}
 
 		// Handle private channels
 		for i := range ev.PrivateChannels {
-			if err := s.Cabinet.ChannelSet(&ev.PrivateChannels[i], false); err != nil {
+			ch := &ev.PrivateChannels[i]
+			if err := s.Cabinet.ChannelSet(ch, false); err != nil {
 				s.stateErr(err, "failed to set channel in Ready")
+			} else {
+				s.cacheChanged(CacheChannel, CacheSet, 0, ch.ID, discord.Snowflake(ch.ID))
 			}
 		}
 
 		// Handle user
 		if err := s.Cabinet.MyselfSet(ev.User, false); err != nil {
 			s.stateErr(err, "failed to set self in Ready")
+		} else {
+			s.cacheChanged(CacheMe, CacheSet, 0, 0, discord.Snowflake(ev.User.ID))
 		}
 
 	case *gateway.ReadySupplementalEvent:
@@ -92,8 +114,12 @@ func (s *State) onEvent(iface interface{}) {
 			// Handle guild voice states
 			for i := range guild.VoiceStates {
 				v := &guild.VoiceStates[i]
+				v.GuildID = guild.ID
+
 				if err := s.Cabinet.VoiceStateSet(guild.ID, v, false); err != nil {
 					s.stateErr(err, "failed to set guild voice state in Ready Supplemental")
+				} else {
+					s.cacheChanged(CacheVoiceState, CacheSet, guild.ID, v.ChannelID, discord.Snowflake(v.UserID))
 				}
 			}
 		}
@@ -102,6 +128,8 @@ func (s *State) onEvent(iface interface{}) {
 		for i := range friendPresences {
 			if err := s.Cabinet.PresenceSet(0, &friendPresences[i], false); err != nil {
 				s.stateErr(err, "failed to set friend presence in Ready Supplemental")
+			} else {
+				s.cacheChanged(CachePresence, CacheSet, 0, 0, discord.Snowflake(friendPresences[i].User.ID))
 			}
 		}
 
@@ -115,6 +143,8 @@ func (s *State) onEvent(iface interface{}) {
 			for i := range members {
 				if err := s.Cabinet.MemberSet(guild.ID, &members[i], false); err != nil {
 					s.stateErr(err, "failed to set friend presence in Ready Supplemental")
+				} else {
+					s.cacheChanged(CacheMember, CacheSet, guild.ID, 0, discord.Snowflake(members[i].User.ID))
 				}
 			}
 
@@ -122,26 +152,40 @@ func (s *State) onEvent(iface interface{}) {
 			for i := range presences {
 				if err := s.Cabinet.PresenceSet(guild.ID, &presences[i], false); err != nil {
 					s.stateErr(err, "failed to set member presence in Ready Supplemental")
+				} else {
+					s.cacheChanged(CachePresence, CacheSet, guild.ID, 0, discord.Snowflake(presences[i].User.ID))
 				}
 			}
 		}
 
 	case *gateway.GuildCreateEvent:
-		s.batchLog(storeGuildCreate(s.Cabinet, ev))
+		s.batchLog(s.storeGuildCreate(ev))
 
 	case *gateway.GuildUpdateEvent:
 		if err := s.Cabinet.GuildSet(&ev.Guild, true); err != nil {
 			s.stateErr(err, "failed to update guild in state")
+		} else {
+			s.cacheChanged(CacheGuild, CacheUpdate, ev.ID, 0, discord.Snowflake(ev.ID))
 		}
 
 	case *gateway.GuildDeleteEvent:
-		if err := s.Cabinet.GuildRemove(ev.ID); err != nil && !ev.Unavailable {
-			s.stateErr(err, "failed to delete guild in state")
+		if ev.Unavailable && s.KeepUnavailableGuilds {
+			break
+		}
+
+		if err := s.Cabinet.GuildPurge(ev.ID); err != nil {
+			if !ev.Unavailable {
+				s.stateErr(err, "failed to delete guild in state")
+			}
+		} else {
+			s.cacheChanged(CacheGuild, CacheRemove, ev.ID, 0, discord.Snowflake(ev.ID))
 		}
 
 	case *gateway.GuildMemberAddEvent:
 		if err := s.Cabinet.MemberSet(ev.GuildID, &ev.Member, false); err != nil {
 			s.stateErr(err, "failed to add a member in state")
+		} else {
+			s.cacheChanged(CacheMember, CacheSet, ev.GuildID, 0, discord.Snowflake(ev.User.ID))
 		}
 
 	case *gateway.GuildMemberUpdateEvent:
@@ -156,59 +200,87 @@ func (s *State) onEvent(iface interface{}) {
 
 		if err := s.Cabinet.MemberSet(ev.GuildID, m, true); err != nil {
 			s.stateErr(err, "failed to update a member in state")
+		} else {
+			s.cacheChanged(CacheMember, CacheUpdate, ev.GuildID, 0, discord.Snowflake(ev.User.ID))
 		}
 
 	case *gateway.GuildMemberRemoveEvent:
 		if err := s.Cabinet.MemberRemove(ev.GuildID, ev.User.ID); err != nil {
 			s.stateErr(err, "failed to remove a member in state")
+		} else {
+			s.cacheChanged(CacheMember, CacheRemove, ev.GuildID, 0, discord.Snowflake(ev.User.ID))
+		}
+		if err := s.removeVoiceState(ev.GuildID, ev.User.ID); err != nil {
+			s.stateErr(err, "failed to remove a member's voice state in state")
 		}
 
 	case *gateway.GuildMembersChunkEvent:
 		for i := range ev.Members {
 			if err := s.Cabinet.MemberSet(ev.GuildID, &ev.Members[i], false); err != nil {
 				s.stateErr(err, "failed to add a member from chunk in state")
+			} else {
+				s.cacheChanged(CacheMember, CacheSet, ev.GuildID, 0, discord.Snowflake(ev.Members[i].User.ID))
 			}
 		}
 
 		for i := range ev.Presences {
 			if err := s.Cabinet.PresenceSet(ev.GuildID, &ev.Presences[i], false); err != nil {
 				s.stateErr(err, "failed to add a presence from chunk in state")
+			} else {
+				s.cacheChanged(CachePresence, CacheSet, ev.GuildID, 0, discord.Snowflake(ev.Presences[i].User.ID))
 			}
 		}
 
 	case *gateway.GuildRoleCreateEvent:
 		if err := s.Cabinet.RoleSet(ev.GuildID, &ev.Role, false); err != nil {
 			s.stateErr(err, "failed to add a role in state")
+		} else {
+			s.cacheChanged(CacheRole, CacheSet, ev.GuildID, 0, discord.Snowflake(ev.Role.ID))
 		}
 
 	case *gateway.GuildRoleUpdateEvent:
 		if err := s.Cabinet.RoleSet(ev.GuildID, &ev.Role, true); err != nil {
 			s.stateErr(err, "failed to update a role in state")
+		} else {
+			s.cacheChanged(CacheRole, CacheUpdate, ev.GuildID, 0, discord.Snowflake(ev.Role.ID))
 		}
 
 	case *gateway.GuildRoleDeleteEvent:
 		if err := s.Cabinet.RoleRemove(ev.GuildID, ev.RoleID); err != nil {
 			s.stateErr(err, "failed to remove a role in state")
+		} else {
+			s.cacheChanged(CacheRole, CacheRemove, ev.GuildID, 0, discord.Snowflake(ev.RoleID))
 		}
 
 	case *gateway.GuildEmojisUpdateEvent:
 		if err := s.Cabinet.EmojiSet(ev.GuildID, ev.Emojis, true); err != nil {
 			s.stateErr(err, "failed to update emojis in state")
+		} else {
+			s.cacheChanged(CacheEmoji, CacheUpdate, ev.GuildID, 0, 0)
 		}
 
 	case *gateway.ChannelCreateEvent:
 		if err := s.Cabinet.ChannelSet(&ev.Channel, false); err != nil {
 			s.stateErr(err, "failed to create a channel in state")
+		} else {
+			s.cacheChanged(CacheChannel, CacheSet, ev.GuildID, ev.ID, discord.Snowflake(ev.ID))
 		}
 
 	case *gateway.ChannelUpdateEvent:
 		if err := s.Cabinet.ChannelSet(&ev.Channel, true); err != nil {
 			s.stateErr(err, "failed to update a channel in state")
+		} else {
+			s.cacheChanged(CacheChannel, CacheUpdate, ev.GuildID, ev.ID, discord.Snowflake(ev.ID))
 		}
 
 	case *gateway.ChannelDeleteEvent:
 		if err := s.Cabinet.ChannelRemove(&ev.Channel); err != nil {
 			s.stateErr(err, "failed to remove a channel in state")
+		} else {
+			s.cacheChanged(CacheChannel, CacheRemove, ev.GuildID, ev.ID, discord.Snowflake(ev.ID))
+		}
+		if err := s.removeChannelVoiceStates(ev.GuildID, ev.ID); err != nil {
+			s.stateErr(err, "failed to remove voice states of a deleted channel in state")
 		}
 
 	case *gateway.ChannelPinsUpdateEvent:
@@ -216,47 +288,64 @@ func (s *State) onEvent(iface interface{}) {
 
 	case *gateway.ThreadListSyncEvent:
 		for i := range ev.Threads {
-			if err := s.Cabinet.ChannelSet(&ev.Threads[i], true); err != nil {
+			ch := &ev.Threads[i]
+			if err := s.Cabinet.ChannelSet(ch, true); err != nil {
 				s.stateErr(err, "failed to set a thread in state sync")
+			} else {
+				s.cacheChanged(CacheChannel, CacheUpdate, ev.GuildID, ch.ID, discord.Snowflake(ch.ID))
 			}
 		}
 
 	case *gateway.ThreadCreateEvent:
 		if err := s.Cabinet.ChannelSet(&ev.Channel, false); err != nil {
 			s.stateErr(err, "failed to create a thread in state")
+		} else {
+			s.cacheChanged(CacheChannel, CacheSet, ev.GuildID, ev.ID, discord.Snowflake(ev.ID))
 		}
 
 	case *gateway.ThreadUpdateEvent:
 		if err := s.Cabinet.ChannelSet(&ev.Channel, true); err != nil {
 			s.stateErr(err, "failed to update a thread in state")
+		} else {
+			s.cacheChanged(CacheChannel, CacheUpdate, ev.GuildID, ev.ID, discord.Snowflake(ev.ID))
 		}
 
 	case *gateway.ThreadDeleteEvent:
 		if ch, err := s.Cabinet.Channel(ev.ID); err == nil {
 			if err := s.Cabinet.ChannelRemove(ch); err != nil {
 				s.stateErr(err, "failed to delete a thread in state")
+			} else {
+				s.cacheChanged(CacheChannel, CacheRemove, ev.GuildID, ev.ID, discord.Snowflake(ev.ID))
 			}
 		}
 
 	case *gateway.MessageCreateEvent:
 		if err := s.Cabinet.MessageSet(&ev.Message, false); err != nil {
 			s.stateErr(err, "failed to add a message in state")
+		} else {
+			s.cacheChanged(CacheMessage, CacheSet, ev.GuildID, ev.ChannelID, discord.Snowflake(ev.ID))
 		}
 
 	case *gateway.MessageUpdateEvent:
 		if err := s.Cabinet.MessageSet(&ev.Message, true); err != nil {
 			s.stateErr(err, "failed to update a message in state")
+		} else {
+			s.cacheChanged(CacheMessage, CacheUpdate, ev.GuildID, ev.ChannelID, discord.Snowflake(ev.ID))
 		}
 
 	case *gateway.MessageDeleteEvent:
 		if err := s.Cabinet.MessageRemove(ev.ChannelID, ev.ID); err != nil {
 			s.stateErr(err, "failed to delete a message in state")
+		} else {
+			s.cacheChanged(CacheMessage, CacheRemove, ev.GuildID, ev.ChannelID, discord.Snowflake(ev.ID))
 		}
 
 	case *gateway.MessageDeleteBulkEvent:
 		for _, id := range ev.IDs {
 			if err := s.Cabinet.MessageRemove(ev.ChannelID, id); err != nil {
 				s.stateErr(err, "failed to delete bulk messages in state")
+			} else {
+				s.cacheChanged(CacheMessage, CacheRemove, ev.GuildID, ev.ChannelID, discord.Snowflake(id))
 			}
 		}
 
@@ -266,7 +355,7 @@ func (s *State) onEvent(iface interface{}) {
 			me = ev.UserID == u.ID
 		}
 
-		s.editMessage(ev.ChannelID, ev.MessageID, func(m *discord.Message) bool {
+		s.editMessage(ev.GuildID, ev.ChannelID, ev.MessageID, func(m *discord.Message) bool {
 			if i := findReaction(m.Reactions, ev.Emoji); i > -1 {
 				// Copy the reactions slice so it's not racy.
 				m.Reactions = append([]discord.Reaction(nil), m.Reactions...)
@@ -286,7 +375,7 @@ func (s *State) onEvent(iface interface{}) {
 		})
 
 	case *gateway.MessageReactionRemoveEvent:
-		s.editMessage(ev.ChannelID, ev.MessageID, func(m *discord.Message) bool {
+		s.editMessage(ev.GuildID, ev.ChannelID, ev.MessageID, func(m *discord.Message) bool {
 			var i = findReaction(m.Reactions, ev.Emoji)
 			if i < 0 {
 				return false
@@ -313,13 +402,13 @@ func (s *State) onEvent(iface interface{}) {
 		})
 
 	case *gateway.MessageReactionRemoveAllEvent:
-		s.editMessage(ev.ChannelID, ev.MessageID, func(m *discord.Message) bool {
+		s.editMessage(ev.GuildID, ev.ChannelID, ev.MessageID, func(m *discord.Message) bool {
 			m.Reactions = nil
 			return true
 		})
 
 	case *gateway.MessageReactionRemoveEmojiEvent:
-		s.editMessage(ev.ChannelID, ev.MessageID, func(m *discord.Message) bool {
+		s.editMessage(ev.GuildID, ev.ChannelID, ev.MessageID, func(m *discord.Message) bool {
 			var i = findReaction(m.Reactions, ev.Emoji)
 			if i < 0 {
 				return false
@@ -331,12 +420,16 @@ func (s *State) onEvent(iface interface{}) {
 	case *gateway.PresenceUpdateEvent:
 		if err := s.Cabinet.PresenceSet(ev.GuildID, &ev.Presence, true); err != nil {
 			s.stateErr(err, "failed to update presence in state")
+		} else {
+			s.cacheChanged(CachePresence, CacheUpdate, ev.GuildID, 0, discord.Snowflake(ev.User.ID))
 		}
 
 	case *gateway.PresencesReplaceEvent:
 		for _, p := range *ev {
 			if err := s.Cabinet.PresenceSet(p.GuildID, &p.Presence, true); err != nil {
 				s.stateErr(err, "failed to update presence in state")
+			} else {
+				s.cacheChanged(CachePresence, CacheUpdate, p.GuildID, 0, discord.Snowflake(p.User.ID))
 			}
 		}
 
@@ -357,17 +450,21 @@ func (s *State) onEvent(iface interface{}) {
 	case *gateway.UserUpdateEvent:
 		if err := s.Cabinet.MyselfSet(ev.User, true); err != nil {
 			s.stateErr(err, "failed to update myself from USER_UPDATE")
+		} else {
+			s.cacheChanged(CacheMe, CacheUpdate, 0, 0, discord.Snowflake(ev.ID))
 		}
 
 	case *gateway.VoiceStateUpdateEvent:
 		vs := &ev.VoiceState
 		if !vs.ChannelID.IsValid() {
-			if err := s.Cabinet.VoiceStateRemove(vs.GuildID, vs.UserID); err != nil {
+			if err := s.removeVoiceState(vs.GuildID, vs.UserID); err != nil {
 				s.stateErr(err, "failed to remove voice state from state")
 			}
 		} else {
 			if err := s.Cabinet.VoiceStateSet(vs.GuildID, vs, true); err != nil {
 				s.stateErr(err, "failed to update voice state in state")
+			} else {
+				s.cacheChanged(CacheVoiceState, CacheUpdate, vs.GuildID, vs.ChannelID, discord.Snowflake(vs.UserID))
 			}
 		}
 	}
@@ -385,7 +482,10 @@ func (s *State) batchLog(errors []error) {
 
 // Helper functions
 
-func (s *State) editMessage(ch discord.ChannelID, msg discord.MessageID, fn func(m *discord.Message) bool) {
+func (s *State) editMessage(
+	guildID discord.GuildID, ch discord.ChannelID, msg discord.MessageID,
+	fn func(m *discord.Message) bool) {
+
 	m, err := s.Cabinet.Message(ch, msg)
 	if err != nil {
 		return
@@ -401,6 +501,8 @@ func (s *State) editMessage(ch discord.ChannelID, msg discord.MessageID, fn func
 
 	if err := s.Cabinet.MessageSet(m, true); err != nil {
 		s.stateErr(err, "failed to save message in reaction add")
+	} else {
+		s.cacheChanged(CacheMessage, CacheUpdate, guildID, ch, discord.Snowflake(msg))
 	}
 }
 
@@ -413,28 +515,37 @@ func findReaction(rs []discord.Reaction, emoji discord.Emoji) int {
 	return -1
 }
 
-func storeGuildCreate(cab *store.Cabinet, guild *gateway.GuildCreateEvent) []error {
+func (s *State) storeGuildCreate(guild *gateway.GuildCreateEvent) []error {
 	if guild.Unavailable {
 		return nil
 	}
 
 	stack, errs := newErrorStack()
+	changed := func(res CacheResource, chID discord.ChannelID, id discord.Snowflake) {
+		s.cacheChanged(res, CacheSet, guild.ID, chID, id)
+	}
 
-	if err := cab.GuildSet(&guild.Guild, false); err != nil {
+	if err := s.Cabinet.GuildSet(&guild.Guild, false); err != nil {
 		errs(err, "failed to set guild in Ready")
+	} else {
+		changed(CacheGuild, 0, discord.Snowflake(guild.ID))
 	}
 
 	// Handle guild emojis
 	if len(guild.Emojis) > 0 {
-		if err := cab.EmojiSet(guild.ID, guild.Emojis, false); err != nil {
+		if err := s.Cabinet.EmojiSet(guild.ID, guild.Emojis, false); err != nil {
 			errs(err, "failed to set guild emojis")
+		} else {
+			changed(CacheEmoji, 0, 0)
 		}
 	}
 
 	// Handle guild member
 	for i := range guild.Members {
-		if err := cab.MemberSet(guild.ID, &guild.Members[i], false); err != nil {
+		if err := s.Cabinet.MemberSet(guild.ID, &guild.Members[i], false); err != nil {
 			errs(err, "failed to set guild member in Ready")
+		} else {
+			changed(CacheMember, 0, discord.Snowflake(guild.Members[i].User.ID))
 		}
 	}
 
@@ -444,8 +555,10 @@ func storeGuildCreate(cab *store.Cabinet, guild *gateway.GuildCreateEvent) []err
 		ch := ch
 		ch.GuildID = guild.ID
 
-		if err := cab.ChannelSet(&ch, false); err != nil {
+		if err := s.Cabinet.ChannelSet(&ch, false); err != nil {
 			errs(err, "failed to set guild channel in Ready")
+		} else {
+			changed(CacheChannel, ch.ID, discord.Snowflake(ch.ID))
 		}
 	}
 
@@ -454,8 +567,10 @@ func storeGuildCreate(cab *store.Cabinet, guild *gateway.GuildCreateEvent) []err
 		ch := ch
 		ch.GuildID = guild.ID
 
-		if err := cab.ChannelSet(&ch, false); err != nil {
+		if err := s.Cabinet.ChannelSet(&ch, false); err != nil {
 			errs(err, "failed to set guild thread in Ready")
+		} else {
+			changed(CacheChannel, ch.ID, discord.Snowflake(ch.ID))
 		}
 	}
 
@@ -464,18 +579,27 @@ func storeGuildCreate(cab *store.Cabinet, guild *gateway.GuildCreateEvent) []err
 		p := p
 		p.GuildID = guild.ID
 
-		if err := cab.PresenceSet(guild.ID, &p, false); err != nil {
+		if err := s.Cabinet.PresenceSet(guild.ID, &p, false); err != nil {
 			errs(err, "failed to set guild presence in Ready")
+		} else {
+			changed(CachePresence, 0, discord.Snowflake(p.User.ID))
 		}
 	}
 
-	// Handle guild voice states
+	// Handle guild voice states. They replace the cached ones, since users may
+	// have left while the guild was unavailable.
+	if err := s.removeStaleVoiceStates(guild.ID, guild.VoiceStates); err != nil {
+		errs(err, "failed to remove stale guild voice states")
+	}
+
 	for _, v := range guild.VoiceStates {
 		v := v
 		v.GuildID = guild.ID
 
-		if err := cab.VoiceStateSet(guild.ID, &v, false); err != nil {
+		if err := s.Cabinet.VoiceStateSet(guild.ID, &v, true); err != nil {
 			errs(err, "failed to set guild voice state in Ready")
+		} else {
+			changed(CacheVoiceState, v.ChannelID, discord.Snowflake(v.UserID))
 		}
 	}
 
@@ -483,14 +607,71 @@ func storeGuildCreate(cab *store.Cabinet, guild *gateway.GuildCreateEvent) []err
 	for _, r := range guild.Roles {
 		r := r
 
-		if err := cab.RoleSet(guild.ID, &r, false); err != nil {
+		if err := s.Cabinet.RoleSet(guild.ID, &r, false); err != nil {
 			errs(err, "failed to set role in Ready")
+		} else {
+			changed(CacheRole, 0, discord.Snowflake(r.ID))
 		}
 	}
 
 	return *stack
 }
 
+// removeVoiceState removes the cached voice state of the user.
+func (s *State) removeVoiceState(guildID discord.GuildID, userID discord.UserID) error {
+	if err := s.Cabinet.VoiceStateRemove(guildID, userID); err != nil {
+		return err
+	}
+
+	s.cacheChanged(CacheVoiceState, CacheRemove, guildID, 0, discord.Snowflake(userID))
+	return nil
+}
+
+// removeStaleVoiceStates removes the cached voice states of the guild whose
+// users aren't in the given voice states.
+func (s *State) removeStaleVoiceStates(guildID discord.GuildID, current []discord.VoiceState) error {
+	cached, err := s.Cabinet.VoiceStates(guildID)
+	if err != nil {
+		return nil
+	}
+
+	users := make(map[discord.UserID]struct{}, len(current))
+	for _, v := range current {
+		users[v.UserID] = struct{}{}
+	}
+
+	for _, v := range cached {
+		if _, ok := users[v.UserID]; ok {
+			continue
+		}
+		if err := s.removeVoiceState(guildID, v.UserID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// removeChannelVoiceStates removes the cached voice states of the users in the
+// given channel.
+func (s *State) removeChannelVoiceStates(guildID discord.GuildID, channelID discord.ChannelID) error {
+	cached, err := s.Cabinet.VoiceStates(guildID)
+	if err != nil {
+		return nil
+	}
+
+	for _, v := range cached {
+		if v.ChannelID != channelID {
+			continue
+		}
+		if err := s.removeVoiceState(guildID, v.UserID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func newErrorStack() (*[]error, func(error, string)) {
 	var errs = new([]error)
 	return errs, func(err error, wrap string) {
@@ -0,0 +1,446 @@
+package state
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/gateway"
+	"github.com/diamondburned/arikawa/v3/state/store"
+	"github.com/diamondburned/arikawa/v3/state/store/defaultstore"
+	"github.com/diamondburned/arikawa/v3/utils/json"
+)
+
+func TestVoiceStates(t *testing.T) {
+	const (
+		guildID  discord.GuildID   = 1
+		general  discord.ChannelID = 10
+		music    discord.ChannelID = 11
+		me       discord.UserID    = 100
+		alice    discord.UserID    = 101
+		bob      discord.UserID    = 102
+		stranger discord.UserID    = 103
+	)
+
+	s := New("Bot token")
+	s.Session.Handler.Call(&gateway.ReadyEvent{User: discord.User{ID: me}})
+	s.Session.Handler.Call(&gateway.GuildCreateEvent{
+		Guild: discord.Guild{ID: guildID},
+		Channels: []discord.Channel{
+			{ID: general, GuildID: guildID, Type: discord.GuildVoice},
+			{ID: music, GuildID: guildID, Type: discord.GuildVoice},
+		},
+		VoiceStates: []discord.VoiceState{
+			{ChannelID: general, UserID: alice},
+		},
+	})
+
+	voiceUpdate := func(userID discord.UserID, channelID discord.ChannelID) {
+		s.Session.Handler.Call(&gateway.VoiceStateUpdateEvent{
+			VoiceState: discord.VoiceState{
+				GuildID:   guildID,
+				ChannelID: channelID,
+				UserID:    userID,
+			},
+		})
+	}
+
+	assertUsers := func(channelID discord.ChannelID, expect ...discord.UserID) {
+		t.Helper()
+
+		states, err := s.VoiceStatesInChannel(channelID)
+		if err != nil {
+			t.Fatalf("failed to get voice states in %d: %v", channelID, err)
+		}
+
+		var users []discord.UserID
+		for _, vs := range states {
+			users = append(users, vs.UserID)
+		}
+		sort.Slice(users, func(i, j int) bool { return users[i] < users[j] })
+
+		if !reflect.DeepEqual(users, expect) {
+			t.Fatalf("expected users %v in %d, got %v", expect, channelID, users)
+		}
+	}
+
+	assertChannel := func(userID discord.UserID, expect discord.ChannelID) {
+		t.Helper()
+
+		channelID, err := s.UserVoiceChannel(guildID, userID)
+		if !expect.IsValid() {
+			if !errors.Is(err, store.ErrNotFound) {
+				t.Fatalf("expected user %d in no channel, got %d (%v)", userID, channelID, err)
+			}
+			return
+		}
+
+		if err != nil || channelID != expect {
+			t.Fatalf("expected user %d in %d, got %d (%v)", userID, expect, channelID, err)
+		}
+	}
+
+	assertUsers(general, alice)
+	assertChannel(alice, general)
+
+	// Join, including ourselves.
+	voiceUpdate(bob, general)
+	voiceUpdate(me, music)
+	assertUsers(general, alice, bob)
+	assertUsers(music, me)
+	assertChannel(me, music)
+
+	// Move.
+	voiceUpdate(bob, music)
+	assertUsers(general, alice)
+	assertUsers(music, me, bob)
+	assertChannel(bob, music)
+
+	// Leave, including ourselves.
+	voiceUpdate(me, 0)
+	assertUsers(music, bob)
+	assertChannel(me, 0)
+
+	// Deleting a channel disconnects everyone in it.
+	s.Session.Handler.Call(&gateway.ChannelDeleteEvent{
+		Channel: discord.Channel{ID: music, GuildID: guildID, Type: discord.GuildVoice},
+	})
+	assertChannel(bob, 0)
+
+	// A member leaving the guild is disconnected too.
+	s.Session.Handler.Call(&gateway.GuildMemberRemoveEvent{
+		GuildID: guildID,
+		User:    discord.User{ID: alice},
+	})
+	assertUsers(general)
+	assertChannel(alice, 0)
+
+	assertChannel(stranger, 0)
+
+	// The channel is not in the cache, and it must not be fetched.
+	if _, err := s.VoiceStatesInChannel(music); !errors.Is(err, store.ErrNotFound) {
+		t.Fatal("expected ErrNotFound for an uncached channel, got", err)
+	}
+}
+
+// messageHistory serves the messages 1 to 100 of channel 10 like Discord does,
+// and keeps the queries it got. The messages are numbered using messageID.
+type messageHistory struct {
+	mu      sync.Mutex
+	queries []string
+}
+
+// messageID returns the ID of the nth message. Each message is sent a
+// millisecond after the previous one, since the store orders messages by the
+// time in their IDs.
+func messageID(n int) discord.MessageID {
+	return discord.MessageID(discord.Snowflake(n) << 22)
+}
+
+func (h *messageHistory) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != api.Path+"/channels/10/messages" {
+		http.NotFound(w, r)
+		return
+	}
+
+	q := r.URL.Query()
+	limit, _ := strconv.Atoi(q.Get("limit"))
+
+	var key string
+	var n int
+	for _, k := range []string{"before", "after", "around"} {
+		if v := q.Get(k); v != "" {
+			id, _ := discord.ParseSnowflake(v)
+			key, n = k, int(id>>22)
+		}
+	}
+
+	h.mu.Lock()
+	h.queries = append(h.queries, key+"="+strconv.Itoa(n)+"&limit="+strconv.Itoa(limit))
+	h.mu.Unlock()
+
+	// newest and oldest are the range of messages to return.
+	var newest, oldest int
+	switch key {
+	case "before":
+		newest = n - 1
+		oldest = newest - limit + 1
+	case "after":
+		oldest = n + 1
+		newest = oldest + limit - 1
+	case "around":
+		newest = n + (limit - limit/2) - 1
+		oldest = n - limit/2
+	}
+
+	if newest > 100 {
+		newest = 100
+	}
+	if oldest < 1 {
+		oldest = 1
+	}
+
+	msgs := []discord.Message{}
+	for i := newest; i >= oldest; i-- {
+		msgs = append(msgs, discord.Message{ID: messageID(i), ChannelID: 10})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.EncodeStream(w, msgs)
+}
+
+func (h *messageHistory) takeQueries() []string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	queries := h.queries
+	h.queries = nil
+	return queries
+}
+
+// messageRange returns the IDs of the messages from newest down to oldest.
+func messageRange(newest, oldest int) []discord.MessageID {
+	var ids []discord.MessageID
+	for n := newest; n >= oldest; n-- {
+		ids = append(ids, messageID(n))
+	}
+	return ids
+}
+
+func messageIDs(msgs []discord.Message) []discord.MessageID {
+	var ids []discord.MessageID
+	for _, m := range msgs {
+		ids = append(ids, m.ID)
+	}
+	return ids
+}
+
+func TestMessagesRange(t *testing.T) {
+	type fetchFunc func(s *State) ([]discord.Message, error)
+
+	tests := []struct {
+		name   string
+		fetch  fetchFunc
+		expect []discord.MessageID
+		// queries are the API requests expected.
+		queries []string
+		// cached are the messages expected in the cache afterwards.
+		cached []discord.MessageID
+	}{
+		{
+			name: "before hit",
+			fetch: func(s *State) ([]discord.Message, error) {
+				return s.MessagesBefore(10, messageID(95), 5)
+			},
+			expect: messageRange(94, 90),
+			cached: messageRange(100, 81),
+		},
+		{
+			name: "before partial",
+			fetch: func(s *State) ([]discord.Message, error) {
+				return s.MessagesBefore(10, messageID(85), 10)
+			},
+			expect:  messageRange(84, 75),
+			queries: []string{"before=81&limit=6"},
+			cached:  messageRange(100, 75),
+		},
+		{
+			name: "before miss",
+			fetch: func(s *State) ([]discord.Message, error) {
+				return s.MessagesBefore(10, messageID(50), 5)
+			},
+			expect:  messageRange(49, 45),
+			queries: []string{"before=50&limit=5"},
+			cached:  messageRange(100, 81),
+		},
+		{
+			name: "after hit",
+			fetch: func(s *State) ([]discord.Message, error) {
+				return s.MessagesAfter(10, messageID(95), 3)
+			},
+			expect: messageRange(98, 96),
+			cached: messageRange(100, 81),
+		},
+		{
+			name: "after partial",
+			fetch: func(s *State) ([]discord.Message, error) {
+				return s.MessagesAfter(10, messageID(75), 10)
+			},
+			expect:  messageRange(85, 76),
+			queries: []string{"after=75&limit=10"},
+			cached:  messageRange(100, 76),
+		},
+		{
+			name: "after miss",
+			fetch: func(s *State) ([]discord.Message, error) {
+				return s.MessagesAfter(10, messageID(50), 5)
+			},
+			expect:  messageRange(55, 51),
+			queries: []string{"after=50&limit=5"},
+			cached:  messageRange(100, 81),
+		},
+		{
+			name: "around hit",
+			fetch: func(s *State) ([]discord.Message, error) {
+				return s.MessagesAround(10, messageID(90), 10)
+			},
+			expect: messageRange(94, 85),
+			cached: messageRange(100, 81),
+		},
+		{
+			name: "around partial",
+			fetch: func(s *State) ([]discord.Message, error) {
+				return s.MessagesAround(10, messageID(83), 10)
+			},
+			expect:  messageRange(87, 78),
+			queries: []string{"around=83&limit=10"},
+			cached:  messageRange(100, 78),
+		},
+		{
+			name: "around miss",
+			fetch: func(s *State) ([]discord.Message, error) {
+				return s.MessagesAround(10, messageID(50), 4)
+			},
+			expect:  messageRange(51, 48),
+			queries: []string{"around=50&limit=4"},
+			cached:  messageRange(100, 81),
+		},
+	}
+
+	history := &messageHistory{}
+	srv := httptest.NewServer(history)
+	defer srv.Close()
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := New("Bot token")
+			s.Session.Client = s.Session.Client.WithBaseURL(srv.URL)
+
+			// Cache the latest messages, oldest first.
+			for n := 81; n <= 100; n++ {
+				m := discord.Message{ID: messageID(n), ChannelID: 10, GuildID: 1}
+				if err := s.Cabinet.MessageSet(&m, false); err != nil {
+					t.Fatal("failed to cache message:", err)
+				}
+			}
+
+			history.takeQueries()
+
+			msgs, err := test.fetch(s)
+			if err != nil {
+				t.Fatal("failed to get messages:", err)
+			}
+
+			if ids := messageIDs(msgs); !reflect.DeepEqual(ids, test.expect) {
+				t.Errorf("expected messages %v, got %v", test.expect, ids)
+			}
+
+			if queries := history.takeQueries(); !reflect.DeepEqual(queries, test.queries) {
+				t.Errorf("expected queries %q, got %q", test.queries, queries)
+			}
+
+			cached, err := s.Cabinet.Messages(10)
+			if err != nil {
+				t.Fatal("failed to get cached messages:", err)
+			}
+			if ids := messageIDs(cached); !reflect.DeepEqual(ids, test.cached) {
+				t.Errorf("expected cached messages %v, got %v", test.cached, ids)
+			}
+		})
+	}
+}
+
+// slowPresenceStore is a PresenceStore whose writes are slow, so that reads
+// racing with a GuildMembersChunkEvent would see its members without their
+// presences.
+type slowPresenceStore struct {
+	store.PresenceStore
+}
+
+func (s slowPresenceStore) PresenceSet(guildID discord.GuildID, p *discord.Presence, update bool) error {
+	time.Sleep(100 * time.Microsecond)
+	return s.PresenceStore.PresenceSet(guildID, p, update)
+}
+
+func TestMemberAndPresence(t *testing.T) {
+	const guildID discord.GuildID = 1
+
+	cabinet := defaultstore.New()
+	cabinet.PresenceStore = slowPresenceStore{cabinet.PresenceStore}
+
+	s := NewWithStore("Bot token", cabinet)
+
+	var latest uint64 // discord.UserID
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		for i := 1; i <= 200; i++ {
+			userID := discord.UserID(i)
+			s.Session.Handler.Call(&gateway.GuildMembersChunkEvent{
+				GuildID: guildID,
+				Members: []discord.Member{{User: discord.User{ID: userID}}},
+				Presences: []discord.Presence{{
+					User:    discord.User{ID: userID},
+					GuildID: guildID,
+					Status:  discord.OnlineStatus,
+				}},
+			})
+			atomic.StoreUint64(&latest, uint64(i+1))
+		}
+	}()
+
+	// The member and the presence of each chunk are applied together, so a
+	// cached member always comes with its presence.
+	for reads := 0; ; reads++ {
+		select {
+		case <-done:
+			if reads == 0 {
+				t.Fatal("no reads happened while the chunks were applied")
+			}
+			goto applied
+		default:
+		}
+
+		userID := discord.UserID(atomic.LoadUint64(&latest))
+
+		m, p, err := s.MemberAndPresence(guildID, userID)
+		if err != nil {
+			if !errors.Is(err, store.ErrNotFound) {
+				t.Fatal("unexpected error:", err)
+			}
+			continue
+		}
+
+		if m.User.ID != userID || p == nil || p.User.ID != userID {
+			t.Fatalf("torn read for user %d: member %+v, presence %+v", userID, m, p)
+		}
+	}
+
+applied:
+	// Members without presences, such as without IntentGuildPresences, are
+	// returned with a nil presence.
+	s.Session.Handler.Call(&gateway.GuildMembersChunkEvent{
+		GuildID: guildID,
+		Members: []discord.Member{{User: discord.User{ID: 1000}}},
+	})
+
+	m, p, err := s.MemberAndPresence(guildID, 1000)
+	if err != nil || m.User.ID != 1000 || p != nil {
+		t.Fatalf("unexpected member %+v and presence %+v (%v)", m, p, err)
+	}
+
+	if _, _, err := s.MemberAndPresence(guildID, 1001); !errors.Is(err, store.ErrNotFound) {
+		t.Fatal("expected ErrNotFound for an unknown member, got", err)
+	}
+}
@@ -19,7 +19,10 @@ type Channel struct {
 	guildChs map[discord.GuildID][]discord.ChannelID
 }
 
-var _ store.ChannelStore = (*Channel)(nil)
+var (
+	_ store.ChannelStore = (*Channel)(nil)
+	_ store.GuildPurger  = (*Channel)(nil)
+)
 
 func NewChannel() *Channel {
 	return &Channel{
@@ -173,6 +176,23 @@ func (s *Channel) ChannelRemove(channel *discord.Channel) error {
 	return nil
 }
 
+func (s *Channel) GuildPurge(guildID discord.GuildID) error {
+	// Private channels are stored under the zero guild ID.
+	if !guildID.IsValid() {
+		return nil
+	}
+
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	for _, id := range s.guildChs[guildID] {
+		delete(s.channels, id)
+	}
+	delete(s.guildChs, guildID)
+
+	return nil
+}
+
 func addChannelID(channels []discord.ChannelID, id discord.ChannelID) []discord.ChannelID {
 	for _, ch := range channels {
 		if ch == id {
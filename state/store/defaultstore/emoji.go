@@ -17,7 +17,10 @@ type emojis struct {
 	emojis []discord.Emoji
 }
 
-var _ store.EmojiStore = (*Emoji)(nil)
+var (
+	_ store.EmojiStore  = (*Emoji)(nil)
+	_ store.GuildPurger = (*Emoji)(nil)
+)
 
 func NewEmoji() *Emoji {
 	return &Emoji{
@@ -85,3 +88,8 @@ func (s *Emoji) EmojiSet(guildID discord.GuildID, allEmojis []discord.Emoji, upd
 
 	return nil
 }
+
+func (s *Emoji) GuildPurge(guildID discord.GuildID) error {
+	s.guilds.Delete(guildID)
+	return nil
+}
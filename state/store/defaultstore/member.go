@@ -17,7 +17,10 @@ type guildMembers struct {
 	members map[discord.UserID]discord.Member
 }
 
-var _ store.MemberStore = (*Member)(nil)
+var (
+	_ store.MemberStore = (*Member)(nil)
+	_ store.GuildPurger = (*Member)(nil)
+)
 
 func NewMember() *Member {
 	return &Member{
@@ -98,3 +101,8 @@ func (s *Member) MemberRemove(guildID discord.GuildID, userID discord.UserID) er
 
 	return nil
 }
+
+func (s *Member) GuildPurge(guildID discord.GuildID) error {
+	s.guilds.Delete(guildID)
+	return nil
+}
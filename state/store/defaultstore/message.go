@@ -2,6 +2,7 @@ package defaultstore
 
 import (
 	"sync"
+	"time"
 
 	"github.com/diamondburned/arikawa/v3/discord"
 	"github.com/diamondburned/arikawa/v3/internal/moreatomic"
@@ -11,6 +12,10 @@ import (
 type Message struct {
 	channels moreatomic.Map
 	maxMsgs  int
+
+	retain   MessageRetention
+	stop     chan struct{}
+	stopOnce sync.Once
 }
 
 var _ store.MessageStore = (*Message)(nil)
@@ -18,6 +23,48 @@ var _ store.MessageStore = (*Message)(nil)
 type messages struct {
 	mut      sync.RWMutex
 	messages []discord.Message
+	// pruned is true if the janitor removed this channel from the store, in
+	// which case it must be loaded again.
+	pruned bool
+}
+
+// MessageRetention decides whether a Message store keeps a message. It returns
+// false if the message should be dropped. It may be called concurrently.
+type MessageRetention func(m *discord.Message) bool
+
+// MessagesNewerThan returns a MessageRetention that keeps messages sent within
+// the given duration, according to their IDs.
+func MessagesNewerThan(age time.Duration) MessageRetention {
+	return func(m *discord.Message) bool {
+		return time.Since(m.ID.Time()) < age
+	}
+}
+
+// MessagesInChannels returns a MessageRetention that only keeps messages sent
+// in the given channels.
+func MessagesInChannels(channelIDs ...discord.ChannelID) MessageRetention {
+	channels := make(map[discord.ChannelID]struct{}, len(channelIDs))
+	for _, id := range channelIDs {
+		channels[id] = struct{}{}
+	}
+
+	return func(m *discord.Message) bool {
+		_, ok := channels[m.ChannelID]
+		return ok
+	}
+}
+
+// RetainAll returns a MessageRetention that only keeps messages that all of the
+// given retentions keep.
+func RetainAll(retentions ...MessageRetention) MessageRetention {
+	return func(m *discord.Message) bool {
+		for _, retain := range retentions {
+			if !retain(m) {
+				return false
+			}
+		}
+		return true
+	}
 }
 
 func NewMessage(maxMsgs int) *Message {
@@ -31,6 +78,83 @@ func NewMessage(maxMsgs int) *Message {
 	}
 }
 
+// NewMessageWithRetention creates a new Message store like NewMessage, except
+// it only keeps the messages that retain returns true for. Messages are checked
+// when they are added, and if interval is not 0, the store also starts a
+// janitor goroutine that calls Prune every interval, so that messages that
+// stop being kept, such as ones that become too old, are dropped. Close stops
+// the janitor.
+func NewMessageWithRetention(maxMsgs int, retain MessageRetention, interval time.Duration) *Message {
+	s := NewMessage(maxMsgs)
+	s.retain = retain
+
+	if interval > 0 {
+		s.stop = make(chan struct{})
+		go s.janitor(interval)
+	}
+
+	return s
+}
+
+func (s *Message) janitor(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.stop:
+			return
+		case <-ticker.C:
+			s.Prune()
+		}
+	}
+}
+
+// Close stops the janitor goroutine, if any. The store can still be used
+// afterwards, but it is no longer pruned in the background.
+func (s *Message) Close() error {
+	if s.stop != nil {
+		s.stopOnce.Do(func() { close(s.stop) })
+	}
+	return nil
+}
+
+// Prune drops all messages that the store's MessageRetention doesn't keep,
+// along with channels that end up with no messages. It does nothing if the
+// store was not created with NewMessageWithRetention.
+func (s *Message) Prune() {
+	if s.retain == nil {
+		return
+	}
+
+	s.channels.Range(func(k, v interface{}) bool {
+		msgs := v.(*messages)
+
+		msgs.mut.Lock()
+		defer msgs.mut.Unlock()
+
+		kept := msgs.messages[:0]
+		for i := range msgs.messages {
+			if s.retain(&msgs.messages[i]) {
+				kept = append(kept, msgs.messages[i])
+			}
+		}
+
+		// Zero out the dropped messages so that they can be collected.
+		for i := len(kept); i < len(msgs.messages); i++ {
+			msgs.messages[i] = discord.Message{}
+		}
+		msgs.messages = kept
+
+		if len(msgs.messages) == 0 {
+			msgs.pruned = true
+			s.channels.Delete(k)
+		}
+
+		return true
+	})
+}
+
 func (s *Message) Reset() error {
 	return s.channels.Reset()
 }
@@ -78,11 +202,21 @@ func (s *Message) MessageSet(message *discord.Message, update bool) error {
 		return nil
 	}
 
-	iv, _ := s.channels.LoadOrStore(message.ChannelID)
+	if !update && s.retain != nil && !s.retain(message) {
+		return nil
+	}
 
-	msgs := iv.(*messages)
+	var msgs *messages
+	for {
+		iv, _ := s.channels.LoadOrStore(message.ChannelID)
+		msgs = iv.(*messages)
 
-	msgs.mut.Lock()
+		msgs.mut.Lock()
+		if !msgs.pruned {
+			break
+		}
+		msgs.mut.Unlock()
+	}
 	defer msgs.mut.Unlock()
 
 	if update {
@@ -212,6 +346,20 @@ func DiffMessage(src, dst *discord.Message) {
 	if src.Components != nil {
 		dst.Components = src.Components
 	}
+	// Flags can be cleared, such as when embeds are unsuppressed, so a zero
+	// value is only ignored in partial updates, which carry no author.
+	if src.Flags != 0 || src.Author.ID.IsValid() {
+		dst.Flags = src.Flags
+	}
+	if src.MentionRoleIDs != nil {
+		dst.MentionRoleIDs = src.MentionRoleIDs
+	}
+	if src.MentionChannels != nil {
+		dst.MentionChannels = src.MentionChannels
+	}
+	if src.Stickers != nil {
+		dst.Stickers = src.Stickers
+	}
 }
 
 func (s *Message) MessageRemove(channelID discord.ChannelID, messageID discord.MessageID) error {
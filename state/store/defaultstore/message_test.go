@@ -1,7 +1,9 @@
 package defaultstore
 
 import (
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/diamondburned/arikawa/v3/discord"
 )
@@ -76,3 +78,132 @@ func TestMessagesUpdate(t *testing.T) {
 		}
 	}
 }
+
+func TestMessagesUpdatePartial(t *testing.T) {
+	store := NewMessage(10)
+
+	store.MessageSet(&discord.Message{
+		ID:        1,
+		ChannelID: 1,
+		Author:    discord.User{ID: 2, Username: "author"},
+		Content:   "original",
+	}, false)
+
+	// Partial updates, such as embed unfurls, don't carry the content or the
+	// author.
+	store.MessageSet(&discord.Message{
+		ID:        1,
+		ChannelID: 1,
+		Embeds:    []discord.Embed{{Title: "unfurled"}},
+	}, true)
+
+	msg, err := store.Message(1, 1)
+	if err != nil {
+		t.Fatal("failed to get message:", err)
+	}
+
+	if msg.Content != "original" {
+		t.Errorf("expected content %q, got %q", "original", msg.Content)
+	}
+	if msg.Author.ID != 2 {
+		t.Errorf("expected author 2, got %d", msg.Author.ID)
+	}
+	if len(msg.Embeds) != 1 {
+		t.Errorf("expected 1 embed, got %d", len(msg.Embeds))
+	}
+}
+
+func TestMessageRetention(t *testing.T) {
+	now := time.Now()
+	newID := discord.MessageID(discord.NewSnowflake(now))
+	oldID := discord.MessageID(discord.NewSnowflake(now.Add(-2 * time.Hour)))
+
+	store := NewMessageWithRetention(10, RetainAll(
+		MessagesNewerThan(time.Hour),
+		MessagesInChannels(1),
+	), 0)
+	defer store.Close()
+
+	store.MessageSet(&discord.Message{ID: oldID, ChannelID: 1}, false)
+	store.MessageSet(&discord.Message{ID: newID, ChannelID: 1}, false)
+	store.MessageSet(&discord.Message{ID: newID, ChannelID: 2}, false)
+
+	msgs, err := store.Messages(1)
+	if err != nil {
+		t.Fatal("failed to get messages:", err)
+	}
+	if len(msgs) != 1 || msgs[0].ID != newID {
+		t.Fatal("unexpected messages in channel 1:", msgs)
+	}
+
+	if _, err := store.Messages(2); err == nil {
+		t.Fatal("unexpected messages in channel 2")
+	}
+
+	t.Run("prune", func(t *testing.T) {
+		var expired int32
+		store := NewMessageWithRetention(10, func(m *discord.Message) bool {
+			return atomic.LoadInt32(&expired) == 0
+		}, time.Millisecond)
+		defer store.Close()
+
+		store.MessageSet(&discord.Message{ID: newID, ChannelID: 1}, false)
+		atomic.StoreInt32(&expired, 1)
+
+		deadline := time.Now().Add(time.Second)
+		for {
+			if _, err := store.Messages(1); err != nil {
+				break
+			}
+			if time.Now().After(deadline) {
+				t.Fatal("janitor did not prune the channel")
+			}
+			time.Sleep(time.Millisecond)
+		}
+
+		// The pruned channel can be used again.
+		atomic.StoreInt32(&expired, 0)
+		store.MessageSet(&discord.Message{ID: newID, ChannelID: 1}, false)
+
+		if _, err := store.Message(1, newID); err != nil {
+			t.Fatal("failed to get message after pruning:", err)
+		}
+	})
+}
+
+func TestDiffMessageFlags(t *testing.T) {
+	author := discord.User{ID: 1}
+
+	tests := []struct {
+		name   string
+		src    discord.Message
+		expect discord.MessageFlags
+	}{
+		{
+			name:   "partial update",
+			src:    discord.Message{Embeds: []discord.Embed{}},
+			expect: discord.SuppressEmbeds,
+		},
+		{
+			name:   "set",
+			src:    discord.Message{Author: author, Flags: discord.CrosspostedMessage},
+			expect: discord.CrosspostedMessage,
+		},
+		{
+			name:   "cleared",
+			src:    discord.Message{Author: author},
+			expect: 0,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			dst := discord.Message{Author: author, Flags: discord.SuppressEmbeds}
+			DiffMessage(&test.src, &dst)
+
+			if dst.Flags != test.expect {
+				t.Fatalf("expected flags %d, got %d", test.expect, dst.Flags)
+			}
+		})
+	}
+}
@@ -17,7 +17,10 @@ type presences struct {
 	presences map[discord.UserID]discord.Presence
 }
 
-var _ store.PresenceStore = (*Presence)(nil)
+var (
+	_ store.PresenceStore = (*Presence)(nil)
+	_ store.GuildPurger   = (*Presence)(nil)
+)
 
 func NewPresence() *Presence {
 	return &Presence{
@@ -105,3 +108,8 @@ func (s *Presence) PresenceRemove(guildID discord.GuildID, userID discord.UserID
 
 	return nil
 }
+
+func (s *Presence) GuildPurge(guildID discord.GuildID) error {
+	s.guilds.Delete(guildID)
+	return nil
+}
@@ -12,7 +12,10 @@ type Role struct {
 	guilds moreatomic.Map
 }
 
-var _ store.RoleStore = (*Role)(nil)
+var (
+	_ store.RoleStore   = (*Role)(nil)
+	_ store.GuildPurger = (*Role)(nil)
+)
 
 type roles struct {
 	mut   sync.RWMutex
@@ -99,3 +102,8 @@ func (s *Role) RoleRemove(guildID discord.GuildID, roleID discord.RoleID) error
 
 	return nil
 }
+
+func (s *Role) GuildPurge(guildID discord.GuildID) error {
+	s.guilds.Delete(guildID)
+	return nil
+}
@@ -12,7 +12,10 @@ type VoiceState struct {
 	guilds moreatomic.Map
 }
 
-var _ store.VoiceStateStore = (*VoiceState)(nil)
+var (
+	_ store.VoiceStateStore = (*VoiceState)(nil)
+	_ store.GuildPurger     = (*VoiceState)(nil)
+)
 
 type voiceStates struct {
 	mut         sync.RWMutex
@@ -103,3 +106,8 @@ func (s *VoiceState) VoiceStateRemove(guildID discord.GuildID, userID discord.Us
 
 	return nil
 }
+
+func (s *VoiceState) GuildPurge(guildID discord.GuildID) error {
+	s.guilds.Delete(guildID)
+	return nil
+}
@@ -0,0 +1,76 @@
+package store
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+)
+
+// MigrateProgress describes the progress of Migrate. Guilds are migrated one
+// at a time, followed by the private channels.
+type MigrateProgress struct {
+	// GuildID is the guild that was just migrated. It is zero once the private
+	// channels are migrated, which is the last step.
+	GuildID discord.GuildID
+	// Done is the number of guilds migrated so far.
+	Done int
+	// Total is the total number of guilds to migrate.
+	Total int
+}
+
+// Migrate copies everything in the src cabinet into the dst cabinet. It can be
+// used to move the state from one store backend to another without refetching
+// everything from Discord, such as from defaultstore to a persistent store.
+//
+// Resources are enumerated using the Guilds and PrivateChannels methods of src,
+// so stores that cannot list those are not fully migrated. Getters of src
+// returning ErrNotFound are ignored, since that's what stores without data
+// return. Messages are migrated oldest first, so if dst keeps less messages
+// than src, then the latest ones are kept.
+//
+// If progress is not nil, then it is called after each guild is migrated. The
+// context is checked between each guild. Migrate should be done while neither
+// cabinets are in use, since it doesn't lock either of them.
+func Migrate(ctx context.Context, src, dst *Cabinet, progress func(MigrateProgress)) error {
+	var total int
+
+	me, privates, err := walk(ctx, src, func(snap *guildSnapshot, done, n int) error {
+		if err := writeGuild(dst, snap); err != nil {
+			return fmt.Errorf("failed to migrate guild %d: %w", snap.Guild.ID, err)
+		}
+
+		total = n
+		if progress != nil {
+			progress(MigrateProgress{
+				GuildID: snap.Guild.ID,
+				Done:    done,
+				Total:   n,
+			})
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if me != nil {
+		if err := dst.MyselfSet(*me, false); err != nil {
+			return fmt.Errorf("failed to set me: %w", err)
+		}
+	}
+
+	if err := writeChannels(dst, privates); err != nil {
+		return fmt.Errorf("failed to migrate private channels: %w", err)
+	}
+
+	if progress != nil {
+		progress(MigrateProgress{
+			Done:  total,
+			Total: total,
+		})
+	}
+
+	return nil
+}
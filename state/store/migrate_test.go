@@ -0,0 +1,70 @@
+package store_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/state/store"
+	"github.com/diamondburned/arikawa/v3/state/store/defaultstore"
+)
+
+func TestMigrate(t *testing.T) {
+	src := defaultstore.New()
+	dst := defaultstore.New()
+
+	guild := discord.Guild{ID: 1, Name: "guild"}
+	channel := discord.Channel{ID: 2, GuildID: guild.ID, Type: discord.GuildText}
+	private := discord.Channel{
+		ID:           3,
+		Type:         discord.DirectMessage,
+		DMRecipients: []discord.User{{ID: 11}},
+	}
+
+	src.MyselfSet(discord.User{ID: 10}, false)
+	src.GuildSet(&guild, false)
+	src.ChannelSet(&channel, false)
+	src.ChannelSet(&private, false)
+	src.RoleSet(guild.ID, &discord.Role{ID: 4}, false)
+	src.MemberSet(guild.ID, &discord.Member{User: discord.User{ID: 10}}, false)
+	src.MessageSet(&discord.Message{ID: 5, ChannelID: channel.ID}, false)
+	src.MessageSet(&discord.Message{ID: 6, ChannelID: channel.ID}, false)
+	src.MessageSet(&discord.Message{ID: 7, ChannelID: private.ID}, false)
+
+	var progress []store.MigrateProgress
+
+	err := store.Migrate(context.Background(), src, dst, func(p store.MigrateProgress) {
+		progress = append(progress, p)
+	})
+	if err != nil {
+		t.Fatal("failed to migrate:", err)
+	}
+
+	if len(progress) != 2 || progress[0].GuildID != guild.ID || progress[1].Done != 1 {
+		t.Fatalf("unexpected progress %+v", progress)
+	}
+
+	if me, err := dst.Me(); err != nil || me.ID != 10 {
+		t.Fatalf("me not migrated: %v", err)
+	}
+	if _, err := dst.Guild(guild.ID); err != nil {
+		t.Fatal("guild not migrated:", err)
+	}
+	if _, err := dst.Role(guild.ID, 4); err != nil {
+		t.Fatal("role not migrated:", err)
+	}
+	if _, err := dst.Member(guild.ID, 10); err != nil {
+		t.Fatal("member not migrated:", err)
+	}
+	if _, err := dst.Channel(private.ID); err != nil {
+		t.Fatal("private channel not migrated:", err)
+	}
+
+	msgs, err := dst.Messages(channel.ID)
+	if err != nil || len(msgs) != 2 || msgs[0].ID != 6 {
+		t.Fatalf("messages not migrated in order: %v %+v", err, msgs)
+	}
+	if _, err := dst.Message(private.ID, 7); err != nil {
+		t.Fatal("private message not migrated:", err)
+	}
+}
@@ -0,0 +1,576 @@
+package store
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+)
+
+// Namespaces shares one backend cabinet between several namespaces, such as the
+// shards of a shard.Manager or the tenants of a multi-tenant bot, so that
+// several State instances can use one physical backend:
+//
+//	namespaces := store.NewNamespaces(backend)
+//
+//	for _, tenant := range tenants {
+//		s := state.NewWithStore(tenant.Token, namespaces.Cabinet(tenant.Name))
+//		// ...
+//	}
+//
+// The cabinet of each namespace only sees what was stored through it: guilds
+// and channels belong to the namespaces that stored them or anything under
+// them, and the current user is kept per namespace. Resetting a namespace,
+// which the State does on every Ready event, purges only what it owns from the
+// backend, leaving the other namespaces untouched.
+//
+// Since the stores are keyed by Discord IDs, namespaces storing the same guild
+// or channel, such as two bots in the same guild, share its data, and resetting
+// one of them removes it for both. Shards never share guilds, so this does not
+// happen to them. Ownership is kept in memory; whatever a previous process left
+// in the backend is not seen by any namespace.
+//
+// A zero-value Namespaces is not valid; use NewNamespaces.
+type Namespaces struct {
+	backend *Cabinet
+
+	mutex      sync.Mutex
+	namespaces map[string]*namespace
+}
+
+// NewNamespaces creates a new Namespaces sharing the given backend cabinet.
+func NewNamespaces(backend *Cabinet) *Namespaces {
+	return &Namespaces{
+		backend:    backend,
+		namespaces: make(map[string]*namespace),
+	}
+}
+
+// Cabinet returns the cabinet scoped to the given namespace, creating it if it
+// does not exist yet. Calling Cabinet with the same namespace always returns
+// the same cabinet.
+func (n *Namespaces) Cabinet(ns string) *Cabinet {
+	return n.namespace(ns).cabinet
+}
+
+func (n *Namespaces) namespace(ns string) *namespace {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	scoped, ok := n.namespaces[ns]
+	if !ok {
+		scoped = newNamespace(n.backend)
+		n.namespaces[ns] = scoped
+	}
+
+	return scoped
+}
+
+// Namespaces returns the sorted list of namespaces that have a cabinet.
+func (n *Namespaces) Namespaces() []string {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	namespaces := make([]string, 0, len(n.namespaces))
+	for ns := range n.namespaces {
+		namespaces = append(namespaces, ns)
+	}
+	sort.Strings(namespaces)
+
+	return namespaces
+}
+
+// Reset removes everything owned by the given namespace from the backend. Other
+// namespaces are left untouched. If the namespace has no cabinet, then nil is
+// returned.
+func (n *Namespaces) Reset(ns string) error {
+	n.mutex.Lock()
+	scoped, ok := n.namespaces[ns]
+	n.mutex.Unlock()
+
+	if !ok {
+		return nil
+	}
+
+	return scoped.Reset()
+}
+
+// ResetAll resets all namespaces.
+func (n *Namespaces) ResetAll() error {
+	var errs ResetErrors
+	for _, ns := range n.Namespaces() {
+		errs.append(n.Reset(ns))
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
+// namespace implements all stores of a namespace on top of the backend. It
+// keeps track of the guilds and channels that the namespace owns, and hides
+// everything else.
+//
+// The same namespace is used for every store in its cabinet, so Cabinet.Reset
+// calls its Reset once per store. Only the first call has anything to remove.
+type namespace struct {
+	backend *Cabinet
+	cabinet *Cabinet
+
+	mutex    sync.RWMutex
+	me       discord.User
+	guilds   map[discord.GuildID]struct{}
+	channels map[discord.ChannelID]struct{}
+	// privates is the subset of channels that are not in a guild.
+	privates map[discord.ChannelID]struct{}
+}
+
+var (
+	_ MeStore         = (*namespace)(nil)
+	_ ChannelStore    = (*namespace)(nil)
+	_ EmojiStore      = (*namespace)(nil)
+	_ GuildStore      = (*namespace)(nil)
+	_ MemberStore     = (*namespace)(nil)
+	_ MessageStore    = (*namespace)(nil)
+	_ PresenceStore   = (*namespace)(nil)
+	_ RoleStore       = (*namespace)(nil)
+	_ VoiceStateStore = (*namespace)(nil)
+)
+
+func newNamespace(backend *Cabinet) *namespace {
+	ns := &namespace{
+		backend:  backend,
+		guilds:   make(map[discord.GuildID]struct{}),
+		channels: make(map[discord.ChannelID]struct{}),
+		privates: make(map[discord.ChannelID]struct{}),
+	}
+
+	ns.cabinet = &Cabinet{
+		MeStore:         ns,
+		ChannelStore:    ns,
+		EmojiStore:      ns,
+		GuildStore:      ns,
+		MemberStore:     ns,
+		MessageStore:    ns,
+		PresenceStore:   ns,
+		RoleStore:       ns,
+		VoiceStateStore: ns,
+	}
+
+	return ns
+}
+
+// Reset purges the namespace's guilds, channels and messages from the backend.
+// The namespace forgets them even if the backend fails to remove them.
+func (ns *namespace) Reset() error {
+	ns.mutex.Lock()
+	guilds := ns.guilds
+	channels := ns.channels
+	privates := ns.privates
+	ns.me = discord.User{}
+	ns.guilds = make(map[discord.GuildID]struct{})
+	ns.channels = make(map[discord.ChannelID]struct{})
+	ns.privates = make(map[discord.ChannelID]struct{})
+	ns.mutex.Unlock()
+
+	var errs ResetErrors
+
+	for guildID := range guilds {
+		errs.append(ns.backend.GuildPurge(guildID))
+	}
+
+	// Messages can be stored without their channel, so they are purged by
+	// channel as well.
+	for channelID := range channels {
+		errs.append(purgeMessages(ns.backend.MessageStore, channelID))
+	}
+
+	for channelID := range privates {
+		ch, err := ns.backend.Channel(channelID)
+		if err != nil {
+			errs.append(ignoreNotFound(err))
+			continue
+		}
+		errs.append(ns.backend.ChannelRemove(ch))
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
+func (ns *namespace) hasGuild(guildID discord.GuildID) bool {
+	ns.mutex.RLock()
+	_, ok := ns.guilds[guildID]
+	ns.mutex.RUnlock()
+	return ok
+}
+
+func (ns *namespace) hasChannel(channelID discord.ChannelID) bool {
+	ns.mutex.RLock()
+	_, ok := ns.channels[channelID]
+	ns.mutex.RUnlock()
+	return ok
+}
+
+func (ns *namespace) addGuild(guildID discord.GuildID) {
+	ns.mutex.Lock()
+	ns.guilds[guildID] = struct{}{}
+	ns.mutex.Unlock()
+}
+
+func (ns *namespace) addChannel(ch *discord.Channel) {
+	ns.mutex.Lock()
+	ns.channels[ch.ID] = struct{}{}
+	if ch.GuildID.IsValid() {
+		ns.guilds[ch.GuildID] = struct{}{}
+	} else {
+		ns.privates[ch.ID] = struct{}{}
+	}
+	ns.mutex.Unlock()
+}
+
+// Me
+
+func (ns *namespace) Me() (*discord.User, error) {
+	ns.mutex.RLock()
+	me := ns.me
+	ns.mutex.RUnlock()
+
+	if !me.ID.IsValid() {
+		return nil, ErrNotFound
+	}
+
+	return &me, nil
+}
+
+func (ns *namespace) MyselfSet(me discord.User, update bool) error {
+	ns.mutex.Lock()
+	if !ns.me.ID.IsValid() || update {
+		ns.me = me
+	}
+	ns.mutex.Unlock()
+
+	return nil
+}
+
+// Channel
+
+func (ns *namespace) Channel(id discord.ChannelID) (*discord.Channel, error) {
+	if !ns.hasChannel(id) {
+		return nil, ErrNotFound
+	}
+	return ns.backend.Channel(id)
+}
+
+func (ns *namespace) CreatePrivateChannel(recipient discord.UserID) (*discord.Channel, error) {
+	ch, err := ns.backend.CreatePrivateChannel(recipient)
+	if err != nil {
+		return nil, err
+	}
+	if !ns.hasChannel(ch.ID) {
+		return nil, ErrNotFound
+	}
+	return ch, nil
+}
+
+func (ns *namespace) Channels(guildID discord.GuildID) ([]discord.Channel, error) {
+	if !ns.hasGuild(guildID) {
+		return nil, ErrNotFound
+	}
+	return ns.backend.Channels(guildID)
+}
+
+func (ns *namespace) PrivateChannels() ([]discord.Channel, error) {
+	ns.mutex.RLock()
+	ids := make([]discord.ChannelID, 0, len(ns.privates))
+	for id := range ns.privates {
+		ids = append(ids, id)
+	}
+	ns.mutex.RUnlock()
+
+	channels := make([]discord.Channel, 0, len(ids))
+	for _, id := range ids {
+		ch, err := ns.backend.Channel(id)
+		if err != nil {
+			if err := ignoreNotFound(err); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		channels = append(channels, *ch)
+	}
+
+	return channels, nil
+}
+
+func (ns *namespace) ChannelSet(ch *discord.Channel, update bool) error {
+	if err := ns.backend.ChannelSet(ch, update); err != nil {
+		return err
+	}
+	ns.addChannel(ch)
+	return nil
+}
+
+func (ns *namespace) ChannelRemove(ch *discord.Channel) error {
+	if !ns.hasChannel(ch.ID) {
+		return nil
+	}
+	if err := ns.backend.ChannelRemove(ch); err != nil {
+		return err
+	}
+
+	ns.mutex.Lock()
+	delete(ns.channels, ch.ID)
+	delete(ns.privates, ch.ID)
+	ns.mutex.Unlock()
+
+	return nil
+}
+
+// Emoji
+
+func (ns *namespace) Emoji(guildID discord.GuildID, emojiID discord.EmojiID) (*discord.Emoji, error) {
+	if !ns.hasGuild(guildID) {
+		return nil, ErrNotFound
+	}
+	return ns.backend.Emoji(guildID, emojiID)
+}
+
+func (ns *namespace) Emojis(guildID discord.GuildID) ([]discord.Emoji, error) {
+	if !ns.hasGuild(guildID) {
+		return nil, ErrNotFound
+	}
+	return ns.backend.Emojis(guildID)
+}
+
+func (ns *namespace) EmojiSet(guildID discord.GuildID, emojis []discord.Emoji, update bool) error {
+	if err := ns.backend.EmojiSet(guildID, emojis, update); err != nil {
+		return err
+	}
+	ns.addGuild(guildID)
+	return nil
+}
+
+// Guild
+
+func (ns *namespace) Guild(id discord.GuildID) (*discord.Guild, error) {
+	if !ns.hasGuild(id) {
+		return nil, ErrNotFound
+	}
+	return ns.backend.Guild(id)
+}
+
+func (ns *namespace) Guilds() ([]discord.Guild, error) {
+	ns.mutex.RLock()
+	ids := make([]discord.GuildID, 0, len(ns.guilds))
+	for id := range ns.guilds {
+		ids = append(ids, id)
+	}
+	ns.mutex.RUnlock()
+
+	guilds := make([]discord.Guild, 0, len(ids))
+	for _, id := range ids {
+		g, err := ns.backend.Guild(id)
+		if err != nil {
+			if err := ignoreNotFound(err); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		guilds = append(guilds, *g)
+	}
+
+	return guilds, nil
+}
+
+func (ns *namespace) GuildSet(g *discord.Guild, update bool) error {
+	if err := ns.backend.GuildSet(g, update); err != nil {
+		return err
+	}
+	ns.addGuild(g.ID)
+	return nil
+}
+
+func (ns *namespace) GuildRemove(id discord.GuildID) error {
+	if !ns.hasGuild(id) {
+		return nil
+	}
+	if err := ns.backend.GuildRemove(id); err != nil {
+		return err
+	}
+
+	ns.mutex.Lock()
+	delete(ns.guilds, id)
+	ns.mutex.Unlock()
+
+	return nil
+}
+
+// Member
+
+func (ns *namespace) Member(guildID discord.GuildID, userID discord.UserID) (*discord.Member, error) {
+	if !ns.hasGuild(guildID) {
+		return nil, ErrNotFound
+	}
+	return ns.backend.Member(guildID, userID)
+}
+
+func (ns *namespace) Members(guildID discord.GuildID) ([]discord.Member, error) {
+	if !ns.hasGuild(guildID) {
+		return nil, ErrNotFound
+	}
+	return ns.backend.Members(guildID)
+}
+
+func (ns *namespace) MemberSet(guildID discord.GuildID, m *discord.Member, update bool) error {
+	if err := ns.backend.MemberSet(guildID, m, update); err != nil {
+		return err
+	}
+	ns.addGuild(guildID)
+	return nil
+}
+
+func (ns *namespace) MemberRemove(guildID discord.GuildID, userID discord.UserID) error {
+	if !ns.hasGuild(guildID) {
+		return nil
+	}
+	return ns.backend.MemberRemove(guildID, userID)
+}
+
+// Message
+
+func (ns *namespace) MaxMessages() int {
+	return ns.backend.MaxMessages()
+}
+
+func (ns *namespace) Message(channelID discord.ChannelID, messageID discord.MessageID) (*discord.Message, error) {
+	if !ns.hasChannel(channelID) {
+		return nil, ErrNotFound
+	}
+	return ns.backend.Message(channelID, messageID)
+}
+
+func (ns *namespace) Messages(channelID discord.ChannelID) ([]discord.Message, error) {
+	if !ns.hasChannel(channelID) {
+		return nil, ErrNotFound
+	}
+	return ns.backend.Messages(channelID)
+}
+
+func (ns *namespace) MessageSet(m *discord.Message, update bool) error {
+	if err := ns.backend.MessageSet(m, update); err != nil {
+		return err
+	}
+	// Messages fetched from the API have no guild ID, so only the channel is
+	// claimed.
+	ns.mutex.Lock()
+	ns.channels[m.ChannelID] = struct{}{}
+	ns.mutex.Unlock()
+
+	return nil
+}
+
+func (ns *namespace) MessageRemove(channelID discord.ChannelID, messageID discord.MessageID) error {
+	if !ns.hasChannel(channelID) {
+		return nil
+	}
+	return ns.backend.MessageRemove(channelID, messageID)
+}
+
+// Presence
+
+func (ns *namespace) Presence(guildID discord.GuildID, userID discord.UserID) (*discord.Presence, error) {
+	if !ns.hasGuild(guildID) {
+		return nil, ErrNotFound
+	}
+	return ns.backend.Presence(guildID, userID)
+}
+
+func (ns *namespace) Presences(guildID discord.GuildID) ([]discord.Presence, error) {
+	if !ns.hasGuild(guildID) {
+		return nil, ErrNotFound
+	}
+	return ns.backend.Presences(guildID)
+}
+
+func (ns *namespace) PresenceSet(guildID discord.GuildID, p *discord.Presence, update bool) error {
+	if err := ns.backend.PresenceSet(guildID, p, update); err != nil {
+		return err
+	}
+	ns.addGuild(guildID)
+	return nil
+}
+
+func (ns *namespace) PresenceRemove(guildID discord.GuildID, userID discord.UserID) error {
+	if !ns.hasGuild(guildID) {
+		return nil
+	}
+	return ns.backend.PresenceRemove(guildID, userID)
+}
+
+// Role
+
+func (ns *namespace) Role(guildID discord.GuildID, roleID discord.RoleID) (*discord.Role, error) {
+	if !ns.hasGuild(guildID) {
+		return nil, ErrNotFound
+	}
+	return ns.backend.Role(guildID, roleID)
+}
+
+func (ns *namespace) Roles(guildID discord.GuildID) ([]discord.Role, error) {
+	if !ns.hasGuild(guildID) {
+		return nil, ErrNotFound
+	}
+	return ns.backend.Roles(guildID)
+}
+
+func (ns *namespace) RoleSet(guildID discord.GuildID, r *discord.Role, update bool) error {
+	if err := ns.backend.RoleSet(guildID, r, update); err != nil {
+		return err
+	}
+	ns.addGuild(guildID)
+	return nil
+}
+
+func (ns *namespace) RoleRemove(guildID discord.GuildID, roleID discord.RoleID) error {
+	if !ns.hasGuild(guildID) {
+		return nil
+	}
+	return ns.backend.RoleRemove(guildID, roleID)
+}
+
+// VoiceState
+
+func (ns *namespace) VoiceState(guildID discord.GuildID, userID discord.UserID) (*discord.VoiceState, error) {
+	if !ns.hasGuild(guildID) {
+		return nil, ErrNotFound
+	}
+	return ns.backend.VoiceState(guildID, userID)
+}
+
+func (ns *namespace) VoiceStates(guildID discord.GuildID) ([]discord.VoiceState, error) {
+	if !ns.hasGuild(guildID) {
+		return nil, ErrNotFound
+	}
+	return ns.backend.VoiceStates(guildID)
+}
+
+func (ns *namespace) VoiceStateSet(guildID discord.GuildID, s *discord.VoiceState, update bool) error {
+	if err := ns.backend.VoiceStateSet(guildID, s, update); err != nil {
+		return err
+	}
+	ns.addGuild(guildID)
+	return nil
+}
+
+func (ns *namespace) VoiceStateRemove(guildID discord.GuildID, userID discord.UserID) error {
+	if !ns.hasGuild(guildID) {
+		return nil
+	}
+	return ns.backend.VoiceStateRemove(guildID, userID)
+}
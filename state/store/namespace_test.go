@@ -0,0 +1,126 @@
+package store_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/state/store"
+	"github.com/diamondburned/arikawa/v3/state/store/defaultstore"
+)
+
+func TestNamespaces(t *testing.T) {
+	backend := defaultstore.New()
+	namespaces := store.NewNamespaces(backend)
+
+	a := namespaces.Cabinet("a")
+	b := namespaces.Cabinet("b")
+
+	if namespaces.Cabinet("a") != a {
+		t.Fatal("expected the same cabinet for the same namespace")
+	}
+	if a == b {
+		t.Fatal("expected different cabinets for different namespaces")
+	}
+	if ns := namespaces.Namespaces(); !reflect.DeepEqual(ns, []string{"a", "b"}) {
+		t.Fatal("unexpected namespaces:", ns)
+	}
+
+	// Each namespace stores its own guild, channel, member and DM, and its
+	// own current user.
+	for i, cab := range []*store.Cabinet{a, b} {
+		guildID := discord.GuildID(i + 1)
+		channelID := discord.ChannelID(i + 10)
+		dmID := discord.ChannelID(i + 20)
+		userID := discord.UserID(i + 30)
+
+		mustNoErr(t, cab.MyselfSet(discord.User{ID: userID}, false))
+		mustNoErr(t, cab.GuildSet(&discord.Guild{ID: guildID}, false))
+		mustNoErr(t, cab.ChannelSet(&discord.Channel{ID: channelID, GuildID: guildID}, false))
+		mustNoErr(t, cab.MemberSet(guildID, &discord.Member{User: discord.User{ID: userID}}, false))
+		mustNoErr(t, cab.ChannelSet(&discord.Channel{
+			ID:           dmID,
+			Type:         discord.DirectMessage,
+			DMRecipients: []discord.User{{ID: discord.UserID(i + 40)}},
+		}, false))
+		mustNoErr(t, cab.MessageSet(&discord.Message{ID: 1, ChannelID: dmID}, false))
+	}
+
+	if me, err := a.Me(); err != nil || me.ID != 30 {
+		t.Fatal("unexpected me in a:", me, err)
+	}
+	if me, err := b.Me(); err != nil || me.ID != 31 {
+		t.Fatal("unexpected me in b:", me, err)
+	}
+
+	guilds, err := a.Guilds()
+	if err != nil || len(guilds) != 1 || guilds[0].ID != 1 {
+		t.Fatal("expected only guild 1 in a, got", guilds, err)
+	}
+	if _, err := a.Guild(2); !errors.Is(err, store.ErrNotFound) {
+		t.Fatal("expected guild 2 to be hidden from a, got", err)
+	}
+	if _, err := a.Members(2); !errors.Is(err, store.ErrNotFound) {
+		t.Fatal("expected members of guild 2 to be hidden from a, got", err)
+	}
+	if _, err := a.Channel(11); !errors.Is(err, store.ErrNotFound) {
+		t.Fatal("expected channel 11 to be hidden from a, got", err)
+	}
+
+	privates, err := b.PrivateChannels()
+	if err != nil || len(privates) != 1 || privates[0].ID != 21 {
+		t.Fatal("expected only DM 21 in b, got", privates, err)
+	}
+
+	if guilds, _ := backend.Guilds(); len(guilds) != 2 {
+		t.Fatal("expected both guilds in the backend, got", guilds)
+	}
+
+	// Resetting a namespace purges only what it owns from the backend, as the
+	// State does on Ready.
+	if err := a.Reset(); err != nil {
+		t.Fatal("failed to reset a:", err)
+	}
+
+	if _, err := backend.Guild(1); !errors.Is(err, store.ErrNotFound) {
+		t.Fatal("expected guild 1 to be purged, got", err)
+	}
+	if _, err := backend.Channel(20); !errors.Is(err, store.ErrNotFound) {
+		t.Fatal("expected DM 20 to be purged, got", err)
+	}
+	if _, err := backend.Message(20, 1); !errors.Is(err, store.ErrNotFound) {
+		t.Fatal("expected the message in DM 20 to be purged, got", err)
+	}
+	if _, err := a.Me(); !errors.Is(err, store.ErrNotFound) {
+		t.Fatal("expected me to be reset in a, got", err)
+	}
+
+	if _, err := b.Guild(2); err != nil {
+		t.Fatal("expected guild 2 to be kept in b, got", err)
+	}
+	if _, err := b.Member(2, 31); err != nil {
+		t.Fatal("expected member to be kept in b, got", err)
+	}
+	if _, err := b.Message(21, 1); err != nil {
+		t.Fatal("expected message to be kept in b, got", err)
+	}
+
+	if err := namespaces.Reset("unknown"); err != nil {
+		t.Fatal("unexpected error resetting an unknown namespace:", err)
+	}
+
+	if err := namespaces.ResetAll(); err != nil {
+		t.Fatal("failed to reset all:", err)
+	}
+	if guilds, _ := backend.Guilds(); len(guilds) != 0 {
+		t.Fatal("expected the backend to be empty, got", guilds)
+	}
+}
+
+func mustNoErr(t *testing.T, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+}
@@ -0,0 +1,135 @@
+package store
+
+import "github.com/diamondburned/arikawa/v3/discord"
+
+// GuildPurger is an optional interface that stores holding guild-scoped data
+// can implement to remove all of a guild's data at once. Stores that don't
+// implement it are purged by Cabinet.GuildPurge one item at a time.
+type GuildPurger interface {
+	// GuildPurge removes everything that belongs to the given guild. Like other
+	// remove methods, it should return a nil error if there is nothing to
+	// remove.
+	GuildPurge(discord.GuildID) error
+}
+
+// GuildPurge removes the guild and everything that belongs to it from the
+// cabinet, which includes its channels, the messages in those channels, its
+// emojis, members, presences, roles and voice states. The State calls this
+// when the current user leaves or is removed from a guild.
+//
+// Purging continues if a store fails, and the first error is returned.
+func (sc *Cabinet) GuildPurge(guildID discord.GuildID) error {
+	var firstErr error
+	keep := func(err error) {
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	// Messages are stored per channel, so they must be purged while the
+	// guild's channels are still known.
+	if channels, err := sc.ChannelStore.Channels(guildID); err == nil {
+		for _, ch := range channels {
+			keep(purgeMessages(sc.MessageStore, ch.ID))
+		}
+	}
+
+	keep(purgeGuild(sc.ChannelStore, guildID, func() error {
+		channels, err := sc.ChannelStore.Channels(guildID)
+		if err != nil {
+			return nil
+		}
+		for i := range channels {
+			if err := sc.ChannelStore.ChannelRemove(&channels[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	}))
+
+	keep(purgeGuild(sc.EmojiStore, guildID, func() error {
+		if _, err := sc.EmojiStore.Emojis(guildID); err != nil {
+			return nil
+		}
+		return sc.EmojiStore.EmojiSet(guildID, nil, true)
+	}))
+
+	keep(purgeGuild(sc.MemberStore, guildID, func() error {
+		members, err := sc.MemberStore.Members(guildID)
+		if err != nil {
+			return nil
+		}
+		for _, m := range members {
+			if err := sc.MemberStore.MemberRemove(guildID, m.User.ID); err != nil {
+				return err
+			}
+		}
+		return nil
+	}))
+
+	keep(purgeGuild(sc.PresenceStore, guildID, func() error {
+		presences, err := sc.PresenceStore.Presences(guildID)
+		if err != nil {
+			return nil
+		}
+		for _, p := range presences {
+			if err := sc.PresenceStore.PresenceRemove(guildID, p.User.ID); err != nil {
+				return err
+			}
+		}
+		return nil
+	}))
+
+	keep(purgeGuild(sc.RoleStore, guildID, func() error {
+		roles, err := sc.RoleStore.Roles(guildID)
+		if err != nil {
+			return nil
+		}
+		for _, r := range roles {
+			if err := sc.RoleStore.RoleRemove(guildID, r.ID); err != nil {
+				return err
+			}
+		}
+		return nil
+	}))
+
+	keep(purgeGuild(sc.VoiceStateStore, guildID, func() error {
+		states, err := sc.VoiceStateStore.VoiceStates(guildID)
+		if err != nil {
+			return nil
+		}
+		for _, vs := range states {
+			if err := sc.VoiceStateStore.VoiceStateRemove(guildID, vs.UserID); err != nil {
+				return err
+			}
+		}
+		return nil
+	}))
+
+	keep(sc.GuildStore.GuildRemove(guildID))
+
+	return firstErr
+}
+
+// purgeGuild calls GuildPurge if the store implements GuildPurger, or the
+// fallback function otherwise.
+func purgeGuild(s interface{}, guildID discord.GuildID, fallback func() error) error {
+	if purger, ok := s.(GuildPurger); ok {
+		return purger.GuildPurge(guildID)
+	}
+	return fallback()
+}
+
+// purgeMessages removes all messages in the given channel.
+func purgeMessages(s MessageStore, channelID discord.ChannelID) error {
+	messages, err := s.Messages(channelID)
+	if err != nil {
+		return nil
+	}
+	for _, m := range messages {
+		if err := s.MessageRemove(channelID, m.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package store_test
+
+import (
+	"testing"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/state/store"
+	"github.com/diamondburned/arikawa/v3/state/store/defaultstore"
+)
+
+func TestGuildPurge(t *testing.T) {
+	cab := defaultstore.New()
+	// Hide GuildPurge from the member store to test the fallback.
+	cab.MemberStore = struct{ store.MemberStore }{cab.MemberStore}
+
+	for _, guildID := range []discord.GuildID{1, 2} {
+		chID := discord.ChannelID(guildID * 10)
+		userID := discord.UserID(guildID * 100)
+
+		cab.GuildSet(&discord.Guild{ID: guildID}, false)
+		cab.ChannelSet(&discord.Channel{ID: chID, GuildID: guildID, Type: discord.GuildText}, false)
+		cab.MessageSet(&discord.Message{ID: 1, ChannelID: chID, GuildID: guildID}, false)
+		cab.EmojiSet(guildID, []discord.Emoji{{ID: 1}}, false)
+		cab.MemberSet(guildID, &discord.Member{User: discord.User{ID: userID}}, false)
+		cab.PresenceSet(guildID, &discord.Presence{User: discord.User{ID: userID}}, false)
+		cab.RoleSet(guildID, &discord.Role{ID: 1}, false)
+		cab.VoiceStateSet(guildID, &discord.VoiceState{UserID: userID}, false)
+	}
+
+	if err := cab.GuildPurge(1); err != nil {
+		t.Fatal("failed to purge guild:", err)
+	}
+
+	if _, err := cab.Guild(1); err != store.ErrNotFound {
+		t.Error("guild not purged:", err)
+	}
+	if _, err := cab.Channel(10); err != store.ErrNotFound {
+		t.Error("channel not purged:", err)
+	}
+	if _, err := cab.Message(10, 1); err != store.ErrNotFound {
+		t.Error("message not purged:", err)
+	}
+	if _, err := cab.Emoji(1, 1); err != store.ErrNotFound {
+		t.Error("emoji not purged:", err)
+	}
+	if _, err := cab.Member(1, 100); err != store.ErrNotFound {
+		t.Error("member not purged:", err)
+	}
+	if _, err := cab.Presence(1, 100); err != store.ErrNotFound {
+		t.Error("presence not purged:", err)
+	}
+	if _, err := cab.Role(1, 1); err != store.ErrNotFound {
+		t.Error("role not purged:", err)
+	}
+	if _, err := cab.VoiceState(1, 100); err != store.ErrNotFound {
+		t.Error("voice state not purged:", err)
+	}
+
+	// The other guild must be left alone.
+	if _, err := cab.Guild(2); err != nil {
+		t.Error("other guild purged:", err)
+	}
+	if _, err := cab.Message(20, 1); err != nil {
+		t.Error("other guild's message purged:", err)
+	}
+	if _, err := cab.Member(2, 200); err != nil {
+		t.Error("other guild's member purged:", err)
+	}
+	if _, err := cab.VoiceState(2, 200); err != nil {
+		t.Error("other guild's voice state purged:", err)
+	}
+}
@@ -0,0 +1,97 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/utils/json"
+)
+
+// SnapshotVersion is the version of the format written by Snapshot. It is
+// bumped whenever the format changes incompatibly; RestoreSnapshot refuses to
+// restore snapshots of other versions.
+const SnapshotVersion = 1
+
+// ErrSnapshotVersion is returned by RestoreSnapshot if the snapshot was written
+// with a different SnapshotVersion.
+var ErrSnapshotVersion = fmt.Errorf("snapshot version is not %d", SnapshotVersion)
+
+type snapshot struct {
+	Version         int               `json:"version"`
+	Me              *discord.User     `json:"me,omitempty"`
+	Guilds          []guildSnapshot   `json:"guilds"`
+	PrivateChannels []channelSnapshot `json:"private_channels"`
+}
+
+// Snapshot writes everything in the cabinet into w as versioned JSON, so that
+// it can be restored later using RestoreSnapshot. It lets long-running bots
+// persist their cache across planned restarts.
+//
+// Like Migrate, resources are enumerated using the Guilds and PrivateChannels
+// methods, and getters returning ErrNotFound are ignored. The cabinet should
+// not be in use while the snapshot is taken, since it isn't locked.
+func (sc *Cabinet) Snapshot(w io.Writer) error {
+	snap := snapshot{
+		Version: SnapshotVersion,
+		Guilds:  []guildSnapshot{},
+	}
+
+	me, privates, err := walk(context.Background(), sc, func(guild *guildSnapshot, _, _ int) error {
+		snap.Guilds = append(snap.Guilds, *guild)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	snap.Me = me
+	snap.PrivateChannels = privates
+
+	if err := json.EncodeStream(w, snap); err != nil {
+		return fmt.Errorf("failed to encode snapshot: %w", err)
+	}
+
+	return nil
+}
+
+// RestoreSnapshot resets the cabinet and fills it with the snapshot read from
+// r, which must have been written by Snapshot. If the snapshot has a different
+// version, ErrSnapshotVersion is returned and the cabinet is left untouched.
+//
+// The restored cache may be outdated. The State replaces it anyway once the
+// gateway sends a Ready event, so RestoreSnapshot is mostly useful to serve
+// requests from the cache while the bot is starting up.
+func (sc *Cabinet) RestoreSnapshot(r io.Reader) error {
+	var snap snapshot
+	if err := json.DecodeStream(r, &snap); err != nil {
+		return fmt.Errorf("failed to decode snapshot: %w", err)
+	}
+
+	if snap.Version != SnapshotVersion {
+		return ErrSnapshotVersion
+	}
+
+	if err := sc.Reset(); err != nil {
+		return fmt.Errorf("failed to reset cabinet: %w", err)
+	}
+
+	if snap.Me != nil {
+		if err := sc.MyselfSet(*snap.Me, false); err != nil {
+			return fmt.Errorf("failed to set me: %w", err)
+		}
+	}
+
+	for i := range snap.Guilds {
+		if err := writeGuild(sc, &snap.Guilds[i]); err != nil {
+			return fmt.Errorf("failed to restore guild %d: %w", snap.Guilds[i].Guild.ID, err)
+		}
+	}
+
+	if err := writeChannels(sc, snap.PrivateChannels); err != nil {
+		return fmt.Errorf("failed to restore private channels: %w", err)
+	}
+
+	return nil
+}
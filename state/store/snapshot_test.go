@@ -0,0 +1,88 @@
+package store_test
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/state/store"
+	"github.com/diamondburned/arikawa/v3/state/store/defaultstore"
+)
+
+func TestSnapshot(t *testing.T) {
+	src := defaultstore.New()
+
+	guild := discord.Guild{ID: 1, Name: "guild"}
+	channel := discord.Channel{ID: 2, GuildID: guild.ID, Type: discord.GuildText}
+	private := discord.Channel{
+		ID:           3,
+		Type:         discord.DirectMessage,
+		DMRecipients: []discord.User{{ID: 11}},
+	}
+
+	src.MyselfSet(discord.User{ID: 10}, false)
+	src.GuildSet(&guild, false)
+	src.ChannelSet(&channel, false)
+	src.ChannelSet(&private, false)
+	src.RoleSet(guild.ID, &discord.Role{ID: 4}, false)
+	src.MemberSet(guild.ID, &discord.Member{User: discord.User{ID: 10}}, false)
+	src.MessageSet(&discord.Message{ID: 5, ChannelID: channel.ID}, false)
+	src.MessageSet(&discord.Message{ID: 6, ChannelID: channel.ID}, false)
+	src.MessageSet(&discord.Message{ID: 7, ChannelID: private.ID, Content: "hi"}, false)
+
+	var buf bytes.Buffer
+	if err := src.Snapshot(&buf); err != nil {
+		t.Fatal("failed to snapshot:", err)
+	}
+
+	dst := defaultstore.New()
+	// Stale data must be gone after restoring.
+	dst.GuildSet(&discord.Guild{ID: 100}, false)
+
+	if err := dst.RestoreSnapshot(&buf); err != nil {
+		t.Fatal("failed to restore snapshot:", err)
+	}
+
+	if me, err := dst.Me(); err != nil || me.ID != 10 {
+		t.Fatalf("me not restored: %v", err)
+	}
+	if g, err := dst.Guild(guild.ID); err != nil || g.Name != guild.Name {
+		t.Fatal("guild not restored:", err)
+	}
+	if _, err := dst.Guild(100); !errors.Is(err, store.ErrNotFound) {
+		t.Fatal("stale guild was not reset:", err)
+	}
+	if _, err := dst.Role(guild.ID, 4); err != nil {
+		t.Fatal("role not restored:", err)
+	}
+	if _, err := dst.Member(guild.ID, 10); err != nil {
+		t.Fatal("member not restored:", err)
+	}
+	if _, err := dst.Channel(private.ID); err != nil {
+		t.Fatal("private channel not restored:", err)
+	}
+
+	messages, err := dst.Messages(channel.ID)
+	if err != nil || len(messages) != 2 || messages[0].ID != 6 {
+		t.Fatalf("messages not restored in order: %v %+v", err, messages)
+	}
+	if m, err := dst.Message(private.ID, 7); err != nil || m.Content != "hi" {
+		t.Fatal("private message not restored:", err)
+	}
+}
+
+func TestRestoreSnapshotVersion(t *testing.T) {
+	cab := defaultstore.New()
+	cab.GuildSet(&discord.Guild{ID: 1}, false)
+
+	err := cab.RestoreSnapshot(strings.NewReader(`{"version":0}`))
+	if !errors.Is(err, store.ErrSnapshotVersion) {
+		t.Fatal("expected ErrSnapshotVersion, got", err)
+	}
+
+	if _, err := cab.Guild(1); err != nil {
+		t.Fatal("cabinet was modified:", err)
+	}
+}
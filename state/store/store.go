@@ -294,7 +294,10 @@ type MessageStore interface {
 	//
 	// If update is set to true, MessageSet will check if a message with the
 	// id of the passed message is stored, and update it if so. Otherwise, if
-	// there is no such message, it will be discarded.
+	// there is no such message, it will be discarded. Since Message Update
+	// events may only carry the changed fields, an update should only patch
+	// the non-zero fields of the passed message into the stored one instead
+	// of replacing it.
 	MessageSet(m *discord.Message, update bool) error
 	MessageRemove(discord.ChannelID, discord.MessageID) error
 }
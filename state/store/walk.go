@@ -0,0 +1,211 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+)
+
+// guildSnapshot is a guild along with everything stored for it.
+type guildSnapshot struct {
+	Guild       discord.Guild        `json:"guild"`
+	Channels    []channelSnapshot    `json:"channels,omitempty"`
+	Emojis      []discord.Emoji      `json:"emojis,omitempty"`
+	Roles       []discord.Role       `json:"roles,omitempty"`
+	Members     []discord.Member     `json:"members,omitempty"`
+	Presences   []discord.Presence   `json:"presences,omitempty"`
+	VoiceStates []discord.VoiceState `json:"voice_states,omitempty"`
+}
+
+// channelSnapshot is a channel along with its stored messages.
+type channelSnapshot struct {
+	Channel discord.Channel `json:"channel"`
+	// Messages are ordered from latest to oldest, like Messages returns them.
+	Messages []discord.Message `json:"messages,omitempty"`
+}
+
+// walk reads everything in src. It is shared by Migrate and Snapshot.
+//
+// Resources are enumerated using the Guilds and PrivateChannels methods, and
+// getters returning ErrNotFound are ignored. Guilds are read and given to
+// guildFn one at a time, so that only one is kept in memory; the context is
+// checked between each of them. The current user and the private channels are
+// returned once all guilds are walked.
+func walk(
+	ctx context.Context, src *Cabinet,
+	guildFn func(snap *guildSnapshot, done, total int) error) (*discord.User, []channelSnapshot, error) {
+
+	me, err := src.Me()
+	if err := ignoreNotFound(err); err != nil {
+		return nil, nil, fmt.Errorf("failed to get me: %w", err)
+	}
+
+	guilds, err := src.Guilds()
+	if err := ignoreNotFound(err); err != nil {
+		return nil, nil, fmt.Errorf("failed to get guilds: %w", err)
+	}
+
+	for i := range guilds {
+		if err := ctx.Err(); err != nil {
+			return nil, nil, err
+		}
+
+		snap, err := readGuild(src, &guilds[i])
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read guild %d: %w", guilds[i].ID, err)
+		}
+
+		if err := guildFn(&snap, i+1, len(guilds)); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	privates, err := src.PrivateChannels()
+	if err := ignoreNotFound(err); err != nil {
+		return nil, nil, fmt.Errorf("failed to get private channels: %w", err)
+	}
+
+	privateSnaps, err := readChannels(src, privates)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read private channels: %w", err)
+	}
+
+	return me, privateSnaps, nil
+}
+
+func readGuild(src *Cabinet, guild *discord.Guild) (guildSnapshot, error) {
+	snap := guildSnapshot{Guild: *guild}
+
+	channels, err := src.Channels(guild.ID)
+	if err := ignoreNotFound(err); err != nil {
+		return snap, fmt.Errorf("failed to get channels: %w", err)
+	}
+	snap.Channels, err = readChannels(src, channels)
+	if err != nil {
+		return snap, err
+	}
+
+	snap.Emojis, err = src.Emojis(guild.ID)
+	if err := ignoreNotFound(err); err != nil {
+		return snap, fmt.Errorf("failed to get emojis: %w", err)
+	}
+
+	snap.Roles, err = src.Roles(guild.ID)
+	if err := ignoreNotFound(err); err != nil {
+		return snap, fmt.Errorf("failed to get roles: %w", err)
+	}
+
+	snap.Members, err = src.Members(guild.ID)
+	if err := ignoreNotFound(err); err != nil {
+		return snap, fmt.Errorf("failed to get members: %w", err)
+	}
+
+	snap.Presences, err = src.Presences(guild.ID)
+	if err := ignoreNotFound(err); err != nil {
+		return snap, fmt.Errorf("failed to get presences: %w", err)
+	}
+
+	snap.VoiceStates, err = src.VoiceStates(guild.ID)
+	if err := ignoreNotFound(err); err != nil {
+		return snap, fmt.Errorf("failed to get voice states: %w", err)
+	}
+
+	return snap, nil
+}
+
+func readChannels(src *Cabinet, channels []discord.Channel) ([]channelSnapshot, error) {
+	snaps := make([]channelSnapshot, len(channels))
+
+	for i := range channels {
+		messages, err := src.Messages(channels[i].ID)
+		if err := ignoreNotFound(err); err != nil {
+			return nil, fmt.Errorf("failed to get messages of channel %d: %w", channels[i].ID, err)
+		}
+
+		snaps[i] = channelSnapshot{
+			Channel:  channels[i],
+			Messages: messages,
+		}
+	}
+
+	return snaps, nil
+}
+
+// writeGuild stores the guild and everything read with it into dst.
+func writeGuild(dst *Cabinet, snap *guildSnapshot) error {
+	guildID := snap.Guild.ID
+
+	if err := dst.GuildSet(&snap.Guild, false); err != nil {
+		return fmt.Errorf("failed to set guild: %w", err)
+	}
+
+	if err := writeChannels(dst, snap.Channels); err != nil {
+		return err
+	}
+
+	if snap.Emojis != nil {
+		if err := dst.EmojiSet(guildID, snap.Emojis, false); err != nil {
+			return fmt.Errorf("failed to set emojis: %w", err)
+		}
+	}
+
+	for i := range snap.Roles {
+		if err := dst.RoleSet(guildID, &snap.Roles[i], false); err != nil {
+			return fmt.Errorf("failed to set role %d: %w", snap.Roles[i].ID, err)
+		}
+	}
+
+	for i := range snap.Members {
+		if err := dst.MemberSet(guildID, &snap.Members[i], false); err != nil {
+			return fmt.Errorf("failed to set member %d: %w", snap.Members[i].User.ID, err)
+		}
+	}
+
+	for i := range snap.Presences {
+		if err := dst.PresenceSet(guildID, &snap.Presences[i], false); err != nil {
+			return fmt.Errorf("failed to set presence %d: %w", snap.Presences[i].User.ID, err)
+		}
+	}
+
+	for i := range snap.VoiceStates {
+		if err := dst.VoiceStateSet(guildID, &snap.VoiceStates[i], false); err != nil {
+			return fmt.Errorf("failed to set voice state %d: %w", snap.VoiceStates[i].UserID, err)
+		}
+	}
+
+	return nil
+}
+
+// writeChannels stores the channels and their messages into dst. Messages are
+// stored oldest first, so if dst keeps less messages, then the latest ones are
+// kept.
+func writeChannels(dst *Cabinet, snaps []channelSnapshot) error {
+	for i := range snaps {
+		if err := dst.ChannelSet(&snaps[i].Channel, false); err != nil {
+			return fmt.Errorf("failed to set channel %d: %w", snaps[i].Channel.ID, err)
+		}
+
+		// Messages are ordered from latest to oldest, so go backwards.
+		messages := snaps[i].Messages
+		for j := len(messages) - 1; j >= 0; j-- {
+			if err := dst.MessageSet(&messages[j], false); err != nil {
+				return fmt.Errorf("failed to set message %d: %w", messages[j].ID, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func ignoreNotFound(err error) error {
+	if errors.Is(err, ErrNotFound) {
+		return nil
+	}
+	return err
+}
@@ -28,6 +28,9 @@ type registry struct {
 type EventType struct {
 	StructName string
 	EventName  string
+	// Name is the stable name of the event, which is EventName for dispatch
+	// events and a name guessed from StructName for everything else.
+	Name       string
 	IsDispatch bool
 	OpCode     int
 }
@@ -77,6 +80,10 @@ func main() {
 		}
 	}
 
+	if err := r.checkNames(); err != nil {
+		log.Fatalln("invalid event names:", err)
+	}
+
 	buf := bytes.Buffer{}
 	if err := tmpl.Execute(&buf, &r); err != nil {
 		log.Fatalln("failed to execute template:", err)
@@ -141,14 +148,33 @@ func (r *registry) CrawlFile(name string) error {
 			t.EventName = guessEventName(t.StructName)
 		}
 
+		if t.IsDispatch {
+			t.Name = t.EventName
+		} else {
+			t.Name = guessEventName(t.StructName)
+		}
+
 		r.EventTypes = append(r.EventTypes, t)
 	}
 
 	return nil
 }
 
+// checkNames ensures that no two event types share the same name.
+func (r *registry) checkNames() error {
+	names := make(map[string]string, len(r.EventTypes))
+	for _, t := range r.EventTypes {
+		if other, ok := names[t.Name]; ok {
+			return fmt.Errorf("%s and %s have the same name %q", other, t.StructName, t.Name)
+		}
+		names[t.Name] = t.StructName
+	}
+	return nil
+}
+
 func guessEventName(structName string) string {
 	name := strings.TrimSuffix(structName, "Event")
+	name = strings.TrimSuffix(name, "Command")
 
 	var newName strings.Builder
 	newName.Grow(len(name) * 2)
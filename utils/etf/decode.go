@@ -0,0 +1,418 @@
+package etf
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"unicode/utf8"
+)
+
+// ToJSON transcodes the term in src, which must start with the version byte,
+// into JSON and appends it to dst.
+func ToJSON(dst, src []byte) ([]byte, error) {
+	if len(src) == 0 {
+		return dst, ErrUnexpectedEOF
+	}
+	if src[0] != Version {
+		return dst, fmt.Errorf("etf: unknown version %d", src[0])
+	}
+
+	d := decoder{src: src, pos: 1}
+
+	dst, err := d.term(dst, 0)
+	if err != nil {
+		return dst, err
+	}
+
+	if d.pos != len(d.src) {
+		return dst, errors.New("etf: trailing data after term")
+	}
+
+	return dst, nil
+}
+
+type decoder struct {
+	src []byte
+	pos int
+}
+
+func (d *decoder) next(n int) ([]byte, error) {
+	if n < 0 || len(d.src)-d.pos < n {
+		return nil, ErrUnexpectedEOF
+	}
+	b := d.src[d.pos : d.pos+n]
+	d.pos += n
+	return b, nil
+}
+
+func (d *decoder) uint8() (int, error) {
+	b, err := d.next(1)
+	if err != nil {
+		return 0, err
+	}
+	return int(b[0]), nil
+}
+
+func (d *decoder) uint16() (int, error) {
+	b, err := d.next(2)
+	if err != nil {
+		return 0, err
+	}
+	return int(binary.BigEndian.Uint16(b)), nil
+}
+
+func (d *decoder) uint32() (int, error) {
+	b, err := d.next(4)
+	if err != nil {
+		return 0, err
+	}
+	n := binary.BigEndian.Uint32(b)
+	// Every element takes up at least a byte, so lengths longer than the rest
+	// of the term are invalid anyway.
+	if int64(n) > int64(len(d.src)-d.pos) {
+		return 0, ErrUnexpectedEOF
+	}
+	return int(n), nil
+}
+
+func (d *decoder) term(dst []byte, depth int) ([]byte, error) {
+	if depth > maxDepth {
+		return dst, errors.New("etf: term is nested too deeply")
+	}
+
+	tag, err := d.uint8()
+	if err != nil {
+		return dst, err
+	}
+
+	switch tag {
+	case smallIntegerExt:
+		n, err := d.uint8()
+		if err != nil {
+			return dst, err
+		}
+		return strconv.AppendInt(dst, int64(n), 10), nil
+
+	case integerExt:
+		b, err := d.next(4)
+		if err != nil {
+			return dst, err
+		}
+		return strconv.AppendInt(dst, int64(int32(binary.BigEndian.Uint32(b))), 10), nil
+
+	case newFloatExt:
+		b, err := d.next(8)
+		if err != nil {
+			return dst, err
+		}
+		return appendFloat(dst, math.Float64frombits(binary.BigEndian.Uint64(b)))
+
+	case floatExt:
+		b, err := d.next(floatExtStringLen)
+		if err != nil {
+			return dst, err
+		}
+		f, err := strconv.ParseFloat(string(trimNull(b)), 64)
+		if err != nil {
+			return dst, fmt.Errorf("etf: invalid float: %w", err)
+		}
+		return appendFloat(dst, f)
+
+	case smallBigExt:
+		n, err := d.uint8()
+		if err != nil {
+			return dst, err
+		}
+		return d.big(dst, n)
+
+	case largeBigExt:
+		n, err := d.uint32()
+		if err != nil {
+			return dst, err
+		}
+		return d.big(dst, n)
+
+	case atomExt, atomUTF8Ext:
+		n, err := d.uint16()
+		if err != nil {
+			return dst, err
+		}
+		return d.atom(dst, n)
+
+	case smallAtomExt, smallAtomUTF8Ext:
+		n, err := d.uint8()
+		if err != nil {
+			return dst, err
+		}
+		return d.atom(dst, n)
+
+	case binaryExt:
+		n, err := d.uint32()
+		if err != nil {
+			return dst, err
+		}
+		b, err := d.next(n)
+		if err != nil {
+			return dst, err
+		}
+		return appendString(dst, b), nil
+
+	case stringExt:
+		// STRING_EXT is a list of bytes, not a string.
+		n, err := d.uint16()
+		if err != nil {
+			return dst, err
+		}
+		b, err := d.next(n)
+		if err != nil {
+			return dst, err
+		}
+		dst = append(dst, '[')
+		for i, c := range b {
+			if i > 0 {
+				dst = append(dst, ',')
+			}
+			dst = strconv.AppendInt(dst, int64(c), 10)
+		}
+		return append(dst, ']'), nil
+
+	case nilExt:
+		return append(dst, "[]"...), nil
+
+	case listExt:
+		n, err := d.uint32()
+		if err != nil {
+			return dst, err
+		}
+		dst, err = d.array(dst, n, depth)
+		if err != nil {
+			return dst, err
+		}
+		// Only proper lists, which end with an empty list, are supported.
+		tail, err := d.uint8()
+		if err != nil {
+			return dst, err
+		}
+		if tail != nilExt {
+			return dst, errors.New("etf: improper lists are not supported")
+		}
+		return dst, nil
+
+	case smallTupleExt:
+		n, err := d.uint8()
+		if err != nil {
+			return dst, err
+		}
+		return d.array(dst, n, depth)
+
+	case largeTupleExt:
+		n, err := d.uint32()
+		if err != nil {
+			return dst, err
+		}
+		return d.array(dst, n, depth)
+
+	case mapExt:
+		n, err := d.uint32()
+		if err != nil {
+			return dst, err
+		}
+		return d.object(dst, n, depth)
+
+	default:
+		return dst, UnsupportedTagError{Tag: byte(tag)}
+	}
+}
+
+func (d *decoder) array(dst []byte, n, depth int) ([]byte, error) {
+	var err error
+
+	dst = append(dst, '[')
+	for i := 0; i < n; i++ {
+		if i > 0 {
+			dst = append(dst, ',')
+		}
+		dst, err = d.term(dst, depth+1)
+		if err != nil {
+			return dst, err
+		}
+	}
+
+	return append(dst, ']'), nil
+}
+
+func (d *decoder) object(dst []byte, n, depth int) ([]byte, error) {
+	var err error
+
+	dst = append(dst, '{')
+	for i := 0; i < n; i++ {
+		if i > 0 {
+			dst = append(dst, ',')
+		}
+		dst, err = d.key(dst)
+		if err != nil {
+			return dst, err
+		}
+		dst = append(dst, ':')
+		dst, err = d.term(dst, depth+1)
+		if err != nil {
+			return dst, err
+		}
+	}
+
+	return append(dst, '}'), nil
+}
+
+// key appends a map key as a JSON string. Only atoms, binaries and integers
+// are valid keys.
+func (d *decoder) key(dst []byte) ([]byte, error) {
+	tag, err := d.uint8()
+	if err != nil {
+		return dst, err
+	}
+
+	var b []byte
+
+	switch tag {
+	case atomExt, atomUTF8Ext:
+		n, err := d.uint16()
+		if err != nil {
+			return dst, err
+		}
+		b, err = d.next(n)
+		if err != nil {
+			return dst, err
+		}
+	case smallAtomExt, smallAtomUTF8Ext:
+		n, err := d.uint8()
+		if err != nil {
+			return dst, err
+		}
+		b, err = d.next(n)
+		if err != nil {
+			return dst, err
+		}
+	case binaryExt:
+		n, err := d.uint32()
+		if err != nil {
+			return dst, err
+		}
+		b, err = d.next(n)
+		if err != nil {
+			return dst, err
+		}
+	case smallIntegerExt, integerExt, smallBigExt, largeBigExt:
+		d.pos--
+		dst = append(dst, '"')
+		dst, err = d.term(dst, 0)
+		if err != nil {
+			return dst, err
+		}
+		return append(dst, '"'), nil
+	default:
+		return dst, fmt.Errorf("etf: unsupported map key tag %d", tag)
+	}
+
+	return appendString(dst, b), nil
+}
+
+func (d *decoder) atom(dst []byte, n int) ([]byte, error) {
+	b, err := d.next(n)
+	if err != nil {
+		return dst, err
+	}
+
+	switch string(b) {
+	case "nil":
+		return append(dst, "null"...), nil
+	case "true", "false":
+		return append(dst, b...), nil
+	default:
+		return appendString(dst, b), nil
+	}
+}
+
+func (d *decoder) big(dst []byte, n int) ([]byte, error) {
+	sign, err := d.uint8()
+	if err != nil {
+		return dst, err
+	}
+
+	// Digits are stored in little-endian.
+	b, err := d.next(n)
+	if err != nil {
+		return dst, err
+	}
+
+	if sign != 0 {
+		dst = append(dst, '-')
+	}
+
+	if n <= 8 {
+		var u uint64
+		for i := n - 1; i >= 0; i-- {
+			u = u<<8 | uint64(b[i])
+		}
+		return strconv.AppendUint(dst, u, 10), nil
+	}
+
+	be := make([]byte, n)
+	for i, c := range b {
+		be[n-1-i] = c
+	}
+
+	return new(big.Int).SetBytes(be).Append(dst, 10), nil
+}
+
+func appendFloat(dst []byte, f float64) ([]byte, error) {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return dst, fmt.Errorf("etf: unsupported float %v", f)
+	}
+	return strconv.AppendFloat(dst, f, 'g', -1, 64), nil
+}
+
+func trimNull(b []byte) []byte {
+	for i, c := range b {
+		if c == 0 {
+			return b[:i]
+		}
+	}
+	return b
+}
+
+const hex = "0123456789abcdef"
+
+// appendString appends b as a quoted JSON string. Invalid UTF-8 is replaced
+// with the replacement character, like encoding/json does.
+func appendString(dst, b []byte) []byte {
+	dst = append(dst, '"')
+
+	for i := 0; i < len(b); {
+		c := b[i]
+		if c < utf8.RuneSelf {
+			switch {
+			case c == '"' || c == '\\':
+				dst = append(dst, '\\', c)
+			case c < 0x20:
+				dst = append(dst, '\\', 'u', '0', '0', hex[c>>4], hex[c&0xF])
+			default:
+				dst = append(dst, c)
+			}
+			i++
+			continue
+		}
+
+		r, size := utf8.DecodeRune(b[i:])
+		if r == utf8.RuneError && size == 1 {
+			dst = append(dst, "\ufffd"...)
+		} else {
+			dst = append(dst, b[i:i+size]...)
+		}
+		i += size
+	}
+
+	return append(dst, '"')
+}
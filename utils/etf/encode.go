@@ -0,0 +1,193 @@
+package etf
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+)
+
+// FromJSON transcodes the JSON value in src into a term, including the version
+// byte, and appends it to dst. Strings become binaries, null becomes the nil
+// atom and numbers become the smallest fitting integer term, or a float if they
+// are not integers.
+func FromJSON(dst, src []byte) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(src))
+	dec.UseNumber()
+
+	e := encoder{dec: dec, buf: append(dst, Version)}
+
+	tok, err := dec.Token()
+	if err != nil {
+		return dst, fmt.Errorf("etf: invalid JSON: %w", err)
+	}
+
+	if err := e.value(tok, 0); err != nil {
+		return dst, err
+	}
+
+	if _, err := dec.Token(); err != io.EOF {
+		return dst, errors.New("etf: trailing data after JSON value")
+	}
+
+	return e.buf, nil
+}
+
+type encoder struct {
+	dec *json.Decoder
+	buf []byte
+}
+
+func (e *encoder) value(tok json.Token, depth int) error {
+	if depth > maxDepth {
+		return errors.New("etf: JSON is nested too deeply")
+	}
+
+	switch v := tok.(type) {
+	case nil:
+		e.atom("nil")
+	case bool:
+		e.atom(strconv.FormatBool(v))
+	case string:
+		e.binary(v)
+	case json.Number:
+		return e.number(v)
+	case json.Delim:
+		switch v {
+		case '[':
+			return e.list(depth)
+		case '{':
+			return e.object(depth)
+		}
+		return fmt.Errorf("etf: unexpected JSON delimiter %q", v)
+	default:
+		return fmt.Errorf("etf: unexpected JSON token %T", tok)
+	}
+
+	return nil
+}
+
+func (e *encoder) list(depth int) error {
+	start := len(e.buf)
+	e.buf = append(e.buf, listExt, 0, 0, 0, 0)
+
+	var n uint32
+	for e.dec.More() {
+		if err := e.next(depth); err != nil {
+			return err
+		}
+		n++
+	}
+
+	// Consume the closing bracket.
+	if _, err := e.dec.Token(); err != nil {
+		return fmt.Errorf("etf: invalid JSON: %w", err)
+	}
+
+	if n == 0 {
+		e.buf = append(e.buf[:start], nilExt)
+		return nil
+	}
+
+	binary.BigEndian.PutUint32(e.buf[start+1:], n)
+	e.buf = append(e.buf, nilExt)
+	return nil
+}
+
+func (e *encoder) object(depth int) error {
+	start := len(e.buf)
+	e.buf = append(e.buf, mapExt, 0, 0, 0, 0)
+
+	var n uint32
+	for e.dec.More() {
+		key, err := e.dec.Token()
+		if err != nil {
+			return fmt.Errorf("etf: invalid JSON: %w", err)
+		}
+		e.binary(key.(string))
+
+		if err := e.next(depth); err != nil {
+			return err
+		}
+		n++
+	}
+
+	// Consume the closing brace.
+	if _, err := e.dec.Token(); err != nil {
+		return fmt.Errorf("etf: invalid JSON: %w", err)
+	}
+
+	binary.BigEndian.PutUint32(e.buf[start+1:], n)
+	return nil
+}
+
+func (e *encoder) next(depth int) error {
+	tok, err := e.dec.Token()
+	if err != nil {
+		return fmt.Errorf("etf: invalid JSON: %w", err)
+	}
+	return e.value(tok, depth+1)
+}
+
+func (e *encoder) atom(name string) {
+	e.buf = append(e.buf, smallAtomUTF8Ext, byte(len(name)))
+	e.buf = append(e.buf, name...)
+}
+
+func (e *encoder) binary(s string) {
+	e.buf = append(e.buf, binaryExt, 0, 0, 0, 0)
+	binary.BigEndian.PutUint32(e.buf[len(e.buf)-4:], uint32(len(s)))
+	e.buf = append(e.buf, s...)
+}
+
+func (e *encoder) number(n json.Number) error {
+	if i, err := strconv.ParseInt(string(n), 10, 64); err == nil {
+		switch {
+		case i >= 0 && i <= math.MaxUint8:
+			e.buf = append(e.buf, smallIntegerExt, byte(i))
+		case i >= math.MinInt32 && i <= math.MaxInt32:
+			e.buf = append(e.buf, integerExt, 0, 0, 0, 0)
+			binary.BigEndian.PutUint32(e.buf[len(e.buf)-4:], uint32(int32(i)))
+		case i < 0:
+			e.big(uint64(-i), true)
+		default:
+			e.big(uint64(i), false)
+		}
+		return nil
+	}
+
+	if u, err := strconv.ParseUint(string(n), 10, 64); err == nil {
+		e.big(u, false)
+		return nil
+	}
+
+	f, err := strconv.ParseFloat(string(n), 64)
+	if err != nil {
+		return fmt.Errorf("etf: invalid number %q: %w", n, err)
+	}
+
+	e.buf = append(e.buf, newFloatExt, 0, 0, 0, 0, 0, 0, 0, 0)
+	binary.BigEndian.PutUint64(e.buf[len(e.buf)-8:], math.Float64bits(f))
+	return nil
+}
+
+// big appends u as a SMALL_BIG_EXT with the given sign.
+func (e *encoder) big(u uint64, negative bool) {
+	start := len(e.buf)
+	e.buf = append(e.buf, smallBigExt, 0, 0)
+	if negative {
+		e.buf[start+2] = 1
+	}
+
+	var n byte
+	for ; u > 0; u >>= 8 {
+		e.buf = append(e.buf, byte(u))
+		n++
+	}
+
+	e.buf[start+1] = n
+}
@@ -0,0 +1,66 @@
+// Package etf implements a transcoder between Erlang's External Term Format
+// and JSON.
+//
+// Discord's gateway can send and receive payloads in ETF instead of JSON. As
+// every Discord type in this module is already decoded from JSON, ETF payloads
+// are transcoded to JSON rather than decoded directly. Terms are mapped to JSON
+// as follows:
+//
+//   - integers and floats are JSON numbers
+//   - binaries and atoms are JSON strings
+//   - the atoms nil, true and false are JSON null, true and false
+//   - lists and tuples are JSON arrays
+//   - maps are JSON objects
+//
+// Snowflakes are sent by Discord as integers over ETF, so they become JSON
+// numbers, which discord.Snowflake accepts.
+package etf
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Version is the version byte that prefixes every term.
+const Version = 131
+
+// Term tags.
+const (
+	newFloatExt      = 70
+	smallIntegerExt  = 97
+	integerExt       = 98
+	floatExt         = 99
+	atomExt          = 100
+	smallTupleExt    = 104
+	largeTupleExt    = 105
+	nilExt           = 106
+	stringExt        = 107
+	listExt          = 108
+	binaryExt        = 109
+	smallBigExt      = 110
+	largeBigExt      = 111
+	smallAtomExt     = 115
+	mapExt           = 116
+	atomUTF8Ext      = 118
+	smallAtomUTF8Ext = 119
+)
+
+// maxDepth is the maximum nesting depth of terms.
+const maxDepth = 1000
+
+// floatExtStringLen is the length of the string in the old FLOAT_EXT term.
+const floatExtStringLen = 31
+
+// ErrUnexpectedEOF is returned if a term ends prematurely.
+var ErrUnexpectedEOF = errors.New("etf: unexpected end of term")
+
+// UnsupportedTagError is returned when a term has a tag that cannot be
+// represented in JSON, such as a PID or a reference.
+type UnsupportedTagError struct {
+	Tag byte
+}
+
+// Error implements error.
+func (err UnsupportedTagError) Error() string {
+	return fmt.Sprintf("etf: unsupported term tag %d", err.Tag)
+}
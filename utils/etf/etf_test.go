@@ -0,0 +1,186 @@
+package etf
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestToJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		term []byte
+		json string
+	}{
+		{
+			name: "small integer",
+			term: []byte{Version, smallIntegerExt, 42},
+			json: `42`,
+		},
+		{
+			name: "negative integer",
+			term: []byte{Version, integerExt, 0xff, 0xff, 0xff, 0xfe},
+			json: `-2`,
+		},
+		{
+			name: "snowflake",
+			// 175928847299117063
+			term: []byte{Version, smallBigExt, 8, 0, 0x07, 0x00, 0x02, 0xc1, 0x5a, 0x06, 0x71, 0x02},
+			json: `175928847299117063`,
+		},
+		{
+			name: "float",
+			term: []byte{Version, newFloatExt, 0x3f, 0xf8, 0, 0, 0, 0, 0, 0},
+			json: `1.5`,
+		},
+		{
+			name: "atoms",
+			term: []byte{
+				Version, smallTupleExt, 4,
+				smallAtomUTF8Ext, 3, 'n', 'i', 'l',
+				smallAtomUTF8Ext, 4, 't', 'r', 'u', 'e',
+				atomExt, 0, 5, 'f', 'a', 'l', 's', 'e',
+				smallAtomExt, 2, 'o', 'k',
+			},
+			json: `[null,true,false,"ok"]`,
+		},
+		{
+			name: "binary with escapes",
+			term: []byte{Version, binaryExt, 0, 0, 0, 4, 'a', '"', '\n', 0xff},
+			json: `"a\"\u000a` + "\ufffd" + `"`,
+		},
+		{
+			name: "byte list",
+			term: []byte{Version, stringExt, 0, 3, 1, 2, 3},
+			json: `[1,2,3]`,
+		},
+		{
+			name: "empty list",
+			term: []byte{Version, nilExt},
+			json: `[]`,
+		},
+		{
+			name: "map",
+			term: []byte{
+				Version, mapExt, 0, 0, 0, 2,
+				smallAtomUTF8Ext, 2, 'o', 'p',
+				smallIntegerExt, 0,
+				binaryExt, 0, 0, 0, 1, 'd',
+				listExt, 0, 0, 0, 1, smallIntegerExt, 7, nilExt,
+			},
+			json: `{"op":0,"d":[7]}`,
+		},
+		{
+			name: "integer map key",
+			term: []byte{
+				Version, mapExt, 0, 0, 0, 1,
+				smallIntegerExt, 1,
+				binaryExt, 0, 0, 0, 0,
+			},
+			json: `{"1":""}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			b, err := ToJSON(nil, test.term)
+			if err != nil {
+				t.Fatal("unexpected error:", err)
+			}
+
+			if string(b) != test.json {
+				t.Fatalf("expected %s, got %s", test.json, b)
+			}
+		})
+	}
+}
+
+func TestToJSONErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		term []byte
+	}{
+		{"empty", nil},
+		{"bad version", []byte{130, nilExt}},
+		{"truncated", []byte{Version, binaryExt, 0, 0, 0, 5, 'a'}},
+		{"trailing data", []byte{Version, nilExt, nilExt}},
+		{"improper list", []byte{Version, listExt, 0, 0, 0, 1, nilExt, smallIntegerExt, 1}},
+		{"unsupported tag", []byte{Version, 103}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := ToJSON(nil, test.term); err == nil {
+				t.Fatal("unexpected nil error")
+			}
+		})
+	}
+
+	var tagErr UnsupportedTagError
+	if _, err := ToJSON(nil, []byte{Version, 103}); !errors.As(err, &tagErr) || tagErr.Tag != 103 {
+		t.Fatalf("expected UnsupportedTagError for tag 103, got %v", err)
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	const payload = `{
+		"op": 2,
+		"d": {
+			"token": "a\"b",
+			"intents": 3276799,
+			"large_threshold": 250,
+			"shard": [0, 1],
+			"presence": null,
+			"compress": false,
+			"guild_subscriptions": true,
+			"channel_id": 175928847299117063,
+			"big": 18446744073709551615,
+			"negative": -175928847299117063,
+			"ratio": 0.25,
+			"roles": [],
+			"properties": {}
+		}
+	}`
+
+	term, err := FromJSON(nil, []byte(payload))
+	if err != nil {
+		t.Fatal("failed to encode:", err)
+	}
+
+	if term[0] != Version {
+		t.Fatalf("expected version byte, got %d", term[0])
+	}
+
+	b, err := ToJSON(nil, term)
+	if err != nil {
+		t.Fatal("failed to decode:", err)
+	}
+
+	var expected, got interface{}
+	if err := unmarshalNumber([]byte(payload), &expected); err != nil {
+		t.Fatal("failed to unmarshal payload:", err)
+	}
+	if err := unmarshalNumber(b, &got); err != nil {
+		t.Fatalf("failed to unmarshal transcoded JSON %s: %v", b, err)
+	}
+
+	if !reflect.DeepEqual(expected, got) {
+		t.Fatalf("round trip mismatch:\nexpected %v\ngot      %v", expected, got)
+	}
+}
+
+func TestFromJSONErrors(t *testing.T) {
+	for _, src := range []string{``, `{`, `[1,`, `1 2`} {
+		if _, err := FromJSON(nil, []byte(src)); err == nil {
+			t.Errorf("expected error for %q", src)
+		}
+	}
+}
+
+func unmarshalNumber(b []byte, v interface{}) error {
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	return dec.Decode(v)
+}
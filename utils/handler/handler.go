@@ -21,14 +21,42 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"sort"
 	"sync"
+	"sync/atomic"
 )
 
+// Priority is the priority of a handler. Handlers of a higher priority are
+// called before handlers of a lower priority. Handlers of the same priority
+// are called in no particular order.
+//
+// Note that only synchronous handlers are guaranteed to finish before the
+// handlers of a lower priority are called; asynchronous handlers are only
+// started in order.
+type Priority int
+
+const (
+	// PriorityLow is a priority for handlers that must run after user
+	// handlers.
+	PriorityLow Priority = -100
+	// PriorityNormal is the priority of handlers added without one.
+	PriorityNormal Priority = 0
+	// PriorityHigh is a priority for handlers that must run before user
+	// handlers, such as metrics or audit handlers.
+	PriorityHigh Priority = 100
+)
+
+type eventKey struct {
+	event    reflect.Type // nil type for interfaces
+	priority Priority
+}
+
 // Handler is a container for command handlers. A zero-value instance is a valid
 // instance.
 type Handler struct {
-	mutex  sync.RWMutex
-	events map[reflect.Type]slab // nil type for interfaces
+	mutex      sync.RWMutex
+	events     map[eventKey]slab
+	priorities []Priority // sorted descending
 }
 
 func New() *Handler {
@@ -48,6 +76,32 @@ func (h *Handler) Call(ev interface{}) {
 	})
 }
 
+// CallWait calls all handlers with the given event like Call, except it
+// returns a function that blocks until every handler has returned, including
+// asynchronous ones. Channel handlers return once the event is received. This
+// is an internal method; use with care.
+func (h *Handler) CallWait(ev interface{}) (wait func()) {
+	v := reflect.ValueOf(ev)
+	t := reflect.TypeOf(ev)
+
+	var wg sync.WaitGroup
+
+	all := h.AllCallersForType(t)
+	all(func(caller Caller) bool {
+		entry, ok := caller.(slabEntry)
+		if !ok {
+			caller.Call(v)
+			return true
+		}
+
+		wg.Add(1)
+		entry.callDone(v, wg.Done)
+		return true
+	})
+
+	return wg.Wait
+}
+
 // AllCallersForType returns all callers for the given event type. This is an
 // internal method that is rarely useful for external use and should be used
 // with care.
@@ -56,31 +110,58 @@ func (h *Handler) AllCallersForType(t reflect.Type) func(yield func(Caller) bool
 		h.mutex.RLock()
 		defer h.mutex.RUnlock()
 
-		typedHandlers := h.events[t].Entries
-		anyHandlers := h.events[nil].Entries
-
-		if len(typedHandlers) == 0 && len(anyHandlers) == 0 {
-			return
-		}
-
-		for _, entry := range typedHandlers {
-			if entry.isInvalid() {
-				continue
+		for _, priority := range h.priorities {
+			typedHandlers := h.events[eventKey{t, priority}].Entries
+			anyHandlers := h.events[eventKey{nil, priority}].Entries
+
+			for _, entry := range typedHandlers {
+				if entry.isInvalid() {
+					continue
+				}
+				if !yield(entry) {
+					return
+				}
 			}
-			if !yield(entry) {
-				return
+
+			for _, entry := range anyHandlers {
+				if entry.isInvalid() || entry.not(t) {
+					continue
+				}
+				if !yield(entry) {
+					return
+				}
 			}
 		}
+	}
+}
 
-		for _, entry := range anyHandlers {
-			if entry.isInvalid() || entry.not(t) {
-				continue
-			}
-			if !yield(entry) {
-				return
+// EventTypes returns the event types that have at least one handler added
+// specifically for them. Handlers that accept an interface type are not
+// included. The returned slice is in no particular order.
+func (h *Handler) EventTypes() []reflect.Type {
+	h.mutex.RLock()
+	defer h.mutex.RUnlock()
+
+	seen := make(map[reflect.Type]struct{}, len(h.events))
+	types := make([]reflect.Type, 0, len(h.events))
+
+	for key, slab := range h.events {
+		if key.event == nil {
+			continue
+		}
+		if _, ok := seen[key.event]; ok {
+			continue
+		}
+		for _, entry := range slab.Entries {
+			if !entry.isInvalid() {
+				seen[key.event] = struct{}{}
+				types = append(types, key.event)
+				break
 			}
 		}
 	}
+
+	return types
 }
 
 // WaitFor blocks until there's an event. It's advised to use ChanFor instead,
@@ -165,19 +246,79 @@ func (h *Handler) ChanFor(fn func(interface{}) bool) (out <-chan interface{}, ca
 //	ch := make(chan *gateway.MessageCreateEvent)
 //	h.AddHandler(ch)
 func (h *Handler) AddHandler(handler interface{}) (rm func()) {
-	rm, err := h.addHandler(handler, false)
+	rm, err := h.addHandler(handler, handlerOpts{})
 	if err != nil {
 		panic(err)
 	}
 	return rm
 }
 
+var contextType = reflect.TypeOf((*context.Context)(nil)).Elem()
+
+// WithContext wraps the given function handler of signature
+// func(context.Context, T) into a handler of signature func(T) that can be
+// given to AddHandler. The context is obtained from ctxFn every time the
+// handler is called. WithContext panics if fn has the wrong signature.
+func WithContext(fn interface{}, ctxFn func() context.Context) interface{} {
+	fnv := reflect.ValueOf(fn)
+	fnt := fnv.Type()
+
+	if fnt.Kind() != reflect.Func {
+		panic("handler: WithContext given a non-function handler")
+	}
+	if fnt.NumIn() != 2 || fnt.In(0) != contextType || fnt.NumOut() != 0 {
+		panic("handler: WithContext handler must be func(context.Context, T)")
+	}
+
+	handlerType := reflect.FuncOf([]reflect.Type{fnt.In(1)}, nil, false)
+	handler := reflect.MakeFunc(handlerType, func(args []reflect.Value) []reflect.Value {
+		ctx := reflect.ValueOf(ctxFn())
+		return fnv.Call([]reflect.Value{ctx, args[0]})
+	})
+
+	return handler.Interface()
+}
+
 // AddSyncHandler is a synchronous variant of AddHandler. Handlers added using
 // this method will block the Call method, which is helpful if the user needs to
 // rely on the order of events arriving. Handlers added using this method should
 // not block for very long, as it may clog up other handlers.
 func (h *Handler) AddSyncHandler(handler interface{}) (rm func()) {
-	rm, err := h.addHandler(handler, true)
+	rm, err := h.addHandler(handler, handlerOpts{sync: true})
+	if err != nil {
+		panic(err)
+	}
+	return rm
+}
+
+// AddHandlerOnce is similar to AddHandler, except the handler is removed after
+// it is called for the first time. The returned rm function can still be used
+// to remove the handler before it is ever called.
+func (h *Handler) AddHandlerOnce(handler interface{}) (rm func()) {
+	rm, err := h.addHandler(handler, handlerOpts{once: true})
+	if err != nil {
+		panic(err)
+	}
+	return rm
+}
+
+// AddHandlerPriority is similar to AddHandler, except the handler is added
+// with the given priority. Handlers added using AddHandler have the
+// PriorityNormal priority.
+func (h *Handler) AddHandlerPriority(handler interface{}, priority Priority) (rm func()) {
+	rm, err := h.addHandler(handler, handlerOpts{priority: priority})
+	if err != nil {
+		panic(err)
+	}
+	return rm
+}
+
+// AddSyncHandlerPriority is the synchronous variant of AddHandlerPriority.
+// Since synchronous handlers block Call, a handler added with a higher
+// priority is guaranteed to finish before handlers of a lower priority are
+// called.
+func (h *Handler) AddSyncHandlerPriority(handler interface{}, priority Priority) (rm func()) {
+	rm, err := h.addHandler(handler, handlerOpts{sync: true, priority: priority})
 	if err != nil {
 		panic(err)
 	}
@@ -199,7 +340,7 @@ func (h *Handler) AddHandlerCheck(handler interface{}) (rm func(), err error) {
 		}
 	}()
 
-	return h.addHandler(handler, false)
+	return h.addHandler(handler, handlerOpts{})
 }
 
 // AddSyncHandlerCheck is the safe-guarded version of AddSyncHandler. It is
@@ -217,42 +358,75 @@ func (h *Handler) AddSyncHandlerCheck(handler interface{}) (rm func(), err error
 		}
 	}()
 
-	return h.addHandler(handler, true)
+	return h.addHandler(handler, handlerOpts{sync: true})
 }
 
-func (h *Handler) addHandler(fn interface{}, sync bool) (rm func(), err error) {
+type handlerOpts struct {
+	sync     bool
+	once     bool
+	priority Priority
+}
+
+func (h *Handler) addHandler(fn interface{}, opts handlerOpts) (rm func(), err error) {
 	// Reflect the handler
-	r, err := newHandler(fn, sync)
+	r, err := newHandler(fn, opts.sync)
 	if err != nil {
 		return nil, fmt.Errorf("handler reflect failed: %w", err)
 	}
 
-	var id int
-	var t reflect.Type
+	key := eventKey{priority: opts.priority}
 	if !r.isIface {
-		t = r.event
+		key.event = r.event
+	}
+
+	var id int
+	var rmOnce sync.Once
+
+	rm = func() {
+		rmOnce.Do(func() {
+			h.mutex.Lock()
+			slab := h.events[key]
+			popped := slab.Pop(id)
+			h.mutex.Unlock()
+
+			popped.cleanup()
+		})
+	}
+
+	if opts.once {
+		r.once = &handlerOnce{remove: rm}
 	}
 
 	h.mutex.Lock()
 
 	if h.events == nil {
-		h.events = make(map[reflect.Type]slab, 10)
+		h.events = make(map[eventKey]slab, 10)
 	}
 
-	slab := h.events[t]
+	slab := h.events[key]
 	id = slab.Put(r)
-	h.events[t] = slab
+	h.events[key] = slab
+
+	h.addPriority(opts.priority)
 
 	h.mutex.Unlock()
 
-	return func() {
-		h.mutex.Lock()
-		slab := h.events[t]
-		popped := slab.Pop(id)
-		h.mutex.Unlock()
+	return rm, nil
+}
+
+// addPriority adds the priority into the sorted priorities list if it's not
+// there yet. The mutex must be acquired.
+func (h *Handler) addPriority(priority Priority) {
+	i := sort.Search(len(h.priorities), func(i int) bool {
+		return h.priorities[i] <= priority
+	})
+	if i < len(h.priorities) && h.priorities[i] == priority {
+		return
+	}
 
-		popped.cleanup()
-	}, nil
+	h.priorities = append(h.priorities, 0)
+	copy(h.priorities[i+1:], h.priorities[i:])
+	h.priorities[i] = priority
 }
 
 // Caller is an interface that can be used to call a handler.
@@ -265,7 +439,12 @@ type handler struct {
 	chanclose reflect.Value // IsValid() if chan
 	isIface   bool
 	isSync    bool
-	isOnce    bool
+	once      *handlerOnce // non-nil if once
+}
+
+type handlerOnce struct {
+	called int32 // atomic
+	remove func()
 }
 
 var _ Caller = (*handler)(nil)
@@ -324,10 +503,51 @@ func (h handler) not(event reflect.Type) bool {
 }
 
 func (h handler) Call(event reflect.Value) {
-	if h.isSync {
-		h.call(event)
-	} else {
+	h.callDone(event, nil)
+}
+
+// callDone calls the handler like Call. If done is not nil, then it is called
+// once the handler has returned, or right away if the handler was not called.
+func (h handler) callDone(event reflect.Value, done func()) {
+	if h.once != nil {
+		if !atomic.CompareAndSwapInt32(&h.once.called, 0, 1) {
+			if done != nil {
+				done()
+			}
+			return
+		}
+
+		// Remove the handler only after it's called, since removing a
+		// channel handler cancels its ongoing sends. Removing must also be
+		// done in another goroutine, since Call may be called with the mutex
+		// acquired.
+		if h.isSync {
+			h.callAndDone(event, done)
+			go h.once.remove()
+		} else {
+			go func() {
+				h.callAndDone(event, done)
+				h.once.remove()
+			}()
+		}
+
+		return
+	}
+
+	switch {
+	case h.isSync:
+		h.callAndDone(event, done)
+	case done == nil:
 		go h.call(event)
+	default:
+		go h.callAndDone(event, done)
+	}
+}
+
+func (h handler) callAndDone(event reflect.Value, done func()) {
+	h.call(event)
+	if done != nil {
+		done()
 	}
 }
 
@@ -276,6 +276,99 @@ func TestHandlerChanFor(t *testing.T) {
 	}
 }
 
+func TestHandlerOnce(t *testing.T) {
+	h := &Handler{}
+
+	results := make(chan *gateway.MessageCreateEvent, 2)
+	h.AddHandlerOnce(results)
+
+	h.Call(newMessage("hime arikawa"))
+	h.Call(newMessage("astolfo"))
+
+	if r := <-results; r.Content != "hime arikawa" {
+		t.Fatal("Returned results is wrong:", r.Content)
+	}
+
+	select {
+	case r := <-results:
+		t.Fatal("Unexpected results:", r.Content)
+	case <-time.After(5 * time.Millisecond):
+	}
+
+	// Calling rm after the handler removed itself should be a no-op.
+	rm := h.AddHandlerOnce(func(*gateway.MessageCreateEvent) {})
+	h.Call(newMessage("hime arikawa"))
+	rm()
+	rm()
+}
+
+func TestHandlerPriority(t *testing.T) {
+	h := &Handler{}
+
+	var order []Priority
+	for _, priority := range []Priority{PriorityNormal, PriorityLow, PriorityHigh, 5} {
+		priority := priority
+		h.AddSyncHandlerPriority(func(*gateway.MessageCreateEvent) {
+			order = append(order, priority)
+		}, priority)
+	}
+
+	h.Call(newMessage("hime arikawa"))
+
+	expect := []Priority{PriorityHigh, 5, PriorityNormal, PriorityLow}
+	if !reflect.DeepEqual(order, expect) {
+		t.Fatalf("expected order %v, got %v", expect, order)
+	}
+}
+
+func TestHandlerCallWait(t *testing.T) {
+	h := &Handler{}
+
+	release := make(chan struct{})
+	returned := make(chan string, 10)
+
+	h.AddHandler(func(m *gateway.MessageCreateEvent) {
+		<-release
+		returned <- "async"
+	})
+	h.AddHandlerOnce(func(m *gateway.MessageCreateEvent) {
+		<-release
+		returned <- "once"
+	})
+	h.AddSyncHandler(func(m *gateway.MessageCreateEvent) {
+		returned <- "sync"
+	})
+
+	wait := h.CallWait(newMessage("hime arikawa"))
+
+	waited := make(chan struct{})
+	go func() {
+		wait()
+		close(waited)
+	}()
+
+	select {
+	case <-waited:
+		t.Fatal("wait returned before the async handlers")
+	case <-time.After(5 * time.Millisecond):
+	}
+
+	close(release)
+
+	select {
+	case <-waited:
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for the handlers")
+	}
+
+	if len(returned) != 3 {
+		t.Fatalf("expected 3 handlers to return, got %d", len(returned))
+	}
+
+	// The once handler is no longer called, and so isn't waited for.
+	h.CallWait(newMessage("astolfo"))()
+}
+
 func BenchmarkReflect(b *testing.B) {
 	h, err := newHandler(func(m *gateway.MessageCreateEvent) {}, false)
 	if err != nil {
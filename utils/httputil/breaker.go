@@ -0,0 +1,188 @@
+package httputil
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned by requests that are rejected by an open
+// CircuitBreaker. It is wrapped in a RequestError.
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// Budget limits the number of requests in flight. A request is in flight from
+// when it is sent until its response headers are received. A Budget can be
+// shared between multiple Clients. The zero value is not usable; use NewBudget.
+type Budget struct {
+	slots chan struct{}
+}
+
+// NewBudget creates a new Budget that allows up to max requests in flight.
+func NewBudget(max int) *Budget {
+	if max < 1 {
+		panic("httputil: budget must allow at least 1 request")
+	}
+	return &Budget{slots: make(chan struct{}, max)}
+}
+
+// Acquire blocks until a request can be sent or until ctx is done. A
+// successful Acquire must be followed by a Release.
+func (b *Budget) Acquire(ctx context.Context) error {
+	select {
+	case b.slots <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Release frees the slot taken by Acquire.
+func (b *Budget) Release() {
+	<-b.slots
+}
+
+// InFlight returns the number of requests currently in flight.
+func (b *Budget) InFlight() int {
+	return len(b.slots)
+}
+
+// CircuitState is the state of a CircuitBreaker.
+type CircuitState uint8
+
+const (
+	// CircuitClosed lets all requests through.
+	CircuitClosed CircuitState = iota
+	// CircuitOpen rejects all requests until the cool-down is over.
+	CircuitOpen
+	// CircuitHalfOpen lets a single request through to probe whether the
+	// server has recovered. The circuit is closed again if it succeeds, and
+	// opened again if it fails.
+	CircuitHalfOpen
+)
+
+// String returns the name of the state.
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+// CircuitBreaker stops requests from being sent after too many consecutive
+// failures, so that a bot doesn't keep hammering Discord during an incident.
+// Network errors and 5xx responses count as failures; any other response,
+// including 429, resets the count. A CircuitBreaker can be shared between
+// multiple Clients. The zero value is not usable; use NewCircuitBreaker.
+type CircuitBreaker struct {
+	// OnStateChange, if not nil, is called when the state of the breaker
+	// changes. It is called with the breaker's mutex held, so it must not call
+	// the breaker's methods.
+	OnStateChange func(from, to CircuitState)
+
+	threshold int
+	cooldown  time.Duration
+
+	mutex    sync.Mutex
+	state    CircuitState
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+// NewCircuitBreaker creates a new CircuitBreaker that opens after threshold
+// consecutive failures and stays open for the given cool-down before letting a
+// probe request through.
+func NewCircuitBreaker(threshold int, cooldown time.Duration) *CircuitBreaker {
+	if threshold < 1 {
+		panic("httputil: circuit breaker threshold must be at least 1")
+	}
+	return &CircuitBreaker{
+		threshold: threshold,
+		cooldown:  cooldown,
+	}
+}
+
+// State returns the current state of the breaker.
+func (b *CircuitBreaker) State() CircuitState {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if b.state == CircuitOpen && time.Since(b.openedAt) >= b.cooldown {
+		return CircuitHalfOpen
+	}
+	return b.state
+}
+
+// Allow returns ErrCircuitOpen if a request must not be sent. Otherwise, the
+// result of the request must be reported using Success or Failure.
+func (b *CircuitBreaker) Allow() error {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	switch b.state {
+	case CircuitOpen:
+		if time.Since(b.openedAt) < b.cooldown {
+			return ErrCircuitOpen
+		}
+		b.setState(CircuitHalfOpen)
+		fallthrough
+	case CircuitHalfOpen:
+		if b.probing {
+			return ErrCircuitOpen
+		}
+		b.probing = true
+	}
+
+	return nil
+}
+
+// Success reports that an allowed request succeeded.
+func (b *CircuitBreaker) Success() {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.failures = 0
+	b.probing = false
+	b.setState(CircuitClosed)
+}
+
+// Failure reports that an allowed request failed.
+func (b *CircuitBreaker) Failure() {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.failures++
+	b.probing = false
+
+	if b.state == CircuitHalfOpen || b.failures >= b.threshold {
+		b.openedAt = time.Now()
+		b.setState(CircuitOpen)
+	}
+}
+
+// cancel reports that an allowed request was never sent.
+func (b *CircuitBreaker) cancel() {
+	b.mutex.Lock()
+	b.probing = false
+	b.mutex.Unlock()
+}
+
+func (b *CircuitBreaker) setState(state CircuitState) {
+	if b.state == state {
+		return
+	}
+
+	from := b.state
+	b.state = state
+
+	if b.OnStateChange != nil {
+		b.OnStateChange(from, state)
+	}
+}
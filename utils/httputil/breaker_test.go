@@ -0,0 +1,146 @@
+package httputil
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	const cooldown = 50 * time.Millisecond
+
+	var status int32 = http.StatusInternalServerError
+	var hits int32
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		w.WriteHeader(int(atomic.LoadInt32(&status)))
+	}))
+	defer srv.Close()
+
+	client := NewClient()
+	client.Retries = 1
+	client.CircuitBreaker = NewCircuitBreaker(3, cooldown)
+
+	for i := 0; i < 3; i++ {
+		var httpErr *HTTPError
+		if err := client.FastRequest("GET", srv.URL); !errors.As(err, &httpErr) {
+			t.Fatalf("request %d: expected HTTPError, got %v", i, err)
+		}
+	}
+
+	if state := client.CircuitBreaker.State(); state != CircuitOpen {
+		t.Fatal("expected open circuit, got", state)
+	}
+
+	if err := client.FastRequest("GET", srv.URL); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatal("expected ErrCircuitOpen, got", err)
+	}
+	if n := atomic.LoadInt32(&hits); n != 3 {
+		t.Fatal("expected 3 requests to reach the server, got", n)
+	}
+
+	time.Sleep(cooldown)
+
+	if state := client.CircuitBreaker.State(); state != CircuitHalfOpen {
+		t.Fatal("expected half-open circuit, got", state)
+	}
+
+	// A failing probe opens the circuit again.
+	if err := client.FastRequest("GET", srv.URL); errors.Is(err, ErrCircuitOpen) {
+		t.Fatal("expected probe to be sent")
+	}
+	if err := client.FastRequest("GET", srv.URL); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatal("expected ErrCircuitOpen after failed probe, got", err)
+	}
+
+	time.Sleep(cooldown)
+	atomic.StoreInt32(&status, http.StatusOK)
+
+	if err := client.FastRequest("GET", srv.URL); err != nil {
+		t.Fatal("expected probe to succeed, got", err)
+	}
+	if state := client.CircuitBreaker.State(); state != CircuitClosed {
+		t.Fatal("expected closed circuit, got", state)
+	}
+}
+
+func TestCircuitBreakerProbe(t *testing.T) {
+	b := NewCircuitBreaker(1, 0)
+	b.Failure()
+
+	if err := b.Allow(); err != nil {
+		t.Fatal("expected probe to be allowed, got", err)
+	}
+	if err := b.Allow(); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatal("expected only one probe, got", err)
+	}
+
+	b.cancel()
+
+	if err := b.Allow(); err != nil {
+		t.Fatal("expected probe to be allowed after cancel, got", err)
+	}
+}
+
+func TestBudget(t *testing.T) {
+	const max = 2
+
+	var inFlight, peak int32
+	release := make(chan struct{})
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+
+		for {
+			p := atomic.LoadInt32(&peak)
+			if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+				break
+			}
+		}
+
+		<-release
+	}))
+	defer srv.Close()
+
+	client := NewClient()
+	client.Budget = NewBudget(max)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := client.FastRequest("GET", srv.URL); err != nil {
+				t.Error("failed to request:", err)
+			}
+		}()
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if peak > max {
+		t.Fatalf("expected at most %d requests in flight, got %d", max, peak)
+	}
+
+	t.Run("context", func(t *testing.T) {
+		budget := NewBudget(1)
+		budget.Acquire(context.Background())
+		defer budget.Release()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		if err := budget.Acquire(ctx); !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatal("expected deadline exceeded, got", err)
+		}
+	})
+}
@@ -8,10 +8,12 @@ import (
 	"fmt"
 	"io"
 	"mime/multipart"
+	"strings"
 	"time"
 
 	"github.com/diamondburned/arikawa/v3/utils/httputil/httpdriver"
 	"github.com/diamondburned/arikawa/v3/utils/json"
+	"github.com/diamondburned/arikawa/v3/utils/logger"
 )
 
 // StatusTooManyRequests is the HTTP status code discord sends on rate-limiting.
@@ -40,6 +42,17 @@ type Client struct {
 	// Default to the global Retries variable (5).
 	Retries uint
 
+	// Logger, if not nil, is used to log requests and their retries.
+	Logger logger.Logger
+
+	// Budget, if not nil, limits the number of requests in flight. Requests
+	// over the budget wait for a slot.
+	Budget *Budget
+
+	// CircuitBreaker, if not nil, rejects requests with ErrCircuitOpen after
+	// too many consecutive failures, without retrying them.
+	CircuitBreaker *CircuitBreaker
+
 	context context.Context
 }
 
@@ -181,6 +194,29 @@ func (c *Client) Request(method, url string, opts ...RequestOption) (httpdriver.
 	return response, nil
 }
 
+// redactedToken replaces the webhook and interaction tokens in logged URLs.
+const redactedToken = "REDACTED"
+
+// redactURL returns the URL with the webhook and interaction tokens in its path
+// replaced, so that they don't end up in the logs. These tokens are always the
+// segment right after the webhook or interaction ID.
+func redactURL(url string) string {
+	path, query := url, ""
+	if i := strings.IndexByte(url, '?'); i != -1 {
+		path, query = url[:i], url[i:]
+	}
+
+	parts := strings.Split(path, "/")
+	for i := 0; i+2 < len(parts); i++ {
+		if parts[i] == "webhooks" || parts[i] == "interactions" {
+			parts[i+2] = redactedToken
+			i += 2
+		}
+	}
+
+	return strings.Join(parts, "/") + query
+}
+
 func (c *Client) request(
 	method, url string,
 	opts []RequestOption) (r httpdriver.Response, cancel context.CancelFunc, doErr error) {
@@ -191,6 +227,8 @@ func (c *Client) request(
 	var status int
 
 	ctx := c.context
+	log := logger.Or(c.Logger)
+	logURL := redactURL(url)
 
 	if c.Timeout > 0 {
 		ctx, cancel = context.WithTimeout(ctx, c.Timeout)
@@ -216,7 +254,17 @@ func (c *Client) request(
 			return
 		}
 
+		if err := c.acquire(ctx); err != nil {
+			for _, fn := range c.OnResponse {
+				fn(q, nil)
+			}
+
+			doErr = RequestError{err}
+			return
+		}
+
 		r, doErr = c.Client.Do(q)
+		c.release(ctx, r, doErr)
 
 		// Call OnResponse() even if the request failed.
 		for _, fn := range c.OnResponse {
@@ -226,14 +274,25 @@ func (c *Client) request(
 			}
 		}
 
-		if onRespErr != nil || doErr != nil {
+		if doErr != nil {
+			log.Warn("request failed", "method", method, "url", logURL, "try", i, "err", doErr)
+			continue
+		}
+
+		if onRespErr != nil {
+			log.Warn("OnResponse handler failed",
+				"method", method, "url", logURL, "try", i, "err", onRespErr)
 			continue
 		}
 
 		if status = r.GetStatus(); status == StatusTooManyRequests || status >= 500 {
+			log.Warn("request got retryable status",
+				"method", method, "url", logURL, "try", i, "status", status)
 			continue
 		}
 
+		log.Debug("request done", "method", method, "url", logURL, "status", status)
+
 		break
 	}
 
@@ -271,3 +330,44 @@ func (c *Client) request(
 
 	return
 }
+
+// acquire checks the circuit breaker and takes a slot from the budget, if any.
+func (c *Client) acquire(ctx context.Context) error {
+	if c.CircuitBreaker != nil {
+		if err := c.CircuitBreaker.Allow(); err != nil {
+			return err
+		}
+	}
+
+	if c.Budget != nil {
+		if err := c.Budget.Acquire(ctx); err != nil {
+			if c.CircuitBreaker != nil {
+				c.CircuitBreaker.cancel()
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+// release frees what acquire took and reports the result of the request to the
+// circuit breaker. Requests that failed because ctx is done aren't counted.
+func (c *Client) release(ctx context.Context, r httpdriver.Response, err error) {
+	if c.Budget != nil {
+		c.Budget.Release()
+	}
+
+	if c.CircuitBreaker == nil {
+		return
+	}
+
+	switch {
+	case err != nil && ctx.Err() != nil:
+		c.CircuitBreaker.cancel()
+	case err != nil || r.GetStatus() >= 500:
+		c.CircuitBreaker.Failure()
+	default:
+		c.CircuitBreaker.Success()
+	}
+}
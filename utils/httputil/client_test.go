@@ -0,0 +1,19 @@
+package httputil
+
+import "testing"
+
+func TestRedactURL(t *testing.T) {
+	tests := map[string]string{
+		"https://discord.com/api/v10/webhooks/1/token?wait=true":          "https://discord.com/api/v10/webhooks/1/REDACTED?wait=true",
+		"https://discord.com/api/v10/webhooks/1/token/messages/@original": "https://discord.com/api/v10/webhooks/1/REDACTED/messages/@original",
+		"https://discord.com/api/v10/interactions/1/token/callback":       "https://discord.com/api/v10/interactions/1/REDACTED/callback",
+		"https://discord.com/api/v10/channels/1/webhooks":                 "https://discord.com/api/v10/channels/1/webhooks",
+		"https://discord.com/api/v10/webhooks/1":                          "https://discord.com/api/v10/webhooks/1",
+	}
+
+	for url, expect := range tests {
+		if got := redactURL(url); got != expect {
+			t.Errorf("redactURL(%q) = %q, expected %q", url, got, expect)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package httputil
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/diamondburned/arikawa/v3/utils/httputil/httpdriver"
+)
+
+// ErrTooLarge is returned by DownloadWithLimit if the response body is larger
+// than the given limit.
+var ErrTooLarge = errors.New("response body exceeds size limit")
+
+// NewCDNClient creates a new client for downloading files from Discord's CDN,
+// such as attachments, avatars and emojis. The client never sends any
+// authorization header and isn't rate limited, so it is safe to use with URLs
+// pointing to hosts other than Discord.
+//
+// Unlike NewClient, the underlying HTTP client has no timeout, since downloads
+// may take arbitrarily long. Use WithContext or Timeout to bound them.
+func NewCDNClient() *Client {
+	return &Client{
+		Client:        httpdriver.WrapClient(http.Client{}),
+		SchemaEncoder: &DefaultSchema{},
+		Retries:       Retries,
+		context:       context.Background(),
+	}
+}
+
+// DownloadWithLimit downloads the file at the given URL into memory. If the
+// file is larger than max bytes, then ErrTooLarge is returned.
+func (c *Client) DownloadWithLimit(url string, max int64) ([]byte, error) {
+	r, err := c.Request("GET", url)
+	if err != nil {
+		return nil, err
+	}
+
+	body := r.GetBody()
+	defer body.Close()
+
+	// Fail early if the server already tells us that the file is too large.
+	if length := r.GetHeader().Get("Content-Length"); length != "" {
+		n, err := strconv.ParseInt(length, 10, 64)
+		if err == nil && n > max {
+			return nil, ErrTooLarge
+		}
+	}
+
+	// Read one more byte than the limit so we can tell if the body has been
+	// truncated.
+	b, err := io.ReadAll(io.LimitReader(body, max+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read body: %w", err)
+	}
+
+	if int64(len(b)) > max {
+		return nil, ErrTooLarge
+	}
+
+	return b, nil
+}
+
+// DownloadToFile downloads the file at the given URL and writes it to the
+// given path. The file is first written into a temporary file in the same
+// directory and then renamed, so the path will never contain a partially
+// downloaded file.
+func (c *Client) DownloadToFile(url, path string) error {
+	r, err := c.Request("GET", url)
+	if err != nil {
+		return err
+	}
+
+	body := r.GetBody()
+	defer body.Close()
+
+	f, err := os.CreateTemp(filepath.Dir(path), ".download-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	defer os.Remove(f.Name())
+
+	if _, err := io.Copy(f, body); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to close file: %w", err)
+	}
+
+	if err := os.Rename(f.Name(), path); err != nil {
+		return fmt.Errorf("failed to rename file: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,70 @@
+package httputil
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDownload(t *testing.T) {
+	const content = "hello, world"
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if auth := r.Header.Get("Authorization"); auth != "" {
+			t.Errorf("unexpected authorization header %q", auth)
+		}
+		w.Write([]byte(content))
+	}))
+	defer srv.Close()
+
+	client := NewCDNClient()
+
+	t.Run("limit", func(t *testing.T) {
+		b, err := client.DownloadWithLimit(srv.URL, int64(len(content)))
+		if err != nil {
+			t.Fatal("failed to download:", err)
+		}
+		if string(b) != content {
+			t.Fatalf("unexpected content %q", b)
+		}
+
+		_, err = client.DownloadWithLimit(srv.URL, int64(len(content)-1))
+		if !errors.Is(err, ErrTooLarge) {
+			t.Fatal("expected ErrTooLarge, got", err)
+		}
+	})
+
+	t.Run("file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "file.txt")
+
+		if err := client.DownloadToFile(srv.URL, path); err != nil {
+			t.Fatal("failed to download:", err)
+		}
+
+		b, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal("failed to read file:", err)
+		}
+		if string(b) != content {
+			t.Fatalf("unexpected content %q", b)
+		}
+	})
+
+	t.Run("file error", func(t *testing.T) {
+		dir := t.TempDir()
+		path := filepath.Join(dir, "file.txt")
+
+		err := client.DownloadToFile(srv.URL+"/", filepath.Join(path, "nested"))
+		if err == nil || !strings.Contains(err.Error(), "temporary file") {
+			t.Fatal("expected temporary file error, got", err)
+		}
+
+		if _, err := os.Stat(path); !os.IsNotExist(err) {
+			t.Fatal("unexpected file left behind:", err)
+		}
+	})
+}
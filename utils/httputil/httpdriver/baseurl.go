@@ -0,0 +1,37 @@
+package httpdriver
+
+import (
+	"context"
+	"strings"
+)
+
+// BaseURLClient wraps around a Client and rewrites the base URL of all
+// requests. It is useful for routing requests through an API proxy.
+type BaseURLClient struct {
+	Client
+	// Old is the base URL to be replaced, e.g. "https://discord.com".
+	Old string
+	// New is the base URL to replace Old with, e.g. "http://localhost:8080".
+	New string
+}
+
+var _ Client = (*BaseURLClient)(nil)
+
+// WithBaseURL wraps the given client so that every request URL starting with
+// old has that prefix replaced by new. URLs not starting with old are left
+// untouched.
+func WithBaseURL(client Client, old, new string) *BaseURLClient {
+	return &BaseURLClient{
+		Client: client,
+		Old:    strings.TrimSuffix(old, "/"),
+		New:    strings.TrimSuffix(new, "/"),
+	}
+}
+
+// NewRequest creates a new request with the base URL rewritten.
+func (c *BaseURLClient) NewRequest(ctx context.Context, method, url string) (Request, error) {
+	if strings.HasPrefix(url, c.Old) {
+		url = c.New + strings.TrimPrefix(url, c.Old)
+	}
+	return c.Client.NewRequest(ctx, method, url)
+}
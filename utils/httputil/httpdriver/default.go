@@ -51,12 +51,16 @@ func (d DefaultClient) Do(req Request) (Response, error) {
 // interface.
 type DefaultRequest http.Request
 
-var _ Request = (*DefaultRequest)(nil)
+var _ HostRequest = (*DefaultRequest)(nil)
 
 func (r *DefaultRequest) GetPath() string {
 	return r.URL.Path
 }
 
+func (r *DefaultRequest) GetHost() string {
+	return r.URL.Host
+}
+
 func (r *DefaultRequest) GetContext() context.Context {
 	return (*http.Request)(r).Context()
 }
@@ -37,6 +37,23 @@ type Request interface {
 	WithBody(io.ReadCloser)
 }
 
+// HostRequest is an optional interface that a Request may implement to expose
+// the host that it will be sent to.
+type HostRequest interface {
+	Request
+	// GetHost returns the URL host, for example "discord.com".
+	GetHost() string
+}
+
+// RequestHost returns the host of the given request if it implements
+// HostRequest. An empty string is returned otherwise.
+func RequestHost(r Request) string {
+	if hr, ok := r.(HostRequest); ok {
+		return hr.GetHost()
+	}
+	return ""
+}
+
 // Response is returned from (Requester).DoContext().
 type Response interface {
 	GetStatus() int
@@ -74,6 +74,10 @@ func (r *MockRequest) GetPath() string {
 	return r.URL.Path
 }
 
+func (r *MockRequest) GetHost() string {
+	return r.URL.Host
+}
+
 func (r *MockRequest) GetContext() context.Context {
 	return r.ctx
 }
@@ -24,6 +24,18 @@ var ZeroInt = NewInt(0)
 // NewInt creates a new Int using the value of the passed int.
 func NewInt(i int) Int { return &i }
 
+// ================================ Int64 ================================
+
+// Int64 is the option type for 64-bit integers (int64). Unlike Int, it can
+// hold values beyond 32 bits on every platform.
+type Int64 *int64
+
+// ZeroInt64 is an Int64 with 0 as value.
+var ZeroInt64 = NewInt64(0)
+
+// NewInt64 creates a new Int64 using the value of the passed int64.
+func NewInt64(i int64) Int64 { return &i }
+
 // ================================ Float ================================
 
 // Float is the option type for floating-point numbers (float64).
@@ -3,4 +3,7 @@
 // assume a nil value, which is considered as omitted by encoding/json.
 // To generate pointerrized primitives, there are helper functions NewT() for
 // each option type.
+//
+// From Go 1.18, the generic Optional type is also available, which works like
+// the Nullable types for any type.
 package option
@@ -0,0 +1,67 @@
+//go:build go1.18
+// +build go1.18
+
+package option
+
+import "github.com/diamondburned/arikawa/v3/utils/json"
+
+// ================================ Optional ================================
+
+// Optional is the generic option type that can be absent, null, or a value. It
+// works like the Nullable types: fields should be of type *Optional[T] with the
+// omitempty option, so that a nil pointer is omitted, an Optional without a
+// value is encoded as null, and an Optional with a value is encoded as that
+// value.
+//
+//	type Data struct {
+//		Name *option.Optional[string] `json:"name,omitempty"`
+//	}
+//
+//	Data{}                                  // {}
+//	Data{Name: option.Null[string]()}       // {"name":null}
+//	Data{Name: option.Some("arikawa")}      // {"name":"arikawa"}
+type Optional[T any] struct {
+	Val  T
+	Init bool
+}
+
+// Some creates a new Optional with the given value.
+func Some[T any](v T) *Optional[T] {
+	return &Optional[T]{Val: v, Init: true}
+}
+
+// Null creates a new Optional that serializes to JSON null.
+func Null[T any]() *Optional[T] {
+	return &Optional[T]{}
+}
+
+// Get returns the value and true if o has a value. Otherwise, the zero value
+// and false are returned. It is safe to call on a nil Optional.
+func (o *Optional[T]) Get() (T, bool) {
+	if o == nil || !o.Init {
+		var zero T
+		return zero, false
+	}
+	return o.Val, true
+}
+
+func (o Optional[T]) MarshalJSON() ([]byte, error) {
+	if !o.Init {
+		return []byte("null"), nil
+	}
+	return json.Marshal(o.Val)
+}
+
+func (o *Optional[T]) UnmarshalJSON(b []byte) error {
+	if string(b) == "null" {
+		*o = Optional[T]{}
+		return nil
+	}
+
+	if err := json.Unmarshal(b, &o.Val); err != nil {
+		return err
+	}
+
+	o.Init = true
+	return nil
+}
@@ -0,0 +1,46 @@
+//go:build go1.18
+// +build go1.18
+
+package option
+
+import (
+	"testing"
+
+	"github.com/diamondburned/arikawa/v3/utils/json"
+)
+
+func TestOptional(t *testing.T) {
+	type data struct {
+		A *Optional[string] `json:"a,omitempty"`
+		B *Optional[string] `json:"b,omitempty"`
+		C *Optional[int]    `json:"c,omitempty"`
+	}
+
+	b, err := json.Marshal(data{A: Some("hi"), B: Null[string]()})
+	if err != nil {
+		t.Fatal("failed to marshal:", err)
+	}
+	if string(b) != `{"a":"hi","b":null}` {
+		t.Fatalf("unexpected JSON %s", b)
+	}
+
+	var o Optional[int]
+	if err := json.Unmarshal([]byte(`5`), &o); err != nil {
+		t.Fatal("failed to unmarshal:", err)
+	}
+	if v, ok := o.Get(); !ok || v != 5 {
+		t.Errorf("unexpected value %v (%v)", v, ok)
+	}
+
+	if err := json.Unmarshal([]byte(`null`), &o); err != nil {
+		t.Fatal("failed to unmarshal null:", err)
+	}
+	if _, ok := o.Get(); ok {
+		t.Error("expected null to have no value")
+	}
+
+	var absent *Optional[int]
+	if _, ok := absent.Get(); ok {
+		t.Error("expected nil Optional to have no value")
+	}
+}
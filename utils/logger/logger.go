@@ -0,0 +1,79 @@
+// Package logger provides the structured logging interface used by the
+// gateway, websocket, voice and HTTP packages.
+package logger
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Logger is a structured logger. Each method takes a message and optional
+// fields given as alternating keys and values, similarly to log/slog:
+//
+//	l.Debug("sending command", "op", op.Code, "type", op.Type)
+//
+// Implementations must be thread-safe.
+type Logger interface {
+	Debug(msg string, fields ...interface{})
+	Info(msg string, fields ...interface{})
+	Warn(msg string, fields ...interface{})
+	Error(msg string, fields ...interface{})
+}
+
+// Nop is a Logger that discards everything.
+var Nop Logger = nopLogger{}
+
+type nopLogger struct{}
+
+func (nopLogger) Debug(string, ...interface{}) {}
+func (nopLogger) Info(string, ...interface{})  {}
+func (nopLogger) Warn(string, ...interface{})  {}
+func (nopLogger) Error(string, ...interface{}) {}
+
+// Or returns l if it's not nil, otherwise Nop.
+func Or(l Logger) Logger {
+	if l == nil {
+		return Nop
+	}
+	return l
+}
+
+// Println creates a Logger that formats each entry into a single line and
+// passes it to the given function, which would usually be log.Println. Each
+// line is prefixed with the level, followed by the message and then the fields
+// as key=value pairs.
+func Println(println func(v ...interface{})) Logger {
+	return printlnLogger(println)
+}
+
+type printlnLogger func(v ...interface{})
+
+func (l printlnLogger) Debug(msg string, fields ...interface{}) { l.log("DEBUG", msg, fields) }
+func (l printlnLogger) Info(msg string, fields ...interface{})  { l.log("INFO", msg, fields) }
+func (l printlnLogger) Warn(msg string, fields ...interface{})  { l.log("WARN", msg, fields) }
+func (l printlnLogger) Error(msg string, fields ...interface{}) { l.log("ERROR", msg, fields) }
+
+func (l printlnLogger) log(level, msg string, fields []interface{}) {
+	l(level + " " + Format(msg, fields...))
+}
+
+// Format formats the message and fields into a single line. The fields are
+// appended as key=value pairs. A lone trailing value is given the key
+// "!BADKEY", like log/slog does.
+func Format(msg string, fields ...interface{}) string {
+	var b strings.Builder
+	b.WriteString(msg)
+
+	for i := 0; i < len(fields); i += 2 {
+		b.WriteByte(' ')
+
+		if i+1 == len(fields) {
+			fmt.Fprintf(&b, "!BADKEY=%v", fields[i])
+			break
+		}
+
+		fmt.Fprintf(&b, "%v=%v", fields[i], fields[i+1])
+	}
+
+	return b.String()
+}
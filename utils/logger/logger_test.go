@@ -0,0 +1,31 @@
+package logger
+
+import "testing"
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		msg    string
+		fields []interface{}
+		expect string
+	}{
+		{"hello", nil, "hello"},
+		{"sending command", []interface{}{"op", 2, "type", "IDENTIFY"}, "sending command op=2 type=IDENTIFY"},
+		{"odd", []interface{}{"a", 1, "b"}, "odd a=1 !BADKEY=b"},
+	}
+
+	for _, test := range tests {
+		if got := Format(test.msg, test.fields...); got != test.expect {
+			t.Errorf("expected %q, got %q", test.expect, got)
+		}
+	}
+}
+
+func TestPrintln(t *testing.T) {
+	var got string
+	l := Println(func(v ...interface{}) { got = v[0].(string) })
+
+	l.Warn("reconnecting", "try", 1)
+	if got != "WARN reconnecting try=1" {
+		t.Fatalf("unexpected line %q", got)
+	}
+}
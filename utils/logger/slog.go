@@ -0,0 +1,38 @@
+//go:build go1.21
+// +build go1.21
+
+package logger
+
+import (
+	"context"
+	"log/slog"
+)
+
+// Slog creates a Logger that writes to the given slog.Logger. If l is nil,
+// then slog.Default() is used.
+func Slog(l *slog.Logger) Logger {
+	if l == nil {
+		l = slog.Default()
+	}
+	return slogLogger{l}
+}
+
+type slogLogger struct {
+	l *slog.Logger
+}
+
+func (l slogLogger) Debug(msg string, fields ...interface{}) {
+	l.l.Log(context.Background(), slog.LevelDebug, msg, fields...)
+}
+
+func (l slogLogger) Info(msg string, fields ...interface{}) {
+	l.l.Log(context.Background(), slog.LevelInfo, msg, fields...)
+}
+
+func (l slogLogger) Warn(msg string, fields ...interface{}) {
+	l.l.Log(context.Background(), slog.LevelWarn, msg, fields...)
+}
+
+func (l slogLogger) Error(msg string, fields ...interface{}) {
+	l.l.Log(context.Background(), slog.LevelError, msg, fields...)
+}
@@ -9,12 +9,58 @@ import (
 	"github.com/diamondburned/arikawa/v3/utils/json"
 )
 
+// Encoding is the format that payloads are serialized in over the Websocket.
+type Encoding interface {
+	// Name returns the name of the encoding as used in the gateway URL's
+	// encoding parameter.
+	Name() string
+	// Binary returns true if payloads are sent as binary messages instead of
+	// text messages.
+	Binary() bool
+	// Marshal encodes v into a payload.
+	Marshal(v interface{}) ([]byte, error)
+	// DecodeStream decodes a payload from r into v. v is always a type that
+	// can be unmarshaled from JSON.
+	DecodeStream(r io.Reader, v interface{}) error
+}
+
+// JSONEncoding is the default Encoding. It uses the json package.
+var JSONEncoding Encoding = jsonEncoding{}
+
+type jsonEncoding struct{}
+
+func (jsonEncoding) Name() string { return "json" }
+func (jsonEncoding) Binary() bool { return false }
+
+func (jsonEncoding) Marshal(v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (jsonEncoding) DecodeStream(r io.Reader, v interface{}) error {
+	return json.DecodeStream(r, v)
+}
+
+// EncodingByName returns the Encoding with the given name, which is either
+// "json" or "etf". It returns nil if the name is unknown.
+func EncodingByName(name string) Encoding {
+	switch name {
+	case JSONEncoding.Name():
+		return JSONEncoding
+	case ETFEncoding.Name():
+		return ETFEncoding
+	default:
+		return nil
+	}
+}
+
 // Codec holds the codec states for Websocket implementations to share with the
 // manager. It is used internally in the Websocket and the Connection
 // implementation.
 type Codec struct {
 	Unmarshalers OpUnmarshalers
 	Headers      http.Header
+	// Encoding is the payload encoding. If nil, JSONEncoding is used.
+	Encoding Encoding
 }
 
 // NewCodec creates a new default Codec instance.
@@ -24,7 +70,15 @@ func NewCodec(unmarshalers OpUnmarshalers) Codec {
 		Headers: http.Header{
 			"Accept-Encoding": {"zlib"},
 		},
+		Encoding: JSONEncoding,
+	}
+}
+
+func (c Codec) encoding() Encoding {
+	if c.Encoding == nil {
+		return JSONEncoding
 	}
+	return c.Encoding
 }
 
 type codecOp struct {
@@ -58,8 +112,8 @@ func (c Codec) DecodeInto(ctx context.Context, r io.Reader, buf *DecodeBuffer, o
 	var op codecOp
 	op.Data = json.Raw(buf.buf)
 
-	if err := json.DecodeStream(r, &op); err != nil {
-		return c.send(ctx, out, newErrOp(err, "cannot read JSON stream"))
+	if err := c.encoding().DecodeStream(r, &op); err != nil {
+		return c.send(ctx, out, newErrOp(err, "cannot read payload stream"))
 	}
 
 	if EnableRawEvents {
@@ -90,7 +144,7 @@ func (c Codec) DecodeInto(ctx context.Context, r io.Reader, buf *DecodeBuffer, o
 
 	op.Op.Data = fn()
 	if err := op.Data.UnmarshalTo(op.Op.Data); err != nil {
-		return c.send(ctx, out, newErrOp(err, "cannot unmarshal JSON data from gateway"))
+		return c.send(ctx, out, newBrokenEventOp(op, err))
 	}
 
 	return c.send(ctx, out, op.Op)
@@ -120,3 +174,94 @@ func newErrOp(err error, wrap string) Op {
 		Data: ev,
 	}
 }
+
+// IncludeBrokenEventPayload, if true, will cause each BrokenEventError to carry
+// a copy of the payload that failed to unmarshal, truncated to
+// BrokenEventPayloadLimit bytes. It should only be used for debugging, since
+// payloads may contain private data.
+var IncludeBrokenEventPayload = false
+
+// BrokenEventPayloadLimit is the maximum number of payload bytes kept in a
+// BrokenEventError if IncludeBrokenEventPayload is true.
+var BrokenEventPayloadLimit = 1024
+
+// BrokenEventError is sent in place of an event whose payload cannot be
+// unmarshaled. It is both an Event and an error, so it can be handled like any
+// other event.
+type BrokenEventError struct {
+	// Err is the unmarshaling error.
+	Err error
+	// Code is the Op code of the broken event.
+	Code OpCode
+	// Type is the type of the broken event, if it is a dispatch event.
+	Type EventType
+	// Sequence is the sequence number of the broken event, if it is a
+	// dispatch event.
+	Sequence int64
+	// Payload is the truncated payload of the event. It is only set if
+	// IncludeBrokenEventPayload is true.
+	Payload []byte
+	// Truncated is true if Payload was truncated.
+	Truncated bool
+}
+
+var _ Event = (*BrokenEventError)(nil)
+
+func newBrokenEventOp(op codecOp, err error) Op {
+	ev := &BrokenEventError{
+		Err:      err,
+		Code:     op.Code,
+		Type:     op.Type,
+		Sequence: op.Sequence,
+	}
+
+	if IncludeBrokenEventPayload {
+		payload := op.Data
+		if len(payload) > BrokenEventPayloadLimit {
+			payload = payload[:BrokenEventPayloadLimit]
+			ev.Truncated = true
+		}
+		// Copy the payload, since its buffer is reused.
+		ev.Payload = append([]byte(nil), payload...)
+	}
+
+	return Op{
+		Code:     ev.Op(),
+		Type:     ev.EventType(),
+		Sequence: op.Sequence,
+		Data:     ev,
+	}
+}
+
+// Unwrap returns err.Err.
+func (err *BrokenEventError) Unwrap() error { return err.Err }
+
+// Error formats the BrokenEventError. The payload is included if there is
+// one.
+func (err *BrokenEventError) Error() string {
+	var msg string
+	if err.Type != "" {
+		msg = fmt.Sprintf("cannot unmarshal event %s (op %d, seq %d): %v",
+			err.Type, err.Code, err.Sequence, err.Err)
+	} else {
+		msg = fmt.Sprintf("cannot unmarshal op %d: %v", err.Code, err.Err)
+	}
+
+	if err.Payload != nil {
+		msg += fmt.Sprintf(" (payload: %s", err.Payload)
+		if err.Truncated {
+			msg += "..."
+		}
+		msg += ")"
+	}
+
+	return msg
+}
+
+// Op implements Event. It returns -1.
+func (err *BrokenEventError) Op() OpCode { return -1 }
+
+// EventType implements Event. It returns an opaque unique string.
+func (err *BrokenEventError) EventType() EventType {
+	return "__ws.BrokenEventError"
+}
@@ -1,6 +1,7 @@
 package ws
 
 import (
+	"bufio"
 	"compress/zlib"
 	"context"
 	"errors"
@@ -11,10 +12,16 @@ import (
 	"time"
 
 	"github.com/gorilla/websocket"
+
+	"github.com/diamondburned/arikawa/v3/utils/logger"
 )
 
 const rwBufferSize = 1 << 15 // 32KB
 
+// zlibHeader is the first byte of a zlib stream using deflate with the default
+// window size.
+const zlibHeader = 0x78
+
 // ErrWebsocketClosed is returned if the websocket is already closed.
 var ErrWebsocketClosed = errors.New("websocket is closed")
 
@@ -47,8 +54,9 @@ type Conn struct {
 	// conn is used for synchronizing the conn instance itself. Any use of conn
 	// must copy conn out.
 	conn *connMutex
-	// mut is used for synchronizing the conn field.
-	mut sync.Mutex
+	// mut is used for synchronizing the conn and logger fields.
+	mut    sync.Mutex
+	logger logger.Logger
 
 	// CloseTimeout is the timeout for graceful closing. It's defaulted to 5s.
 	CloseTimeout time.Duration
@@ -58,6 +66,7 @@ type connMutex struct {
 	*websocket.Conn
 	wrmut  chan struct{}
 	cancel context.CancelFunc
+	logger logger.Logger
 }
 
 var _ Connection = (*Conn)(nil)
@@ -79,10 +88,24 @@ func NewConnWithDialer(codec Codec, dialer websocket.Dialer) *Conn {
 	return &Conn{
 		dialer:       dialer,
 		codec:        codec,
+		logger:       DefaultLogger,
 		CloseTimeout: 5 * time.Second,
 	}
 }
 
+// Encoding returns the payload encoding of the connection.
+func (c *Conn) Encoding() Encoding {
+	return c.codec.encoding()
+}
+
+// SetLogger sets the logger of the connection. It takes effect on the next
+// Dial. If l is nil, then DefaultLogger is used.
+func (c *Conn) SetLogger(l logger.Logger) {
+	c.mut.Lock()
+	c.logger = loggerOrDefault(l)
+	c.mut.Unlock()
+}
+
 // Dial starts a new connection and returns the listening channel for it. If the
 // websocket is already dialed, then the connection is closed first.
 func (c *Conn) Dial(ctx context.Context, addr string) (<-chan Op, error) {
@@ -105,12 +128,13 @@ func (c *Conn) Dial(ctx context.Context, addr string) (<-chan Op, error) {
 	ctx, cancel := context.WithCancel(context.Background())
 
 	events := make(chan Op, 1)
-	go readLoop(ctx, conn, c.codec, events)
+	go readLoop(ctx, conn, c.codec, c.logger, events)
 
 	c.conn = &connMutex{
 		wrmut:  make(chan struct{}, 1),
 		Conn:   conn,
 		cancel: cancel,
+		logger: c.logger,
 	}
 
 	return events, err
@@ -126,11 +150,10 @@ func (c *Conn) Close(gracefully bool) error {
 
 func (c *connMutex) close(timeout time.Duration, gracefully bool) error {
 	if c == nil || c.Conn == nil {
-		WSDebug("Conn: Close is called on already closed connection")
 		return ErrWebsocketClosed
 	}
 
-	WSDebug("Conn: Close is called; shutting down the Websocket connection.")
+	c.logger.Debug("shutting down the websocket connection")
 
 	if gracefully {
 		// Have a deadline before closing.
@@ -144,13 +167,13 @@ func (c *connMutex) close(timeout time.Duration, gracefully bool) error {
 			// Lock acquired. We can now safely set the deadline and write.
 			c.SetWriteDeadline(deadline)
 
-			WSDebug("Conn: Graceful closing requested, sending close frame.")
+			c.logger.Debug("graceful closing requested, sending close frame")
 
 			if err := c.WriteMessage(
 				websocket.CloseMessage,
 				websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""),
 			); err != nil {
-				WSError(err)
+				c.logger.Error("failed to send close frame", "err", err)
 			}
 
 			// Release the lock.
@@ -166,9 +189,9 @@ func (c *connMutex) close(timeout time.Duration, gracefully bool) error {
 	err := c.Conn.Close()
 
 	if err != nil {
-		WSDebug("Conn: Websocket closed; error:", err)
+		c.logger.Debug("websocket closed with error", "err", err)
 	} else {
-		WSDebug("Conn: Websocket closed successfully")
+		c.logger.Debug("websocket closed successfully")
 	}
 
 	c.Conn = nil
@@ -204,7 +227,12 @@ func (c *Conn) Send(ctx context.Context, b []byte) error {
 			}
 		}
 
-		return conn.WriteMessage(websocket.TextMessage, b)
+		msgType := websocket.TextMessage
+		if c.codec.encoding().Binary() {
+			msgType = websocket.BinaryMessage
+		}
+
+		return conn.WriteMessage(msgType, b)
 	case <-ctx.Done():
 		return ctx.Err()
 	}
@@ -217,10 +245,11 @@ type loopState struct {
 	conn  *websocket.Conn
 	codec Codec
 	zlib  io.ReadCloser
+	peek  *bufio.Reader
 	buf   DecodeBuffer
 }
 
-func readLoop(ctx context.Context, conn *websocket.Conn, codec Codec, opCh chan<- Op) {
+func readLoop(ctx context.Context, conn *websocket.Conn, codec Codec, logger logger.Logger, opCh chan<- Op) {
 	// Clean up the events channel in the end.
 	defer close(opCh)
 
@@ -233,7 +262,7 @@ func readLoop(ctx context.Context, conn *websocket.Conn, codec Codec, opCh chan<
 
 	for {
 		if err := state.handle(ctx, opCh); err != nil {
-			WSDebug("Conn: fatal Conn error:", err)
+			logger.Debug("fatal connection error", "err", err)
 
 			closeEv := &CloseEvent{
 				Err:  err,
@@ -264,6 +293,21 @@ func (state *loopState) handle(ctx context.Context, opCh chan<- Op) error {
 		return err
 	}
 
+	if t == websocket.BinaryMessage && state.codec.encoding().Binary() {
+		// Binary encodings use binary messages for uncompressed payloads too,
+		// so only treat the message as zlib if it has a zlib header.
+		if state.peek == nil {
+			state.peek = bufio.NewReaderSize(r, 16)
+		} else {
+			state.peek.Reset(r)
+		}
+		r = state.peek
+
+		if b, err := state.peek.Peek(1); err != nil || b[0] != zlibHeader {
+			t = websocket.TextMessage
+		}
+	}
+
 	if t == websocket.BinaryMessage {
 		// Probably a zlib payload.
 
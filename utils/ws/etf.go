@@ -0,0 +1,62 @@
+package ws
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/diamondburned/arikawa/v3/utils/etf"
+	"github.com/diamondburned/arikawa/v3/utils/json"
+)
+
+// ETFEncoding is the Encoding for Erlang's External Term Format. Payloads are
+// transcoded from and to JSON, so every type that works with JSONEncoding also
+// works with it. Its main benefit is the smaller size of payloads received.
+var ETFEncoding Encoding = etfEncoding{}
+
+type etfEncoding struct{}
+
+func (etfEncoding) Name() string { return "etf" }
+func (etfEncoding) Binary() bool { return true }
+
+func (etfEncoding) Marshal(v interface{}) ([]byte, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return etf.FromJSON(nil, b)
+}
+
+type etfBuffers struct {
+	term bytes.Buffer
+	json []byte
+}
+
+var etfBufferPool = sync.Pool{
+	New: func() interface{} { return &etfBuffers{} },
+}
+
+func (etfEncoding) DecodeStream(r io.Reader, v interface{}) error {
+	bufs := etfBufferPool.Get().(*etfBuffers)
+	defer func() {
+		// Don't keep the buffers of huge payloads, such as large guilds, around.
+		if bufs.term.Cap() <= maxSharedBufferSize && cap(bufs.json) <= maxSharedBufferSize {
+			etfBufferPool.Put(bufs)
+		}
+	}()
+
+	bufs.term.Reset()
+	if _, err := bufs.term.ReadFrom(r); err != nil {
+		return err
+	}
+
+	var err error
+
+	bufs.json, err = etf.ToJSON(bufs.json[:0], bufs.term.Bytes())
+	if err != nil {
+		return fmt.Errorf("cannot transcode ETF: %w", err)
+	}
+
+	return json.Unmarshal(bufs.json, v)
+}
@@ -8,7 +8,7 @@ import (
 	"time"
 
 	"github.com/diamondburned/arikawa/v3/internal/lazytime"
-	"github.com/diamondburned/arikawa/v3/utils/json"
+	"github.com/diamondburned/arikawa/v3/utils/logger"
 )
 
 // ConnectionError is given to the user if the gateway fails to connect to the
@@ -72,10 +72,25 @@ type GatewayOpts struct {
 	// will be made. Default is 0.
 	ReconnectAttempt int
 
+	// OnReconnect, if not nil, is called by the event loop before it
+	// reconnects on its own, such as when the connection is closed or when the
+	// server asks for a reconnection. It is not called for the initial
+	// connection nor between failed dials, which use ReconnectDelay instead.
+	// err is the error that caused the reconnection; it is nil if the server
+	// asked for it.
+	//
+	// The event loop waits for the returned duration before dialing. If an
+	// error is returned, then the gateway exits with it instead.
+	OnReconnect func(err error) (time.Duration, error)
+
 	// AlwaysCloseGracefully, if true, will always make the Gateway close
 	// gracefully once the context given to Open is cancelled. It governs the
 	// Close behavior. The default is true.
 	AlwaysCloseGracefully bool
+
+	// Logger is the logger used by the gateway and its websocket. If nil,
+	// DefaultLogger is used.
+	Logger logger.Logger
 }
 
 // DefaultGatewayOpts is the default event loop options.
@@ -117,6 +132,8 @@ type Gateway struct {
 	srcOp     <-chan Op // from WS
 	outer     outerState
 	lastError error
+	// reconnectErr is the last error at the time QueueReconnect was called.
+	reconnectErr error
 
 	opts GatewayOpts
 }
@@ -153,6 +170,10 @@ func NewGateway(ws *Websocket, opts *GatewayOpts) *Gateway {
 		opts = &DefaultGatewayOpts
 	}
 
+	if opts.Logger != nil {
+		ws.SetLogger(opts.Logger)
+	}
+
 	return &Gateway{
 		ws:   ws,
 		opts: *opts,
@@ -166,6 +187,10 @@ func (g *Gateway) Opts() *GatewayOpts {
 	return &cpy
 }
 
+func (g *Gateway) logger() logger.Logger {
+	return loggerOrDefault(g.opts.Logger)
+}
+
 // Send is a function to send an Op payload to the Gateway.
 func (g *Gateway) Send(ctx context.Context, data Event) error {
 	op := Op{
@@ -174,9 +199,9 @@ func (g *Gateway) Send(ctx context.Context, data Event) error {
 		Data: data,
 	}
 
-	WSDebug("sending command Op", op.Code, "type", op.Type)
+	g.logger().Debug("sending command", "op", op.Code, "type", op.Type)
 
-	b, err := json.Marshal(op)
+	b, err := g.ws.Encoding().Marshal(op)
 	if err != nil {
 		return fmt.Errorf("failed to encode payload: %w", err)
 	}
@@ -258,7 +283,9 @@ func (g *Gateway) finalize(h Handler) {
 		err = g.ws.Close()
 	}
 
-	if err != nil {
+	// The websocket is already closed if it never connected, so ignore that,
+	// lest the error that made the gateway give up be overridden.
+	if err != nil && !errors.Is(err, ErrWebsocketClosed) {
 		g.SendErrorWrap(err, "failed to finalize websocket")
 	}
 
@@ -278,6 +305,7 @@ func (g *Gateway) finalize(h Handler) {
 func (g *Gateway) QueueReconnect() {
 	select {
 	case g.reconnect <- struct{}{}:
+		g.reconnectErr = g.lastError
 	default:
 	}
 
@@ -289,6 +317,17 @@ func (g *Gateway) ResetHeartbeat(d time.Duration) {
 	g.heart.Reset(d)
 }
 
+// SendEvent sends the given event into the event channel. It is used for
+// events that are synthesized by the Handler rather than received from the
+// websocket, and must only be called from within the Handler's OnOp.
+func (g *Gateway) SendEvent(ev Event) {
+	g.outer.ch <- Op{
+		Code: ev.Op(),
+		Type: ev.EventType(),
+		Data: ev,
+	}
+}
+
 // SendError sends the given error wrapped in a BackgroundErrorEvent into the
 // event channel.
 func (g *Gateway) SendError(err error) {
@@ -317,6 +356,9 @@ func (g *Gateway) spin(ctx context.Context, h Handler) {
 	g.reconnect = make(chan struct{}, 1)
 	g.reconnect <- struct{}{}
 
+	// initial is true until the first connection is attempted.
+	initial := true
+
 	for {
 		select {
 		case <-ctx.Done():
@@ -331,12 +373,17 @@ func (g *Gateway) spin(ctx context.Context, h Handler) {
 			switch data := op.Data.(type) {
 			case *CloseEvent:
 				if g.opts.ErrorIsFatalClose(data) {
+					g.logger().Warn("gateway closed with fatal code", "code", data.Code)
 					// Don't wrap the error, but instead, just pipe it as-is
 					// through the channel.
 					g.outer.ch <- op
 					g.lastError = data
 					return
 				}
+
+				// Keep the close as the cause of the reconnection that the
+				// handler is about to queue.
+				g.lastError = data
 			}
 
 			ok = h.OnOp(ctx, op)
@@ -361,6 +408,25 @@ func (g *Gateway) spin(ctx context.Context, h Handler) {
 			// Invalidate our srcOp.
 			g.srcOp = nil
 
+			if !initial && g.opts.OnReconnect != nil {
+				delay, err := g.opts.OnReconnect(g.reconnectErr)
+				if err != nil {
+					g.SendError(ConnectionError{err})
+					return
+				}
+
+				if delay > 0 {
+					retryTimer.Reset(delay)
+					if err := retryTimer.Wait(ctx); err != nil {
+						g.SendError(ConnectionError{ctx.Err()})
+						return
+					}
+				}
+			}
+			initial = false
+
+			g.logger().Info("connecting to the gateway")
+
 			// Keep track of the last error for notifying.
 			var err error
 
@@ -379,6 +445,9 @@ func (g *Gateway) spin(ctx context.Context, h Handler) {
 				default:
 				}
 
+				g.logger().Warn("failed to connect to the gateway, retrying",
+					"try", try, "err", err)
+
 				// Signal an error before retrying.
 				g.SendError(ConnectionError{err})
 
@@ -4,27 +4,58 @@ package ws
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"sync"
 
 	"golang.org/x/time/rate"
+
+	"github.com/diamondburned/arikawa/v3/utils/logger"
 )
 
 var (
 	// WSError is the default error handler
+	//
+	// Deprecated: Use GatewayOpts.Logger or SetLogger instead. WSError is only
+	// called by DefaultLogger.
 	WSError = func(err error) { log.Println("Gateway error:", err) }
 	// WSDebug is used for extra debug logging. This is expected to behave
 	// similarly to log.Println().
+	//
+	// Deprecated: Use GatewayOpts.Logger or SetLogger instead. WSDebug is only
+	// called by DefaultLogger.
 	WSDebug = func(v ...interface{}) {}
 )
 
+// DefaultLogger is the Logger used when none is given. It forwards errors to
+// WSError and everything else to WSDebug for backwards compatibility.
+var DefaultLogger logger.Logger = legacyLogger{}
+
+type legacyLogger struct{}
+
+func (legacyLogger) Debug(msg string, fields ...interface{}) { WSDebug(logger.Format(msg, fields...)) }
+func (legacyLogger) Info(msg string, fields ...interface{})  { WSDebug(logger.Format(msg, fields...)) }
+func (legacyLogger) Warn(msg string, fields ...interface{})  { WSDebug(logger.Format(msg, fields...)) }
+
+func (legacyLogger) Error(msg string, fields ...interface{}) {
+	WSError(errors.New(logger.Format(msg, fields...)))
+}
+
+func loggerOrDefault(l logger.Logger) logger.Logger {
+	if l == nil {
+		return DefaultLogger
+	}
+	return l
+}
+
 // Websocket is a wrapper around a websocket Conn with thread safety and rate
 // limiting for sending and throttling.
 type Websocket struct {
 	mutex sync.Mutex
 	conn  Connection
 	addr  string
+	enc   Encoding
 
 	// If you ever need access to these fields from outside the package, please
 	// open an issue. It might be worth it to refactor these out for distributed
@@ -32,6 +63,8 @@ type Websocket struct {
 
 	sendLimiter *rate.Limiter
 	dialLimiter *rate.Limiter
+
+	logger logger.Logger
 }
 
 // NewWebsocket creates a default Websocket with the given address.
@@ -39,14 +72,49 @@ func NewWebsocket(c Codec, addr string) *Websocket {
 	return NewCustomWebsocket(NewConn(c), addr)
 }
 
-// NewCustomWebsocket creates a new undialed Websocket.
+// NewCustomWebsocket creates a new undialed Websocket. If conn has an
+// Encoding method, such as *Conn, then payloads are sent using that encoding.
+// Otherwise, JSONEncoding is used.
 func NewCustomWebsocket(conn Connection, addr string) *Websocket {
+	enc := JSONEncoding
+	if encoder, ok := conn.(interface{ Encoding() Encoding }); ok {
+		enc = encoder.Encoding()
+	}
+
 	return &Websocket{
 		conn: conn,
 		addr: addr,
+		enc:  enc,
 
 		sendLimiter: NewSendLimiter(),
 		dialLimiter: NewDialLimiter(),
+
+		logger: DefaultLogger,
+	}
+}
+
+// Encoding returns the encoding that payloads are sent in. A nil or zero-value
+// Websocket uses JSONEncoding.
+func (ws *Websocket) Encoding() Encoding {
+	if ws == nil || ws.enc == nil {
+		return JSONEncoding
+	}
+	return ws.enc
+}
+
+// SetLogger sets the logger of the Websocket. If the underlying connection has
+// a SetLogger method, such as *Conn, then it is also called. If l is nil, then
+// DefaultLogger is used.
+func (ws *Websocket) SetLogger(l logger.Logger) {
+	l = loggerOrDefault(l)
+
+	ws.mutex.Lock()
+	defer ws.mutex.Unlock()
+
+	ws.logger = l
+
+	if conn, ok := ws.conn.(interface{ SetLogger(logger.Logger) }); ok {
+		conn.SetLogger(l)
 	}
 }
 
@@ -70,21 +138,20 @@ func (ws *Websocket) Dial(ctx context.Context) (<-chan Op, error) {
 // Send sends b over the Websocket with a deadline. It closes the internal
 // Websocket if the Send method errors out.
 func (ws *Websocket) Send(ctx context.Context, b []byte) error {
-	WSDebug("Acquiring the websocket mutex for sending.")
-
 	ws.mutex.Lock()
 	sendLimiter := ws.sendLimiter
 	conn := ws.conn
+	logger := ws.logger
 	ws.mutex.Unlock()
 
-	WSDebug("Waiting for the send rate limiter...")
+	logger.Debug("waiting for the send rate limiter")
 
 	if err := sendLimiter.Wait(ctx); err != nil {
-		WSDebug("Send rate limiter timed out.")
+		logger.Debug("send rate limiter timed out", "err", err)
 		return fmt.Errorf("SendLimiter failed: %w", err)
 	}
 
-	WSDebug("Send has passed the rate limiting.")
+	logger.Debug("send has passed the rate limiting")
 
 	return conn.Send(ctx, b)
 }
@@ -93,12 +160,10 @@ func (ws *Websocket) Send(ctx context.Context, b []byte) error {
 // closed even when it returns an error. If the Websocket was already closed
 // before, ErrWebsocketClosed will be returned.
 func (ws *Websocket) Close() error {
-	WSDebug("Conn: Acquiring mutex lock to close...")
-
 	ws.mutex.Lock()
 	defer ws.mutex.Unlock()
 
-	WSDebug("Conn: Write mutex acquired")
+	ws.logger.Debug("closing websocket", "gracefully", false)
 
 	return ws.conn.Close(false)
 }
@@ -106,12 +171,10 @@ func (ws *Websocket) Close() error {
 // CloseGracefully is similar to Close, but a proper close frame is sent to
 // Discord, invalidating the internal session ID and voiding resumes.
 func (ws *Websocket) CloseGracefully() error {
-	WSDebug("Conn: Acquiring mutex lock to close...")
-
 	ws.mutex.Lock()
 	defer ws.mutex.Unlock()
 
-	WSDebug("Conn: Write mutex acquired")
+	ws.logger.Debug("closing websocket", "gracefully", true)
 
 	return ws.conn.Close(true)
 }
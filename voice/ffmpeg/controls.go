@@ -0,0 +1,138 @@
+package ffmpeg
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrNotSeekable is returned by Controls' Seek if the playback cannot be
+// seeked, which is the case for PlayReader.
+var ErrNotSeekable = errors.New("playback is not seekable")
+
+// Controls controls a playback started by PlayFile or PlayReader while it is
+// running. A zero-value Controls is ready to use, but it must only be used for
+// one playback at a time.
+type Controls struct {
+	mu       sync.Mutex
+	paused   bool
+	seekable bool
+	seek     *time.Duration
+	position time.Duration
+	notify   chan struct{}
+}
+
+// Pause pauses the playback. A few frames of silence are sent, and then
+// nothing is sent until Resume is called.
+func (c *Controls) Pause() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.paused = true
+}
+
+// Resume resumes the playback if it is paused.
+func (c *Controls) Resume() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.paused = false
+	c.signal()
+}
+
+// Paused returns true if the playback is paused.
+func (c *Controls) Paused() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.paused
+}
+
+// Seek makes the playback continue from the given position. It returns
+// ErrNotSeekable if the playback isn't of a file or hasn't started yet. Seeking
+// restarts ffmpeg, so it doesn't happen instantly.
+func (c *Controls) Seek(pos time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.seekable {
+		return ErrNotSeekable
+	}
+
+	if pos < 0 {
+		pos = 0
+	}
+
+	c.seek = &pos
+	c.signal()
+
+	return nil
+}
+
+// Position returns the position of the playback, which is the position it
+// started or was last seeked at plus the duration of the frames sent since.
+func (c *Controls) Position() time.Duration {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.position
+}
+
+// reset prepares the controls for a new playback.
+func (c *Controls) reset(seekable bool, pos time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.paused = false
+	c.seekable = seekable
+	c.seek = nil
+	c.position = pos
+}
+
+// state returns whether the playback is paused and takes the position to seek
+// to, if any.
+func (c *Controls) state() (paused bool, seek *time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	seek = c.seek
+	c.seek = nil
+
+	if seek != nil {
+		c.position = *seek
+	}
+
+	return c.paused, seek
+}
+
+// advance adds d to the position.
+func (c *Controls) advance(d time.Duration) {
+	c.mu.Lock()
+	c.position += d
+	c.mu.Unlock()
+}
+
+// changed returns a channel that receives a value when the playback is resumed
+// or seeked.
+func (c *Controls) changed() <-chan struct{} {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.notify == nil {
+		c.notify = make(chan struct{}, 1)
+	}
+
+	return c.notify
+}
+
+// signal wakes up the playback if it's waiting on changed. c.mu must be held.
+func (c *Controls) signal() {
+	if c.notify == nil {
+		c.notify = make(chan struct{}, 1)
+	}
+
+	select {
+	case c.notify <- struct{}{}:
+	default:
+	}
+}
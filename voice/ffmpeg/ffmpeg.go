@@ -0,0 +1,306 @@
+// Package ffmpeg plays audio files and streams into voice sessions. It uses the
+// ffmpeg binary to encode the audio into Opus, which is then sent using the
+// session's Stream method.
+//
+// Audio from tools such as yt-dlp can be played by giving their standard output
+// to PlayReader.
+package ffmpeg
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/voice"
+)
+
+// DefaultBinary is the path to the ffmpeg binary used if Options doesn't have
+// one. By default, ffmpeg is looked up in $PATH.
+var DefaultBinary = "ffmpeg"
+
+const (
+	// DefaultBitrate is the default Opus bitrate in kilobits per second.
+	DefaultBitrate = 96
+	// DefaultFrameDuration is the default Opus frame duration, which is also
+	// the default frequency of voice sessions.
+	DefaultFrameDuration = 20 * time.Millisecond
+)
+
+// Options are the options for PlayFile and PlayReader. The zero value is valid.
+type Options struct {
+	// Binary is the path to the ffmpeg binary. If it is empty, then
+	// DefaultBinary is used.
+	Binary string
+	// Bitrate is the Opus bitrate in kilobits per second. If it is 0, then
+	// DefaultBitrate is used.
+	Bitrate int
+	// FrameDuration is the duration of each Opus frame. It must match the
+	// frequency of the session's UDP connection, which is 20ms unless it is
+	// changed using udp.DialFuncWithFrequency. If it is 0, then
+	// DefaultFrameDuration is used.
+	FrameDuration time.Duration
+	// Start is the position to start playing from.
+	Start time.Duration
+
+	// InputArgs are extra arguments given to ffmpeg right before the input.
+	InputArgs []string
+	// OutputArgs are extra arguments given to ffmpeg right before the output.
+	OutputArgs []string
+
+	// Stderr, if not nil, receives the standard error of ffmpeg. Otherwise, it
+	// is included in the error returned if ffmpeg fails.
+	Stderr io.Writer
+	// Controls, if not nil, can be used to pause, resume and seek the playback
+	// while it is running.
+	Controls *Controls
+}
+
+func (o *Options) binary() string {
+	if o.Binary != "" {
+		return o.Binary
+	}
+	return DefaultBinary
+}
+
+func (o *Options) bitrate() int {
+	if o.Bitrate > 0 {
+		return o.Bitrate
+	}
+	return DefaultBitrate
+}
+
+func (o *Options) frameDuration() time.Duration {
+	if o.FrameDuration > 0 {
+		return o.FrameDuration
+	}
+	return DefaultFrameDuration
+}
+
+// args returns the arguments to ffmpeg to encode the given input starting at
+// pos.
+func (o *Options) args(input string, pos time.Duration) []string {
+	args := []string{
+		"-hide_banner", "-loglevel", "error",
+		// Streaming is slow, so a single thread is all we need.
+		"-threads", "1",
+	}
+
+	args = append(args, o.InputArgs...)
+	if pos > 0 {
+		args = append(args, "-ss", formatSeconds(pos))
+	}
+	args = append(args, "-i", input)
+
+	frameMs := float64(o.frameDuration()) / float64(time.Millisecond)
+
+	args = append(args,
+		"-c:a", "libopus",
+		"-ar", "48000",
+		"-ac", "2",
+		"-b:a", strconv.Itoa(o.bitrate())+"k",
+		"-frame_duration", strconv.FormatFloat(frameMs, 'f', -1, 64),
+		// Disable variable bitrate to keep packet sizes consistent.
+		"-vbr", "off",
+	)
+
+	args = append(args, o.OutputArgs...)
+	return append(args, "-f", "opus", "-")
+}
+
+func formatSeconds(d time.Duration) string {
+	return strconv.FormatFloat(d.Seconds(), 'f', -1, 64)
+}
+
+// PlayFile plays the audio file at the given path into the session, which must
+// have joined a channel already. It blocks until the file is played entirely.
+// The file can be anything that ffmpeg can read, including URLs. opts may be
+// nil.
+//
+// If ctx is canceled, then the session leaves the channel. See voice.Session's
+// Stream.
+func PlayFile(ctx context.Context, s *voice.Session, path string, opts *Options) error {
+	p, err := newPlayer(ctx, path, nil, opts)
+	if err != nil {
+		return err
+	}
+	defer p.stop()
+
+	return s.Stream(ctx, p)
+}
+
+// PlayReader is like PlayFile, but it plays the audio read from r, which is
+// given to ffmpeg's standard input. Playbacks of readers cannot be seeked.
+func PlayReader(ctx context.Context, s *voice.Session, r io.Reader, opts *Options) error {
+	p, err := newPlayer(ctx, "pipe:0", r, opts)
+	if err != nil {
+		return err
+	}
+	defer p.stop()
+
+	return s.Stream(ctx, p)
+}
+
+// player is a voice.AudioSource that reads Opus frames from ffmpeg.
+type player struct {
+	ctx   context.Context
+	opts  Options
+	input string
+	stdin io.Reader
+
+	controls *Controls
+	// silence is the number of silence frames sent since the playback was
+	// paused.
+	silence int
+
+	cmd    *exec.Cmd
+	cancel context.CancelFunc
+	stderr *bytes.Buffer
+	ogg    *oggReader
+}
+
+var _ voice.AudioSource = (*player)(nil)
+
+func newPlayer(ctx context.Context, input string, stdin io.Reader, opts *Options) (*player, error) {
+	p := &player{
+		ctx:   ctx,
+		input: input,
+		stdin: stdin,
+	}
+
+	if opts != nil {
+		p.opts = *opts
+	}
+
+	p.controls = p.opts.Controls
+	if p.controls == nil {
+		p.controls = &Controls{}
+	}
+	p.controls.reset(stdin == nil, p.opts.Start)
+
+	if err := p.start(p.opts.Start); err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// start starts ffmpeg at the given position.
+func (p *player) start(pos time.Duration) error {
+	ctx, cancel := context.WithCancel(p.ctx)
+
+	cmd := exec.CommandContext(ctx, p.opts.binary(), p.opts.args(p.input, pos)...)
+	cmd.Stdin = p.stdin
+
+	var stderr *bytes.Buffer
+	if p.opts.Stderr != nil {
+		cmd.Stderr = p.opts.Stderr
+	} else {
+		stderr = &bytes.Buffer{}
+		cmd.Stderr = stderr
+	}
+
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		cancel()
+		return fmt.Errorf("failed to get stdout pipe: %w", err)
+	}
+
+	if err := cmd.Start(); err != nil {
+		cancel()
+		return fmt.Errorf("failed to start ffmpeg: %w", err)
+	}
+
+	p.cmd = cmd
+	p.cancel = cancel
+	p.stderr = stderr
+	p.ogg = newOggReader(stdout)
+
+	return nil
+}
+
+// stop kills ffmpeg if it is still running.
+func (p *player) stop() {
+	if p.cmd == nil {
+		return
+	}
+
+	p.cancel()
+	p.cmd.Wait()
+	p.cmd = nil
+}
+
+// wait waits for ffmpeg to exit after its output is exhausted.
+func (p *player) wait() error {
+	err := p.cmd.Wait()
+	p.cancel()
+	p.cmd = nil
+
+	if err == nil {
+		return nil
+	}
+
+	if p.stderr != nil {
+		if msg := strings.TrimSpace(p.stderr.String()); msg != "" {
+			return fmt.Errorf("ffmpeg failed: %w: %s", err, msg)
+		}
+	}
+
+	return fmt.Errorf("ffmpeg failed: %w", err)
+}
+
+// NextFrame implements voice.AudioSource.
+func (p *player) NextFrame(ctx context.Context) ([]byte, error) {
+	for {
+		paused, seek := p.controls.state()
+
+		if seek != nil {
+			p.stop()
+			if err := p.start(*seek); err != nil {
+				return nil, err
+			}
+		}
+
+		if paused {
+			// Send some silence to avoid Opus interpolation before going
+			// quiet.
+			if p.silence < voice.SilenceFrames {
+				p.silence++
+				return voice.SilenceFrame, nil
+			}
+
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-p.controls.changed():
+				continue
+			}
+		}
+
+		p.silence = 0
+
+		if p.cmd == nil {
+			return nil, io.EOF
+		}
+
+		frame, err := p.ogg.nextPacket()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				if err := p.wait(); err != nil {
+					return nil, err
+				}
+				return nil, io.EOF
+			}
+
+			return nil, fmt.Errorf("failed to read ffmpeg output: %w", err)
+		}
+
+		p.controls.advance(p.opts.frameDuration())
+		return frame, nil
+	}
+}
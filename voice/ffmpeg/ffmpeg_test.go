@@ -0,0 +1,222 @@
+package ffmpeg
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/voice"
+)
+
+// oggPage encodes a single Ogg page containing the given segments.
+func oggPage(segments ...[]byte) []byte {
+	var page bytes.Buffer
+	page.WriteString("OggS")
+	page.Write(make([]byte, 22)) // version, flags, granule, serial, seq, crc
+	page.WriteByte(byte(len(segments)))
+	for _, seg := range segments {
+		page.WriteByte(byte(len(seg)))
+	}
+	for _, seg := range segments {
+		page.Write(seg)
+	}
+	return page.Bytes()
+}
+
+func testOggStream() (stream []byte, packets [][]byte) {
+	long := bytes.Repeat([]byte{'l'}, 300)
+	split := bytes.Repeat([]byte{'s'}, 255+10)
+
+	var buf bytes.Buffer
+	buf.Write(oggPage([]byte("OpusHead....")))
+	buf.Write(oggPage([]byte("OpusTags....")))
+	buf.Write(oggPage([]byte("one"), long[:255], long[255:], []byte("two")))
+	buf.Write(oggPage(split[:255]))
+	buf.Write(oggPage(split[255:]))
+
+	return buf.Bytes(), [][]byte{[]byte("one"), long, []byte("two"), split}
+}
+
+func TestOggReader(t *testing.T) {
+	stream, packets := testOggStream()
+	r := newOggReader(bytes.NewReader(stream))
+
+	for i, expect := range packets {
+		packet, err := r.nextPacket()
+		if err != nil {
+			t.Fatalf("packet %d: unexpected error: %v", i, err)
+		}
+		if !bytes.Equal(packet, expect) {
+			t.Fatalf("packet %d: expected %d bytes, got %d", i, len(expect), len(packet))
+		}
+	}
+
+	if _, err := r.nextPacket(); !errors.Is(err, io.EOF) {
+		t.Fatal("expected EOF, got", err)
+	}
+
+	t.Run("truncated", func(t *testing.T) {
+		r := newOggReader(bytes.NewReader(stream[:len(stream)-5]))
+
+		var err error
+		for err == nil {
+			_, err = r.nextPacket()
+		}
+
+		if !errors.Is(err, io.ErrUnexpectedEOF) {
+			t.Fatal("expected unexpected EOF, got", err)
+		}
+	})
+}
+
+func TestOptionsArgs(t *testing.T) {
+	opts := Options{
+		Bitrate:       64,
+		FrameDuration: 60 * time.Millisecond,
+		InputArgs:     []string{"-re"},
+	}
+
+	args := strings.Join(opts.args("song.mp3", 1500*time.Millisecond), " ")
+
+	for _, expect := range []string{
+		"-re -ss 1.5 -i song.mp3",
+		"-b:a 64k",
+		"-frame_duration 60",
+		"-f opus -",
+	} {
+		if !strings.Contains(args, expect) {
+			t.Errorf("args %q do not contain %q", args, expect)
+		}
+	}
+}
+
+// fakeFFmpeg writes a script that pretends to be ffmpeg by printing the given
+// Ogg stream. The arguments of every run are appended to the returned log file.
+func fakeFFmpeg(t *testing.T, stream []byte) (binary, argsLog string) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake ffmpeg requires a POSIX shell")
+	}
+
+	dir := t.TempDir()
+	oggPath := filepath.Join(dir, "stream.ogg")
+	argsLog = filepath.Join(dir, "args.log")
+	binary = filepath.Join(dir, "ffmpeg")
+
+	if err := os.WriteFile(oggPath, stream, 0644); err != nil {
+		t.Fatal("failed to write ogg:", err)
+	}
+
+	script := "#!/bin/sh\necho \"$@\" >> '" + argsLog + "'\ncat '" + oggPath + "'\n"
+	if err := os.WriteFile(binary, []byte(script), 0755); err != nil {
+		t.Fatal("failed to write script:", err)
+	}
+
+	return binary, argsLog
+}
+
+func TestPlayer(t *testing.T) {
+	stream, packets := testOggStream()
+	binary, argsLog := fakeFFmpeg(t, stream)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	controls := &Controls{}
+
+	p, err := newPlayer(ctx, "song.mp3", nil, &Options{
+		Binary:   binary,
+		Controls: controls,
+	})
+	if err != nil {
+		t.Fatal("failed to start player:", err)
+	}
+	defer p.stop()
+
+	next := func() []byte {
+		t.Helper()
+
+		frame, err := p.NextFrame(ctx)
+		if err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+		return frame
+	}
+
+	if frame := next(); !bytes.Equal(frame, packets[0]) {
+		t.Fatalf("unexpected first frame %q", frame)
+	}
+
+	controls.Pause()
+
+	for i := 0; i < voice.SilenceFrames; i++ {
+		if frame := next(); !bytes.Equal(frame, voice.SilenceFrame) {
+			t.Fatalf("expected silence frame %d while paused, got %q", i, frame)
+		}
+	}
+
+	time.AfterFunc(10*time.Millisecond, controls.Resume)
+
+	if frame := next(); !bytes.Equal(frame, packets[1]) {
+		t.Fatalf("unexpected frame after resuming: %q", frame)
+	}
+
+	if err := controls.Seek(time.Minute); err != nil {
+		t.Fatal("failed to seek:", err)
+	}
+
+	if frame := next(); !bytes.Equal(frame, packets[0]) {
+		t.Fatalf("unexpected frame after seeking: %q", frame)
+	}
+
+	if pos := controls.Position(); pos != time.Minute+DefaultFrameDuration {
+		t.Fatal("unexpected position", pos)
+	}
+
+	for range packets[1:] {
+		next()
+	}
+
+	if _, err := p.NextFrame(ctx); !errors.Is(err, io.EOF) {
+		t.Fatal("expected EOF, got", err)
+	}
+
+	log, err := os.ReadFile(argsLog)
+	if err != nil {
+		t.Fatal("failed to read args log:", err)
+	}
+
+	runs := strings.Split(strings.TrimSpace(string(log)), "\n")
+	if len(runs) != 2 {
+		t.Fatalf("expected ffmpeg to run twice, got %d runs", len(runs))
+	}
+	if !strings.Contains(runs[1], "-ss 60 -i song.mp3") {
+		t.Fatalf("expected second run to seek, got %q", runs[1])
+	}
+}
+
+func TestPlayerReaderNotSeekable(t *testing.T) {
+	stream, _ := testOggStream()
+	binary, _ := fakeFFmpeg(t, stream)
+
+	controls := &Controls{}
+
+	p, err := newPlayer(context.Background(), "pipe:0", strings.NewReader(""), &Options{
+		Binary:   binary,
+		Controls: controls,
+	})
+	if err != nil {
+		t.Fatal("failed to start player:", err)
+	}
+	defer p.stop()
+
+	if err := controls.Seek(time.Second); !errors.Is(err, ErrNotSeekable) {
+		t.Fatal("expected ErrNotSeekable, got", err)
+	}
+}
@@ -0,0 +1,104 @@
+package ffmpeg
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+)
+
+var (
+	oggMagic = []byte("OggS")
+	opusHead = []byte("OpusHead")
+	opusTags = []byte("OpusTags")
+)
+
+// oggReader reads the Opus packets out of an Ogg stream. The OpusHead and
+// OpusTags header packets are skipped.
+//
+// https://datatracker.ietf.org/doc/html/rfc3533#section-6
+type oggReader struct {
+	r        *bufio.Reader
+	header   [27]byte
+	segments [255]byte
+	// segs is the part of the current page's segment table that is left.
+	segs   []byte
+	packet []byte
+}
+
+func newOggReader(r io.Reader) *oggReader {
+	return &oggReader{r: bufio.NewReader(r)}
+}
+
+// nextPacket returns the next Opus packet. The packet is only valid until
+// nextPacket is called again. io.EOF is returned once the stream ends.
+func (o *oggReader) nextPacket() ([]byte, error) {
+	o.packet = o.packet[:0]
+
+	for {
+		if len(o.segs) == 0 {
+			if err := o.readPage(); err != nil {
+				if errors.Is(err, io.EOF) && len(o.packet) > 0 {
+					err = io.ErrUnexpectedEOF
+				}
+				return nil, err
+			}
+			continue
+		}
+
+		n := int(o.segs[0])
+		o.segs = o.segs[1:]
+
+		start := len(o.packet)
+		o.packet = append(o.packet, make([]byte, n)...)
+
+		if _, err := io.ReadFull(o.r, o.packet[start:]); err != nil {
+			return nil, fmt.Errorf("failed to read segment: %w", unexpectedEOF(err))
+		}
+
+		// Segments shorter than 255 bytes end the packet.
+		if n == 255 {
+			continue
+		}
+
+		if bytes.HasPrefix(o.packet, opusHead) || bytes.HasPrefix(o.packet, opusTags) {
+			o.packet = o.packet[:0]
+			continue
+		}
+
+		return o.packet, nil
+	}
+}
+
+// readPage reads the header of the next page.
+func (o *oggReader) readPage() error {
+	if _, err := io.ReadFull(o.r, o.header[:]); err != nil {
+		if errors.Is(err, io.EOF) {
+			return io.EOF
+		}
+		return fmt.Errorf("failed to read page header: %w", unexpectedEOF(err))
+	}
+
+	if !bytes.Equal(o.header[:4], oggMagic) {
+		return errors.New("invalid ogg page: missing capture pattern")
+	}
+	if o.header[4] != 0 {
+		return fmt.Errorf("unsupported ogg version %d", o.header[4])
+	}
+
+	segs := o.segments[:o.header[26]]
+	if _, err := io.ReadFull(o.r, segs); err != nil {
+		return fmt.Errorf("failed to read segment table: %w", unexpectedEOF(err))
+	}
+
+	o.segs = segs
+	return nil
+}
+
+func unexpectedEOF(err error) error {
+	if errors.Is(err, io.EOF) {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
@@ -13,14 +13,20 @@ import (
 	"github.com/diamondburned/arikawa/v3/session"
 	"github.com/diamondburned/arikawa/v3/state"
 	"github.com/diamondburned/arikawa/v3/utils/handler"
+	"github.com/diamondburned/arikawa/v3/utils/logger"
 	"github.com/diamondburned/arikawa/v3/utils/ws"
 	"github.com/diamondburned/arikawa/v3/utils/ws/ophandler"
 	"github.com/diamondburned/arikawa/v3/voice/udp"
 	"github.com/diamondburned/arikawa/v3/voice/voicegateway"
 )
 
-// Protocol is the encryption protocol that this library uses.
-const Protocol = "xsalsa20_poly1305"
+// Protocol is the legacy encryption mode that this library used to always use.
+// It is now only used if the voice gateway offers no mode in
+// udp.SupportedModes.
+//
+// Deprecated: The encryption mode is negotiated with the voice gateway. See
+// udp.SupportedModes.
+const Protocol = udp.ModeXSalsa20Poly1305
 
 // ErrAlreadyConnecting is returned when the session is already connecting.
 var ErrAlreadyConnecting = errors.New("already connecting")
@@ -47,6 +53,44 @@ func (e ReconnectError) Error() string {
 // Unwrap returns e.Err.
 func (e ReconnectError) Unwrap() error { return e.Err }
 
+// JoinError is returned by JoinChannel if joining the channel has failed. Joins
+// that fail because the voice gateway invalidated the session are retried up to
+// Session.JoinMaxRetry times before JoinError is returned.
+type JoinError struct {
+	// Attempts is the number of times that joining was attempted.
+	Attempts int
+	// Err is the error of the last attempt.
+	Err error
+}
+
+// Error implements error.
+func (e *JoinError) Error() string {
+	return fmt.Sprintf("cannot join voice channel after %d attempts: %v", e.Attempts, e.Err)
+}
+
+// Unwrap returns e.Err.
+func (e *JoinError) Unwrap() error { return e.Err }
+
+// IsStaleSession returns true if the given error is caused by the voice gateway
+// closing with a code that invalidates the voice session, such as 4006 or 4014.
+// See voicegateway.CodeSessionNoLongerValid.
+func IsStaleSession(err error) bool {
+	var closeEv *ws.CloseEvent
+	if !errors.As(err, &closeEv) {
+		return false
+	}
+
+	switch closeEv.Code {
+	case
+		voicegateway.CodeSessionNoLongerValid,
+		voicegateway.CodeSessionTimeout,
+		voicegateway.CodeDisconnected:
+		return true
+	default:
+		return false
+	}
+}
+
 // MainSession abstracts both session.Session and state.State.
 type MainSession interface {
 	// AddHandler describes the method in handler.Handler.
@@ -87,11 +131,21 @@ type Session struct {
 	gwCancel context.CancelFunc
 	gwDone   <-chan struct{}
 
+	logger logger.Logger // guarded by mut
+
 	WSTimeout      time.Duration // global WSTimeout
 	WSMaxRetry     int           // 2
 	WSRetryDelay   time.Duration // 2s
 	WSWaitDuration time.Duration // 5s
 
+	// JoinMaxRetry is the maximum number of times that JoinChannel attempts
+	// the whole handshake if the voice session is invalidated; see
+	// IsStaleSession. The delay between attempts starts at JoinRetryDelay and
+	// doubles every attempt, up to JoinMaxRetryDelay.
+	JoinMaxRetry      int           // 3
+	JoinRetryDelay    time.Duration // 1s
+	JoinMaxRetryDelay time.Duration // 8s
+
 	// joining determines the behavior of incoming event callbacks (Update).
 	// If this is true, incoming events will just send into Updated channels. If
 	// false, events will trigger a reconnection.
@@ -124,11 +178,16 @@ func NewSessionCustom(ses MainSession, userID discord.UserID) *Session {
 			UserID: userID,
 		},
 		udpManager:     udp.NewManager(),
+		logger:         ws.DefaultLogger,
 		WSTimeout:      WSTimeout,
 		WSMaxRetry:     2,
 		WSRetryDelay:   2 * time.Second,
 		WSWaitDuration: 5 * time.Second,
 
+		JoinMaxRetry:      3,
+		JoinRetryDelay:    time.Second,
+		JoinMaxRetryDelay: 8 * time.Second,
+
 		// Set this pair of value in so we never have to nil-check the channel.
 		// We can just assume that it's either closed or connected.
 		disconnected:     closed,
@@ -144,6 +203,21 @@ func (s *Session) SetUDPDialer(d udp.DialFunc) {
 	s.udpManager.SetDialer(d)
 }
 
+// SetLogger sets the logger used by the session, its voice gateway and its UDP
+// connections. It takes effect on the next reconnection. If l is nil, then
+// ws.DefaultLogger is used.
+func (s *Session) SetLogger(l logger.Logger) {
+	if l == nil {
+		l = ws.DefaultLogger
+	}
+
+	s.mut.Lock()
+	s.logger = l
+	s.mut.Unlock()
+
+	s.udpManager.SetLogger(l)
+}
+
 func (s *Session) acquireUpdate(f func()) bool {
 	if s.joining.Get() {
 		return false
@@ -210,7 +284,10 @@ type waitEventChs struct {
 	stateUpdate  chan *gateway.VoiceStateUpdateEvent
 }
 
-// JoinChannel joins the given voice channel with the default timeout.
+// JoinChannel joins the given voice channel with the default timeout. If the
+// voice gateway invalidates the session while joining, then the voice state is
+// sent to Discord again and the handshake is retried; see JoinMaxRetry. Errors
+// from the handshake are returned as a *JoinError.
 func (s *Session) JoinChannel(ctx context.Context, chID discord.ChannelID, mute, deaf bool) error {
 	var ch *discord.Channel
 
@@ -279,6 +356,42 @@ func (s *Session) JoinChannel(ctx context.Context, chID discord.ChannelID, mute,
 		SelfDeaf:  deaf,
 	}
 
+	delay := s.JoinRetryDelay
+
+	for attempt := 1; ; attempt++ {
+		err := s.join(ctx, data, chs)
+		if err == nil {
+			return nil
+		}
+
+		if !IsStaleSession(err) || attempt >= s.JoinMaxRetry {
+			return &JoinError{Attempts: attempt, Err: err}
+		}
+
+		s.logger.Warn("voice session invalidated while joining, retrying",
+			"attempt", attempt, "delay", delay, "err", err)
+
+		s.resetJoin(ctx)
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return &JoinError{Attempts: attempt, Err: ctx.Err()}
+		}
+
+		if delay *= 2; delay > s.JoinMaxRetryDelay {
+			delay = s.JoinMaxRetryDelay
+		}
+	}
+}
+
+// join does a single attempt of the voice handshake. It asks Discord for the
+// voice server, then connects to it. s.mut must be held.
+func (s *Session) join(
+	ctx context.Context, data *gateway.UpdateVoiceStateCommand, chs waitEventChs) error {
+
 	var err error
 	var timer *time.Timer
 
@@ -311,6 +424,10 @@ func (s *Session) JoinChannel(ctx context.Context, chID discord.ChannelID, mute,
 		}
 	}
 
+	if err != nil {
+		return fmt.Errorf("cannot ask Discord for events: %w", err)
+	}
+
 	// These 2 methods should've updated s.state before sending into these
 	// channels. Since s.state is already filled, we can go ahead and connect.
 
@@ -321,6 +438,21 @@ func (s *Session) JoinChannel(ctx context.Context, chID discord.ChannelID, mute,
 	return s.reconnectCtx(ctx)
 }
 
+// resetJoin makes Discord forget the invalidated voice session by leaving the
+// channel, so that the next attempt gets a new session and voice server.
+func (s *Session) resetJoin(ctx context.Context) {
+	s.ensureClosed()
+
+	s.session.SendGateway(ctx, &gateway.UpdateVoiceStateCommand{
+		GuildID:   s.state.GuildID,
+		ChannelID: discord.ChannelID(discord.NullSnowflake),
+	})
+
+	s.state.SessionID = ""
+	s.state.Token = ""
+	s.state.Endpoint = ""
+}
+
 func (s *Session) askDiscord(
 	ctx context.Context, data *gateway.UpdateVoiceStateCommand, chs waitEventChs) error {
 
@@ -362,6 +494,10 @@ func (s *Session) waitForIncoming(ctx context.Context, chs waitEventChs) error {
 			if s.state.GuildID != ev.GuildID || s.state.UserID != ev.UserID {
 				continue
 			}
+			// Skip the event of leaving the channel in resetJoin.
+			if s.state.ChannelID.IsValid() && !ev.ChannelID.IsValid() {
+				continue
+			}
 			s.state.SessionID = ev.SessionID
 			s.state.ChannelID = ev.ChannelID
 			state = true
@@ -375,9 +511,10 @@ func (s *Session) waitForIncoming(ctx context.Context, chs waitEventChs) error {
 }
 
 // reconnect uses the current state to reconnect to a new gateway and UDP
-// connection.
+// connection. s.mut must be held, since the state and the logger are read.
 func (s *Session) reconnectCtx(ctx context.Context) error {
-	ws.WSDebug("Sending stop handle.")
+	log := s.logger
+	log.Debug("pausing the UDP manager to reconnect")
 
 	if err := s.udpManager.Pause(ctx); err != nil {
 		return fmt.Errorf("cannot pause UDP manager: %w", err)
@@ -390,18 +527,21 @@ func (s *Session) reconnectCtx(ctx context.Context) error {
 
 	s.ensureClosed()
 
-	ws.WSDebug("Start gateway.")
-	s.gateway = voicegateway.New(s.state)
+	log.Debug("starting the voice gateway")
+
+	opts := voicegateway.DefaultGatewayOpts
+	opts.Logger = log
+	s.gateway = voicegateway.NewWithOpts(s.state, &opts)
 
 	// Open the voice gateway. The function will block until Ready is received.
 	gwctx, gwcancel := context.WithCancel(context.Background())
 	s.gwCancel = gwcancel
 
 	gwch := s.gateway.Connect(gwctx)
-	ws.WSDebug("Voice Gateway connected")
+	log.Debug("voice gateway connected")
 
-	if err := s.spinGateway(ctx, gwch); err != nil {
-		ws.WSDebug("Voice spinGateway error:", err)
+	if err := s.spinGateway(ctx, gwch, log); err != nil {
+		log.Warn("failed to wait for the voice gateway", "err", err)
 		// Early cancel the gateway.
 		gwcancel()
 		// Nil this so future reconnects don't use the invalid gwDone.
@@ -415,12 +555,12 @@ func (s *Session) reconnectCtx(ctx context.Context) error {
 	// Start dispatching.
 	s.gwDone = ophandler.Loop(gwch, s.Handler)
 
-	ws.WSDebug("Voice reconnectCtx finished with no error")
+	log.Debug("voice reconnection finished")
 
 	return nil
 }
 
-func (s *Session) spinGateway(ctx context.Context, gwch <-chan ws.Op) error {
+func (s *Session) spinGateway(ctx context.Context, gwch <-chan ws.Op, log logger.Logger) error {
 	var err error
 	var conn *udp.Connection
 
@@ -435,10 +575,10 @@ func (s *Session) spinGateway(ctx context.Context, gwch <-chan ws.Op) error {
 
 			switch data := ev.Data.(type) {
 			case *ws.CloseEvent:
-				return fmt.Errorf("voice gateway error: %w", err)
+				return fmt.Errorf("voice gateway error: %w", data)
 
 			case *voicegateway.ReadyEvent:
-				ws.WSDebug("Got ready from voice gateway, SSRC:", data.SSRC)
+				log.Debug("got ready from the voice gateway", "ssrc", data.SSRC)
 
 				// Prepare the UDP voice connection.
 				conn, err = s.udpManager.Dial(ctx, data.Addr(), data.SSRC)
@@ -446,12 +586,17 @@ func (s *Session) spinGateway(ctx context.Context, gwch <-chan ws.Op) error {
 					return fmt.Errorf("failed to open voice UDP connection: %w", err)
 				}
 
+				mode, ok := udp.PreferredMode(data.Modes)
+				if !ok {
+					mode = Protocol
+				}
+
 				if err := s.gateway.Send(ctx, &voicegateway.SelectProtocolCommand{
 					Protocol: "udp",
 					Data: voicegateway.SelectProtocolData{
 						Address: conn.GatewayIP,
 						Port:    conn.GatewayPort,
-						Mode:    Protocol,
+						Mode:    mode,
 					},
 				}); err != nil {
 					return fmt.Errorf("failed to send SelectProtocolCommand: %w", err)
@@ -462,10 +607,15 @@ func (s *Session) spinGateway(ctx context.Context, gwch <-chan ws.Op) error {
 					return errors.New("server bug: SessionDescription before Ready")
 				}
 
-				ws.WSDebug("Received secret key from voice gateway")
+				log.Debug("received secret key from the voice gateway", "mode", data.Mode)
+
+				cipher, err := udp.NewCipher(data.Mode, data.SecretKey)
+				if err != nil {
+					return fmt.Errorf("failed to use voice encryption: %w", err)
+				}
 
 				// We're done.
-				conn.UseSecret(data.SecretKey)
+				conn.UseCipher(cipher)
 				return nil
 			}
 
@@ -508,6 +658,15 @@ func (s *Session) ReadPacket() (*udp.Packet, error) {
 	return s.udpManager.ReadPacket()
 }
 
+// Stats returns a snapshot of the statistics of the current UDP connection,
+// such as the number of packets sent, the estimated packet loss and the
+// round-trip time. The statistics are reset when the connection is
+// re-established. The packet loss and the round-trip time are only known if
+// ReadPacket is called; see udp.Stats.
+func (s *Session) Stats() udp.Stats {
+	return s.udpManager.Stats()
+}
+
 // Leave disconnects the current voice session from the currently connected
 // channel.
 func (s *Session) Leave(ctx context.Context) error {
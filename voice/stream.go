@@ -0,0 +1,106 @@
+package voice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/voice/voicegateway"
+)
+
+// SilenceFrame is an Opus frame of silence. Discord recommends sending a few of
+// these after the audio stops to avoid unintended Opus interpolation.
+var SilenceFrame = []byte{0xF8, 0xFF, 0xFE}
+
+// SilenceFrames is the number of silence frames that Stream sends after the
+// audio source is exhausted.
+const SilenceFrames = 5
+
+// StreamLeaveTimeout is the timeout used by Stream to leave the channel once
+// its context is canceled.
+var StreamLeaveTimeout = 5 * time.Second
+
+// AudioSource is a source of Opus frames. It can be implemented by anything
+// that produces audio programmatically, such as TTS engines or mixers.
+type AudioSource interface {
+	// NextFrame returns the next Opus frame. The frame is only used until
+	// NextFrame is called again, so its backing array may be reused. io.EOF
+	// is returned once the source is exhausted.
+	NextFrame(ctx context.Context) ([]byte, error)
+}
+
+// AudioSourceFunc is a function that implements AudioSource.
+type AudioSourceFunc func(ctx context.Context) ([]byte, error)
+
+// NextFrame implements AudioSource.
+func (f AudioSourceFunc) NextFrame(ctx context.Context) ([]byte, error) {
+	return f(ctx)
+}
+
+// Stream plays the given audio source until it returns io.EOF. Frames are
+// paced to match the real playback time, so the source can produce them as
+// fast as it likes. Once the source is exhausted, SilenceFrames frames of
+// silence are sent and the session stops speaking.
+//
+// If ctx is canceled while streaming, then the session leaves the channel
+// before Stream returns the context's error. Like Write, Stream must not be
+// called concurrently.
+func (s *Session) Stream(ctx context.Context, src AudioSource) error {
+	if err := s.Speaking(ctx, voicegateway.Microphone); err != nil {
+		return fmt.Errorf("failed to start speaking: %w", err)
+	}
+
+	for {
+		frame, err := src.NextFrame(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return s.leaveStream(ctx.Err())
+			}
+
+			s.writeSilence()
+			s.Speaking(ctx, voicegateway.NotSpeaking)
+
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return fmt.Errorf("failed to get next frame: %w", err)
+		}
+
+		if _, err := s.Write(frame); err != nil {
+			if ctx.Err() != nil {
+				return s.leaveStream(ctx.Err())
+			}
+			return fmt.Errorf("failed to write frame: %w", err)
+		}
+
+		if ctx.Err() != nil {
+			return s.leaveStream(ctx.Err())
+		}
+	}
+}
+
+// writeSilence writes SilenceFrames frames of silence, ignoring errors.
+func (s *Session) writeSilence() {
+	for i := 0; i < SilenceFrames; i++ {
+		if _, err := s.Write(SilenceFrame); err != nil {
+			return
+		}
+	}
+}
+
+// leaveStream stops a canceled stream and leaves the channel. The given error
+// is returned unless leaving fails.
+func (s *Session) leaveStream(ctxErr error) error {
+	s.writeSilence()
+
+	ctx, cancel := context.WithTimeout(context.Background(), StreamLeaveTimeout)
+	defer cancel()
+
+	if err := s.Leave(ctx); err != nil {
+		return fmt.Errorf("failed to leave after stream was canceled: %w", err)
+	}
+
+	return ctxErr
+}
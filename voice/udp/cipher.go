@@ -0,0 +1,177 @@
+package udp
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/binary"
+	"fmt"
+
+	"golang.org/x/crypto/chacha20poly1305"
+	"golang.org/x/crypto/nacl/secretbox"
+)
+
+// Encryption modes supported by this package.
+//
+// https://discord.com/developers/docs/topics/voice-connections#transport-encryption-modes
+const (
+	// ModeAES256GCM is the AEAD AES256-GCM mode. It is the preferred mode
+	// when it is available.
+	ModeAES256GCM = "aead_aes256_gcm_rtpsize"
+	// ModeXChaCha20Poly1305 is the AEAD XChaCha20-Poly1305 mode. Discord
+	// guarantees that it is always available.
+	ModeXChaCha20Poly1305 = "aead_xchacha20_poly1305_rtpsize"
+	// ModeXSalsa20Poly1305 is the legacy XSalsa20-Poly1305 mode. Discord is
+	// deprecating it, so it's only used if no other mode is available.
+	ModeXSalsa20Poly1305 = "xsalsa20_poly1305"
+)
+
+// SupportedModes is the list of supported encryption modes, ordered from the
+// most preferred to the least preferred.
+var SupportedModes = []string{
+	ModeAES256GCM,
+	ModeXChaCha20Poly1305,
+	ModeXSalsa20Poly1305,
+}
+
+// PreferredMode returns the most preferred mode in SupportedModes out of the
+// given modes, which are usually the ones listed in the voice gateway's Ready
+// event. False is returned if none of the given modes is supported.
+func PreferredMode(modes []string) (string, bool) {
+	for _, supported := range SupportedModes {
+		for _, mode := range modes {
+			if mode == supported {
+				return mode, true
+			}
+		}
+	}
+	return "", false
+}
+
+// Cipher encrypts and decrypts voice packets using an encryption mode.
+//
+// A Connection sends and reads packets concurrently, so Seal and Open may be
+// called concurrently with each other. Neither of them is called concurrently
+// with itself, so implementations may reuse buffers that are only used by one
+// of them.
+type Cipher interface {
+	// Mode returns the encryption mode of the Cipher.
+	Mode() string
+	// Seal encrypts the payload of the packet with the given RTP header. The
+	// whole packet to be sent is appended to dst and returned.
+	Seal(dst, header, payload []byte) []byte
+	// Open decrypts the given whole packet and appends its payload to dst.
+	// If the packet has an RTP header extension, then the payload starts with
+	// the extension header, just like if it was not encrypted. False is
+	// returned if the packet cannot be decrypted.
+	Open(dst, packet []byte) ([]byte, bool)
+}
+
+// NewCipher creates a new Cipher for the given encryption mode using the
+// given secret key.
+func NewCipher(mode string, secret [32]byte) (Cipher, error) {
+	switch mode {
+	case ModeAES256GCM:
+		block, err := aes.NewCipher(secret[:])
+		if err != nil {
+			return nil, err
+		}
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			return nil, err
+		}
+		return &rtpSizeCipher{mode: mode, aead: aead}, nil
+
+	case ModeXChaCha20Poly1305:
+		aead, err := chacha20poly1305.NewX(secret[:])
+		if err != nil {
+			return nil, err
+		}
+		return &rtpSizeCipher{mode: mode, aead: aead}, nil
+
+	case ModeXSalsa20Poly1305:
+		return &xsalsa20Cipher{secret: secret}, nil
+
+	default:
+		return nil, fmt.Errorf("unsupported encryption mode %q", mode)
+	}
+}
+
+// xsalsa20Cipher implements the legacy xsalsa20_poly1305 mode. The nonce is
+// the RTP header, and everything after the header is encrypted. Sending and
+// receiving use separate nonce buffers, since they happen concurrently.
+type xsalsa20Cipher struct {
+	secret    [32]byte
+	nonce     [24]byte
+	recvNonce [24]byte
+}
+
+func (c *xsalsa20Cipher) Mode() string { return ModeXSalsa20Poly1305 }
+
+func (c *xsalsa20Cipher) Seal(dst, header, payload []byte) []byte {
+	copy(c.nonce[:], header[:packetHeaderSize])
+	return secretbox.Seal(append(dst, header...), payload, &c.nonce, &c.secret)
+}
+
+func (c *xsalsa20Cipher) Open(dst, packet []byte) ([]byte, bool) {
+	if len(packet) < packetHeaderSize {
+		return nil, false
+	}
+
+	copy(c.recvNonce[:], packet[:packetHeaderSize])
+	return secretbox.Open(dst, packet[packetHeaderSize:], &c.recvNonce, &c.secret)
+}
+
+// rtpSizeNonceSize is the size of the nonce counter appended to the end of
+// packets encrypted using the rtpsize modes.
+const rtpSizeNonceSize = 4
+
+// rtpSizeCipher implements the AEAD rtpsize modes. The RTP header, including
+// the header of its extension, is the additional data, and a 32-bit
+// incrementing nonce is appended to the packet.
+type rtpSizeCipher struct {
+	mode  string
+	aead  cipher.AEAD
+	nonce [chacha20poly1305.NonceSizeX]byte // fits both AEADs
+	count uint32
+}
+
+func (c *rtpSizeCipher) Mode() string { return c.mode }
+
+func (c *rtpSizeCipher) Seal(dst, header, payload []byte) []byte {
+	c.count++
+	binary.BigEndian.PutUint32(c.nonce[:rtpSizeNonceSize], c.count)
+
+	dst = append(dst, header...)
+	dst = c.aead.Seal(dst, c.nonce[:c.aead.NonceSize()], payload, header)
+	return append(dst, c.nonce[:rtpSizeNonceSize]...)
+}
+
+func (c *rtpSizeCipher) Open(dst, packet []byte) ([]byte, bool) {
+	if len(packet) < packetHeaderSize+rtpSizeNonceSize+c.aead.Overhead() {
+		return nil, false
+	}
+
+	headerSize := packetHeaderSize
+	// The header of the extension is not encrypted if there is one. Include
+	// it in the additional data, and give it back with the payload.
+	hasExtension := packet[0]&0x10 == 0x10
+	if hasExtension {
+		headerSize += 4
+	}
+
+	nonceAt := len(packet) - rtpSizeNonceSize
+	if nonceAt-headerSize < c.aead.Overhead() {
+		return nil, false
+	}
+
+	var nonce [chacha20poly1305.NonceSizeX]byte
+	copy(nonce[:], packet[nonceAt:])
+
+	if hasExtension {
+		dst = append(dst, packet[packetHeaderSize:headerSize]...)
+	}
+
+	dst, err := c.aead.Open(dst,
+		nonce[:c.aead.NonceSize()], packet[headerSize:nonceAt], packet[:headerSize])
+	return dst, err == nil
+}
@@ -0,0 +1,93 @@
+package udp
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestCipherRoundTrip(t *testing.T) {
+	var secret [32]byte
+	for i := range secret {
+		secret[i] = byte(i)
+	}
+
+	header := []byte{0x80, 0x78, 0, 1, 0, 0, 0, 2, 0, 0, 0, 3}
+	payload := []byte("opus frame")
+
+	for _, mode := range SupportedModes {
+		t.Run(mode, func(t *testing.T) {
+			sealer, err := NewCipher(mode, secret)
+			if err != nil {
+				t.Fatal("failed to create cipher:", err)
+			}
+			opener, _ := NewCipher(mode, secret)
+
+			packet := sealer.Seal(nil, header, payload)
+			if !bytes.HasPrefix(packet, header) {
+				t.Fatal("sealed packet does not start with the header")
+			}
+
+			got, ok := opener.Open(nil, packet)
+			if !ok {
+				t.Fatal("failed to open sealed packet")
+			}
+			if !bytes.Equal(got, payload) {
+				t.Fatalf("expected payload %q, got %q", payload, got)
+			}
+
+			packet[len(packet)-rtpSizeNonceSize-1] ^= 0xFF
+			if _, ok := opener.Open(nil, packet); ok {
+				t.Fatal("tampered packet was opened")
+			}
+		})
+	}
+}
+
+func TestPreferredMode(t *testing.T) {
+	mode, ok := PreferredMode([]string{"xsalsa20_poly1305", ModeXChaCha20Poly1305})
+	if !ok || mode != ModeXChaCha20Poly1305 {
+		t.Fatalf("unexpected mode %q (%v)", mode, ok)
+	}
+
+	if _, ok := PreferredMode([]string{"unknown"}); ok {
+		t.Fatal("unexpected supported mode")
+	}
+}
+
+func TestCipherConcurrentSealOpen(t *testing.T) {
+	var secret [32]byte
+
+	header := []byte{0x80, 0x78, 0, 1, 0, 0, 0, 2, 0, 0, 0, 3}
+	payload := []byte("opus frame")
+
+	for _, mode := range SupportedModes {
+		t.Run(mode, func(t *testing.T) {
+			c, err := NewCipher(mode, secret)
+			if err != nil {
+				t.Fatal("failed to create cipher:", err)
+			}
+
+			peer, _ := NewCipher(mode, secret)
+			packet := peer.Seal(nil, header, payload)
+
+			// Write and ReadPacket use the same cipher from different
+			// goroutines, which the race detector checks here.
+			done := make(chan struct{})
+			go func() {
+				defer close(done)
+				for i := 0; i < 100; i++ {
+					c.Seal(nil, header, payload)
+				}
+			}()
+
+			for i := 0; i < 100; i++ {
+				if _, ok := c.Open(nil, packet); !ok {
+					t.Error("failed to open packet")
+					break
+				}
+			}
+
+			<-done
+		})
+	}
+}
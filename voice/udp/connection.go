@@ -10,8 +10,6 @@ import (
 	"net"
 	"sync"
 	"time"
-
-	"golang.org/x/crypto/nacl/secretbox"
 )
 
 // ErrDecryptionFailed is returned from ReadPacket if the received packet fails
@@ -37,19 +35,20 @@ type Connection struct {
 	timeIncr  uint32
 	stopFreq  chan struct{}
 
-	packet [12]byte
-	secret [32]byte
+	packet  [12]byte
+	cipher  Cipher
+	sendBuf []byte
 
 	sequence  uint16
 	timestamp uint32
-	nonce     [24]byte
 
 	// recv fields
-	recvNonce  [24]byte
 	recvBuf    []byte  // len 1400
 	recvOpus   []byte  // len 1400
 	recvPacket *Packet // uses recvOpus' backing array
 
+	stats *connStats
+
 	closed sync.Once
 }
 
@@ -130,7 +129,7 @@ func DialConnectionCustom(
 	// Write SSRC to the header.
 	binary.BigEndian.PutUint32(packet[8:12], ssrc) // SSRC
 
-	return &Connection{
+	c := &Connection{
 		GatewayIP:   string(ip),
 		GatewayPort: port,
 		frequency:   time.NewTicker(20 * time.Millisecond),
@@ -142,7 +141,44 @@ func DialConnectionCustom(
 		recvBuf:     make([]byte, 1400),
 		recvOpus:    make([]byte, 1400),
 		recvPacket:  &Packet{},
-	}, nil
+		stats:       newConnStats(),
+	}
+
+	go c.keepalive(KeepaliveInterval)
+
+	return c, nil
+}
+
+// keepalive sends a keepalive packet every interval until the connection is
+// closed.
+func (c *Connection) keepalive(interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var packet [keepaliveSize]byte
+
+	for {
+		c.stats.nextKeepalive(&packet)
+
+		if _, err := c.conn.Write(packet[:]); err != nil {
+			return
+		}
+
+		select {
+		case <-ticker.C:
+		case <-c.stopFreq:
+			return
+		}
+	}
+}
+
+// Stats returns a snapshot of the connection's statistics.
+func (c *Connection) Stats() Stats {
+	return c.stats.snapshot()
 }
 
 // ResetFrequency resets the internal frequency ticker as well as the timestamp
@@ -174,10 +210,19 @@ func (c *Connection) ResetFrequency(frameDuration time.Duration, timeIncr uint32
 	c.timeIncr = timeIncr
 }
 
-// UseSecret uses the given secret. This method is not thread-safe, so it should
-// only be used right after initialization.
+// UseSecret uses the given secret with the legacy xsalsa20_poly1305 mode. This
+// method is not thread-safe, so it should only be used right after
+// initialization.
+//
+// Deprecated: Use UseCipher with the mode negotiated with the voice gateway.
 func (c *Connection) UseSecret(secret [32]byte) {
-	c.secret = secret
+	c.cipher = &xsalsa20Cipher{secret: secret}
+}
+
+// UseCipher uses the given cipher to encrypt and decrypt packets. This method
+// is not thread-safe, so it should only be used right after initialization.
+func (c *Connection) UseCipher(cipher Cipher) {
+	c.cipher = cipher
 }
 
 // SetWriteDeadline sets the UDP connection's write deadline.
@@ -212,12 +257,9 @@ func (c *Connection) Write(b []byte) (int, error) {
 	binary.BigEndian.PutUint32(c.packet[4:8], c.timestamp)
 	c.timestamp += c.timeIncr
 
-	// Copy the first 12 bytes from the packet into the nonce.
-	copy(c.nonce[:12], c.packet[:])
-
-	// Seal the message, but reuse the packet buffer. We pass in the first 12
-	// bytes of the packet, but allow it to reuse the whole packet buffer
-	toSend := secretbox.Seal(c.packet[:12], b, &c.nonce, &c.secret)
+	// Seal the message, reusing the send buffer.
+	toSend := c.cipher.Seal(c.sendBuf[:0], c.packet[:], b)
+	c.sendBuf = toSend
 
 	select {
 	case <-c.frequency.C:
@@ -231,6 +273,8 @@ func (c *Connection) Write(b []byte) (int, error) {
 		return 0, err
 	}
 
+	c.stats.sent(len(b))
+
 	return len(b), nil
 }
 
@@ -272,27 +316,29 @@ func (c *Connection) ReadPacket() (*Packet, error) {
 		c.recvPacket.header = c.recvBuf[:12]
 	}
 
+	c.stats.reading()
+
 	for {
 		i, err := c.conn.Read(c.recvBuf)
 		if err != nil {
 			return nil, err
 		}
 
-		if i < packetHeaderSize || (c.recvBuf[0] != 0x80 && c.recvBuf[0] != 0x90) {
+		if i == keepaliveSize {
+			c.stats.keepaliveEchoed(c.recvBuf[:i])
 			continue
 		}
 
-		// Copy the nonce to be read.
-		// TODO: once Go 1.17 is released, we can remove recvNonce and directly
-		// cast it as (*[packetHeaderSize]byte)(c.recvBuf).
-		copy(c.recvNonce[:], c.recvBuf[0:packetHeaderSize])
+		if i < packetHeaderSize || (c.recvBuf[0] != 0x80 && c.recvBuf[0] != 0x90) {
+			continue
+		}
 
 		var ok bool
 
 		// Open (decrypt) the rest of the received bytes.
-		c.recvPacket.Opus, ok = secretbox.Open(
-			c.recvOpus[:0], c.recvBuf[packetHeaderSize:i], &c.recvNonce, &c.secret)
+		c.recvPacket.Opus, ok = c.cipher.Open(c.recvOpus[:0], c.recvBuf[:i])
 		if !ok {
+			c.stats.decryptionFailed()
 			return nil, ErrDecryptionFailed
 		}
 
@@ -354,6 +400,8 @@ func (c *Connection) ReadPacket() (*Packet, error) {
 			}
 		}
 
+		c.stats.received(len(c.recvPacket.Opus))
+
 		return c.recvPacket, nil
 	}
 }
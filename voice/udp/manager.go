@@ -7,6 +7,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/diamondburned/arikawa/v3/utils/logger"
 	"github.com/diamondburned/arikawa/v3/utils/ws"
 )
 
@@ -26,6 +27,7 @@ type Manager struct {
 	stopMu   sync.Mutex
 	stopConn chan struct{}
 	stopDial context.CancelFunc
+	logger   logger.Logger // guarded by stopMu
 
 	// conn state
 	conn     *Connection
@@ -48,9 +50,22 @@ func NewManagerWithDialer(dialer DialFunc) *Manager {
 		dialer:   dialer,
 		stopConn: make(chan struct{}),
 		connLock: make(chan struct{}, 1),
+		logger:   ws.DefaultLogger,
 	}
 }
 
+// SetLogger sets the manager's logger. If l is nil, then ws.DefaultLogger is
+// used.
+func (m *Manager) SetLogger(l logger.Logger) {
+	if l == nil {
+		l = ws.DefaultLogger
+	}
+
+	m.stopMu.Lock()
+	m.logger = l
+	m.stopMu.Unlock()
+}
+
 // SetDialer sets the manager's dialer. Calling this function while the Manager
 // is working will cause a panic. Only call this method directly after
 // construction.
@@ -95,11 +110,11 @@ func (m *Manager) Close() (err error) {
 	select {
 	case <-m.stopConn:
 		// m.stopConn already closed
-		ws.WSDebug("UDP manager already closed")
+		m.logger.Debug("UDP manager already closed")
 		return ErrManagerClosed
 	default:
 		close(m.stopConn)
-		ws.WSDebug("UDP manager closed")
+		m.logger.Debug("UDP manager closed")
 	}
 
 	return nil
@@ -114,7 +129,9 @@ func (m *Manager) IsClosed() bool {
 // successfully resumed, then true is returned, otherwise if it's already
 // continued, then false is returned.
 func (m *Manager) Continue() bool {
-	ws.WSDebug("UDP continued")
+	m.stopMu.Lock()
+	m.logger.Debug("UDP manager continued")
+	m.stopMu.Unlock()
 
 	if m.prevConn != nil {
 		m.prevConn.Close()
@@ -161,7 +178,7 @@ func (m *Manager) Dial(ctx context.Context, addr string, ssrc uint32) (*Connecti
 	}
 
 	m.stopMu.Lock()
-	ws.WSDebug("setting UDP conn to one w/ gateway address", conn.GatewayIP)
+	m.logger.Debug("UDP connection dialed", "gateway_ip", conn.GatewayIP)
 	m.conn = conn
 	m.stopDial = nil
 	m.stopConn = make(chan struct{})
@@ -185,6 +202,21 @@ func (m *Manager) ResetFrequency(frameDuration time.Duration, timeIncr uint32) {
 	}
 }
 
+// Stats returns a snapshot of the current connection's statistics. The
+// statistics are reset every time the connection is re-established. If no
+// connection was dialed yet, the zero value is returned.
+func (m *Manager) Stats() Stats {
+	m.stopMu.Lock()
+	conn := m.conn
+	m.stopMu.Unlock()
+
+	if conn == nil {
+		return Stats{}
+	}
+
+	return conn.Stats()
+}
+
 // ReadPacket reads the current packet. It blocks until a packet arrives or
 // the Manager is closed.
 func (m *Manager) ReadPacket() (p *Packet, err error) {
@@ -220,14 +252,14 @@ func (m *Manager) acquireConn() *Connection {
 
 	select {
 	case <-m.stopConn:
-		ws.WSDebug("UDP acquisition got stopped conn")
+		m.logger.Debug("UDP acquisition got stopped conn")
 		return nil
 	default:
 		// ok
 	}
 
 	if m.conn == nil {
-		ws.WSDebug("UDP acquisition got nil conn")
+		m.logger.Debug("UDP acquisition got nil conn")
 	}
 
 	return m.conn
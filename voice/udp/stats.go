@@ -0,0 +1,195 @@
+package udp
+
+import (
+	"encoding/binary"
+	"sync"
+	"time"
+)
+
+// KeepaliveInterval is the interval between UDP keepalive packets. Discord
+// echoes keepalive packets back, which is used to estimate the round-trip time
+// and packet loss of the connection. It is read when the connection is dialed.
+var KeepaliveInterval = 5 * time.Second
+
+// keepaliveSize is the size of a keepalive packet, which is a 64-bit counter.
+const keepaliveSize = 8
+
+// maxPendingKeepalives is the number of unanswered keepalives to keep track of.
+// Older keepalives are considered lost.
+const maxPendingKeepalives = 16
+
+// Stats is a snapshot of the statistics of a voice UDP connection.
+//
+// Discord's keepalive echoes arrive along with the audio packets, so they are
+// only seen while the connection is being read from, such as using ReadPacket.
+// For connections that are only sent to, RoundTrip stays 0 and Loss reports
+// that the loss is unknown.
+type Stats struct {
+	// Since is the time the connection was dialed.
+	Since time.Time
+	// PacketsSent is the number of audio packets sent.
+	PacketsSent uint64
+	// BytesSent is the number of Opus bytes sent, excluding the RTP header and
+	// the encryption overhead.
+	BytesSent uint64
+	// PacketsReceived is the number of audio packets received.
+	PacketsReceived uint64
+	// BytesReceived is the number of Opus bytes received.
+	BytesReceived uint64
+	// DecryptionFailures is the number of received packets that failed to
+	// decrypt.
+	DecryptionFailures uint64
+	// KeepalivesSent is the number of keepalive packets sent.
+	KeepalivesSent uint64
+	// KeepalivesReceived is the number of keepalive packets echoed back.
+	KeepalivesReceived uint64
+	// RoundTrip is the smoothed round-trip time of keepalive packets. It is 0
+	// if no keepalive was echoed back yet.
+	RoundTrip time.Duration
+	// inFlight is true if the last keepalive wasn't echoed back yet.
+	inFlight bool
+	// read is true if the connection was ever read from.
+	read bool
+	// unread is the number of keepalives sent before the connection was read
+	// from, which are not counted by Loss.
+	unread uint64
+}
+
+// Loss returns the estimated packet loss of the connection from 0 to 1,
+// computed from the number of keepalives that weren't echoed back. The
+// keepalive that is still in flight is not counted as lost.
+//
+// ok is false if the loss is unknown, which is the case if the connection was
+// never read from or until a keepalive is sent after it was.
+func (s Stats) Loss() (loss float64, ok bool) {
+	sent := s.KeepalivesSent - s.unread
+	if s.inFlight && sent > 0 {
+		sent--
+	}
+
+	if sent == 0 || !s.read {
+		return 0, false
+	}
+
+	if s.KeepalivesReceived >= sent {
+		return 0, true
+	}
+
+	return 1 - float64(s.KeepalivesReceived)/float64(sent), true
+}
+
+// SendBitrate returns the average Opus bitrate sent since the connection was
+// dialed in bits per second.
+func (s Stats) SendBitrate() float64 {
+	return bitrate(s.BytesSent, s.Since)
+}
+
+// ReceiveBitrate returns the average Opus bitrate received since the
+// connection was dialed in bits per second.
+func (s Stats) ReceiveBitrate() float64 {
+	return bitrate(s.BytesReceived, s.Since)
+}
+
+func bitrate(bytes uint64, since time.Time) float64 {
+	elapsed := time.Since(since).Seconds()
+	if since.IsZero() || elapsed <= 0 {
+		return 0
+	}
+	return float64(bytes*8) / elapsed
+}
+
+// connStats is the thread-safe statistics tracker of a Connection.
+type connStats struct {
+	mu      sync.Mutex
+	stats   Stats
+	pending map[uint64]time.Time
+	counter uint64
+}
+
+func newConnStats() *connStats {
+	return &connStats{
+		stats:   Stats{Since: time.Now()},
+		pending: make(map[uint64]time.Time, maxPendingKeepalives),
+	}
+}
+
+func (s *connStats) snapshot() Stats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stats := s.stats
+	_, stats.inFlight = s.pending[s.counter]
+
+	return stats
+}
+
+func (s *connStats) sent(n int) {
+	s.mu.Lock()
+	s.stats.PacketsSent++
+	s.stats.BytesSent += uint64(n)
+	s.mu.Unlock()
+}
+
+// reading records that the connection is being read from.
+func (s *connStats) reading() {
+	s.mu.Lock()
+	if !s.stats.read {
+		s.stats.read = true
+		s.stats.unread = s.stats.KeepalivesSent
+	}
+	s.mu.Unlock()
+}
+
+func (s *connStats) received(n int) {
+	s.mu.Lock()
+	s.stats.PacketsReceived++
+	s.stats.BytesReceived += uint64(n)
+	s.mu.Unlock()
+}
+
+func (s *connStats) decryptionFailed() {
+	s.mu.Lock()
+	s.stats.DecryptionFailures++
+	s.mu.Unlock()
+}
+
+// nextKeepalive records a new keepalive and writes it into b.
+func (s *connStats) nextKeepalive(b *[keepaliveSize]byte) {
+	now := time.Now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.counter++
+	binary.BigEndian.PutUint64(b[:], s.counter)
+
+	s.pending[s.counter] = now
+	// Forget the oldest keepalive, which is considered lost.
+	delete(s.pending, s.counter-maxPendingKeepalives)
+
+	s.stats.KeepalivesSent++
+}
+
+// keepaliveEchoed records the echo of a keepalive.
+func (s *connStats) keepaliveEchoed(b []byte) {
+	counter := binary.BigEndian.Uint64(b)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sentAt, ok := s.pending[counter]
+	if !ok {
+		return
+	}
+	delete(s.pending, counter)
+
+	rtt := time.Since(sentAt)
+	if s.stats.RoundTrip == 0 {
+		s.stats.RoundTrip = rtt
+	} else {
+		// Smooth the round-trip time like RFC 6298 does.
+		s.stats.RoundTrip = (7*s.stats.RoundTrip + rtt) / 8
+	}
+
+	s.stats.KeepalivesReceived++
+}
@@ -0,0 +1,93 @@
+package udp
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStatsKeepalive(t *testing.T) {
+	stats := newConnStats()
+	stats.reading()
+
+	var packet [keepaliveSize]byte
+	for i := 0; i < 4; i++ {
+		stats.nextKeepalive(&packet)
+		// Only echo the first two keepalives back.
+		if i < 2 {
+			stats.keepaliveEchoed(packet[:])
+		}
+	}
+
+	// Echoing a keepalive twice must not count it twice.
+	stats.nextKeepalive(&packet)
+	stats.keepaliveEchoed(packet[:])
+	stats.keepaliveEchoed(packet[:])
+
+	s := stats.snapshot()
+	if s.KeepalivesSent != 5 || s.KeepalivesReceived != 3 {
+		t.Fatalf("unexpected keepalive counts %+v", s)
+	}
+	if s.RoundTrip <= 0 {
+		t.Fatal("round-trip time was not measured")
+	}
+
+	if loss, ok := s.Loss(); !ok || loss != 0.4 {
+		t.Fatalf("expected loss 0.4, got %v (ok: %v)", loss, ok)
+	}
+
+	// The last keepalive is still in flight, so it isn't counted as lost.
+	stats.nextKeepalive(&packet)
+
+	if loss, ok := stats.snapshot().Loss(); !ok || loss != 0.4 {
+		t.Fatalf("expected loss 0.4 with a keepalive in flight, got %v (ok: %v)", loss, ok)
+	}
+}
+
+func TestStatsSendOnly(t *testing.T) {
+	stats := newConnStats()
+
+	// Nothing reads the connection, so the echoes are never seen.
+	var packet [keepaliveSize]byte
+	for i := 0; i < 4; i++ {
+		stats.nextKeepalive(&packet)
+	}
+
+	s := stats.snapshot()
+	if loss, ok := s.Loss(); ok {
+		t.Fatalf("expected unknown loss for a send-only connection, got %v", loss)
+	}
+	if s.RoundTrip != 0 {
+		t.Fatalf("expected no round-trip time, got %v", s.RoundTrip)
+	}
+
+	// Once read from, the loss is known for the keepalives sent since.
+	stats.reading()
+	stats.keepaliveEchoed(packet[:])
+
+	if loss, ok := stats.snapshot().Loss(); ok {
+		t.Fatalf("expected unknown loss before a keepalive is sent, got %v", loss)
+	}
+
+	stats.nextKeepalive(&packet)
+	stats.keepaliveEchoed(packet[:])
+
+	if loss, ok := stats.snapshot().Loss(); !ok || loss != 0 {
+		t.Fatalf("expected no loss, got %v (ok: %v)", loss, ok)
+	}
+}
+
+func TestStatsBitrate(t *testing.T) {
+	s := Stats{
+		Since:     time.Now().Add(-time.Second),
+		BytesSent: 16000,
+	}
+
+	// 128 kbps with some leeway for the time elapsed since.
+	if rate := s.SendBitrate(); rate < 120000 || rate > 128000 {
+		t.Fatalf("unexpected bitrate %v", rate)
+	}
+
+	if rate := (Stats{}).ReceiveBitrate(); rate != 0 {
+		t.Fatalf("expected 0 bitrate for zero stats, got %v", rate)
+	}
+}
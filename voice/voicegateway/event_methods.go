@@ -21,74 +21,165 @@ func init() {
 	)
 }
 
+var eventNames = []string{
+	"IDENTIFY",
+	"SELECT_PROTOCOL",
+	"READY",
+	"HEARTBEAT",
+	"SESSION_DESCRIPTION",
+	"SPEAKING",
+	"HEARTBEAT_ACK",
+	"RESUME",
+	"HELLO",
+	"RESUMED",
+	"CLIENT_CONNECT",
+	"CLIENT_DISCONNECT",
+}
+
+// AllEventNames returns the names of all events and commands in this package,
+// in the order that they're registered in OpUnmarshalers.
+func AllEventNames() []string {
+	return append([]string(nil), eventNames...)
+}
+
+// EventType returns the name of the given event, which is the same as what its
+// EventName method returns. An empty string is returned if the event does not
+// belong to this package.
+func EventType(ev ws.Event) string {
+	switch ev.(type) {
+	case *IdentifyCommand:
+		return "IDENTIFY"
+	case *SelectProtocolCommand:
+		return "SELECT_PROTOCOL"
+	case *ReadyEvent:
+		return "READY"
+	case *HeartbeatCommand:
+		return "HEARTBEAT"
+	case *SessionDescriptionEvent:
+		return "SESSION_DESCRIPTION"
+	case *SpeakingEvent:
+		return "SPEAKING"
+	case *HeartbeatAckEvent:
+		return "HEARTBEAT_ACK"
+	case *ResumeCommand:
+		return "RESUME"
+	case *HelloEvent:
+		return "HELLO"
+	case *ResumedEvent:
+		return "RESUMED"
+	case *ClientConnectEvent:
+		return "CLIENT_CONNECT"
+	case *ClientDisconnectEvent:
+		return "CLIENT_DISCONNECT"
+	default:
+		return ""
+	}
+}
+
 // Op implements Event. It always returns Op 0.
 func (*IdentifyCommand) Op() ws.OpCode { return 0 }
 
 // EventType implements Event.
 func (*IdentifyCommand) EventType() ws.EventType { return "" }
 
+// EventName returns the stable name of the event, which is "IDENTIFY".
+func (*IdentifyCommand) EventName() string { return "IDENTIFY" }
+
 // Op implements Event. It always returns Op 1.
 func (*SelectProtocolCommand) Op() ws.OpCode { return 1 }
 
 // EventType implements Event.
 func (*SelectProtocolCommand) EventType() ws.EventType { return "" }
 
+// EventName returns the stable name of the event, which is "SELECT_PROTOCOL".
+func (*SelectProtocolCommand) EventName() string { return "SELECT_PROTOCOL" }
+
 // Op implements Event. It always returns Op 2.
 func (*ReadyEvent) Op() ws.OpCode { return 2 }
 
 // EventType implements Event.
 func (*ReadyEvent) EventType() ws.EventType { return "" }
 
+// EventName returns the stable name of the event, which is "READY".
+func (*ReadyEvent) EventName() string { return "READY" }
+
 // Op implements Event. It always returns Op 3.
 func (*HeartbeatCommand) Op() ws.OpCode { return 3 }
 
 // EventType implements Event.
 func (*HeartbeatCommand) EventType() ws.EventType { return "" }
 
+// EventName returns the stable name of the event, which is "HEARTBEAT".
+func (*HeartbeatCommand) EventName() string { return "HEARTBEAT" }
+
 // Op implements Event. It always returns Op 4.
 func (*SessionDescriptionEvent) Op() ws.OpCode { return 4 }
 
 // EventType implements Event.
 func (*SessionDescriptionEvent) EventType() ws.EventType { return "" }
 
+// EventName returns the stable name of the event, which is "SESSION_DESCRIPTION".
+func (*SessionDescriptionEvent) EventName() string { return "SESSION_DESCRIPTION" }
+
 // Op implements Event. It always returns Op 5.
 func (*SpeakingEvent) Op() ws.OpCode { return 5 }
 
 // EventType implements Event.
 func (*SpeakingEvent) EventType() ws.EventType { return "" }
 
+// EventName returns the stable name of the event, which is "SPEAKING".
+func (*SpeakingEvent) EventName() string { return "SPEAKING" }
+
 // Op implements Event. It always returns Op 6.
 func (*HeartbeatAckEvent) Op() ws.OpCode { return 6 }
 
 // EventType implements Event.
 func (*HeartbeatAckEvent) EventType() ws.EventType { return "" }
 
+// EventName returns the stable name of the event, which is "HEARTBEAT_ACK".
+func (*HeartbeatAckEvent) EventName() string { return "HEARTBEAT_ACK" }
+
 // Op implements Event. It always returns Op 7.
 func (*ResumeCommand) Op() ws.OpCode { return 7 }
 
 // EventType implements Event.
 func (*ResumeCommand) EventType() ws.EventType { return "" }
 
+// EventName returns the stable name of the event, which is "RESUME".
+func (*ResumeCommand) EventName() string { return "RESUME" }
+
 // Op implements Event. It always returns Op 8.
 func (*HelloEvent) Op() ws.OpCode { return 8 }
 
 // EventType implements Event.
 func (*HelloEvent) EventType() ws.EventType { return "" }
 
+// EventName returns the stable name of the event, which is "HELLO".
+func (*HelloEvent) EventName() string { return "HELLO" }
+
 // Op implements Event. It always returns Op 9.
 func (*ResumedEvent) Op() ws.OpCode { return 9 }
 
 // EventType implements Event.
 func (*ResumedEvent) EventType() ws.EventType { return "" }
 
+// EventName returns the stable name of the event, which is "RESUMED".
+func (*ResumedEvent) EventName() string { return "RESUMED" }
+
 // Op implements Event. It always returns Op 12.
 func (*ClientConnectEvent) Op() ws.OpCode { return 12 }
 
 // EventType implements Event.
 func (*ClientConnectEvent) EventType() ws.EventType { return "" }
 
+// EventName returns the stable name of the event, which is "CLIENT_CONNECT".
+func (*ClientConnectEvent) EventName() string { return "CLIENT_CONNECT" }
+
 // Op implements Event. It always returns Op 13.
 func (*ClientDisconnectEvent) Op() ws.OpCode { return 13 }
 
 // EventType implements Event.
 func (*ClientDisconnectEvent) EventType() ws.EventType { return "" }
+
+// EventName returns the stable name of the event, which is "CLIENT_DISCONNECT".
+func (*ClientDisconnectEvent) EventName() string { return "CLIENT_DISCONNECT" }
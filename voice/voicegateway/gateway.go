@@ -36,7 +36,10 @@ type State struct {
 
 	SessionID string
 	Token     string
-	Endpoint  string
+	// Endpoint is the voice server's host as given by Discord. It may instead
+	// be a full URL with a scheme, such as "ws://127.0.0.1:8080", which is
+	// used as-is.
+	Endpoint string
 }
 
 // Gateway represents a Discord Gateway Gateway connection.
@@ -48,6 +51,17 @@ type Gateway struct {
 	ready *ReadyEvent
 }
 
+// Voice gateway close codes that invalidate the voice session. The session
+// can only be re-established by sending a new voice state update over the main
+// gateway.
+//
+// https://discord.com/developers/docs/topics/opcodes-and-status-codes#voice-voice-close-event-codes
+const (
+	CodeSessionNoLongerValid = 4006
+	CodeSessionTimeout       = 4009
+	CodeDisconnected         = 4014
+)
+
 // DefaultGatewayOpts contains the default options to be used for connecting to
 // the gateway.
 var DefaultGatewayOpts = ws.GatewayOpts{
@@ -61,11 +75,11 @@ var DefaultGatewayOpts = ws.GatewayOpts{
 	FatalCloseCodes: []int{
 		4003, // not authenticated
 		4004, // authentication failed
-		4006, // session invalid
-		4009, // session timed out
+		CodeSessionNoLongerValid,
+		CodeSessionTimeout,
 		4011, // server not found
 		4012, // unknown protocol
-		4014, // disconnected
+		CodeDisconnected,
 		4016, // unknown encryption mode
 	},
 	DialTimeout:           0,
@@ -75,12 +89,26 @@ var DefaultGatewayOpts = ws.GatewayOpts{
 
 // New creates a new voice gateway.
 func New(state State) *Gateway {
+	return NewWithOpts(state, nil)
+}
+
+// NewWithOpts creates a new voice gateway with the given gateway options. If
+// opts is nil, then DefaultGatewayOpts is used.
+func NewWithOpts(state State, opts *ws.GatewayOpts) *Gateway {
+	if opts == nil {
+		opts = &DefaultGatewayOpts
+	}
+
 	// https://discord.com/developers/docs/topics/voice-connections#establishing-a-voice-websocket-connection
-	endpoint := "wss://" + strings.TrimSuffix(state.Endpoint, ":80") + "/?v=" + Version
+	endpoint := state.Endpoint
+	if !strings.Contains(endpoint, "://") {
+		endpoint = "wss://" + strings.TrimSuffix(endpoint, ":80")
+	}
+	endpoint += "/?v=" + Version
 
 	gw := ws.NewGateway(
 		ws.NewWebsocket(ws.NewCodec(OpUnmarshalers), endpoint),
-		&DefaultGatewayOpts,
+		opts,
 	)
 
 	return &Gateway{
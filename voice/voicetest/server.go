@@ -0,0 +1,389 @@
+// Package voicetest provides a loopback voice server for testing the voice
+// package without Discord. The Server implements the voice gateway and UDP
+// handshakes, including encryption, and Session stands in for the main
+// gateway session that tells the voice session where the Server is.
+package voicetest
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/utils/json"
+	"github.com/diamondburned/arikawa/v3/voice/udp"
+	"github.com/diamondburned/arikawa/v3/voice/voicegateway"
+)
+
+// HeartbeatInterval is the heartbeat interval that the Server sends in its
+// Hello event.
+var HeartbeatInterval = discord.Milliseconds(41250)
+
+// Packet is an audio packet that the Server received from a client.
+type Packet struct {
+	SSRC      uint32
+	Sequence  uint16
+	Timestamp uint32
+	Opus      []byte
+}
+
+// Server is a loopback voice server. It serves one voice connection at a time,
+// which is the latest one to connect. A Server must be created with NewServer.
+type Server struct {
+	// Modes are the encryption modes that the Server offers in its Ready
+	// event. It defaults to udp.SupportedModes and must be set before a client
+	// connects.
+	Modes []string
+
+	http    *httptest.Server
+	udp     *net.UDPConn
+	packets chan Packet
+
+	mu         sync.Mutex
+	ws         *websocket.Conn
+	closeCode  int
+	closeTimes int
+	nextSSRC   uint32
+	clientAddr *net.UDPAddr
+	recvCipher udp.Cipher
+	sendCipher udp.Cipher
+	senders    map[uint32]*sender
+	speaking   []voicegateway.SpeakingEvent
+
+	// wsMu guards writing to ws, which must not happen concurrently.
+	wsMu sync.Mutex
+}
+
+type sender struct {
+	sequence  uint16
+	timestamp uint32
+}
+
+var upgrader = websocket.Upgrader{}
+
+// NewServer creates and starts a new Server listening on the loopback
+// interface. It must be closed with Close.
+func NewServer() (*Server, error) {
+	conn, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen UDP: %w", err)
+	}
+
+	s := &Server{
+		Modes:    udp.SupportedModes,
+		udp:      conn,
+		packets:  make(chan Packet, 128),
+		nextSSRC: 1,
+	}
+
+	s.http = httptest.NewServer(http.HandlerFunc(s.serveWS))
+	go s.serveUDP()
+
+	return s, nil
+}
+
+// Endpoint returns the voice server endpoint to give to the voice session in a
+// VoiceServerUpdateEvent.
+func (s *Server) Endpoint() string {
+	return "ws://" + strings.TrimPrefix(s.http.URL, "http://")
+}
+
+// Close closes the Server and any connection to it.
+func (s *Server) Close() error {
+	s.mu.Lock()
+	if s.ws != nil {
+		s.ws.Close()
+	}
+	s.mu.Unlock()
+
+	s.http.Close()
+	return s.udp.Close()
+}
+
+// CloseOnIdentify makes the Server close the next n connections with the given
+// close code once they identify, e.g. voicegateway.CodeSessionNoLongerValid to
+// simulate an invalidated voice session.
+func (s *Server) CloseOnIdentify(code, n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.closeCode = code
+	s.closeTimes = n
+}
+
+// ReadPacket reads the next audio packet sent by the client. Packets are
+// dropped if too many are left unread.
+func (s *Server) ReadPacket(ctx context.Context) (Packet, error) {
+	select {
+	case pkt := <-s.packets:
+		return pkt, nil
+	case <-ctx.Done():
+		return Packet{}, ctx.Err()
+	}
+}
+
+// Speaking returns all speaking events that the client has sent so far.
+func (s *Server) Speaking() []voicegateway.SpeakingEvent {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]voicegateway.SpeakingEvent(nil), s.speaking...)
+}
+
+// SendAudio sends an Opus packet to the client as if it was spoken by the user
+// with the given ID and SSRC. This allows tests to simulate multiple users
+// speaking at once. The first packet sent for an SSRC is preceded by a
+// SpeakingEvent that maps the SSRC to the user.
+func (s *Server) SendAudio(userID discord.UserID, ssrc uint32, opus []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.clientAddr == nil || s.sendCipher == nil {
+		return errors.New("no client has finished the voice handshake")
+	}
+
+	snd, ok := s.senders[ssrc]
+	if !ok {
+		err := s.send(s.ws, 5, voicegateway.SpeakingEvent{
+			Speaking: voicegateway.Microphone,
+			SSRC:     ssrc,
+			UserID:   userID,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to send speaking event: %w", err)
+		}
+
+		snd = &sender{}
+		s.senders[ssrc] = snd
+	}
+
+	header := make([]byte, 12)
+	header[0] = 0x80 // Version + Flags
+	header[1] = 0x78 // Payload Type
+	binary.BigEndian.PutUint16(header[2:4], snd.sequence)
+	binary.BigEndian.PutUint32(header[4:8], snd.timestamp)
+	binary.BigEndian.PutUint32(header[8:12], ssrc)
+
+	snd.sequence++
+	snd.timestamp += 960
+
+	packet := s.sendCipher.Seal(nil, header, opus)
+
+	_, err := s.udp.WriteToUDP(packet, s.clientAddr)
+	return err
+}
+
+type rawOp struct {
+	Code int      `json:"op"`
+	Data json.Raw `json:"d,omitempty"`
+}
+
+func (s *Server) send(conn *websocket.Conn, code int, data interface{}) error {
+	b, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	s.wsMu.Lock()
+	defer s.wsMu.Unlock()
+
+	return conn.WriteJSON(rawOp{Code: code, Data: b})
+}
+
+func (s *Server) serveWS(w http.ResponseWriter, r *http.Request) {
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	s.mu.Lock()
+	if s.ws != nil {
+		s.ws.Close()
+	}
+	s.ws = conn
+	s.clientAddr = nil
+	s.recvCipher = nil
+	s.sendCipher = nil
+	s.senders = make(map[uint32]*sender)
+	s.mu.Unlock()
+
+	if err := s.send(conn, 8, voicegateway.HelloEvent{
+		HeartbeatInterval: HeartbeatInterval,
+	}); err != nil {
+		return
+	}
+
+	for {
+		var op rawOp
+		if err := conn.ReadJSON(&op); err != nil {
+			return
+		}
+
+		if err := s.handleOp(conn, op); err != nil {
+			code := 4000
+			var closeErr *websocket.CloseError
+			if errors.As(err, &closeErr) {
+				code = closeErr.Code
+			}
+
+			s.wsMu.Lock()
+			conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(code, err.Error()))
+			s.wsMu.Unlock()
+			return
+		}
+	}
+}
+
+func (s *Server) handleOp(conn *websocket.Conn, op rawOp) error {
+	switch op.Code {
+	case 0: // Identify
+		var id voicegateway.IdentifyCommand
+		if err := op.Data.UnmarshalTo(&id); err != nil {
+			return err
+		}
+
+		s.mu.Lock()
+		if s.closeTimes > 0 {
+			s.closeTimes--
+			code := s.closeCode
+			s.mu.Unlock()
+			return &websocket.CloseError{Code: code, Text: "closed by CloseOnIdentify"}
+		}
+		ssrc := s.nextSSRC
+		s.nextSSRC++
+		s.mu.Unlock()
+
+		addr := s.udp.LocalAddr().(*net.UDPAddr)
+
+		return s.send(conn, 2, voicegateway.ReadyEvent{
+			SSRC:  ssrc,
+			IP:    addr.IP.String(),
+			Port:  addr.Port,
+			Modes: s.Modes,
+		})
+
+	case 1: // Select Protocol
+		var sel voicegateway.SelectProtocolCommand
+		if err := op.Data.UnmarshalTo(&sel); err != nil {
+			return err
+		}
+
+		var secret [32]byte
+		if _, err := rand.Read(secret[:]); err != nil {
+			return err
+		}
+
+		recv, err := udp.NewCipher(sel.Data.Mode, secret)
+		if err != nil {
+			return err
+		}
+		send, _ := udp.NewCipher(sel.Data.Mode, secret)
+
+		s.mu.Lock()
+		s.recvCipher = recv
+		s.sendCipher = send
+		s.mu.Unlock()
+
+		return s.send(conn, 4, voicegateway.SessionDescriptionEvent{
+			Mode:      sel.Data.Mode,
+			SecretKey: secret,
+		})
+
+	case 3: // Heartbeat
+		return s.send(conn, 6, op.Data)
+
+	case 5: // Speaking
+		var speaking voicegateway.SpeakingEvent
+		if err := op.Data.UnmarshalTo(&speaking); err != nil {
+			return err
+		}
+
+		s.mu.Lock()
+		s.speaking = append(s.speaking, speaking)
+		s.mu.Unlock()
+
+		return nil
+
+	case 7: // Resume
+		return s.send(conn, 9, voicegateway.ResumedEvent{})
+
+	default:
+		return nil
+	}
+}
+
+func (s *Server) serveUDP() {
+	buf := make([]byte, 1500)
+
+	for {
+		n, addr, err := s.udp.ReadFromUDP(buf)
+		if err != nil {
+			return
+		}
+
+		b := buf[:n]
+
+		// https://discord.com/developers/docs/topics/voice-connections#ip-discovery
+		if n == 74 && binary.BigEndian.Uint16(b[0:2]) == 1 {
+			s.mu.Lock()
+			s.clientAddr = addr
+			s.mu.Unlock()
+
+			var reply [74]byte
+			binary.BigEndian.PutUint16(reply[0:2], 2)
+			binary.BigEndian.PutUint16(reply[2:4], 70)
+			copy(reply[4:8], b[4:8])
+			copy(reply[8:71], addr.IP.String())
+			// The udp package reads the port as little-endian.
+			binary.LittleEndian.PutUint16(reply[72:74], uint16(addr.Port))
+
+			s.udp.WriteToUDP(reply[:], addr)
+			continue
+		}
+
+		// Echo keepalives back like Discord does.
+		if n == 8 {
+			s.udp.WriteToUDP(b, addr)
+			continue
+		}
+
+		if n < 12 {
+			continue
+		}
+
+		s.mu.Lock()
+		cipher := s.recvCipher
+		s.mu.Unlock()
+
+		if cipher == nil {
+			continue
+		}
+
+		opus, ok := cipher.Open(nil, b)
+		if !ok {
+			continue
+		}
+
+		pkt := Packet{
+			Sequence:  binary.BigEndian.Uint16(b[2:4]),
+			Timestamp: binary.BigEndian.Uint32(b[4:8]),
+			SSRC:      binary.BigEndian.Uint32(b[8:12]),
+			Opus:      opus,
+		}
+
+		select {
+		case s.packets <- pkt:
+		default:
+		}
+	}
+}
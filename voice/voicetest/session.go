@@ -0,0 +1,80 @@
+package voicetest
+
+import (
+	"context"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/gateway"
+	"github.com/diamondburned/arikawa/v3/utils/handler"
+	"github.com/diamondburned/arikawa/v3/utils/ws"
+	"github.com/diamondburned/arikawa/v3/voice"
+)
+
+// Session is a fake main gateway session that implements voice.MainSession.
+// It answers voice state updates by pointing the voice session to its Server.
+type Session struct {
+	*handler.Handler
+	server  *Server
+	me      discord.User
+	guildID discord.GuildID
+}
+
+var _ voice.MainSession = (*Session)(nil)
+
+// NewSession creates a new Session for the given user. Every channel is a voice
+// channel in the guild with the given ID.
+func NewSession(srv *Server, me discord.User, guildID discord.GuildID) *Session {
+	return &Session{
+		Handler: handler.New(),
+		server:  srv,
+		me:      me,
+		guildID: guildID,
+	}
+}
+
+// Me implements voice.MainSession.
+func (s *Session) Me() (*discord.User, error) {
+	me := s.me
+	return &me, nil
+}
+
+// Channel implements voice.MainSession. It returns a voice channel with the
+// given ID in the Session's guild.
+func (s *Session) Channel(id discord.ChannelID) (*discord.Channel, error) {
+	return &discord.Channel{
+		ID:      id,
+		GuildID: s.guildID,
+		Type:    discord.GuildVoice,
+	}, nil
+}
+
+// SendGateway implements voice.MainSession. If the given event is an
+// UpdateVoiceStateCommand to join a channel, then the Session replies with
+// the VoiceStateUpdateEvent and VoiceServerUpdateEvent that Discord would
+// send.
+func (s *Session) SendGateway(ctx context.Context, ev ws.Event) error {
+	cmd, ok := ev.(*gateway.UpdateVoiceStateCommand)
+	if !ok || !cmd.ChannelID.IsValid() {
+		return nil
+	}
+
+	go func() {
+		s.Handler.Call(&gateway.VoiceStateUpdateEvent{
+			VoiceState: discord.VoiceState{
+				GuildID:   cmd.GuildID,
+				ChannelID: cmd.ChannelID,
+				UserID:    s.me.ID,
+				SessionID: "voicetest",
+				SelfMute:  cmd.SelfMute,
+				SelfDeaf:  cmd.SelfDeaf,
+			},
+		})
+		s.Handler.Call(&gateway.VoiceServerUpdateEvent{
+			Token:    "voicetest",
+			GuildID:  cmd.GuildID,
+			Endpoint: s.server.Endpoint(),
+		})
+	}()
+
+	return nil
+}
@@ -0,0 +1,251 @@
+package voicetest
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/voice"
+	"github.com/diamondburned/arikawa/v3/voice/udp"
+	"github.com/diamondburned/arikawa/v3/voice/voicegateway"
+)
+
+func TestLoopback(t *testing.T) {
+	for _, mode := range udp.SupportedModes {
+		mode := mode
+		t.Run(mode, func(t *testing.T) { testLoopback(t, mode) })
+	}
+}
+
+func testLoopback(t *testing.T, mode string) {
+	srv, err := NewServer()
+	if err != nil {
+		t.Fatal("failed to create server:", err)
+	}
+	t.Cleanup(func() { srv.Close() })
+
+	srv.Modes = []string{mode}
+
+	ses := NewSession(srv, discord.User{ID: 1}, 2)
+
+	v, err := voice.NewSession(ses)
+	if err != nil {
+		t.Fatal("failed to create voice session:", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	t.Cleanup(cancel)
+
+	if err := v.JoinChannelAndSpeak(ctx, 3, false, false); err != nil {
+		t.Fatal("failed to join:", err)
+	}
+	t.Cleanup(func() { v.Leave(ctx) })
+
+	if _, err := v.Write([]byte("client audio")); err != nil {
+		t.Fatal("failed to write:", err)
+	}
+
+	pkt, err := srv.ReadPacket(ctx)
+	if err != nil {
+		t.Fatal("failed to read packet on server:", err)
+	}
+	if string(pkt.Opus) != "client audio" {
+		t.Fatalf("server got unexpected audio %q", pkt.Opus)
+	}
+
+	speaking := make(chan *voicegateway.SpeakingEvent, 2)
+	v.AddHandler(speaking)
+
+	for _, ssrc := range []uint32{100, 200} {
+		if err := srv.SendAudio(discord.UserID(ssrc), ssrc, []byte("server audio")); err != nil {
+			t.Fatal("failed to send audio:", err)
+		}
+
+		p, err := v.ReadPacket()
+		if err != nil {
+			t.Fatal("failed to read packet on client:", err)
+		}
+		if p.SSRC() != ssrc || string(p.Opus) != "server audio" {
+			t.Fatalf("client got unexpected packet from SSRC %d: %q", p.SSRC(), p.Opus)
+		}
+
+		select {
+		case ev := <-speaking:
+			if ev.SSRC != ssrc || ev.UserID != discord.UserID(ssrc) {
+				t.Fatalf("unexpected speaking event %+v", ev)
+			}
+		case <-ctx.Done():
+			t.Fatal("timed out waiting for speaking event")
+		}
+	}
+
+	// The client's speaking event travels over the websocket, so it may not
+	// have been read yet.
+	for len(srv.Speaking()) == 0 && ctx.Err() == nil {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if s := srv.Speaking(); len(s) == 0 || s[0].Speaking != voicegateway.Microphone {
+		t.Fatalf("unexpected speaking events from client %+v", s)
+	}
+
+	stats := v.Stats()
+	if stats.PacketsSent != 1 || stats.BytesSent != uint64(len("client audio")) {
+		t.Fatalf("unexpected send stats %+v", stats)
+	}
+	if stats.PacketsReceived != 2 || stats.BytesReceived != 2*uint64(len("server audio")) {
+		t.Fatalf("unexpected receive stats %+v", stats)
+	}
+}
+
+func TestStream(t *testing.T) {
+	srv, err := NewServer()
+	if err != nil {
+		t.Fatal("failed to create server:", err)
+	}
+	t.Cleanup(func() { srv.Close() })
+
+	v, err := voice.NewSession(NewSession(srv, discord.User{ID: 1}, 2))
+	if err != nil {
+		t.Fatal("failed to create voice session:", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	t.Cleanup(cancel)
+
+	if err := v.JoinChannel(ctx, 3, false, false); err != nil {
+		t.Fatal("failed to join:", err)
+	}
+	t.Cleanup(func() { v.Leave(ctx) })
+
+	frames := []string{"frame 1", "frame 2", "frame 3"}
+
+	i := 0
+	src := voice.AudioSourceFunc(func(ctx context.Context) ([]byte, error) {
+		if i == len(frames) {
+			return nil, io.EOF
+		}
+		i++
+		return []byte(frames[i-1]), nil
+	})
+
+	if err := v.Stream(ctx, src); err != nil {
+		t.Fatal("failed to stream:", err)
+	}
+
+	for j := 0; j < len(frames)+voice.SilenceFrames; j++ {
+		pkt, err := srv.ReadPacket(ctx)
+		if err != nil {
+			t.Fatal("failed to read packet on server:", err)
+		}
+
+		expect := string(voice.SilenceFrame)
+		if j < len(frames) {
+			expect = frames[j]
+		}
+
+		if string(pkt.Opus) != expect {
+			t.Fatalf("packet %d: expected %q, got %q", j, expect, pkt.Opus)
+		}
+	}
+}
+
+func TestStreamCancel(t *testing.T) {
+	srv, err := NewServer()
+	if err != nil {
+		t.Fatal("failed to create server:", err)
+	}
+	t.Cleanup(func() { srv.Close() })
+
+	v, err := voice.NewSession(NewSession(srv, discord.User{ID: 1}, 2))
+	if err != nil {
+		t.Fatal("failed to create voice session:", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	t.Cleanup(cancel)
+
+	if err := v.JoinChannel(ctx, 3, false, false); err != nil {
+		t.Fatal("failed to join:", err)
+	}
+
+	streamCtx, streamCancel := context.WithCancel(ctx)
+
+	src := voice.AudioSourceFunc(func(ctx context.Context) ([]byte, error) {
+		streamCancel()
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+
+	if err := v.Stream(streamCtx, src); !errors.Is(err, context.Canceled) {
+		t.Fatal("expected context.Canceled, got", err)
+	}
+
+	if _, err := v.Write([]byte("after leave")); !errors.Is(err, udp.ErrManagerClosed) {
+		t.Fatal("expected session to have left, got", err)
+	}
+}
+
+func TestJoinRetry(t *testing.T) {
+	srv, err := NewServer()
+	if err != nil {
+		t.Fatal("failed to create server:", err)
+	}
+	t.Cleanup(func() { srv.Close() })
+
+	v, err := voice.NewSession(NewSession(srv, discord.User{ID: 1}, 2))
+	if err != nil {
+		t.Fatal("failed to create voice session:", err)
+	}
+	v.JoinRetryDelay = 10 * time.Millisecond
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	t.Cleanup(cancel)
+
+	srv.CloseOnIdentify(voicegateway.CodeSessionNoLongerValid, 1)
+
+	if err := v.JoinChannel(ctx, 3, false, false); err != nil {
+		t.Fatal("failed to join after retrying:", err)
+	}
+	t.Cleanup(func() { v.Leave(ctx) })
+
+	if _, err := v.Write([]byte("audio")); err != nil {
+		t.Fatal("failed to write:", err)
+	}
+}
+
+func TestJoinRetryExhausted(t *testing.T) {
+	srv, err := NewServer()
+	if err != nil {
+		t.Fatal("failed to create server:", err)
+	}
+	t.Cleanup(func() { srv.Close() })
+
+	v, err := voice.NewSession(NewSession(srv, discord.User{ID: 1}, 2))
+	if err != nil {
+		t.Fatal("failed to create voice session:", err)
+	}
+	v.JoinRetryDelay = 10 * time.Millisecond
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	t.Cleanup(cancel)
+
+	srv.CloseOnIdentify(voicegateway.CodeDisconnected, v.JoinMaxRetry)
+
+	err = v.JoinChannel(ctx, 3, false, false)
+
+	var joinErr *voice.JoinError
+	if !errors.As(err, &joinErr) {
+		t.Fatal("expected *voice.JoinError, got", err)
+	}
+
+	if joinErr.Attempts != v.JoinMaxRetry {
+		t.Errorf("expected %d attempts, got %d", v.JoinMaxRetry, joinErr.Attempts)
+	}
+
+	if !voice.IsStaleSession(err) {
+		t.Error("expected stale session error, got", err)
+	}
+}